@@ -0,0 +1,194 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.23.3
+// source: admin.proto
+
+package admin
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Service_SwitchEngine_FullMethodName       = "/admin.v1.Service/SwitchEngine"
+	Service_GetEngineSwitch_FullMethodName    = "/admin.v1.Service/GetEngineSwitch"
+	Service_CancelEngineSwitch_FullMethodName = "/admin.v1.Service/CancelEngineSwitch"
+)
+
+// ServiceClient is the client API for Service service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ServiceClient interface {
+	// SwitchEngine starts the online switch of the default search engine to the engine provided.
+	// The index of the engine is built in the background, and the current default search engine
+	// is serving the search requests until the switch is done.
+	SwitchEngine(ctx context.Context, in *SwitchEngineRequest, opts ...grpc.CallOption) (*EngineSwitch, error)
+	// GetEngineSwitch returns the running or the last search engine switch
+	GetEngineSwitch(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*EngineSwitch, error)
+	// CancelEngineSwitch cancels the running search engine switch
+	CancelEngineSwitch(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*EngineSwitch, error)
+}
+
+type serviceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewServiceClient(cc grpc.ClientConnInterface) ServiceClient {
+	return &serviceClient{cc}
+}
+
+func (c *serviceClient) SwitchEngine(ctx context.Context, in *SwitchEngineRequest, opts ...grpc.CallOption) (*EngineSwitch, error) {
+	out := new(EngineSwitch)
+	err := c.cc.Invoke(ctx, Service_SwitchEngine_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetEngineSwitch(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*EngineSwitch, error) {
+	out := new(EngineSwitch)
+	err := c.cc.Invoke(ctx, Service_GetEngineSwitch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) CancelEngineSwitch(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*EngineSwitch, error) {
+	out := new(EngineSwitch)
+	err := c.cc.Invoke(ctx, Service_CancelEngineSwitch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ServiceServer is the server API for Service service.
+// All implementations must embed UnimplementedServiceServer
+// for forward compatibility
+type ServiceServer interface {
+	// SwitchEngine starts the online switch of the default search engine to the engine provided.
+	// The index of the engine is built in the background, and the current default search engine
+	// is serving the search requests until the switch is done.
+	SwitchEngine(context.Context, *SwitchEngineRequest) (*EngineSwitch, error)
+	// GetEngineSwitch returns the running or the last search engine switch
+	GetEngineSwitch(context.Context, *emptypb.Empty) (*EngineSwitch, error)
+	// CancelEngineSwitch cancels the running search engine switch
+	CancelEngineSwitch(context.Context, *emptypb.Empty) (*EngineSwitch, error)
+	mustEmbedUnimplementedServiceServer()
+}
+
+// UnimplementedServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedServiceServer struct {
+}
+
+func (UnimplementedServiceServer) SwitchEngine(context.Context, *SwitchEngineRequest) (*EngineSwitch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SwitchEngine not implemented")
+}
+func (UnimplementedServiceServer) GetEngineSwitch(context.Context, *emptypb.Empty) (*EngineSwitch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEngineSwitch not implemented")
+}
+func (UnimplementedServiceServer) CancelEngineSwitch(context.Context, *emptypb.Empty) (*EngineSwitch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelEngineSwitch not implemented")
+}
+func (UnimplementedServiceServer) mustEmbedUnimplementedServiceServer() {}
+
+// UnsafeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ServiceServer will
+// result in compilation errors.
+type UnsafeServiceServer interface {
+	mustEmbedUnimplementedServiceServer()
+}
+
+func RegisterServiceServer(s grpc.ServiceRegistrar, srv ServiceServer) {
+	s.RegisterService(&Service_ServiceDesc, srv)
+}
+
+func _Service_SwitchEngine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwitchEngineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).SwitchEngine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_SwitchEngine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).SwitchEngine(ctx, req.(*SwitchEngineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetEngineSwitch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetEngineSwitch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_GetEngineSwitch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetEngineSwitch(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_CancelEngineSwitch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).CancelEngineSwitch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_CancelEngineSwitch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).CancelEngineSwitch(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Service_ServiceDesc is the grpc.ServiceDesc for Service service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Service_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "admin.v1.Service",
+	HandlerType: (*ServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SwitchEngine",
+			Handler:    _Service_SwitchEngine_Handler,
+		},
+		{
+			MethodName: "GetEngineSwitch",
+			Handler:    _Service_GetEngineSwitch_Handler,
+		},
+		{
+			MethodName: "CancelEngineSwitch",
+			Handler:    _Service_CancelEngineSwitch_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin.proto",
+}
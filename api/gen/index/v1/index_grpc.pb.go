@@ -23,11 +23,28 @@ const (
 	Service_Create_FullMethodName               = "/index.v1.Service/Create"
 	Service_CreateWithStreamData_FullMethodName = "/index.v1.Service/CreateWithStreamData"
 	Service_UpdateNode_FullMethodName           = "/index.v1.Service/UpdateNode"
+	Service_UpdateNodes_FullMethodName          = "/index.v1.Service/UpdateNodes"
+	Service_MoveNode_FullMethodName             = "/index.v1.Service/MoveNode"
+	Service_CopyNodes_FullMethodName            = "/index.v1.Service/CopyNodes"
 	Service_DeleteNodes_FullMethodName          = "/index.v1.Service/DeleteNodes"
 	Service_ListNodes_FullMethodName            = "/index.v1.Service/ListNodes"
+	Service_ListTrash_FullMethodName            = "/index.v1.Service/ListTrash"
+	Service_RestoreNodes_FullMethodName         = "/index.v1.Service/RestoreNodes"
 	Service_PatchRecords_FullMethodName         = "/index.v1.Service/PatchRecords"
 	Service_ListRecords_FullMethodName          = "/index.v1.Service/ListRecords"
+	Service_ListRecordRevisions_FullMethodName  = "/index.v1.Service/ListRecordRevisions"
+	Service_DiffRecords_FullMethodName          = "/index.v1.Service/DiffRecords"
 	Service_Search_FullMethodName               = "/index.v1.Service/Search"
+	Service_Watch_FullMethodName                = "/index.v1.Service/Watch"
+	Service_GetJob_FullMethodName               = "/index.v1.Service/GetJob"
+	Service_ListJobs_FullMethodName             = "/index.v1.Service/ListJobs"
+	Service_CancelJob_FullMethodName            = "/index.v1.Service/CancelJob"
+	Service_CreateAttrSchema_FullMethodName     = "/index.v1.Service/CreateAttrSchema"
+	Service_DeleteAttrSchema_FullMethodName     = "/index.v1.Service/DeleteAttrSchema"
+	Service_ListAttrSchemas_FullMethodName      = "/index.v1.Service/ListAttrSchemas"
+	Service_GrantAccess_FullMethodName          = "/index.v1.Service/GrantAccess"
+	Service_RevokeAccess_FullMethodName         = "/index.v1.Service/RevokeAccess"
+	Service_GetAccess_FullMethodName            = "/index.v1.Service/GetAccess"
 )
 
 // ServiceClient is the client API for Service service.
@@ -38,19 +55,57 @@ type ServiceClient interface {
 	Create(ctx context.Context, in *CreateRecordsRequest, opts ...grpc.CallOption) (*CreateRecordsResult, error)
 	// CreateWithStreamData allows to create new index records by streaming the records.
 	CreateWithStreamData(ctx context.Context, opts ...grpc.CallOption) (Service_CreateWithStreamDataClient, error)
-	// UpdateNode allows to update Node data, e.g. tags.
-	UpdateNode(ctx context.Context, in *UpdateNodeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	// DeleteNode allows to delete nodes according to the request provided
-	DeleteNodes(ctx context.Context, in *DeleteNodesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// UpdateNode allows to update Node data, e.g. tags. It returns the node updated.
+	UpdateNode(ctx context.Context, in *UpdateNodeRequest, opts ...grpc.CallOption) (*Node, error)
+	// UpdateNodes applies the tags patch to all the nodes matching the filter conditions.
+	UpdateNodes(ctx context.Context, in *UpdateNodesRequest, opts ...grpc.CallOption) (*UpdateNodesResult, error)
+	// MoveNode allows to move or rename the node, the node children are moved with it.
+	MoveNode(ctx context.Context, in *MoveNodeRequest, opts ...grpc.CallOption) (*MoveNodeResult, error)
+	// CopyNodes allows to copy the node with all its children and their index records.
+	CopyNodes(ctx context.Context, in *CopyNodesRequest, opts ...grpc.CallOption) (*CopyNodesResult, error)
+	// DeleteNode allows to delete nodes according to the request provided. If the dryRun flag is set,
+	// the nodes are not deleted, but the result describes what would be deleted.
+	DeleteNodes(ctx context.Context, in *DeleteNodesRequest, opts ...grpc.CallOption) (*DeleteNodesResult, error)
 	// ListNodes returns all known children for the Path provided
 	ListNodes(ctx context.Context, in *ListNodesRequest, opts ...grpc.CallOption) (*Nodes, error)
+	// ListTrash returns the trashed nodes matching the request, the filterConditions may be empty
+	ListTrash(ctx context.Context, in *ListNodesRequest, opts ...grpc.CallOption) (*Nodes, error)
+	// RestoreNodes allows to restore the trashed nodes together with their trashed children and parents
+	RestoreNodes(ctx context.Context, in *RestoreNodesRequest, opts ...grpc.CallOption) (*RestoreNodesResult, error)
 	// Patch allows to insert, update or delete an index's records
 	PatchRecords(ctx context.Context, in *PatchRecordsRequest, opts ...grpc.CallOption) (*PatchRecordsResult, error)
 	// ListRecords returns list of records for a path associated with it
 	ListRecords(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListRecordsResult, error)
+	// ListRecordRevisions returns the replaced and deleted versions of the records for a path, the
+	// revisions are kept only for the records of the formats with the keepHistory flag set
+	ListRecordRevisions(ctx context.Context, in *ListRecordRevisionsRequest, opts ...grpc.CallOption) (*RecordRevisions, error)
+	// DiffRecords compares the records for a path as they were at two points in time
+	DiffRecords(ctx context.Context, in *DiffRecordsRequest, opts ...grpc.CallOption) (*DiffRecordsResult, error)
 	// Search runs the search across all the index records matching the query. Result will
 	// be ordered by the ranks for the request.
 	Search(ctx context.Context, in *SearchRecordsRequest, opts ...grpc.CallOption) (*SearchRecordsResult, error)
+	// Watch streams the changes of the nodes and their index records matching the request. The stream
+	// may be resumed from the cursor of the last change received.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Service_WatchClient, error)
+	// GetJob returns the asynchronous job by its ID
+	GetJob(ctx context.Context, in *JobId, opts ...grpc.CallOption) (*Job, error)
+	// ListJobs returns the asynchronous jobs, the newest ones go first
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*Jobs, error)
+	// CancelJob cancels the asynchronous job, the running job is stopped when the parser stops
+	CancelJob(ctx context.Context, in *JobId, opts ...grpc.CallOption) (*Job, error)
+	// CreateAttrSchema declares the typed node attribute for the nodes with the path prefix
+	CreateAttrSchema(ctx context.Context, in *AttrSchema, opts ...grpc.CallOption) (*AttrSchema, error)
+	// DeleteAttrSchema deletes the attribute declaration, the attribute values of the nodes are kept
+	DeleteAttrSchema(ctx context.Context, in *DeleteAttrSchemaRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListAttrSchemas returns all the attributes declarations
+	ListAttrSchemas(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AttrSchemas, error)
+	// GrantAccess sets the permission of the principal (a user or a group) in the node ACL. The permission
+	// is inherited by the node descendants, unless another one is set for the principal there.
+	GrantAccess(ctx context.Context, in *GrantAccessRequest, opts ...grpc.CallOption) (*NodeACL, error)
+	// RevokeAccess removes the principal from the node ACL, the permission inherited from the ancestors is kept
+	RevokeAccess(ctx context.Context, in *RevokeAccessRequest, opts ...grpc.CallOption) (*NodeACL, error)
+	// GetAccess returns the node ACL together with the permissions inherited from the ancestors
+	GetAccess(ctx context.Context, in *GetAccessRequest, opts ...grpc.CallOption) (*NodeACL, error)
 }
 
 type serviceClient struct {
@@ -104,8 +159,8 @@ func (x *serviceCreateWithStreamDataClient) CloseAndRecv() (*CreateRecordsResult
 	return m, nil
 }
 
-func (c *serviceClient) UpdateNode(ctx context.Context, in *UpdateNodeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
+func (c *serviceClient) UpdateNode(ctx context.Context, in *UpdateNodeRequest, opts ...grpc.CallOption) (*Node, error) {
+	out := new(Node)
 	err := c.cc.Invoke(ctx, Service_UpdateNode_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -113,8 +168,35 @@ func (c *serviceClient) UpdateNode(ctx context.Context, in *UpdateNodeRequest, o
 	return out, nil
 }
 
-func (c *serviceClient) DeleteNodes(ctx context.Context, in *DeleteNodesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
+func (c *serviceClient) UpdateNodes(ctx context.Context, in *UpdateNodesRequest, opts ...grpc.CallOption) (*UpdateNodesResult, error) {
+	out := new(UpdateNodesResult)
+	err := c.cc.Invoke(ctx, Service_UpdateNodes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) MoveNode(ctx context.Context, in *MoveNodeRequest, opts ...grpc.CallOption) (*MoveNodeResult, error) {
+	out := new(MoveNodeResult)
+	err := c.cc.Invoke(ctx, Service_MoveNode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) CopyNodes(ctx context.Context, in *CopyNodesRequest, opts ...grpc.CallOption) (*CopyNodesResult, error) {
+	out := new(CopyNodesResult)
+	err := c.cc.Invoke(ctx, Service_CopyNodes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) DeleteNodes(ctx context.Context, in *DeleteNodesRequest, opts ...grpc.CallOption) (*DeleteNodesResult, error) {
+	out := new(DeleteNodesResult)
 	err := c.cc.Invoke(ctx, Service_DeleteNodes_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -131,6 +213,24 @@ func (c *serviceClient) ListNodes(ctx context.Context, in *ListNodesRequest, opt
 	return out, nil
 }
 
+func (c *serviceClient) ListTrash(ctx context.Context, in *ListNodesRequest, opts ...grpc.CallOption) (*Nodes, error) {
+	out := new(Nodes)
+	err := c.cc.Invoke(ctx, Service_ListTrash_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) RestoreNodes(ctx context.Context, in *RestoreNodesRequest, opts ...grpc.CallOption) (*RestoreNodesResult, error) {
+	out := new(RestoreNodesResult)
+	err := c.cc.Invoke(ctx, Service_RestoreNodes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceClient) PatchRecords(ctx context.Context, in *PatchRecordsRequest, opts ...grpc.CallOption) (*PatchRecordsResult, error) {
 	out := new(PatchRecordsResult)
 	err := c.cc.Invoke(ctx, Service_PatchRecords_FullMethodName, in, out, opts...)
@@ -149,6 +249,24 @@ func (c *serviceClient) ListRecords(ctx context.Context, in *ListRequest, opts .
 	return out, nil
 }
 
+func (c *serviceClient) ListRecordRevisions(ctx context.Context, in *ListRecordRevisionsRequest, opts ...grpc.CallOption) (*RecordRevisions, error) {
+	out := new(RecordRevisions)
+	err := c.cc.Invoke(ctx, Service_ListRecordRevisions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) DiffRecords(ctx context.Context, in *DiffRecordsRequest, opts ...grpc.CallOption) (*DiffRecordsResult, error) {
+	out := new(DiffRecordsResult)
+	err := c.cc.Invoke(ctx, Service_DiffRecords_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceClient) Search(ctx context.Context, in *SearchRecordsRequest, opts ...grpc.CallOption) (*SearchRecordsResult, error) {
 	out := new(SearchRecordsResult)
 	err := c.cc.Invoke(ctx, Service_Search_FullMethodName, in, out, opts...)
@@ -158,6 +276,119 @@ func (c *serviceClient) Search(ctx context.Context, in *SearchRecordsRequest, op
 	return out, nil
 }
 
+func (c *serviceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Service_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[1], Service_Watch_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_WatchClient interface {
+	Recv() (*Change, error)
+	grpc.ClientStream
+}
+
+type serviceWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceWatchClient) Recv() (*Change, error) {
+	m := new(Change)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serviceClient) GetJob(ctx context.Context, in *JobId, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, Service_GetJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*Jobs, error) {
+	out := new(Jobs)
+	err := c.cc.Invoke(ctx, Service_ListJobs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) CancelJob(ctx context.Context, in *JobId, opts ...grpc.CallOption) (*Job, error) {
+	out := new(Job)
+	err := c.cc.Invoke(ctx, Service_CancelJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) CreateAttrSchema(ctx context.Context, in *AttrSchema, opts ...grpc.CallOption) (*AttrSchema, error) {
+	out := new(AttrSchema)
+	err := c.cc.Invoke(ctx, Service_CreateAttrSchema_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) DeleteAttrSchema(ctx context.Context, in *DeleteAttrSchemaRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Service_DeleteAttrSchema_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) ListAttrSchemas(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AttrSchemas, error) {
+	out := new(AttrSchemas)
+	err := c.cc.Invoke(ctx, Service_ListAttrSchemas_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GrantAccess(ctx context.Context, in *GrantAccessRequest, opts ...grpc.CallOption) (*NodeACL, error) {
+	out := new(NodeACL)
+	err := c.cc.Invoke(ctx, Service_GrantAccess_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) RevokeAccess(ctx context.Context, in *RevokeAccessRequest, opts ...grpc.CallOption) (*NodeACL, error) {
+	out := new(NodeACL)
+	err := c.cc.Invoke(ctx, Service_RevokeAccess_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serviceClient) GetAccess(ctx context.Context, in *GetAccessRequest, opts ...grpc.CallOption) (*NodeACL, error) {
+	out := new(NodeACL)
+	err := c.cc.Invoke(ctx, Service_GetAccess_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServiceServer is the server API for Service service.
 // All implementations must embed UnimplementedServiceServer
 // for forward compatibility
@@ -166,19 +397,57 @@ type ServiceServer interface {
 	Create(context.Context, *CreateRecordsRequest) (*CreateRecordsResult, error)
 	// CreateWithStreamData allows to create new index records by streaming the records.
 	CreateWithStreamData(Service_CreateWithStreamDataServer) error
-	// UpdateNode allows to update Node data, e.g. tags.
-	UpdateNode(context.Context, *UpdateNodeRequest) (*emptypb.Empty, error)
-	// DeleteNode allows to delete nodes according to the request provided
-	DeleteNodes(context.Context, *DeleteNodesRequest) (*emptypb.Empty, error)
+	// UpdateNode allows to update Node data, e.g. tags. It returns the node updated.
+	UpdateNode(context.Context, *UpdateNodeRequest) (*Node, error)
+	// UpdateNodes applies the tags patch to all the nodes matching the filter conditions.
+	UpdateNodes(context.Context, *UpdateNodesRequest) (*UpdateNodesResult, error)
+	// MoveNode allows to move or rename the node, the node children are moved with it.
+	MoveNode(context.Context, *MoveNodeRequest) (*MoveNodeResult, error)
+	// CopyNodes allows to copy the node with all its children and their index records.
+	CopyNodes(context.Context, *CopyNodesRequest) (*CopyNodesResult, error)
+	// DeleteNode allows to delete nodes according to the request provided. If the dryRun flag is set,
+	// the nodes are not deleted, but the result describes what would be deleted.
+	DeleteNodes(context.Context, *DeleteNodesRequest) (*DeleteNodesResult, error)
 	// ListNodes returns all known children for the Path provided
 	ListNodes(context.Context, *ListNodesRequest) (*Nodes, error)
+	// ListTrash returns the trashed nodes matching the request, the filterConditions may be empty
+	ListTrash(context.Context, *ListNodesRequest) (*Nodes, error)
+	// RestoreNodes allows to restore the trashed nodes together with their trashed children and parents
+	RestoreNodes(context.Context, *RestoreNodesRequest) (*RestoreNodesResult, error)
 	// Patch allows to insert, update or delete an index's records
 	PatchRecords(context.Context, *PatchRecordsRequest) (*PatchRecordsResult, error)
 	// ListRecords returns list of records for a path associated with it
 	ListRecords(context.Context, *ListRequest) (*ListRecordsResult, error)
+	// ListRecordRevisions returns the replaced and deleted versions of the records for a path, the
+	// revisions are kept only for the records of the formats with the keepHistory flag set
+	ListRecordRevisions(context.Context, *ListRecordRevisionsRequest) (*RecordRevisions, error)
+	// DiffRecords compares the records for a path as they were at two points in time
+	DiffRecords(context.Context, *DiffRecordsRequest) (*DiffRecordsResult, error)
 	// Search runs the search across all the index records matching the query. Result will
 	// be ordered by the ranks for the request.
 	Search(context.Context, *SearchRecordsRequest) (*SearchRecordsResult, error)
+	// Watch streams the changes of the nodes and their index records matching the request. The stream
+	// may be resumed from the cursor of the last change received.
+	Watch(*WatchRequest, Service_WatchServer) error
+	// GetJob returns the asynchronous job by its ID
+	GetJob(context.Context, *JobId) (*Job, error)
+	// ListJobs returns the asynchronous jobs, the newest ones go first
+	ListJobs(context.Context, *ListJobsRequest) (*Jobs, error)
+	// CancelJob cancels the asynchronous job, the running job is stopped when the parser stops
+	CancelJob(context.Context, *JobId) (*Job, error)
+	// CreateAttrSchema declares the typed node attribute for the nodes with the path prefix
+	CreateAttrSchema(context.Context, *AttrSchema) (*AttrSchema, error)
+	// DeleteAttrSchema deletes the attribute declaration, the attribute values of the nodes are kept
+	DeleteAttrSchema(context.Context, *DeleteAttrSchemaRequest) (*emptypb.Empty, error)
+	// ListAttrSchemas returns all the attributes declarations
+	ListAttrSchemas(context.Context, *emptypb.Empty) (*AttrSchemas, error)
+	// GrantAccess sets the permission of the principal (a user or a group) in the node ACL. The permission
+	// is inherited by the node descendants, unless another one is set for the principal there.
+	GrantAccess(context.Context, *GrantAccessRequest) (*NodeACL, error)
+	// RevokeAccess removes the principal from the node ACL, the permission inherited from the ancestors is kept
+	RevokeAccess(context.Context, *RevokeAccessRequest) (*NodeACL, error)
+	// GetAccess returns the node ACL together with the permissions inherited from the ancestors
+	GetAccess(context.Context, *GetAccessRequest) (*NodeACL, error)
 	mustEmbedUnimplementedServiceServer()
 }
 
@@ -192,24 +461,75 @@ func (UnimplementedServiceServer) Create(context.Context, *CreateRecordsRequest)
 func (UnimplementedServiceServer) CreateWithStreamData(Service_CreateWithStreamDataServer) error {
 	return status.Errorf(codes.Unimplemented, "method CreateWithStreamData not implemented")
 }
-func (UnimplementedServiceServer) UpdateNode(context.Context, *UpdateNodeRequest) (*emptypb.Empty, error) {
+func (UnimplementedServiceServer) UpdateNode(context.Context, *UpdateNodeRequest) (*Node, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateNode not implemented")
 }
-func (UnimplementedServiceServer) DeleteNodes(context.Context, *DeleteNodesRequest) (*emptypb.Empty, error) {
+func (UnimplementedServiceServer) UpdateNodes(context.Context, *UpdateNodesRequest) (*UpdateNodesResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNodes not implemented")
+}
+func (UnimplementedServiceServer) MoveNode(context.Context, *MoveNodeRequest) (*MoveNodeResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveNode not implemented")
+}
+func (UnimplementedServiceServer) CopyNodes(context.Context, *CopyNodesRequest) (*CopyNodesResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyNodes not implemented")
+}
+func (UnimplementedServiceServer) DeleteNodes(context.Context, *DeleteNodesRequest) (*DeleteNodesResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteNodes not implemented")
 }
 func (UnimplementedServiceServer) ListNodes(context.Context, *ListNodesRequest) (*Nodes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListNodes not implemented")
 }
+func (UnimplementedServiceServer) ListTrash(context.Context, *ListNodesRequest) (*Nodes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedServiceServer) RestoreNodes(context.Context, *RestoreNodesRequest) (*RestoreNodesResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreNodes not implemented")
+}
 func (UnimplementedServiceServer) PatchRecords(context.Context, *PatchRecordsRequest) (*PatchRecordsResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PatchRecords not implemented")
 }
 func (UnimplementedServiceServer) ListRecords(context.Context, *ListRequest) (*ListRecordsResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListRecords not implemented")
 }
+func (UnimplementedServiceServer) ListRecordRevisions(context.Context, *ListRecordRevisionsRequest) (*RecordRevisions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecordRevisions not implemented")
+}
+func (UnimplementedServiceServer) DiffRecords(context.Context, *DiffRecordsRequest) (*DiffRecordsResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffRecords not implemented")
+}
 func (UnimplementedServiceServer) Search(context.Context, *SearchRecordsRequest) (*SearchRecordsResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
 }
+func (UnimplementedServiceServer) Watch(*WatchRequest, Service_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedServiceServer) GetJob(context.Context, *JobId) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (UnimplementedServiceServer) ListJobs(context.Context, *ListJobsRequest) (*Jobs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (UnimplementedServiceServer) CancelJob(context.Context, *JobId) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelJob not implemented")
+}
+func (UnimplementedServiceServer) CreateAttrSchema(context.Context, *AttrSchema) (*AttrSchema, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAttrSchema not implemented")
+}
+func (UnimplementedServiceServer) DeleteAttrSchema(context.Context, *DeleteAttrSchemaRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttrSchema not implemented")
+}
+func (UnimplementedServiceServer) ListAttrSchemas(context.Context, *emptypb.Empty) (*AttrSchemas, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttrSchemas not implemented")
+}
+func (UnimplementedServiceServer) GrantAccess(context.Context, *GrantAccessRequest) (*NodeACL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantAccess not implemented")
+}
+func (UnimplementedServiceServer) RevokeAccess(context.Context, *RevokeAccessRequest) (*NodeACL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAccess not implemented")
+}
+func (UnimplementedServiceServer) GetAccess(context.Context, *GetAccessRequest) (*NodeACL, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccess not implemented")
+}
 func (UnimplementedServiceServer) mustEmbedUnimplementedServiceServer() {}
 
 // UnsafeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -285,6 +605,60 @@ func _Service_UpdateNode_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_UpdateNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).UpdateNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_UpdateNodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).UpdateNodes(ctx, req.(*UpdateNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_MoveNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).MoveNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_MoveNode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).MoveNode(ctx, req.(*MoveNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_CopyNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).CopyNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_CopyNodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).CopyNodes(ctx, req.(*CopyNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Service_DeleteNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteNodesRequest)
 	if err := dec(in); err != nil {
@@ -321,6 +695,42 @@ func _Service_ListNodes_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_ListTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListTrash(ctx, req.(*ListNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_RestoreNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).RestoreNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_RestoreNodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).RestoreNodes(ctx, req.(*RestoreNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Service_PatchRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PatchRecordsRequest)
 	if err := dec(in); err != nil {
@@ -357,6 +767,42 @@ func _Service_ListRecords_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_ListRecordRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecordRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListRecordRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_ListRecordRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListRecordRevisions(ctx, req.(*ListRecordRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_DiffRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).DiffRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_DiffRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).DiffRecords(ctx, req.(*DiffRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Service_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SearchRecordsRequest)
 	if err := dec(in); err != nil {
@@ -375,6 +821,189 @@ func _Service_Search_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).Watch(m, &serviceWatchServer{stream})
+}
+
+type Service_WatchServer interface {
+	Send(*Change) error
+	grpc.ServerStream
+}
+
+type serviceWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceWatchServer) Send(m *Change) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Service_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_GetJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetJob(ctx, req.(*JobId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_ListJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_CancelJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).CancelJob(ctx, req.(*JobId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_CreateAttrSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttrSchema)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).CreateAttrSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_CreateAttrSchema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).CreateAttrSchema(ctx, req.(*AttrSchema))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_DeleteAttrSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAttrSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).DeleteAttrSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_DeleteAttrSchema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).DeleteAttrSchema(ctx, req.(*DeleteAttrSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_ListAttrSchemas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListAttrSchemas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_ListAttrSchemas_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListAttrSchemas(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GrantAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GrantAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_GrantAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GrantAccess(ctx, req.(*GrantAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_RevokeAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).RevokeAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_RevokeAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).RevokeAccess(ctx, req.(*RevokeAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Service_GetAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).GetAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_GetAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).GetAccess(ctx, req.(*GetAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Service_ServiceDesc is the grpc.ServiceDesc for Service service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -390,6 +1019,18 @@ var Service_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateNode",
 			Handler:    _Service_UpdateNode_Handler,
 		},
+		{
+			MethodName: "UpdateNodes",
+			Handler:    _Service_UpdateNodes_Handler,
+		},
+		{
+			MethodName: "MoveNode",
+			Handler:    _Service_MoveNode_Handler,
+		},
+		{
+			MethodName: "CopyNodes",
+			Handler:    _Service_CopyNodes_Handler,
+		},
 		{
 			MethodName: "DeleteNodes",
 			Handler:    _Service_DeleteNodes_Handler,
@@ -398,6 +1039,14 @@ var Service_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListNodes",
 			Handler:    _Service_ListNodes_Handler,
 		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _Service_ListTrash_Handler,
+		},
+		{
+			MethodName: "RestoreNodes",
+			Handler:    _Service_RestoreNodes_Handler,
+		},
 		{
 			MethodName: "PatchRecords",
 			Handler:    _Service_PatchRecords_Handler,
@@ -406,10 +1055,54 @@ var Service_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListRecords",
 			Handler:    _Service_ListRecords_Handler,
 		},
+		{
+			MethodName: "ListRecordRevisions",
+			Handler:    _Service_ListRecordRevisions_Handler,
+		},
+		{
+			MethodName: "DiffRecords",
+			Handler:    _Service_DiffRecords_Handler,
+		},
 		{
 			MethodName: "Search",
 			Handler:    _Service_Search_Handler,
 		},
+		{
+			MethodName: "GetJob",
+			Handler:    _Service_GetJob_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _Service_ListJobs_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _Service_CancelJob_Handler,
+		},
+		{
+			MethodName: "CreateAttrSchema",
+			Handler:    _Service_CreateAttrSchema_Handler,
+		},
+		{
+			MethodName: "DeleteAttrSchema",
+			Handler:    _Service_DeleteAttrSchema_Handler,
+		},
+		{
+			MethodName: "ListAttrSchemas",
+			Handler:    _Service_ListAttrSchemas_Handler,
+		},
+		{
+			MethodName: "GrantAccess",
+			Handler:    _Service_GrantAccess_Handler,
+		},
+		{
+			MethodName: "RevokeAccess",
+			Handler:    _Service_RevokeAccess_Handler,
+		},
+		{
+			MethodName: "GetAccess",
+			Handler:    _Service_GetAccess_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -417,6 +1110,11 @@ var Service_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Service_CreateWithStreamData_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Service_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "index.proto",
 }
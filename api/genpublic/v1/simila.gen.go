@@ -20,17 +20,188 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for AttrType.
+const (
+	Bool      AttrType = "bool"
+	Number    AttrType = "number"
+	String    AttrType = "string"
+	Strings   AttrType = "strings"
+	Timestamp AttrType = "timestamp"
+)
+
+// Defines values for ChangeObject.
+const (
+	ChangeObjectNode   ChangeObject = "node"
+	ChangeObjectRecord ChangeObject = "record"
+)
+
+// Defines values for ChangeOp.
+const (
+	Create ChangeOp = "create"
+	Delete ChangeOp = "delete"
+	Update ChangeOp = "update"
+)
+
+// Defines values for CreateMode.
+const (
+	Append  CreateMode = "append"
+	Replace CreateMode = "replace"
+	Sync    CreateMode = "sync"
+)
+
+// Defines values for EngineSwitchState.
+const (
+	EngineSwitchStateCanceled EngineSwitchState = "canceled"
+	EngineSwitchStateDone     EngineSwitchState = "done"
+	EngineSwitchStateFailed   EngineSwitchState = "failed"
+	EngineSwitchStateRunning  EngineSwitchState = "running"
+)
+
+// Defines values for FusionMethod.
+const (
+	Rrf      FusionMethod = "rrf"
+	Weighted FusionMethod = "weighted"
+)
+
+// Defines values for JobStatus.
+const (
+	JobStatusCanceled JobStatus = "canceled"
+	JobStatusDone     JobStatus = "done"
+	JobStatusFailed   JobStatus = "failed"
+	JobStatusPending  JobStatus = "pending"
+	JobStatusRunning  JobStatus = "running"
+)
+
 // Defines values for NodeType.
 const (
 	Document NodeType = "document"
 	Folder   NodeType = "folder"
 )
 
+// Defines values for Permission.
+const (
+	Admin Permission = "admin"
+	Read  Permission = "read"
+	Write Permission = "write"
+)
+
+// Defines values for SearchMode.
+const (
+	Hybrid   SearchMode = "hybrid"
+	Lexical  SearchMode = "lexical"
+	Semantic SearchMode = "semantic"
+)
+
+// Defines values for WebhookEvents.
+const (
+	NodeCreate   WebhookEvents = "node.create"
+	NodeDelete   WebhookEvents = "node.delete"
+	NodeUpdate   WebhookEvents = "node.update"
+	RecordCreate WebhookEvents = "record.create"
+	RecordDelete WebhookEvents = "record.delete"
+	RecordUpdate WebhookEvents = "record.update"
+)
+
+// ACL The object describes the permissions of the principals and groups.
+type ACL map[string]Permission
+
+// AttrSchema The object declares the typed attribute for the nodes with the path prefix.
+type AttrSchema struct {
+	// CreatedAt The time the attribute schema was created.
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// Name The attribute name, it must be an identifier.
+	Name string `json:"name"`
+
+	// Prefix The path prefix of the nodes, the / prefix is for all the nodes.
+	Prefix string `json:"prefix"`
+
+	// Type The type of the node attribute values. The timestamp values are RFC 3339 strings, the strings values are the lists of strings.
+	Type AttrType `json:"type"`
+}
+
+// AttrSchemas The object is used as a response of the attribute schemas list request.
+type AttrSchemas struct {
+	AttrSchemas []AttrSchema `json:"attrSchemas"`
+}
+
+// AttrType The type of the node attribute values. The timestamp values are RFC 3339 strings, the strings values are the lists of strings.
+type AttrType string
+
+// Attrs The object describes the node typed attributes, every attribute must be declared by the attribute schema for the node path.
+type Attrs map[string]interface{}
+
+// Change The object describes a change of the node or its index record. The index records of the deleted nodes are deleted with them, so only the node change is sent then.
+type Change struct {
+	// CreatedAt The time of the change.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Cursor The change position in the change feed, it allows to resume the watch from the change.
+	Cursor int64 `json:"cursor"`
+
+	// Format The changed record format, it is set for the record changes only.
+	Format *string `json:"format,omitempty"`
+
+	// Node The object describes the index node.
+	Node Node `json:"node"`
+
+	// Object The changed object type.
+	Object ChangeObject `json:"object"`
+
+	// Op The change operation.
+	Op ChangeOp `json:"op"`
+
+	// RecordId The changed record ID, it is set for the record changes only.
+	RecordId *string `json:"recordId,omitempty"`
+}
+
+// ChangeObject The changed object type.
+type ChangeObject string
+
+// ChangeOp The change operation.
+type ChangeOp string
+
+// CopyNodesRequest The object is used to copy the node.
+type CopyNodesRequest struct {
+	// Tags The object describes the node tags.
+	Tags *Tags `json:"tags,omitempty"`
+
+	// To The path of the node copy, including the node name.
+	To string `json:"to"`
+}
+
+// CopyNodesResult The object is used as a response of the node copy request.
+type CopyNodesResult struct {
+	// Node The object describes the index node.
+	Node Node `json:"node"`
+
+	// NodesCopied The number of the nodes copied.
+	NodesCopied int `json:"nodesCopied"`
+
+	// NodesCreated The list of the parent folders created.
+	NodesCreated []Node `json:"nodesCreated"`
+
+	// RecordsCopied The number of the index records copied.
+	RecordsCopied int `json:"recordsCopied"`
+}
+
+// CreateMode The mode specifies how the existing records of the node are treated. The `append` mode (default) creates the new records and updates the existing ones by their IDs, other records of the node are kept. The `replace` mode writes all the new records and deletes the node records, which are not in the new records list. The `sync` mode is the same as `replace`, but only the new and changed records are written. The async request supports the `append` mode only.
+type CreateMode string
+
 // CreateRecordsRequest The object is used for records creation.
 type CreateRecordsRequest struct {
+	// Async The document is parsed by the asynchronous job if true, the records list must be empty then.
+	Async *bool `json:"async,omitempty"`
+
+	// Attrs The object describes the node typed attributes, every attribute must be declared by the attribute schema for the node path.
+	Attrs *Attrs `json:"attrs,omitempty"`
+
 	// Document The binary data for the document of the specified format.
 	Document []byte `json:"document"`
 
+	// Mode The mode specifies how the existing records of the node are treated. The `append` mode (default) creates the new records and updates the existing ones by their IDs, other records of the node are kept. The `replace` mode writes all the new records and deletes the node records, which are not in the new records list. The `sync` mode is the same as `replace`, but only the new and changed records are written. The async request supports the `append` mode only.
+	Mode *CreateMode `json:"mode,omitempty"`
+
 	// NodeType The object describes the index node type.
 	NodeType NodeType `json:"nodeType"`
 
@@ -49,27 +220,137 @@ type CreateRecordsRequest struct {
 
 // CreateRecordsResult The object is used as a response of the records creation request.
 type CreateRecordsResult struct {
+	// Job The object describes the asynchronous parsing of the document.
+	Job *Job `json:"job,omitempty"`
+
 	// NodesCreated The list of nodes created.
 	NodesCreated []Node `json:"nodesCreated"`
 
 	// RecordsCreated The number of records created.
 	RecordsCreated int `json:"recordsCreated"`
+
+	// RecordsDeleted The number of the records deleted in the `replace` or `sync` mode.
+	RecordsDeleted *int `json:"recordsDeleted,omitempty"`
+
+	// RecordsUpdated The number of the existing records updated in the `replace` or `sync` mode.
+	RecordsUpdated *int `json:"recordsUpdated,omitempty"`
+}
+
+// DeadLetter The object describes an event, which could not be delivered to the webhook.
+type DeadLetter struct {
+	// Attempts The number of the delivery attempts made.
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Cursor The event position in the change feed.
+	Cursor int64 `json:"cursor"`
+
+	// Error The last delivery error.
+	Error string `json:"error"`
+
+	// Event The event type.
+	Event string `json:"event"`
+	Id    int64  `json:"id"`
+
+	// Payload The JSON payload of the event.
+	Payload   string `json:"payload"`
+	WebhookId string `json:"webhookId"`
+}
+
+// DeadLetters The object is used as a response of the dead letters list request.
+type DeadLetters struct {
+	DeadLetters []DeadLetter `json:"deadLetters"`
+}
+
+// DeleteNodesPreview The object describes the nodes which would be deleted by the DeleteNodesRequest.
+type DeleteNodesPreview struct {
+	// ForceRequired The flag is true if the matched folders have children that don't meet the filter conditions, so the force flag is needed to delete the nodes.
+	ForceRequired bool `json:"forceRequired"`
+
+	// Items The requested page of the nodes to be deleted.
+	Items []Node `json:"items"`
+
+	// Records The number of the index records to be deleted.
+	Records int `json:"records"`
+
+	// Total The number of the nodes to be deleted.
+	Total int `json:"total"`
 }
 
 // DeleteNodesRequest The object is used to delete multiple nodes at a time
 type DeleteNodesRequest struct {
+	// DryRun The flag makes the request to return the nodes which would be deleted without deleting them. The force flag is not checked then.
+	DryRun *bool `json:"dryRun,omitempty"`
+
 	// FilterConditions The filter conditions. The filters support `and`, `or` and `not` conditions for `format`, `path` and `tag("name")`, for instance, `tag("public") = "true" and format = "spreadsheetsData" and (path = "/orgs/1234/balance.xlsx" or path like "/orgs/%"))`.
 	FilterConditions string `json:"filterConditions"`
 
 	// Force The flag allows to delete children of a matched node, even if they don't meet the filter criteria
 	Force bool `json:"force"`
+
+	// Limit The max number of the nodes returned in the dryRun mode response, 100 by default.
+	Limit *int `json:"limit,omitempty"`
+
+	// Offset The number of the nodes to be skipped in the dryRun mode response.
+	Offset *int `json:"offset,omitempty"`
+
+	// Trash The flag makes the nodes to be moved to the trash instead of deleting them. The trashed nodes may be restored until they are purged.
+	Trash *bool `json:"trash,omitempty"`
+}
+
+// DiffRecordsResult The object contains the difference between two versions of the node records.
+type DiffRecordsResult struct {
+	// Added The records, which were added.
+	Added []Record `json:"added"`
+
+	// Changed The records, which were changed.
+	Changed []RecordChange `json:"changed"`
+
+	// Removed The records, which were removed.
+	Removed []Record `json:"removed"`
+}
+
+// EngineSwitch The object describes the search engine switch.
+type EngineSwitch struct {
+	// CreatedAt The time the switch started.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Error The reason of the failure, if the switch is failed.
+	Error string `json:"error"`
+
+	// From The default search engine when the switch started.
+	From string `json:"from"`
+
+	// Id The switch identifier.
+	Id int64 `json:"id"`
+
+	// Phase The current phase of the switch (`prepare`, `backfill` or `index`).
+	Phase string `json:"phase"`
+
+	// Progress The progress of the current phase in the [0, 1] range.
+	Progress float64 `json:"progress"`
+
+	// State The state of the search engine switch.
+	State EngineSwitchState `json:"state"`
+
+	// To The search engine to be the default one when the switch is done.
+	To string `json:"to"`
+
+	// UpdatedAt The time the switch was updated last time.
+	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+// EngineSwitchState The state of the search engine switch.
+type EngineSwitchState string
+
 // Format The object describes a data format.
 type Format struct {
 	// Basis The format basis specifies format dimensions.
 	Basis []byte `json:"basis"`
 
+	// KeepHistory The flag makes the replaced and deleted records of the format to be kept as the records revisions.
+	KeepHistory *bool `json:"keepHistory,omitempty"`
+
 	// Name The format name, it is used as the format identifier.
 	Name string `json:"name"`
 }
@@ -80,12 +361,62 @@ type Formats struct {
 	Formats []Format `json:"formats"`
 }
 
+// FusionMethod The method of merging the lexical and the semantic results in the `hybrid` mode, the reciprocal rank fusion (`rrf`, default) or the weighted sum of the normalized scores (`weighted`).
+type FusionMethod string
+
+// GrantAccessRequest The object describes the permission to be granted.
+type GrantAccessRequest struct {
+	// Permission The node permission, every permission includes the previous ones.
+	Permission Permission `json:"permission"`
+}
+
+// Job The object describes the asynchronous parsing of the document.
+type Job struct {
+	// CreatedAt The time the job was created.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Error The reason of the failure, if the job is failed.
+	Error string `json:"error"`
+
+	// Id The job identifier.
+	Id int64 `json:"id"`
+
+	// Parser The parser name (format name) of the document.
+	Parser string `json:"parser"`
+
+	// Path The path of the node the records are written to.
+	Path string `json:"path"`
+
+	// Records The number of the records written so far.
+	Records int64 `json:"records"`
+
+	// Status The status of the asynchronous job.
+	Status JobStatus `json:"status"`
+
+	// UpdatedAt The time the job was updated last time.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// JobStatus The status of the asynchronous job.
+type JobStatus string
+
+// Jobs The object is used as a response of the jobs list request.
+type Jobs struct {
+	Jobs []Job `json:"jobs"`
+}
+
 // ListNodesResult The object is used as a response of the nodes list request.
 type ListNodesResult struct {
 	// Items The list of nodes.
 	Items []Node `json:"items"`
 }
 
+// ListRecordRevisionsResult The object is used as a response to the list records revisions request.
+type ListRecordRevisionsResult struct {
+	// Items The list of revisions ordered from the newest to the oldest ones.
+	Items []RecordRevision `json:"items"`
+}
+
 // ListRecordsResult The object is used a response to the list records request.
 type ListRecordsResult struct {
 	// NextPageId The id of the next page for getting the rest of the records.
@@ -98,8 +429,32 @@ type ListRecordsResult struct {
 	Total int `json:"total"`
 }
 
+// MoveNodeRequest The object is used to move the node.
+type MoveNodeRequest struct {
+	// To The new path of the node, including the node name.
+	To string `json:"to"`
+}
+
+// MoveNodeResult The object is used as a response of the node move request.
+type MoveNodeResult struct {
+	// Node The object describes the index node.
+	Node Node `json:"node"`
+
+	// NodesCreated The list of the parent folders created.
+	NodesCreated []Node `json:"nodesCreated"`
+}
+
 // Node The object describes the index node.
 type Node struct {
+	// Attrs The object describes the node typed attributes, every attribute must be declared by the attribute schema for the node path.
+	Attrs *Attrs `json:"attrs,omitempty"`
+
+	// DeletedAt The time the node was moved to the trash, it is set for the trashed nodes only.
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+
+	// EffectiveTags The node tags together with the tags inherited from its ancestors, the nearer ancestors tags override the farther ones.
+	EffectiveTags *map[string]string `json:"effectiveTags,omitempty"`
+
 	// Name The node name, must be unique among the siblings in the tree.
 	Name string `json:"name"`
 
@@ -111,6 +466,21 @@ type Node struct {
 
 	// Type The object describes the index node type.
 	Type NodeType `json:"type"`
+
+	// Version The node version, it is incremented on every change of the node or its records.
+	Version *int64 `json:"version,omitempty"`
+}
+
+// NodeACL The object describes the node access control list.
+type NodeACL struct {
+	// Acl The object describes the permissions of the principals and groups.
+	Acl ACL `json:"acl"`
+
+	// EffectiveAcl The object describes the permissions of the principals and groups.
+	EffectiveAcl ACL `json:"effectiveAcl"`
+
+	// Path The node path.
+	Path string `json:"path"`
 }
 
 // NodeType The object describes the index node type.
@@ -132,8 +502,14 @@ type PatchRecordsResult struct {
 
 	// Upserted The number of upserted records.
 	Upserted int `json:"upserted"`
+
+	// Version The node version after the patch.
+	Version *int64 `json:"version,omitempty"`
 }
 
+// Permission The node permission, every permission includes the previous ones.
+type Permission string
+
 // Record The object contains information about the index record.
 type Record struct {
 	// Format The format of the record.
@@ -142,6 +518,9 @@ type Record struct {
 	// Id The record identifier within the node.
 	Id string `json:"id"`
 
+	// Meta The record metadata, the search results may be filtered by it using the rmeta() function.
+	Meta *map[string]string `json:"meta,omitempty"`
+
 	// RankMultiplier The priority coefficient (must be >= 1.0) of the record within a search result set.
 	RankMultiplier float32 `json:"rankMultiplier"`
 
@@ -150,22 +529,79 @@ type Record struct {
 
 	// Vector The vector data for the segment.
 	Vector []byte `json:"vector"`
+
+	// Version The record version, it is incremented on every update of the record.
+	Version *int64 `json:"version,omitempty"`
+}
+
+// RecordChange The object contains two versions of the changed record.
+type RecordChange struct {
+	// From The object contains information about the index record.
+	From Record `json:"from"`
+
+	// To The object contains information about the index record.
+	To Record `json:"to"`
 }
 
+// RecordRevision The object describes the replaced or deleted version of the index record.
+type RecordRevision struct {
+	// Deleted The flag is true if the record was deleted at the replacedAt time.
+	Deleted bool `json:"deleted"`
+
+	// Record The object contains information about the index record.
+	Record Record `json:"record"`
+
+	// ReplacedAt The time the record version was replaced or deleted.
+	ReplacedAt time.Time `json:"replacedAt"`
+
+	// Revision The unique sequence number of the revision.
+	Revision int64 `json:"revision"`
+
+	// ValidFrom The time the record version was created or updated.
+	ValidFrom time.Time `json:"validFrom"`
+}
+
+// RestoreNodesRequest The object is used to restore the trashed nodes
+type RestoreNodesRequest struct {
+	// FilterConditions The filter conditions to select the trashed nodes, the same as for the DeleteNodesRequest.
+	FilterConditions string `json:"filterConditions"`
+}
+
+// RestoreNodesResult The object is used as a response of the nodes restore request.
+type RestoreNodesResult struct {
+	// Restored The number of the nodes restored.
+	Restored int `json:"restored"`
+}
+
+// SearchMode The search mode. The `lexical` mode uses the full-text search of the search engine, the `semantic` mode ranks the records by the similarity of the records and the query embeddings, the `hybrid` mode runs both and merges the results. If not set, the default mode of the search engine is used.
+type SearchMode string
+
 // SearchRecordsRequest The object is used to perform search across the index records.
 type SearchRecordsRequest struct {
+	// Engine The name of the search engine (e.g. `pgfts` or `pgtrigram`) to be used, if not set, the default search engine is used. The engine must be turned on in the server configuration.
+	Engine *string `json:"engine,omitempty"`
+
 	// FilterConditions The filter conditions. The filters support `and`, `or` and `not` conditions for `format`, `path` and `tag("name")`, for instance, `tag("public") = "true" and format = "spreadsheetsData" and (path = "/orgs/1234/balance.xlsx" or path like "/orgs/%")`.
 	FilterConditions string `json:"filterConditions"`
 
+	// Fusion The method of merging the lexical and the semantic results in the `hybrid` mode, the reciprocal rank fusion (`rrf`, default) or the weighted sum of the normalized scores (`weighted`).
+	Fusion *FusionMethod `json:"fusion,omitempty"`
+
 	// GroupByPathOff The flag turns off results grouping by path.
 	GroupByPathOff bool `json:"groupByPathOff"`
 
 	// Limit The maximum number of records per page.
 	Limit int `json:"limit"`
 
+	// Mode The search mode. The `lexical` mode uses the full-text search of the search engine, the `semantic` mode ranks the records by the similarity of the records and the query embeddings, the `hybrid` mode runs both and merges the results. If not set, the default mode of the search engine is used.
+	Mode *SearchMode `json:"mode,omitempty"`
+
 	// Offset The number of records to skip before start returning results.
 	Offset int `json:"offset"`
 
+	// SemanticWeight The weight [0, 1] of the semantic score for the `weighted` fusion, 0.5 if not set.
+	SemanticWeight *float32 `json:"semanticWeight,omitempty"`
+
 	// TextQuery The text query. The query must be formed in accordance with the query language of the underlying search engine.
 	TextQuery string `json:"textQuery"`
 }
@@ -181,6 +617,9 @@ type SearchRecordsResult struct {
 
 // SearchRecordsResultItem The object is used as an item in the search records response.
 type SearchRecordsResultItem struct {
+	// LexicalScore The score of the record in the lexical search results, if it is found there.
+	LexicalScore *float32 `json:"lexicalScore,omitempty"`
+
 	// MatchedKeywords The matched keywords within the record.
 	MatchedKeywords []string `json:"matchedKeywords"`
 
@@ -192,11 +631,84 @@ type SearchRecordsResultItem struct {
 
 	// Score The relevancy score of the record.
 	Score float32 `json:"score"`
+
+	// SemanticScore The score of the record in the semantic search results, if it is found there.
+	SemanticScore *float32 `json:"semanticScore,omitempty"`
+}
+
+// SwitchEngineRequest The object is used to start the search engine switch.
+type SwitchEngineRequest struct {
+	// Engine The name of the search engine (`pggroonga`, `pgtrigram`, `pgfts` or `pgvector`) to be the default one.
+	Engine string `json:"engine"`
 }
 
 // Tags The object describes the node tags.
 type Tags map[string]string
 
+// TagsPatch The object describes the changes of the node tags. All the tags are removed first if the `clear` flag is set, then the `remove` keys are removed, and the `set` tags are added or replaced.
+type TagsPatch struct {
+	// Clear The flag makes all the node tags to be removed.
+	Clear *bool `json:"clear,omitempty"`
+
+	// Remove The keys of the tags to be removed.
+	Remove *[]string `json:"remove,omitempty"`
+
+	// Set The object describes the node tags.
+	Set *Tags `json:"set,omitempty"`
+}
+
+// UpdateNodesRequest The object is used to update multiple nodes at a time.
+type UpdateNodesRequest struct {
+	// FilterConditions The filter conditions to select the nodes, the same as for the nodes delete request.
+	FilterConditions string `json:"filterConditions"`
+
+	// TagsPatch The object describes the changes of the node tags. All the tags are removed first if the `clear` flag is set, then the `remove` keys are removed, and the `set` tags are added or replaced.
+	TagsPatch TagsPatch `json:"tagsPatch"`
+}
+
+// UpdateNodesResult The object is used as a response of the nodes update request.
+type UpdateNodesResult struct {
+	// Updated The number of the nodes updated.
+	Updated int64 `json:"updated"`
+}
+
+// Webhook The object describes a webhook subscription for the index events. The events are POSTed to the url as JSON payloads, which are signed by the secret in the X-Simila-Signature header.
+type Webhook struct {
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// Events The event types to deliver, all the events are delivered if empty.
+	Events *[]WebhookEvents `json:"events,omitempty"`
+
+	// Id The webhook identifier.
+	Id string `json:"id"`
+
+	// Path The path of the node, which events and the events of its children are delivered only.
+	Path *string `json:"path,omitempty"`
+
+	// Secret The secret used to sign the payloads, it is never returned.
+	Secret *string `json:"secret,omitempty"`
+
+	// Url The absolute http(s) URL the events are POSTed to.
+	Url string `json:"url"`
+}
+
+// WebhookEvents defines model for Webhook.Events.
+type WebhookEvents string
+
+// Webhooks The object is used as a response of the webhooks list request.
+type Webhooks struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// AsOf defines model for AsOf.
+type AsOf = time.Time
+
+// AttrName defines model for AttrName.
+type AttrName = string
+
+// AttrPrefix defines model for AttrPrefix.
+type AttrPrefix = string
+
 // ConditionFilter defines model for ConditionFilter.
 type ConditionFilter = string
 
@@ -206,6 +718,15 @@ type CreatedAfterFilter = time.Time
 // CreatedBeforeFilter defines model for CreatedBeforeFilter.
 type CreatedBeforeFilter = time.Time
 
+// Cursor defines model for Cursor.
+type Cursor = int64
+
+// DiffFrom defines model for DiffFrom.
+type DiffFrom = time.Time
+
+// DiffTo defines model for DiffTo.
+type DiffTo = time.Time
+
 // Force defines model for Force.
 type Force = bool
 
@@ -215,6 +736,21 @@ type FormatFilter = string
 // FormatId defines model for FormatId.
 type FormatId = string
 
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// JobId defines model for JobId.
+type JobId = int64
+
+// JobStatusFilter The status of the asynchronous job.
+type JobStatusFilter = JobStatus
+
+// LastEventId defines model for LastEventId.
+type LastEventId = int64
+
 // Limit defines model for Limit.
 type Limit = int
 
@@ -227,9 +763,60 @@ type PageId = string
 // Path defines model for Path.
 type Path = string
 
+// PathPrefix defines model for PathPrefix.
+type PathPrefix = string
+
+// Principal defines model for Principal.
+type Principal = string
+
+// RecordIdFilter defines model for RecordIdFilter.
+type RecordIdFilter = string
+
+// RemoveAttrs defines model for RemoveAttrs.
+type RemoveAttrs = []string
+
 // TagsFilter The object describes the node tags.
 type TagsFilter = Tags
 
+// Trash defines model for Trash.
+type Trash = bool
+
+// WebhookId defines model for WebhookId.
+type WebhookId = string
+
+// DeleteAttrSchemaParams defines parameters for DeleteAttrSchema.
+type DeleteAttrSchemaParams struct {
+	// Prefix The path prefix the attribute is declared for.
+	Prefix AttrPrefix `form:"prefix" json:"prefix"`
+}
+
+// WatchChangesParams defines parameters for WatchChanges.
+type WatchChangesParams struct {
+	// Path The path specifies the node, which changes and the changes of its children are returned only.
+	Path *PathPrefix `form:"path,omitempty" json:"path,omitempty"`
+
+	// Condition The condition contatins the simila QL expression to select nodes by the filter
+	Condition *ConditionFilter `form:"condition,omitempty" json:"condition,omitempty"`
+
+	// Cursor The cursor of the last change received, the changes following it are returned.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// LastEventID The id of the last server-sent event received, it is used the same way as the cursor.
+	LastEventID *LastEventId `json:"Last-Event-ID,omitempty"`
+}
+
+// ListJobsParams defines parameters for ListJobs.
+type ListJobsParams struct {
+	// Status The status specifies the status to filter the jobs by.
+	Status *JobStatusFilter `form:"status,omitempty" json:"status,omitempty"`
+
+	// Offset The offset defines the number of the objects that should be skipped in the result response
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Limit The limit defines the max number of objects returned per page.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // ListNodesParams defines parameters for ListNodes.
 type ListNodesParams struct {
 	// Condition The condition contatins the simila QL expression to select nodes by the filter
@@ -246,6 +833,33 @@ type ListNodesParams struct {
 type DeleteNodeParams struct {
 	// Force force flag specifies whether the operation will force the request.
 	Force *Force `form:"force,omitempty" json:"force,omitempty"`
+
+	// Trash trash flag specifies whether the nodes are moved to the trash instead of deleting them.
+	Trash *Trash `form:"trash,omitempty" json:"trash,omitempty"`
+}
+
+// PatchNodeParams defines parameters for PatchNode.
+type PatchNodeParams struct {
+	// IfMatch The expected node version as returned in the ETag header, the request fails if the node version is different.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// UpdateNodeParams defines parameters for UpdateNode.
+type UpdateNodeParams struct {
+	// RemoveAttrs The names of the node attributes to be removed.
+	RemoveAttrs *RemoveAttrs `form:"removeAttrs,omitempty" json:"removeAttrs,omitempty"`
+
+	// IfMatch The expected node version as returned in the ETag header, the request fails if the node version is different.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// DiffNodeRecordsParams defines parameters for DiffNodeRecords.
+type DiffNodeRecordsParams struct {
+	// From The from specifies the time of the first records version to be compared.
+	From DiffFrom `form:"from" json:"from"`
+
+	// To The to specifies the time of the second records version to be compared, the current records are used if it is not set.
+	To *DiffTo `form:"to,omitempty" json:"to,omitempty"`
 }
 
 // ListNodeRecordsParams defines parameters for ListNodeRecords.
@@ -259,6 +873,9 @@ type ListNodeRecordsParams struct {
 	// CreatedBefore The createdBefore specifies the greatest creation time (exclusive) the resulting records can have.
 	CreatedBefore *CreatedBeforeFilter `form:"createdBefore,omitempty" json:"createdBefore,omitempty"`
 
+	// AsOf The asOf specifies the time, the records are returned as they were at the time.
+	AsOf *AsOf `form:"asOf,omitempty" json:"asOf,omitempty"`
+
 	// PageId The pageId specifies from which page to start return results.
 	PageId *PageId `form:"pageId,omitempty" json:"pageId,omitempty"`
 
@@ -266,6 +883,15 @@ type ListNodeRecordsParams struct {
 	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// PatchNodeRecordsParams defines parameters for PatchNodeRecords.
+type PatchNodeRecordsParams struct {
+	// IfMatch The expected node version as returned in the ETag header, the request fails if the node version is different.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+
+	// IdempotencyKey The unique key of the request, the request is executed once and its result is returned for the retries with the same key.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
 // CreateNodeRecordsMultipartBody defines parameters for CreateNodeRecords.
 type CreateNodeRecordsMultipartBody struct {
 	// File The document binary data in the specified format.
@@ -275,29 +901,116 @@ type CreateNodeRecordsMultipartBody struct {
 	Meta *CreateRecordsRequest `json:"meta,omitempty"`
 }
 
-// CreateFormatJSONRequestBody defines body for CreateFormat for application/json ContentType.
-type CreateFormatJSONRequestBody = Format
+// CreateNodeRecordsParams defines parameters for CreateNodeRecords.
+type CreateNodeRecordsParams struct {
+	// IdempotencyKey The unique key of the request, the request is executed once and its result is returned for the retries with the same key.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
 
-// DeleteNodesJSONRequestBody defines body for DeleteNodes for application/json ContentType.
-type DeleteNodesJSONRequestBody = DeleteNodesRequest
+// ListNodeRecordRevisionsParams defines parameters for ListNodeRecordRevisions.
+type ListNodeRecordRevisionsParams struct {
+	// RecordId The recordId specifies the record to filter the revisions by.
+	RecordId *RecordIdFilter `form:"recordId,omitempty" json:"recordId,omitempty"`
 
-// UpdateNodeJSONRequestBody defines body for UpdateNode for application/json ContentType.
-type UpdateNodeJSONRequestBody = Node
+	// Offset The offset defines the number of the objects that should be skipped in the result response
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
 
-// PatchNodeRecordsJSONRequestBody defines body for PatchNodeRecords for application/json ContentType.
-type PatchNodeRecordsJSONRequestBody = PatchRecordsRequest
+	// Limit The limit defines the max number of objects returned per page.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+}
 
-// CreateNodeRecordsJSONRequestBody defines body for CreateNodeRecords for application/json ContentType.
-type CreateNodeRecordsJSONRequestBody = CreateRecordsRequest
+// ListTrashParams defines parameters for ListTrash.
+type ListTrashParams struct {
+	// Condition The condition contatins the simila QL expression to select nodes by the filter
+	Condition *ConditionFilter `form:"condition,omitempty" json:"condition,omitempty"`
 
-// CreateNodeRecordsMultipartRequestBody defines body for CreateNodeRecords for multipart/form-data ContentType.
-type CreateNodeRecordsMultipartRequestBody CreateNodeRecordsMultipartBody
+	// Offset The offset defines the number of the objects that should be skipped in the result response
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Limit The limit defines the max number of objects returned per page.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListWebhookDeadLettersParams defines parameters for ListWebhookDeadLetters.
+type ListWebhookDeadLettersParams struct {
+	// Offset The offset defines the number of the objects that should be skipped in the result response
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Limit The limit defines the max number of objects returned per page.
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// SwitchEngineJSONRequestBody defines body for SwitchEngine for application/json ContentType.
+type SwitchEngineJSONRequestBody = SwitchEngineRequest
+
+// CreateAttrSchemaJSONRequestBody defines body for CreateAttrSchema for application/json ContentType.
+type CreateAttrSchemaJSONRequestBody = AttrSchema
+
+// CreateFormatJSONRequestBody defines body for CreateFormat for application/json ContentType.
+type CreateFormatJSONRequestBody = Format
+
+// DeleteNodesJSONRequestBody defines body for DeleteNodes for application/json ContentType.
+type DeleteNodesJSONRequestBody = DeleteNodesRequest
+
+// UpdateNodesJSONRequestBody defines body for UpdateNodes for application/json ContentType.
+type UpdateNodesJSONRequestBody = UpdateNodesRequest
+
+// PatchNodeJSONRequestBody defines body for PatchNode for application/json ContentType.
+type PatchNodeJSONRequestBody = TagsPatch
+
+// UpdateNodeJSONRequestBody defines body for UpdateNode for application/json ContentType.
+type UpdateNodeJSONRequestBody = Node
+
+// GrantAccessJSONRequestBody defines body for GrantAccess for application/json ContentType.
+type GrantAccessJSONRequestBody = GrantAccessRequest
+
+// CopyNodesJSONRequestBody defines body for CopyNodes for application/json ContentType.
+type CopyNodesJSONRequestBody = CopyNodesRequest
+
+// MoveNodeJSONRequestBody defines body for MoveNode for application/json ContentType.
+type MoveNodeJSONRequestBody = MoveNodeRequest
+
+// PatchNodeRecordsJSONRequestBody defines body for PatchNodeRecords for application/json ContentType.
+type PatchNodeRecordsJSONRequestBody = PatchRecordsRequest
+
+// CreateNodeRecordsJSONRequestBody defines body for CreateNodeRecords for application/json ContentType.
+type CreateNodeRecordsJSONRequestBody = CreateRecordsRequest
+
+// CreateNodeRecordsMultipartRequestBody defines body for CreateNodeRecords for multipart/form-data ContentType.
+type CreateNodeRecordsMultipartRequestBody CreateNodeRecordsMultipartBody
 
 // SearchJSONRequestBody defines body for Search for application/json ContentType.
 type SearchJSONRequestBody = SearchRecordsRequest
 
+// RestoreNodesJSONRequestBody defines body for RestoreNodes for application/json ContentType.
+type RestoreNodesJSONRequestBody = RestoreNodesRequest
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = Webhook
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Cancel the search engine switch
+	// (DELETE /admin/engine-switch)
+	CancelEngineSwitch(c *gin.Context)
+	// Get the search engine switch
+	// (GET /admin/engine-switch)
+	GetEngineSwitch(c *gin.Context)
+	// Switch the search engine
+	// (POST /admin/engine-switch)
+	SwitchEngine(c *gin.Context)
+	// List attribute schemas
+	// (GET /attrs)
+	ListAttrSchemas(c *gin.Context)
+	// Create attribute schema
+	// (POST /attrs)
+	CreateAttrSchema(c *gin.Context)
+	// Delete attribute schema
+	// (DELETE /attrs/{attrName})
+	DeleteAttrSchema(c *gin.Context, attrName AttrName, params DeleteAttrSchemaParams)
+	// Watch changes
+	// (GET /changes)
+	WatchChanges(c *gin.Context, params WatchChangesParams)
 	// List formats
 	// (GET /formats)
 	ListFormats(c *gin.Context)
@@ -310,33 +1023,90 @@ type ServerInterface interface {
 	// Get format
 	// (GET /formats/{formatId})
 	GetFormat(c *gin.Context, formatId FormatId)
+	// List jobs
+	// (GET /jobs)
+	ListJobs(c *gin.Context, params ListJobsParams)
+	// Get job
+	// (GET /jobs/{jobId})
+	GetJob(c *gin.Context, jobId JobId)
+	// Cancel job
+	// (POST /jobs/{jobId}/cancel)
+	CancelJob(c *gin.Context, jobId JobId)
 	// Delete nodes
 	// (DELETE /nodes)
 	DeleteNodes(c *gin.Context)
 	// List nodes
 	// (GET /nodes)
 	ListNodes(c *gin.Context, params ListNodesParams)
+	// Update nodes
+	// (PATCH /nodes)
+	UpdateNodes(c *gin.Context)
 	// Delete node
 	// (DELETE /nodes/{path})
 	DeleteNode(c *gin.Context, path Path, params DeleteNodeParams)
+	// Patch node tags
+	// (PATCH /nodes/{path})
+	PatchNode(c *gin.Context, path Path, params PatchNodeParams)
 	// Update node
 	// (PUT /nodes/{path})
-	UpdateNode(c *gin.Context, path Path)
+	UpdateNode(c *gin.Context, path Path, params UpdateNodeParams)
+	// Get node ACL
+	// (GET /nodes/{path}/acl)
+	GetNodeACL(c *gin.Context, path Path)
+	// Revoke access
+	// (DELETE /nodes/{path}/acl/{principal})
+	RevokeAccess(c *gin.Context, path Path, principal Principal)
+	// Grant access
+	// (PUT /nodes/{path}/acl/{principal})
+	GrantAccess(c *gin.Context, path Path, principal Principal)
+	// Copy node
+	// (POST /nodes/{path}/copy)
+	CopyNodes(c *gin.Context, path Path)
+	// Diff node records
+	// (GET /nodes/{path}/diff)
+	DiffNodeRecords(c *gin.Context, path Path, params DiffNodeRecordsParams)
+	// Move node
+	// (POST /nodes/{path}/move)
+	MoveNode(c *gin.Context, path Path)
 	// List node records
 	// (GET /nodes/{path}/records)
 	ListNodeRecords(c *gin.Context, path Path, params ListNodeRecordsParams)
 	// Patch node records
 	// (PATCH /nodes/{path}/records)
-	PatchNodeRecords(c *gin.Context, path Path)
+	PatchNodeRecords(c *gin.Context, path Path, params PatchNodeRecordsParams)
 	// Create node records
 	// (POST /nodes/{path}/records)
-	CreateNodeRecords(c *gin.Context, path Path)
+	CreateNodeRecords(c *gin.Context, path Path, params CreateNodeRecordsParams)
+	// List node records revisions
+	// (GET /nodes/{path}/revisions)
+	ListNodeRecordRevisions(c *gin.Context, path Path, params ListNodeRecordRevisionsParams)
 	// Health check
 	// (GET /ping)
 	Ping(c *gin.Context)
 
 	// (POST /search)
 	Search(c *gin.Context)
+	// List trashed nodes
+	// (GET /trash)
+	ListTrash(c *gin.Context, params ListTrashParams)
+	// Restore trashed nodes
+	// (POST /trash/restore)
+	RestoreNodes(c *gin.Context)
+	// List webhooks
+	// (GET /webhooks)
+	ListWebhooks(c *gin.Context)
+	// Create new webhook
+	// (POST /webhooks)
+	CreateWebhook(c *gin.Context)
+	// Delete webhook
+	// (DELETE /webhooks/{webhookId})
+	DeleteWebhook(c *gin.Context, webhookId WebhookId)
+	// Get webhook
+	// (GET /webhooks/{webhookId})
+	GetWebhook(c *gin.Context, webhookId WebhookId)
+	// List webhook dead letters
+	// (GET /webhooks/{webhookId}/dead-letters)
+	ListWebhookDeadLetters(c *gin.Context, webhookId WebhookId, params ListWebhookDeadLettersParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -348,8 +1118,8 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
-// ListFormats operation middleware
-func (siw *ServerInterfaceWrapper) ListFormats(c *gin.Context) {
+// CancelEngineSwitch operation middleware
+func (siw *ServerInterfaceWrapper) CancelEngineSwitch(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -358,11 +1128,11 @@ func (siw *ServerInterfaceWrapper) ListFormats(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ListFormats(c)
+	siw.Handler.CancelEngineSwitch(c)
 }
 
-// CreateFormat operation middleware
-func (siw *ServerInterfaceWrapper) CreateFormat(c *gin.Context) {
+// GetEngineSwitch operation middleware
+func (siw *ServerInterfaceWrapper) GetEngineSwitch(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -371,22 +1141,11 @@ func (siw *ServerInterfaceWrapper) CreateFormat(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.CreateFormat(c)
+	siw.Handler.GetEngineSwitch(c)
 }
 
-// DeleteFormat operation middleware
-func (siw *ServerInterfaceWrapper) DeleteFormat(c *gin.Context) {
-
-	var err error
-
-	// ------------- Path parameter "formatId" -------------
-	var formatId FormatId
-
-	err = runtime.BindStyledParameter("simple", false, "formatId", c.Param("formatId"), &formatId)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter formatId: %w", err), http.StatusBadRequest)
-		return
-	}
+// SwitchEngine operation middleware
+func (siw *ServerInterfaceWrapper) SwitchEngine(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -395,22 +1154,11 @@ func (siw *ServerInterfaceWrapper) DeleteFormat(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.DeleteFormat(c, formatId)
+	siw.Handler.SwitchEngine(c)
 }
 
-// GetFormat operation middleware
-func (siw *ServerInterfaceWrapper) GetFormat(c *gin.Context) {
-
-	var err error
-
-	// ------------- Path parameter "formatId" -------------
-	var formatId FormatId
-
-	err = runtime.BindStyledParameter("simple", false, "formatId", c.Param("formatId"), &formatId)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter formatId: %w", err), http.StatusBadRequest)
-		return
-	}
+// ListAttrSchemas operation middleware
+func (siw *ServerInterfaceWrapper) ListAttrSchemas(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -419,11 +1167,11 @@ func (siw *ServerInterfaceWrapper) GetFormat(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetFormat(c, formatId)
+	siw.Handler.ListAttrSchemas(c)
 }
 
-// DeleteNodes operation middleware
-func (siw *ServerInterfaceWrapper) DeleteNodes(c *gin.Context) {
+// CreateAttrSchema operation middleware
+func (siw *ServerInterfaceWrapper) CreateAttrSchema(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -432,38 +1180,38 @@ func (siw *ServerInterfaceWrapper) DeleteNodes(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.DeleteNodes(c)
+	siw.Handler.CreateAttrSchema(c)
 }
 
-// ListNodes operation middleware
-func (siw *ServerInterfaceWrapper) ListNodes(c *gin.Context) {
+// DeleteAttrSchema operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAttrSchema(c *gin.Context) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListNodesParams
-
-	// ------------- Optional query parameter "condition" -------------
+	// ------------- Path parameter "attrName" -------------
+	var attrName AttrName
 
-	err = runtime.BindQueryParameter("form", true, false, "condition", c.Request.URL.Query(), &params.Condition)
+	err = runtime.BindStyledParameter("simple", false, "attrName", c.Param("attrName"), &attrName)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter condition: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter attrName: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteAttrSchemaParams
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+	// ------------- Required query parameter "prefix" -------------
+
+	if paramValue := c.Query("prefix"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument prefix is required, but not found"), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "limit" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	err = runtime.BindQueryParameter("form", true, true, "prefix", c.Request.URL.Query(), &params.Prefix)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter prefix: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -474,34 +1222,62 @@ func (siw *ServerInterfaceWrapper) ListNodes(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ListNodes(c, params)
+	siw.Handler.DeleteAttrSchema(c, attrName, params)
 }
 
-// DeleteNode operation middleware
-func (siw *ServerInterfaceWrapper) DeleteNode(c *gin.Context) {
+// WatchChanges operation middleware
+func (siw *ServerInterfaceWrapper) WatchChanges(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "path" -------------
-	var path Path
+	// Parameter object where we will unmarshal all parameters from the context
+	var params WatchChangesParams
 
-	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	// ------------- Optional query parameter "path" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "path", c.Request.URL.Query(), &params.Path)
 	if err != nil {
 		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params DeleteNodeParams
+	// ------------- Optional query parameter "condition" -------------
 
-	// ------------- Optional query parameter "force" -------------
+	err = runtime.BindQueryParameter("form", true, false, "condition", c.Request.URL.Query(), &params.Condition)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter condition: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	err = runtime.BindQueryParameter("form", true, false, "force", c.Request.URL.Query(), &params.Force)
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter force: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
 		return
 	}
 
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID LastEventId
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Last-Event-ID, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, valueList[0], &LastEventID)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Last-Event-ID: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -509,20 +1285,46 @@ func (siw *ServerInterfaceWrapper) DeleteNode(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.DeleteNode(c, path, params)
+	siw.Handler.WatchChanges(c, params)
 }
 
-// UpdateNode operation middleware
-func (siw *ServerInterfaceWrapper) UpdateNode(c *gin.Context) {
+// ListFormats operation middleware
+func (siw *ServerInterfaceWrapper) ListFormats(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListFormats(c)
+}
+
+// CreateFormat operation middleware
+func (siw *ServerInterfaceWrapper) CreateFormat(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateFormat(c)
+}
+
+// DeleteFormat operation middleware
+func (siw *ServerInterfaceWrapper) DeleteFormat(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "path" -------------
-	var path Path
+	// ------------- Path parameter "formatId" -------------
+	var formatId FormatId
 
-	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	err = runtime.BindStyledParameter("simple", false, "formatId", c.Param("formatId"), &formatId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter formatId: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -533,55 +1335,54 @@ func (siw *ServerInterfaceWrapper) UpdateNode(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.UpdateNode(c, path)
+	siw.Handler.DeleteFormat(c, formatId)
 }
 
-// ListNodeRecords operation middleware
-func (siw *ServerInterfaceWrapper) ListNodeRecords(c *gin.Context) {
+// GetFormat operation middleware
+func (siw *ServerInterfaceWrapper) GetFormat(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "path" -------------
-	var path Path
+	// ------------- Path parameter "formatId" -------------
+	var formatId FormatId
 
-	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	err = runtime.BindStyledParameter("simple", false, "formatId", c.Param("formatId"), &formatId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter formatId: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListNodeRecordsParams
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
-	// ------------- Optional query parameter "format" -------------
+	siw.Handler.GetFormat(c, formatId)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "format", c.Request.URL.Query(), &params.Format)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
-		return
-	}
+// ListJobs operation middleware
+func (siw *ServerInterfaceWrapper) ListJobs(c *gin.Context) {
 
-	// ------------- Optional query parameter "createdAfter" -------------
+	var err error
 
-	err = runtime.BindQueryParameter("form", true, false, "createdAfter", c.Request.URL.Query(), &params.CreatedAfter)
-	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter createdAfter: %w", err), http.StatusBadRequest)
-		return
-	}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListJobsParams
 
-	// ------------- Optional query parameter "createdBefore" -------------
+	// ------------- Optional query parameter "status" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "createdBefore", c.Request.URL.Query(), &params.CreatedBefore)
+	err = runtime.BindQueryParameter("form", true, false, "status", c.Request.URL.Query(), &params.Status)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter createdBefore: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "pageId" -------------
+	// ------------- Optional query parameter "offset" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "pageId", c.Request.URL.Query(), &params.PageId)
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pageId: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -600,20 +1401,20 @@ func (siw *ServerInterfaceWrapper) ListNodeRecords(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.ListNodeRecords(c, path, params)
+	siw.Handler.ListJobs(c, params)
 }
 
-// PatchNodeRecords operation middleware
-func (siw *ServerInterfaceWrapper) PatchNodeRecords(c *gin.Context) {
+// GetJob operation middleware
+func (siw *ServerInterfaceWrapper) GetJob(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "path" -------------
-	var path Path
+	// ------------- Path parameter "jobId" -------------
+	var jobId JobId
 
-	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	err = runtime.BindStyledParameter("simple", false, "jobId", c.Param("jobId"), &jobId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter jobId: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -624,20 +1425,20 @@ func (siw *ServerInterfaceWrapper) PatchNodeRecords(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PatchNodeRecords(c, path)
+	siw.Handler.GetJob(c, jobId)
 }
 
-// CreateNodeRecords operation middleware
-func (siw *ServerInterfaceWrapper) CreateNodeRecords(c *gin.Context) {
+// CancelJob operation middleware
+func (siw *ServerInterfaceWrapper) CancelJob(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "path" -------------
-	var path Path
+	// ------------- Path parameter "jobId" -------------
+	var jobId JobId
 
-	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	err = runtime.BindStyledParameter("simple", false, "jobId", c.Param("jobId"), &jobId)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter jobId: %w", err), http.StatusBadRequest)
 		return
 	}
 
@@ -648,11 +1449,11 @@ func (siw *ServerInterfaceWrapper) CreateNodeRecords(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.CreateNodeRecords(c, path)
+	siw.Handler.CancelJob(c, jobId)
 }
 
-// Ping operation middleware
-func (siw *ServerInterfaceWrapper) Ping(c *gin.Context) {
+// DeleteNodes operation middleware
+func (siw *ServerInterfaceWrapper) DeleteNodes(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -661,20 +1462,836 @@ func (siw *ServerInterfaceWrapper) Ping(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.Ping(c)
+	siw.Handler.DeleteNodes(c)
 }
 
-// Search operation middleware
-func (siw *ServerInterfaceWrapper) Search(c *gin.Context) {
+// ListNodes operation middleware
+func (siw *ServerInterfaceWrapper) ListNodes(c *gin.Context) {
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListNodesParams
+
+	// ------------- Optional query parameter "condition" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "condition", c.Request.URL.Query(), &params.Condition)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter condition: %w", err), http.StatusBadRequest)
+		return
 	}
 
-	siw.Handler.Search(c)
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListNodes(c, params)
+}
+
+// UpdateNodes operation middleware
+func (siw *ServerInterfaceWrapper) UpdateNodes(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateNodes(c)
+}
+
+// DeleteNode operation middleware
+func (siw *ServerInterfaceWrapper) DeleteNode(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "path" -------------
+	var path Path
+
+	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteNodeParams
+
+	// ------------- Optional query parameter "force" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "force", c.Request.URL.Query(), &params.Force)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter force: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "trash" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "trash", c.Request.URL.Query(), &params.Trash)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter trash: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteNode(c, path, params)
+}
+
+// PatchNode operation middleware
+func (siw *ServerInterfaceWrapper) PatchNode(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "path" -------------
+	var path Path
+
+	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchNodeParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchNode(c, path, params)
+}
+
+// UpdateNode operation middleware
+func (siw *ServerInterfaceWrapper) UpdateNode(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "path" -------------
+	var path Path
+
+	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateNodeParams
+
+	// ------------- Optional query parameter "removeAttrs" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "removeAttrs", c.Request.URL.Query(), &params.RemoveAttrs)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter removeAttrs: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.UpdateNode(c, path, params)
+}
+
+// GetNodeACL operation middleware
+func (siw *ServerInterfaceWrapper) GetNodeACL(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "path" -------------
+	var path Path
+
+	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetNodeACL(c, path)
+}
+
+// RevokeAccess operation middleware
+func (siw *ServerInterfaceWrapper) RevokeAccess(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "path" -------------
+	var path Path
+
+	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "principal" -------------
+	var principal Principal
+
+	err = runtime.BindStyledParameter("simple", false, "principal", c.Param("principal"), &principal)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter principal: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RevokeAccess(c, path, principal)
+}
+
+// GrantAccess operation middleware
+func (siw *ServerInterfaceWrapper) GrantAccess(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "path" -------------
+	var path Path
+
+	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "principal" -------------
+	var principal Principal
+
+	err = runtime.BindStyledParameter("simple", false, "principal", c.Param("principal"), &principal)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter principal: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GrantAccess(c, path, principal)
+}
+
+// CopyNodes operation middleware
+func (siw *ServerInterfaceWrapper) CopyNodes(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "path" -------------
+	var path Path
+
+	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CopyNodes(c, path)
+}
+
+// DiffNodeRecords operation middleware
+func (siw *ServerInterfaceWrapper) DiffNodeRecords(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "path" -------------
+	var path Path
+
+	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DiffNodeRecordsParams
+
+	// ------------- Required query parameter "from" -------------
+
+	if paramValue := c.Query("from"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument from is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DiffNodeRecords(c, path, params)
+}
+
+// MoveNode operation middleware
+func (siw *ServerInterfaceWrapper) MoveNode(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "path" -------------
+	var path Path
+
+	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.MoveNode(c, path)
+}
+
+// ListNodeRecords operation middleware
+func (siw *ServerInterfaceWrapper) ListNodeRecords(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "path" -------------
+	var path Path
+
+	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListNodeRecordsParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", c.Request.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter format: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "createdAfter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdAfter", c.Request.URL.Query(), &params.CreatedAfter)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter createdAfter: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "createdBefore" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdBefore", c.Request.URL.Query(), &params.CreatedBefore)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter createdBefore: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "asOf" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "asOf", c.Request.URL.Query(), &params.AsOf)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter asOf: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "pageId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pageId", c.Request.URL.Query(), &params.PageId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter pageId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListNodeRecords(c, path, params)
+}
+
+// PatchNodeRecords operation middleware
+func (siw *ServerInterfaceWrapper) PatchNodeRecords(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "path" -------------
+	var path Path
+
+	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchNodeRecordsParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, valueList[0], &IdempotencyKey)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchNodeRecords(c, path, params)
+}
+
+// CreateNodeRecords operation middleware
+func (siw *ServerInterfaceWrapper) CreateNodeRecords(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "path" -------------
+	var path Path
+
+	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateNodeRecordsParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for Idempotency-Key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, valueList[0], &IdempotencyKey)
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter Idempotency-Key: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateNodeRecords(c, path, params)
+}
+
+// ListNodeRecordRevisions operation middleware
+func (siw *ServerInterfaceWrapper) ListNodeRecordRevisions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "path" -------------
+	var path Path
+
+	err = runtime.BindStyledParameter("simple", false, "path", c.Param("path"), &path)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter path: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListNodeRecordRevisionsParams
+
+	// ------------- Optional query parameter "recordId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "recordId", c.Request.URL.Query(), &params.RecordId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter recordId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListNodeRecordRevisions(c, path, params)
+}
+
+// Ping operation middleware
+func (siw *ServerInterfaceWrapper) Ping(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.Ping(c)
+}
+
+// Search operation middleware
+func (siw *ServerInterfaceWrapper) Search(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.Search(c)
+}
+
+// ListTrash operation middleware
+func (siw *ServerInterfaceWrapper) ListTrash(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTrashParams
+
+	// ------------- Optional query parameter "condition" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "condition", c.Request.URL.Query(), &params.Condition)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter condition: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListTrash(c, params)
+}
+
+// RestoreNodes operation middleware
+func (siw *ServerInterfaceWrapper) RestoreNodes(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.RestoreNodes(c)
+}
+
+// ListWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhooks(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListWebhooks(c)
+}
+
+// CreateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhook(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.CreateWebhook(c)
+}
+
+// DeleteWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhook(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId WebhookId
+
+	err = runtime.BindStyledParameter("simple", false, "webhookId", c.Param("webhookId"), &webhookId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhookId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteWebhook(c, webhookId)
+}
+
+// GetWebhook operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhook(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId WebhookId
+
+	err = runtime.BindStyledParameter("simple", false, "webhookId", c.Param("webhookId"), &webhookId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhookId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetWebhook(c, webhookId)
+}
+
+// ListWebhookDeadLetters operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeadLetters(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId WebhookId
+
+	err = runtime.BindStyledParameter("simple", false, "webhookId", c.Param("webhookId"), &webhookId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhookId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookDeadLettersParams
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", c.Request.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter offset: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListWebhookDeadLetters(c, webhookId, params)
 }
 
 // GinServerOptions provides options for the Gin server.
@@ -704,75 +2321,203 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.DELETE(options.BaseURL+"/admin/engine-switch", wrapper.CancelEngineSwitch)
+	router.GET(options.BaseURL+"/admin/engine-switch", wrapper.GetEngineSwitch)
+	router.POST(options.BaseURL+"/admin/engine-switch", wrapper.SwitchEngine)
+	router.GET(options.BaseURL+"/attrs", wrapper.ListAttrSchemas)
+	router.POST(options.BaseURL+"/attrs", wrapper.CreateAttrSchema)
+	router.DELETE(options.BaseURL+"/attrs/:attrName", wrapper.DeleteAttrSchema)
+	router.GET(options.BaseURL+"/changes", wrapper.WatchChanges)
 	router.GET(options.BaseURL+"/formats", wrapper.ListFormats)
 	router.POST(options.BaseURL+"/formats", wrapper.CreateFormat)
 	router.DELETE(options.BaseURL+"/formats/:formatId", wrapper.DeleteFormat)
 	router.GET(options.BaseURL+"/formats/:formatId", wrapper.GetFormat)
+	router.GET(options.BaseURL+"/jobs", wrapper.ListJobs)
+	router.GET(options.BaseURL+"/jobs/:jobId", wrapper.GetJob)
+	router.POST(options.BaseURL+"/jobs/:jobId/cancel", wrapper.CancelJob)
 	router.DELETE(options.BaseURL+"/nodes", wrapper.DeleteNodes)
 	router.GET(options.BaseURL+"/nodes", wrapper.ListNodes)
+	router.PATCH(options.BaseURL+"/nodes", wrapper.UpdateNodes)
 	router.DELETE(options.BaseURL+"/nodes/:path", wrapper.DeleteNode)
+	router.PATCH(options.BaseURL+"/nodes/:path", wrapper.PatchNode)
 	router.PUT(options.BaseURL+"/nodes/:path", wrapper.UpdateNode)
+	router.GET(options.BaseURL+"/nodes/:path/acl", wrapper.GetNodeACL)
+	router.DELETE(options.BaseURL+"/nodes/:path/acl/:principal", wrapper.RevokeAccess)
+	router.PUT(options.BaseURL+"/nodes/:path/acl/:principal", wrapper.GrantAccess)
+	router.POST(options.BaseURL+"/nodes/:path/copy", wrapper.CopyNodes)
+	router.GET(options.BaseURL+"/nodes/:path/diff", wrapper.DiffNodeRecords)
+	router.POST(options.BaseURL+"/nodes/:path/move", wrapper.MoveNode)
 	router.GET(options.BaseURL+"/nodes/:path/records", wrapper.ListNodeRecords)
 	router.PATCH(options.BaseURL+"/nodes/:path/records", wrapper.PatchNodeRecords)
 	router.POST(options.BaseURL+"/nodes/:path/records", wrapper.CreateNodeRecords)
+	router.GET(options.BaseURL+"/nodes/:path/revisions", wrapper.ListNodeRecordRevisions)
 	router.GET(options.BaseURL+"/ping", wrapper.Ping)
 	router.POST(options.BaseURL+"/search", wrapper.Search)
+	router.GET(options.BaseURL+"/trash", wrapper.ListTrash)
+	router.POST(options.BaseURL+"/trash/restore", wrapper.RestoreNodes)
+	router.GET(options.BaseURL+"/webhooks", wrapper.ListWebhooks)
+	router.POST(options.BaseURL+"/webhooks", wrapper.CreateWebhook)
+	router.DELETE(options.BaseURL+"/webhooks/:webhookId", wrapper.DeleteWebhook)
+	router.GET(options.BaseURL+"/webhooks/:webhookId", wrapper.GetWebhook)
+	router.GET(options.BaseURL+"/webhooks/:webhookId/dead-letters", wrapper.ListWebhookDeadLetters)
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xb3W/cNrb/Vwjde1EbUG2nycs10Ie0RbrGZrdum32qA5gjHs2wkUiVpGwPgvnfF+eQ",
-	"+qY0sh13+7BPzkgUeT5/54v5nGS6rLQC5Wxy+TmpuOElODD063uthHRSq3eycGDwkQCbGVnhw+Qy+bAD",
-	"ljWL8F+OO6kscztgVpay4Ozn9wweKgPW4hKnmYUCMseUFmDZZk9rc79/mkjc9Y8azD5JE8VLSC6T9oAk",
-	"TWy2g5IjIW5f4UvrjFTb5HBIk+8NcAfibe7ALBLcW8dsBZnMJXiaC30P1vkVRK4sgZ3AQ1bUVt7BKS0y",
-	"YOvCSbVlBjJthGUZV2zH7+BsjoPeiQMmcm1K7pLLRHAHX+NpSTrP2XeQawMrWPMLR7xt6d3LcecPfQp7",
-	"77TJYMpQjo9ZXvBtj5P7HbgdGCJVV2A8J/eyKJhf73n4owbr5gimhTFj2mhdAFcNUSV3S8L23I2kHB46",
-	"HWw60OMludkvkISSWjZwT9KVWCRHClAO6THtWRV3u/FRVyJJE5STNCCSS2dqWD78vSyli59c4CsmIJcq",
-	"SKHkD0zV5QYM0znTm98hc5YZcLVRIFgFhlV8O2tRtGFMGFI52IIhgn7KcwszFGl6NyCpI4dMJ5DkdqjB",
-	"na4LwTbA7CdZVSCYVD1fwD+VVhZmqPWHHSP3mm9hTnUVvetZUm50ye53MtvRO4JNx40LIgyE2Tn5+f2O",
-	"WNM1WkWUHIRmhkbDTjprOmX30u2CYKQS8DBjX+HXY2zrA9/aJU9zfGtHfkaPHutl+NFAKv9rIE8uk/85",
-	"76LguX9rz5Go5IDkhScUEQnrfvEn/eJhZsYEycKYtKy2INA7O0ANAIxkVgZRzEmg7YXO6hLUzJYbqbjZ",
-	"M8Edp/2Q6eaLxrAbKYmAB3hGC8ObvYsgcJqgwj/Qw2WR/LNZd0gxU7Bz+vLvGEqdnQRcwh+nqLENdBIZ",
-	"cLDRgjQ3Ic9w9ekfGJSqQjZH5rwuXHL5Ko0db6Q20u1ZpiHPZSZx95Oytg4Pv6kvLl7Dt+zV2cVpI7VG",
-	"M8HEObPATbZrAMCCS2ndHS9qQJ3qOzBGCgGqSWGGRIaVA20wrtBx5J0UNS/CmT2GPUIRw56cObS1pO2G",
-	"ZoKwhjkuBAiUsgt+TK7goLTHdOttGk8P5HBj+J5+oyOsc5a+2//WmVXaeF4wmrQz9I7ZiZ4/tqR4X0ra",
-	"TKj1P0tGsML9uGW8xfGx1tuEqJc3DP2SktWQhS1rxae1ISVaLXx0rJjoA4WLJ3eBbcAP9E2rH4jGGmp3",
-	"nxwXU8APUIADpPdR8Oc0E/QlK72CIUiKO8ZZyAyHMvfI3pYgM94Q8L+tEewZ6x5bZuuq0saxW67Ebcpu",
-	"tbllXAl2q7S77X1F/nnroQrXYRALKx3fntxQBLlJTm9TWimVdVxlkDavq3pTyOwmOWXfspsEI95NQp8H",
-	"9MOntjLAhd0BOPsDdzysOKFIiwvOtdna81ffvH5zvuEF7n/2UNiHm4Rp4+NxIT9Bu/D/bpLT09soYubx",
-	"jJokg/k0Lwp9b3tayXayEAYUmhFnJXfZDgSpKGVwB4pJcpk9E1p95VgJ4HqFG8uMdGAk72jpp9J9i5to",
-	"tSE2ZmzvQuBaMDD/YoOW1AbGEPaG5rThVtrFzJlW9JMw/1jIEpQl01oVSynVWDgGF6RMDrDJzeXv01xp",
-	"4L6c/MazNi9A+3SI9ERZD2+z8JjPHfO9Vo5LhTs3ABnWrobGYAMTcBzbVSAhJoX30rqAWM8LGB6ylmXR",
-	"MnUkRjwzNozY9zvNMf+EeNnxHpKJwLWPMPOBEh7cUqUjRStLeHC+uEFA3YKjnkOoutwoRMfTwjVZUq5r",
-	"JfrbPDcZ0o4XM1UKvuqF48nRR4Kxp6w5IqZLMoV1aNhWaW0WOFLULExR9edBqskqayX/qIHxUgcVWbkp",
-	"pNraplB2BiCqo+p4kZkyocEypR2TKitq4Xs4VDxU3Ljovutz0ubjtZVNHGJDUet62eycgppC6tFKYrgd",
-	"cguqLj2mFWKYLX+MiOIag/UTKlKnWV1ZMI6ykJAHNCjXN9tRiUoLf1nyvbYyoWLPf9DVe1+oKvG0P4IO",
-	"/8GXJmRkLkOq0pG0YiYzVN8T41PA6Ar3Og7SQSPHyolGcQsI1qjh+Gat9NfjYbt12lIcE2DQzJLQsiYN",
-	"kcrnCVjs8Y2uXc8Fu3o8ltwspnSDYBXFKymWTLSX9PW7bI2B/scaIgv9kGjfwsJ2vnvlt+CbApjD2N+4",
-	"4YLU7iBzeqbH5N8Nm2Hh/BV5+jj0UrM0UN+em3Zt+RWtiV+Jv6cBcQUGj2rEzDOjrZ3YZgSN/1skryiS",
-	"4zXy1ui6+m5/zd3upzxfKJZdbZRlOs+btjujTzFd3ezpyLNI3ZuGKUZ035I/yLIuI72b/mhkirZ6YeYx",
-	"3ctpGmiwTRgH9iYIfr7XDhGmJ6GL/kzd83iuix5M3XVvSvTPNl9EffopCs+QFNQSYQmZtF9bcLWtMf0P",
-	"iFMrAabYI2HBC0BtpYLjVXBHahprL4z0nHbzGq+gFb78vKjcYueRsLxQOfpSYoIFqzKXCC9XDsq/XHEz",
-	"R+dauSuGZzQVyUToXidTqYde199hfz+fSTYNsU9hVT9Kd/GrVce0XBkJer4sIgQ7nk+YNulZl7TaTBuY",
-	"S0EKuOMq2zNaND28DaV5oSkYjuL+SNPt/I/OTicCboiJ2cCHUNZx4f2XF9cDZU3ksLLI8uUV3/YttTkV",
-	"6ce8cCqdX/0NFgvmTmbA3l5f3Sj8XroCutdvr68oZTDWf/Xq7OLsgsC6AsUrmVwmr88uzl6HKpL4OO91",
-	"zLYxQH8vreu3ytqrDlcivG1aeyhpb9u02zcXr/APZrwhE+NVVciMPj7/3eLun1dOQJsjSESzyW9oDDkj",
-	"4Q4Es3WWgbV5XRT7MzIOW5clx0Ay4Kopoy9/a7uUH9EzdCxr8hMJxpmC+7ZRmjPpqHegvnIMHnDvvU9M",
-	"h9LyH79rUzmPv99psf/CkvKCGg6+Dy+unyPqueftQGismzR5c/H/i4VNG7OpG8MLzM32XtZ2rNygo05D",
-	"UQ0f0tb4zz83V1EOXVU6JcdPnJq+PutRl3HVbzB4g5C+leTDghL0w+4thgZ/HURMLcQf0VpI/xLcb3EV",
-	"dEvO21s5h48TXb9Zli63LfER1bxe/DijOyvIXU8EVmKm5aUwZpr2PE4Q7kjBfZWBeFNgm5q0MaJG1OBH",
-	"TBUoASqTMDGagXJnICEKkD+C6416hur8EdwL6PLiz/fbWVQ9rko51ORA6J3sZl2URhRLXkm3DXlRTEeJ",
-	"owGvv8U0GRh2peiMN9LA5oXgOjLEXgXdb+Zb2dbniM0MY8ImuwfTesaya4UrBHPuxHHXvJCZm3EmFSTX",
-	"aNZLctaTKCi3I6lpotEo4nGeNL46fEiPfhIu9K1Y6e8ivqiPjmeGM846mAiuToHmNNR63vlnTBXXhEXK",
-	"bE8w0KEzNmP80yWverQu6abgCrX4e7zrwyDRfiQIHvt0FuR68on6QlVHfOFfleCua7wOJehfPkOCH18G",
-	"zfyAeA1+XRwRZk0cflk99ES6wuLPe0PdZbTq9x7ioNVNYV7M3Lsb4ivWR/5/wvqvBnf/V3wWhvB/HTAd",
-	"NtNm4HR8t/EpCdBxk5zYUM8w21Gd79JkkTYNTeyO2CCteb4RvhBixEbG6wHkBUhYson2bi4mUFVoxH1J",
-	"c5jqc84elnoTA3tgXXqcZVA5y9y9ZkLmORhQfiCJf92+Asu+Zig7qlYpeaZRkOCOz/Uy/rKGFb0ej9L2",
-	"RQE3jor/r5G54a6TsdZMzdHdFu9dhm96vot332l9rJdagns6Y5Fm4p/XAIrdhl7jRY/vBw0+R09qtjha",
-	"lETcI+pemAlUqJG5yP834IXbsWwH2afU/wm3Utnb6yvq0oKhiUBFrmRqpaTaRrAZT3kmtI0nUlGZ04gQ",
-	"oaeT81g6faZ6YiESvUz8IINcJAo/fmziB3JLc+OhDPxXL1ReRyfhf3J8iU3wZtQURkUxRTX6COL6eDgc",
-	"Dv8OAAD//8LedpkzOwAA",
+	"H4sIAAAAAAAC/+x9aY/cOLLgXxFyd7E2kK6qPmaBMfA+eOzuGfe4u/3sepgFxg0UU6IyaStFDUlVObfh",
+	"/75gBC9JpETV1d0P88muFI9gMCIYjIu/bkp+7HhLWyU3z3/ddESQI1VUwF8v5M+1/reishSsU4y3m+eb",
+	"ywMtiPy5LmRHS1YzKgt1oIViR7qF/wlaclHJggj9f9WLllYFgVan4oYKWhDlupxtthumh/1XT8Vps920",
+	"5Eg3zzd6hs12I8sDPRINRM3FkajN801FFH2mu262G3XqdGOpBGv3my9ftpsXSomfYIgo3EoJtusVLVoS",
+	"zN0RdQimtkNsN4L+q2eCVpvnSvQ0BCc+81tBa/Y5PreepOigAazew8JkUdGyIYJWRc1FCiXYdyVUL3lb",
+	"MQ3F96xRVMRBK20j/T9FFGtxTyU7soYU//mmoJ87QaXUTRQvJG1oqYqWV1QWuxO0rXH8OOhugs0CtIIS",
+	"RasXtaJiFuCg3YgOG35DpcIWAC470uIJ/Vw2vWTX9KkhUdk3irV7R6wlaYsDuU7SYzjjbejSrOwvtOaC",
+	"ZiwNG47WtodvD7c6nPRWy+uF5KkVwbeC17g/RMN/IO0eJAVl17RCuYE/yqLmTcNvNPhMDYRIEnqcPAo2",
+	"a9X/+daDzFpF91QAzK9YXX8v+DEOdS34MSLi7DJqJqRy+L2mwvLGTrPTsSMiDa8eepaNM5GuF3DJ4+Ar",
+	"PgO8pJohF6A3m9ILQVs1EOq9pFXBar09TBYtV4WkKrVYxW9DT99zUUZkeK1/LuqG7IPV3RyoOlAB4PKO",
+	"CuSMG9Y0BbZHnvhXT2USSmgYE047zhtKWgvUkag55sXVjTBvflTcyMjBIbk7zYCkMTUvMBGk19UsOKyi",
+	"rdLwiMSJV9tR1p0tryt67LiibXn6Oz3FQehb9q+eFp/oyRKf2Ylt+IemI/qZlr2iVcHbkhakrQqmpJFl",
+	"+rtTJWpuUagE7D9TB/hBkiPM5FZ5oKSiwq8zAPiZhjhc3pF8fkPbvTpsnn/9pz/FaPJ1/SNR5SG+Tvq5",
+	"o6WGXh+KjqNIADZrAcjvLsm+QMCGKKgJa6RmK/3jYBStH7C6ppoR02urnyF483v2A9+lqOUj3y2Tyke+",
+	"W6CTHNH7A9+9V0T1co6ZJLQYMZP5cchMH/lujpOwzwAx/1PQevN88z/Ovf57jl/luQMOQH1DpPrumrZJ",
+	"JmPV4GiTVFxT8UzSVhX02ohOc8qhwATx6ej1hpyMcmwOyuQOa0ieASjPXr9afdq9YUem4ito9KeiojVr",
+	"DZaP5HPR9scdhWOb7z7SUgWk3FFRdGSfVCdgwBghhgD9XNeSJiDi8G0AkgdH/2VBUgctbg+8byp9dslP",
+	"rOs8rxnhIajseCtpAlqcbAnct2RPUyTQwbeAUkF5uDmw8gDf4DRWRCiDQgOYTGr6ONc8J7/VfBkFB4QH",
+	"XDeeeH5+CnLSIIa1Ff2c4HDz15qDQIOyePUZ8rGGcWtQZBU/LfNDRZDXcASUB9ZUgrbDCyVvm1Maf2pJ",
+	"Dr4VrC1ZR5oExPZzwUWxF7zv5m6MrvVKvL0DJeB1NScFhWkzwh/+PFEqrpk+MeaEoR1vswTakV9TfaeV",
+	"CSIjR9wid1y5C600mqSAMao0JH6KEBim6FFGoHJijQhBTgDlJdnPHiGK7McHCPy0VhfTnbLPDw0UQieI",
+	"jLCo0j/PKbF4r9bUDvjT0ALk0I+1UlECZ05FGwrXPHWgxyToAMSCZvsPujtw/ikl327w87J2cOPGWcMI",
+	"X+xHtDq9fKP/IRVaDEjzVvCOCsWoXML8WyqODKwUmloixwqcGgV+2BmC6FwnR86On1EmAf+DsDaQ4zgb",
+	"Y/R57xY2MyOYdwwFnvQR5c0/NQ+33Wmzgb1IT90NsGDNEYnzEy57QyMT4qi4IdKaGPSo0euYoKT6uW1O",
+	"ducmfNhmGthA4zn2UmlpQNoR/UyG7TKtZ4HQkahDn9tPTAI6SdP4JtG58Id5ctKbe6nbffkSkvM/vSWu",
+	"Jf7++sssechZ+rBaIZEFceqKXed4D2XRMKnsvWFKG2Q4qROnS0vFPlE5Gy4+HD615kuD3QhlnjoaPzWK",
+	"a9L0VJ4VloClIsfO/ArS8N33L4tvvvnmzwVuo9l780fYUIFSKxUwtPmu8UTb/qiXgD/p/QOlcrMFYag3",
+	"0k672ZpG4Ro99bhzMSGl8qUP4GAkEeRW3xzEyf/imMjZiY3VdcLfoTABpomKrZegYS2ILAsmsea6cNu4",
+	"AN0MFElzgOLOhb84gQonlbkc4xbZX6y4O24LyUGp83OYaZks4DalDrS9nSQ0UOB4SbE32eVyzqiJsHVc",
+	"ouHcKNbm55qa6x7RpkzQNwSVvRHKN/qejneEBFipe5xvkobJ2vWM9cfeOiVVgeUEGjg926jSUznPq0Uh",
+	"+ZNu82VriWsWMGwD5B6yI0yzNZpplN94N7sLzuoXjoqEsdlu+q7C/yDNRSdwWnEOYl+/uj1SR8LUma4N",
+	"+mCpW4sRT9sxQfuSdyeNffkOT4KsE0bxouSdZ7IpQ4Gum6XibjeKz5zWobjQc24L1pZNXxmNFT/YW9U8",
+	"mhRfwoC+T9/+iHUwpk/VNbyg28qXvGM0QVBDYwY019MzVMqmXI8DIjWkbDhS2fE6Aib7mjcVFQN1L0sX",
+	"sKsYagGWR1asa3gUpNc32mxD/IM1D3E6hiVKG9DzR7NrU1CPesv9/evAbwBm+pnJgetsoKsIWiiDTTjs",
+	"rkjX0ba6wtGeVLQmfaOeGpSbE57euMH0bQLFkRzOxlvnSWWieP1KbgsO98EUGJ9opwwMgnYNKakB4kYw",
+	"PbrTgEezowwMdA/z0dpi9NgtV/Y8C7trEjNTylNbmvmY9LZMIj0022LXq+BApzcw/1CUoi6gQVa0xbGJ",
+	"HtsZxWXfdVwonGOIbStgrbzHj0AbAMFmu9FDRcU9EgeaX1aJTy3sHUEbJ2xEA4eJo+NVvOyPmjuZ1Hwq",
+	"A1VOdzoI3vJeoi2+LuD+NbBRAJ9bbZAeO3VyetH4Xr8FVT1L9QdhbkGLA75jLRGnoiLKK5luMdavaNjJ",
+	"6h4DnWZ3UlEt65ghWANmNtLwMuP+9pNtp++WGtcidVrpb3AUFU8QXvjjqbFjuZ0frHnHq7jWJEj76Uft",
+	"gO8aZqcEubB5/tU2bmvkgqlTUXJa16xkevQndo8/9BcX39D/KL46u3jqXWhIDMaiSwpJiSgP1t4tqfGu",
+	"wZVIUxq/pkKwqqKtpbYhkKblYP/gwt5W7JpVPWmsju8XbO5O/mCYP5gszGCxt4sjVeXtW1YdyTqkkHdj",
+	"x1S+/hI5d4BcttbeZ4gmYA2/2Mk+pw8hJ2fupqSM5U5aV/nIdxlOrnWKhVFS7lmXmJvZKxODlad0JNPo",
+	"FV4sc/QTO6y9i5ozzx+oXIRH3ey0/wWneta0ExUDNYJbzR8h4UBnGmE5RqGvKKneUJW0oE+tAS26NZ3z",
+	"BnxvLTfmiYZdU+F52hiEo0YqfXrJHISZUU+F7VQcSWo7BuaAu1/zYalzt/zMazsVIjUFeI3dEqFh9FgB",
+	"UOagtNfqSU9WZTmJtbw7NZwkiPiH9z//VJgWjpSvTUjCZM6b0KMwf7lj1SZsv/WXYlyyh8vicevJZ+mS",
+	"7On7DhbYipKqaHCUBeNrNZwvS0h6GBeNr+Hw8eU2VFG4E7/V3kB6k8nWgQMC2PrGutStcDR6QzDBuxQO",
+	"IJjqnYM6Nj94v5gEBdfGvBy1WQx0D7y36oBF7/wFvaHi7f9WxZFSFcSc+vBVCUZE+OIDxZgsWkqNnoGr",
+	"ifoGAr3Z7VrMHQuLphU69gc3eNQWDcLu64hcf9GegDFldMUVaXJGTixs4RjCddtpQp1pSBoLFLzSrmX2",
+	"9ogqmYWdqIIURvSPWFWc3vXtDH0eySfnbQdI0I4LERyL/KJ1c96rkZO2uJwSJ1dFeaDlJ1rNXOWQ1F00",
+	"d4IsJgxxVvifpb1NF1ekra62xRUXV3Apv2q5ugp6aQiLKzwwdDttzjMtFdk/+QB+rw+bp1dbaMlaqUhb",
+	"0q393PW7hpUfNk+L/yg+bDSPf9hAdxwSfpWdoKSSB0qVfEUUMS2e6LmgwTkXe3n+1dfffHu+I40e/+xz",
+	"Iz9/2BRcoIGxYZ+oa/i/PmyePr2KnkV1PJjU7bM30hsSckKH1wVxcglDVvSZZCTWKSWPBFNUMBLdyCYd",
+	"hzWMuvIUNg4hRMJFK4g9qrbFVxcXWkibu2ac7/lMzFWa8UdRVbHp49OpeABEhMHC6dYEPRSXto3zLh3J",
+	"qYDgE6m4oFXRt4o1uF1E0KLrxX4gxMI4iFCITTjOElJUarG6zr/mQZaFTbKwoZ0lLXZU3VDaFuqG2+DP",
+	"oeXPCNKIOq0v0nMBRM7Ahwk4uvk93LWNNS9/YtNh5dTGXRk9JIFa8gEIY5LusvaxUxzw7+HxqIlRy3ft",
+	"nrX0/Q1LhhNH9TNj5aHQu5DQ/fahIdgf4xJngkGm95D0VUZQInnrUiQIa3pBt1a/MxMyCV8GPBjI6mRC",
+	"hhFsIyzcHGibWE7iHjQd2QI2iE7JuS4diKTJnBfwwUATixAzz5OrTtCOCG0jv9qR8lPNmgYv+6DIXT1N",
+	"RMfwvaBSpgIW8audawiAkdz/vNgWX/1SiKkbnPe7hsZse1IRtWhoDen5PXSY8Q0O9w9Fvgr2l0d2lUl9",
+	"1MZvt8Z6kkvvOgDKdMGrt81CzMxMmdxcTTYP5rko9DQjZQR75m+unj1D0JekxHu7DfEweU9iCRFhnSSi",
+	"b1sMfdH41NADK2q4tIbV0Lj//fuZqINIvIh1EhgXwFA+7YhkcjZfBVqE0dT4c8WOtIUzMcuv8InS7m9M",
+	"Ki5OmTo+WN2qwE1Wjd1vPpVnhy44G7pv27kI3LgWnw6cC9wOgzwBMsghmg2gG9sCMTYN0R2jL9zUO5hF",
+	"EKgli0idmual1YKIMzKbttmns6HLpdPZghDFQq/360eqDjxxPBzhmwbvSMXeRi809DMrSeOC1iU9klax",
+	"0sb2O2vu4bQTzDgtnS+PdYLr3tqHUNQAQvHkSoj6als4L7LxON1Qtj9oYpT90WuD4kga9v/0ryUXVBZP",
+	"rmy7q6cDjhc1mNfwW5S7/ypIq16UJZVZV+5U+Kxhi70ejVZTQvAt18TxjrYyGCS2mz+g5yMT9oHLtSNC",
+	"giu+Hvj5bq9gaR9uTrjt/WlYesZ59Ypl535lmYtv41ON4Heq6yRzWyaRReOKAyaYoFA8OvIKq5rzspoR",
+	"JS9qkosbk3OWn2qWr8hYurpXLcbE8TuPp8uZ87a79RqMX95cgh+vp8z4ke9CIdbRtkK15ZYKzA98d4eD",
+	"DvIL50+5j2aCrGPLOF9nzywYMIbUN0yqewt6W1rWjCV84Bi+o7U7aj9OLR6v5O+spnVrNBgLk0HASH+7",
+	"E0r8KFxU4A51wb4tvTGGZP2X9nRIuPLIlRYJu/x7weUqHC4hMIG2ln5WczmUPo1Wt0TvijYu76my5j49",
+	"sxpJ5/VC3uuafVuFw9w17iTtU4FPwekymXqVPyW2lz/ya/CZrHSZaGPVXChw4gqvg/nGB/H9R/f6Nd1d",
+	"zuFK7zG49zeNxc0ImI0h9KdkMGxUO0avYpwyVkYW4m16UbOBndKqzdQFEIu4H9r9bTxopo5d17RU7Jpe",
+	"kv1cJs+0ZyLf2uSV7jGN02Xxwc+sPVDBlD0HmJKFVlak4sIkMbWUCCr8r9jPhO1Ro+wLGNoeFoksPb/d",
+	"aWOD482ti8QzdTrIkRv+lWzXQF6VuccqQelKXd3lIG2LilNp4oq1kDDbrS8HHRHxO8CqPISV0aDGuTID",
+	"tmlhyY61paD6ugKZ5yY9K50WFYj2yV0hsW/JkC404xj9XAXxiSkWNwm0axLQCFz+wS8leIOR3lOeL5tF",
+	"jn/5ZsBcL7K7ZFDR8kFikKQBHQGRwlU6UXFBJE6SmFDCh7GisXvIW+1LvkXcueJF30kqVGCdzHALYsN3",
+	"c+pQNGpkkEZ4D7oRwr4CDuxw34CMyGUI1XaErRjJDLfvbveOTo+1rDdXefGsI4N13B9vsbo0mMP+7GjZ",
+	"QrQgtS21AIvOMqFE9wou+hYj0Q0a2BhTwsQ1som2/hd7QBnjpr7JaXuEPXSdVZVCOCIk22y2G1IdWRtl",
+	"eEOKWdEArEWsAMp2vFeT4K6UdX3WpzC4MK2yCmKXwDAY1pBphyHJfrQjVeSOGpWZWI+kfUnb0Ldl7esm",
+	"zgPDNDBAkamil+6yqHs/eVrUfVvaNJ0JvTxWrsZMqkY0pULSfToVB4cgu4YWSt+Vhxmo0T25pqVK2ZTx",
+	"2zCzx8yf5WiblQNm+TnqFNoSp+R6Vw0K7JsWoQ4VbtysRI5BHEoWM8dieIbJbxFmNsEPmWcbz207doc5",
+	"j3V6qc6+lK8cOQeqJiZzHNkTIBKouvKoi0UMW94iPoeDqAEsL7xlfOqNFU425yHcD7pwjx0SPcAXQU7+",
+	"ZVXMboa5ukmtQbTl1IuBfTP9FtekYVW6KurcEo1tQ6/QuAVu6ZBwy3VbFMI12Id5beAdxgDeJpjYhA9O",
+	"7QxTpr1dZG5QR3kyx3aQUmtlcjz0fh6VE+CW0XQfTgWLvaRWaxqsSI+3PTLMpW7w2GLfw+GZzgs35zMk",
+	"XGGys/H0m7TjXtqyrn3TPIPz13SJheDgTl7Z4AAzhj5w5Kj0WFBwGxSMkRPShhlAda+CHne0qnwNnEGQ",
+	"QSH6VhY7rg7QSwctOAmNZQeL17Ut27sdxF5B99g67KaHOrDBCxyuuLzNdoOARFVhxPztLr8dFVqQWKBI",
+	"KbiU09yHKaEh+OkSdvHVPqFn+7PiqtvXSmJkXrdXgu0FOV6FWcHgeY9iMo49ICjzm1UaXT1Da2nDCqJa",
+	"VNRs3/vCJtNYyX/nBCznBCRSAvqcOJRBbNCX7QYKwf3lpMtd/lzXM3qK3lOt9dXutgJd9dVkdxobs7KT",
+	"A9ixP0ayYsNqrNMDPSe9PpCJ2UkCgb1G5wcUO1OwPihzijmurtLpFDYrN/4B0Ump2oP6mw1dddyKHTH4",
+	"yZ2QPgTKxFRti4uzPwU8OlBI6oYT5eHyly8t1P9Ty9k4RPozymHkJPivY2c9POZKkFJjSBOpdwdg24a0",
+	"+z5IH+vbiormpPE1kBsZrjMH6jaWsDAi2K2vdYuUlj4g78nI5W67C1auGac6OksnYj7LEBhZy2tFj787",
+	"920Kzly8t4Wewx8gI6T7HJ0h1s0J/l4zUXwu5K+hKcPMYjqPzDJb/zAAokkdqKB5fGcSrf5OTzdpO7Fp",
+	"VHwyrUKTlL9Y5taQzQ01mzGtrL1FyjS2BW3oNWnLUwzveTi0kvF2e+rl6j1tatxB42514x232InyCASz",
+	"Y1D8Su0RD6X8FJpbKo1X3X4vOG/35GobaozbkSaJFiinSI6SH5aFvgEvhqR78GbPlKkk+3gVXD3rW7Iu",
+	"mSko8j2coHhhSlfpv0zNb4wHwDdXjO3nqmwoEVfOLmQ1cFdDQ/e50mJiMMjW3aSuJFVXfhIsRcOFs9RM",
+	"yQJmXMwlCGvPumrTw2rYMVOU/hgfG5Zg0BQfL1/aGcUuqzbOZJuxxsltrCnGtJtKzT57IKPKjDEFITCO",
+	"VJE0p6Cr3dH2Et6wYUYmqR/1lyVE34c9xuA/qX71+dVrwgGr23nyqmSEkqlDnp1oZAuTy37nWrstRpUR",
+	"aoiYOy/+H9j97c/vL32IUS8ajb6wxMmgIp5k+9bXwJC0FNSVyPu/z96D1ebZe7ZvieoFNc+7LGQO3K70",
+	"Ni5hqQyMTWZn11RsnUQKlu8L9bAay8gN5EhYmfXMFVKFv1w1VfgLGcgd6b6t+du1Nn/PlGAdiyq2qhj9",
+	"HVII7EZb9JjzwfwZe4TCYy9ZOhdpJGVi1N+8dsL2rXGOW8ozL2tRbQcKXz+bTNOLxJ2F7CRvekWLg1Ld",
+	"E/m0+K93bwqV4IGzvBwBPdkM194hwN7s6VI0+k0wUdb9z0C2GAniBp4uTzfVjvnp4pDtwVzHSlq8ePv6",
+	"Q6sRyVRD/ecXb19vAh/p5quzi7MLrGlMW9KxzfPNN2cXZ9+YGC9Y0jnEE5yjnvdMBnniwD3TTDpIfUB9",
+	"HvMjoorunIVSKnLylTzPoBCxKar8unJTDBLXtxu7jQD01xcX+h/tADWea9J1DSthjPOPEs1teU9oDOaB",
+	"LUhnbctiR/WKbf7HmUbutxffTtH0Ew86GUSdAS3I/ngk4jREZQyFGxuX+M/NC4z50GbBGKP/1dQEsRti",
+	"DiXzRlT8FjJE+V+p+h3iG52ZSjB6DSmJEDmofSGnHMTfEFu8bYh2i61snHc8pn2+dxc93jZ+BF/HK0L6",
+	"Ydl6007Ht08M+LueNe7Q12n72rjWjp5JTJr/QUrY0Fr8aMSc9BVKIunuQ5II78DmiRUq1V94dbo3Uohd",
+	"s78M5aUSPf0yocavfytqNCUfYrR4kVOCoCStKV+Ig+KpjARkxvnzdJwXLRZoXpQouI4pdUfI+st2c+4i",
+	"66NSRefsDB9+kO5VEJtr5MpI4zMl5mEteK9pSlF6wPCVkgcUMuE0iV1NPnWSkDcDRANuJiOEaLbf5IwI",
+	"eYUvbAQv9YyeKcl4rqcYrsVEqoVvPBdS63akcXuE5bKZkjCpdw7aq6t91YbX7rWn0QENmrfH8QOJh2CC",
+	"LKnw1YPNvEw9g6iUfOkwGQYC1iAAJS0OLscvepNGUFKdhrtuYkpr9nmLqQKDt7+BloiRKxhfPlJOYC0T",
+	"AFMk7sTJ+a/2TfMvc2rkKx9W7qdA6MjgZm2XMGxpHuAZ2AlcqfoJveJkA3oNX5//Z5wYfJNz99L7l21W",
+	"W/NA4ZdfJjT6bSYdhHFmeYpPchh94IApfbzFZg/WbLGxpCbPjPdKUHJMGV3dhZeJUR1JU3pk8qCpPCu+",
+	"w1q1+q/iQEKDrn3tm0h8H6hyJTklguHLs/XHMDEWFOPhg6nW3mKGdPupWcd8GryJau0uEN5CGbBRYBl2",
+	"q9cKaBCTbsQkGnoAyOBiPyTaf2iT3UuD77UEG7ySmUGy44f7c7oAnnJaho/aRhhifOZrL/M57M0zRFC+",
+	"1Lal2xJaHNLEIDzWENnWWyswNHlQNA+HNbaFeXEePDHtpfiA52BXLXUEnGY3GtksqGOTVs2CAjZTPcsW",
+	"3HnAQ9JOkcA3grdaqaod4BY1dp60HmXPKrhMmXnRi1hxKnXZTKiDXpyoSikz37sA7QdQZMzgj6zEhLMm",
+	"t2dBbfnzbLaHU0jBUWm1EMC1TOgTfoeiOxwQ//mv9qn4HEUC254VAXQlacM0M+dW1uchmgdbLCYvT1LR",
+	"o3kBukqpD45C1kli92p+vjIQbM2MCvDNbOdxqXwchbUlNVgYL3pOrQgACpWJZQJBUoDrhr/5WmiqnqLr",
+	"oKNtRduSUZnQUGYIZsYi5ovCTWxdD7CXF4/Pt2tNY0F3NqMWetwlWdRWoFmwG4RVdiYviugxkF8boqhU",
+	"8EOx5+h6j59qUFpn7a65ykD52o15Lz5Hu4HYtgelB1h0ghrCakHZR+xHxKLdWhjf7+v5rx/5zgjdWWvz",
+	"4vZGWU/XI7rFDj4002mw0ji+NbuZKmmzvPaR75Z34xxdDnqWhA7knQnLG1NcRmqOmWuOBtkE2Oo/cd4G",
+	"+6av2Dj9H3dzrUfnbns7cyKa1lZJqlnL5IFWCZ9QkiYwL2hGH7qEPWuaaaX5UUQMOqLT71vA1VZ/ucIi",
+	"7KPwJ2060k4G5pQUNCsuPlaAAVLezxzTtX4y2U8PoYxHHn3IUswvHgIC+3BKgjpnEflkVCD/KRBgUqu0",
+	"FetVebDOocmeY91ylzM4R884XFKrgzt03bBSjSkcV+8S3CyN45YnFTo4uFz5t6lmYClmney5heHjd6Ua",
+	"jOvzzZLRWjUhvUNdOv4SRY9ekIm91L1N/QnFXXhQpgiabHUQrfZA4iESePjI4mEakTcvHaigrj5nvudh",
+	"yvtcjDeMCGoDtmbc7YFgSW3jkLpwgUn6csfc+a/a0ZVz+2/hSVx9n9cEZuOnns4dL7cyqeaw/fdclFmO",
+	"gkt4sSTfLOAqlrkDgItI9bKnK1QYN+SSj8CUfcsWBS+6zjzGO+R/HwcneSH50Xw3hnpJFUZF46rss0aC",
+	"ahXVHlrgsvLayev62Y8wOhrk4a1bwa9ZRautm07/alkEnglmGGlnU8rZXDgSxM4+KMm8rn/EYN5fHkam",
+	"heHCjyrKsKRgWnoNaguHZItb990l2dt9HZjktRUxrPozL+oCEmRySaAl2WJBF3K05MrPeYk4otExj8HW",
+	"+Bj+OJ/1EaUoEKR4pXPhA2GeQcB2YSiHTz8wzxs9GkP5A+7BOOodiBBbFPI3Z0DPB/9tee/3wUoBR2Ro",
+	"FuemzOCsmStVtDBSgNOX9prU4fQj2bKbJnJHh2MFHQchP5qNLMFEjWm2BOPt2OiXB6Y+DdkcAb54+cYa",
+	"1rAuQb6XYwXa7qb/aCqwwGZS1PmvnWBtyTrSzOquKKOQcGwHLZUhkXpENS9evtmuJzE4BPTSCNSntEUh",
+	"7kBz7+g1/0Tx/Y4HE95vLTZ+UxIN8INEqlf+u6RR3BQjo/K1B3iKZURUQxht9cgJdQaKgEtWMdRYMHV3",
+	"yeYfiXk0Irv/Qz/y1M1voALkU7h5TGeFDWFAGCNCMpbxMJTxN5flQPBJNpnI8pJ3pxmHC+9OHkyMp2ya",
+	"UQJXPNwNGQSW2+7HZduDvAANBsjwoJT7yO3Cu9PtTJ+BCnD/pO/AWkX4Xz3E/EsGNPT+8I6tonzo6Y3f",
+	"pj9rwfJtqzJD2K3+SdMEv2l16iiWNr93jdlH4jiymY/G0cSbqyXrV2OTavJLfuxsDHtYINqEdJ7MI7AK",
+	"ilR2nLXmuTIWuxnqp23xGQZbJ/lhhL+eBwr8Zba95A+rikyf9E1QrEVu8JDvrFt6TZzi3eyErK4H+x8Q",
+	"lqtyPSUtW4kgLmL1qxxop4AAM288nGofmgS91dApIfchY+3bIL8vETt+heWRVYvRgylLAvbI1xHoSL5i",
+	"9z+OeAXCzRWvwQtC867PsAxU3AP60HITo8BWRImbIgS1omJ1r79AEEp+txfy5zpL88eXoX4/Htwsye/f",
+	"+7KVttZHIy2L8QmtRcV40u0TWLKdqptrVg4fVuxM4S1rWlZjy2BoX3YzVPTYcUXb8vTs7/Q0M5HGnPRV",
+	"qfAsdDyuB9WFcIxdKqhmartgcaCgdEDCYfTQ7OjM1hlNPXL+Tk8PdirF3hx55JMp8m7GgjJ1QwOS+6Na",
+	"wrf2Ds78TgMV6ykh3B3SOk3GnyV6LuI8oDuCcugee59xWi2IirmUjaGo8AEkZUk7hYXtraqL9e591Zln",
+	"haYLLDusI9ugZm9FFPn9CQRc7KNIhMdhc1zQhM+3G9iJjggFWRzP9HYMR52U3kqEMDolb8daIk6wsa5y",
+	"oHmtvQpC/P3LEdB+7q2QWy0sUqnlEc0ZQ6iyZVoysec+KyisAE7HwFrjHgQpe17zaUhTbcAuwwcoz0vO",
+	"AQ600LQDTGITH0Fobjfffp0OCZa6Sm8PVk69O6mkqdX3avcq7HKWhnstwr/2VU1eEwkhyHlg1gSYGyBc",
+	"3DgqdMM3ZOT4if8An7T7G5OKixPGHkuqlu4+7qneBwwt0PO8rv7AIaPxV40XLx7+ueJHuXr4GZM0r6uc",
+	"Jyn8b5Q06lCUB1p+2uI/9i7x4u1rW/oeAlmA9l1NlYk+b54Dv8uWjEuexR0y2lSlkeTxOsZSuKgALQAi",
+	"4gQLv6SNalj/GSuLz71tMKoGhKM+UB2g2GsNj3xtiJUiT2wTYji6UXY/DLpwRyAsc1kQmzjaSUCn0Ytt",
+	"OK0NloQ4Nt0QGNQGVg/edokLS4w7/XeofHRvB/hbHTI/fr3HkgOiPKCGc/NuTJpN36UeBVqMmp8GJjHh",
+	"hhi4JtEqLmNhHv6Vngfi+dh7SY/M8pG3iJYj7c3GrShHN9y9FXHyjgQyqCosV5kWM7ZVXDC42poPiHM3",
+	"RwLTBsLVnHfjYbfocVNlFo6IFfhF2Wu/mGIxMihuagu6HkjXUfv6KMQAmHzLlDXAQPdA3GVHf+SL6mDa",
+	"9OberlZW0mn55/nive4+war8GhU3bnsi5BQy3Pmv5n95dSosUEMZrQmmoqQqGqoUFTKVuOKJZt3h/Q8L",
+	"4op0k3Cz1leiCnsvJZfM4nqmwIPpF42MfRBMXfwWjHKb25btv5R6fisqP9eU+sxQ6rJaO5ST47okpra1",
+	"0Xct3ChGrU6LGBgWi8BRF8pFmMW8oqR6Y8C9Az38wRTecNUJMgtFzsKJe0diC8/owbQJ0vvy5f8PAOWZ",
+	"nEAvzgAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -8,6 +8,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/rodaine/table"
 	"github.com/simila-io/simila/api/gen/index/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
 	"sort"
 	"strings"
 )
@@ -43,6 +44,22 @@ type (
 	cmdListNodes struct {
 		cs *Commands
 	}
+
+	cmdListJobs struct {
+		cs *Commands
+	}
+
+	cmdGetJob struct {
+		cs *Commands
+	}
+
+	cmdCancelJob struct {
+		cs *Commands
+	}
+
+	cmdListAttrs struct {
+		cs *Commands
+	}
 )
 
 const (
@@ -55,6 +72,10 @@ func New(ctx context.Context, isc index.ServiceClient) *Commands {
 	cs.cmds = append(cs.cmds, cmdListRecords{cs: cs})
 	cs.cmds = append(cs.cmds, cmdSearch{cs: cs})
 	cs.cmds = append(cs.cmds, cmdListNodes{cs: cs})
+	cs.cmds = append(cs.cmds, cmdListJobs{cs: cs})
+	cs.cmds = append(cs.cmds, cmdGetJob{cs: cs})
+	cs.cmds = append(cs.cmds, cmdCancelJob{cs: cs})
+	cs.cmds = append(cs.cmds, cmdListAttrs{cs: cs})
 	return cs
 }
 
@@ -224,6 +245,20 @@ func (c cmdSearch) Run(prompt string) error {
 				return fmt.Errorf("the limit value %s is wrong. It must be a positive number", v)
 			}
 			req.Limit = cast.Ptr(int64(limit))
+		case "mode":
+			m, ok := index.SearchMode_value[strings.ToUpper(strings.Trim(v, Spaces))]
+			if !ok {
+				return fmt.Errorf("the mode value %s is wrong. It must be lexical, semantic or hybrid", v)
+			}
+			req.Mode = cast.Ptr(index.SearchMode(m))
+		case "fusion":
+			f, ok := index.FusionMethod_value[strings.ToUpper(strings.Trim(v, Spaces))]
+			if !ok {
+				return fmt.Errorf("the fusion value %s is wrong. It must be rrf or weighted", v)
+			}
+			req.Fusion = cast.Ptr(index.FusionMethod(f))
+		case "engine":
+			req.Engine = cast.Ptr(strings.Trim(v, Spaces))
 		case "as-table":
 			if err := json.Unmarshal(cast.StringToByteArray(v), &asTable); err != nil {
 				return fmt.Errorf("the as-table value %s is wrong. It must be a boolean value true/false", v)
@@ -253,11 +288,16 @@ func (c cmdSearch) printAsTable(srr *index.SearchRecordsResult) {
 	tbl.WithHeaderFormatter(headerFmt)
 
 	for _, r := range srr.Items {
+		// no keywords are matched by the semantic search
+		var kw string
+		if len(r.MatchedKeywords) > 0 {
+			kw = r.MatchedKeywords[0]
+		}
 		tbl.AddRow(
 			cutStr(fmt.Sprintf("%.2f", cast.Value(r.Score, -1.0)), 5),
 			cutStr(r.Path, 16),
 			cutStr(fmt.Sprintf("%v", r.MatchedKeywords), 40),
-			cutToKeyword(r.Record.Segment, r.MatchedKeywords[0], 80),
+			cutToKeyword(r.Record.Segment, kw, 80),
 		)
 	}
 
@@ -295,6 +335,9 @@ search <params> - returns the search results. It accepts the following params:
 	filterConditions=<string> - the filter conditions
 	groupByPathOff=<bool> - the flag turns off results grouping by path
 	limit=<int> - the number of records in the response
+	mode=<string> - the search mode: lexical, semantic or hybrid
+	fusion=<string> - the hybrid search results merging method: rrf or weighted
+	engine=<string> - the search engine name, e.g. pgfts or pgtrigram
 	as-table=<bool> - prints the result in a table form
 `
 }
@@ -329,6 +372,223 @@ func (c cmdListNodes) Prefix() string {
 	return "ls"
 }
 
+// -------------------------------- cmdListJobs ----------------------------------
+
+func (c cmdListJobs) Run(prompt string) error {
+	req := &index.ListJobsRequest{}
+	var asTable bool
+	params := parseParams(prompt)
+	for k, v := range params {
+		switch k {
+		case "status":
+			st, ok := index.JobStatus_value[strings.ToUpper(strings.Trim(v, Spaces))]
+			if !ok {
+				return fmt.Errorf("the status value %s is wrong. It must be pending, running, done, failed or canceled", v)
+			}
+			req.Status = cast.Ptr(index.JobStatus(st))
+		case "offset":
+			var offset int
+			if err := json.Unmarshal(cast.StringToByteArray(v), &offset); err != nil || offset < 0 {
+				return fmt.Errorf("the offset value %s is wrong. It must be a non-negative number", v)
+			}
+			req.Offset = int64(offset)
+		case "limit":
+			var limit int
+			if err := json.Unmarshal(cast.StringToByteArray(v), &limit); err != nil || limit <= 0 {
+				return fmt.Errorf("the limit value %s is wrong. It must be a positive number", v)
+			}
+			req.Limit = int64(limit)
+		case "as-table":
+			if err := json.Unmarshal(cast.StringToByteArray(v), &asTable); err != nil {
+				return fmt.Errorf("the as-table value %s is wrong. It must be a boolean value true/false", v)
+			}
+		default:
+			return fmt.Errorf("unexpected parameter %s", k)
+		}
+	}
+	jobs, err := c.cs.isc.ListJobs(c.cs.ctx, req)
+	if err != nil {
+		return err
+	}
+	if asTable {
+		c.printAsTable(jobs)
+	} else {
+		b, _ := json.MarshalIndent(jobs, "", "  ")
+		fmt.Println(string(b))
+	}
+	return nil
+}
+
+func (c cmdListJobs) printAsTable(js *index.Jobs) {
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	columnFmt := color.New(color.FgYellow).SprintfFunc()
+
+	tbl := table.New("Id", "Status", "Path", "Parser", "Records", "Error")
+	tbl.WithHeaderFormatter(headerFmt).WithFirstColumnFormatter(columnFmt)
+
+	for _, j := range js.Jobs {
+		tbl.AddRow(
+			j.Id,
+			strings.ToLower(j.Status.String()),
+			cutStr(j.Path, 32),
+			j.Parser,
+			j.Records,
+			cutStr(j.Error, 40),
+		)
+	}
+
+	tbl.Print()
+}
+
+func (c cmdListJobs) shortDescription() string {
+	return "list jobs <params> - allows to request the asynchronous records creation jobs"
+}
+
+func (c cmdListJobs) description() string {
+	return `
+list jobs <params> - lists the asynchronous records creation jobs, the latest jobs go first. It accepts the following params:
+
+	status=<string> - the job status: pending, running, done, failed or canceled
+	offset=<int> - the number of jobs to skip
+	limit=<int> - the number of jobs in the response
+	as-table=<bool> - prints the result in a table form
+`
+}
+
+func (c cmdListJobs) Prefix() string {
+	return "list jobs"
+}
+
+// -------------------------------- cmdGetJob ----------------------------------
+
+func (c cmdGetJob) Run(prompt string) error {
+	id, err := parseJobID(prompt)
+	if err != nil {
+		return err
+	}
+	job, err := c.cs.isc.GetJob(c.cs.ctx, &index.JobId{Id: id})
+	if err != nil {
+		return err
+	}
+	b, _ := json.MarshalIndent(job, "", "  ")
+	fmt.Println(string(b))
+	return nil
+}
+
+func (c cmdGetJob) shortDescription() string {
+	return "get job <id> - prints the asynchronous records creation job"
+}
+
+func (c cmdGetJob) description() string {
+	return `
+get job <id> - prints the asynchronous records creation job by its id, including its status and the number of the records written
+`
+}
+
+func (c cmdGetJob) Prefix() string {
+	return "get job"
+}
+
+// -------------------------------- cmdCancelJob ----------------------------------
+
+func (c cmdCancelJob) Run(prompt string) error {
+	id, err := parseJobID(prompt)
+	if err != nil {
+		return err
+	}
+	job, err := c.cs.isc.CancelJob(c.cs.ctx, &index.JobId{Id: id})
+	if err != nil {
+		return err
+	}
+	b, _ := json.MarshalIndent(job, "", "  ")
+	fmt.Println(string(b))
+	return nil
+}
+
+func (c cmdCancelJob) shortDescription() string {
+	return "cancel job <id> - cancels the asynchronous records creation job"
+}
+
+func (c cmdCancelJob) description() string {
+	return `
+cancel job <id> - cancels the pending or running asynchronous records creation job by its id. The records written before the cancellation are kept
+`
+}
+
+func (c cmdCancelJob) Prefix() string {
+	return "cancel job"
+}
+
+// -------------------------------- cmdListAttrs ----------------------------------
+
+func (c cmdListAttrs) Run(prompt string) error {
+	var asTable bool
+	params := parseParams(prompt)
+	for k, v := range params {
+		switch k {
+		case "as-table":
+			if err := json.Unmarshal(cast.StringToByteArray(v), &asTable); err != nil {
+				return fmt.Errorf("the as-table value %s is wrong. It must be a boolean value true/false", v)
+			}
+		default:
+			return fmt.Errorf("unexpected parameter %s", k)
+		}
+	}
+	ass, err := c.cs.isc.ListAttrSchemas(c.cs.ctx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+	if asTable {
+		c.printAsTable(ass)
+	} else {
+		b, _ := json.MarshalIndent(ass, "", "  ")
+		fmt.Println(string(b))
+	}
+	return nil
+}
+
+func (c cmdListAttrs) printAsTable(ass *index.AttrSchemas) {
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	columnFmt := color.New(color.FgYellow).SprintfFunc()
+
+	tbl := table.New("Prefix", "Name", "Type")
+	tbl.WithHeaderFormatter(headerFmt).WithFirstColumnFormatter(columnFmt)
+
+	for _, as := range ass.AttrSchemas {
+		tbl.AddRow(
+			cutStr(as.Prefix, 32),
+			as.Name,
+			strings.ToLower(as.Type.String()),
+		)
+	}
+
+	tbl.Print()
+}
+
+func (c cmdListAttrs) shortDescription() string {
+	return "list attrs <params> - allows to request the node attributes schemas"
+}
+
+func (c cmdListAttrs) description() string {
+	return `
+list attrs <params> - lists the node attributes schemas ordered by their prefixes and names. It accepts the following params:
+
+	as-table=<bool> - prints the result in a table form
+`
+}
+
+func (c cmdListAttrs) Prefix() string {
+	return "list attrs"
+}
+
+func parseJobID(s string) (int64, error) {
+	var id int64
+	if err := json.Unmarshal(cast.StringToByteArray(strings.Trim(s, Spaces)), &id); err != nil || id <= 0 {
+		return 0, fmt.Errorf("the job id %q is wrong. It must be a positive number", s)
+	}
+	return id, nil
+}
+
 func parseParams(s string) map[string]string {
 	vals := splitParams(s)
 	res := map[string]string{}
@@ -31,3 +31,15 @@ func TestSplitParams(t *testing.T) {
 	assert.Equal(t, []string{"a", "b  c", "d"}, splitParams("   a=b  c=  d"))
 	assert.Equal(t, []string{"a", "b  c=  d"}, splitParams("   a=b  c\\=  d"))
 }
+
+func TestParseJobID(t *testing.T) {
+	id, err := parseJobID(" 12 ")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(12), id)
+	_, err = parseJobID("")
+	assert.NotNil(t, err)
+	_, err = parseJobID("abc")
+	assert.NotNil(t, err)
+	_, err = parseJobID("-1")
+	assert.NotNil(t, err)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/simila-io/simila/cmd/scli/commands"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"os"
 	"path/filepath"
 	"strings"
@@ -21,7 +22,8 @@ var (
 	logger          = logging.NewLogger("scli")
 	historyFileName = filepath.Join(os.TempDir(), ".scli_history")
 
-	addr = flag.String("addr", "localhost:50051", "the address to connect to")
+	addr  = flag.String("addr", "localhost:50051", "the address to connect to")
+	token = flag.String("token", "", "the API bearer token, if the authentication is turned on")
 )
 
 func main() {
@@ -35,6 +37,9 @@ func main() {
 	defer conn.Close()
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	if *token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+	}
 	sc := index.NewServiceClient(conn)
 
 	line := liner.NewLiner()
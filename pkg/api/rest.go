@@ -6,14 +6,23 @@ import (
 	"github.com/acquirecloud/golibs/cast"
 	"github.com/acquirecloud/golibs/errors"
 	"github.com/acquirecloud/golibs/logging"
+	"github.com/gin-contrib/sse"
 	"github.com/gin-gonic/gin"
+	"github.com/simila-io/simila/api/gen/admin/v1"
 	"github.com/simila-io/simila/api/gen/format/v1"
 	"github.com/simila-io/simila/api/gen/index/v1"
+	"github.com/simila-io/simila/api/gen/webhook/v1"
 	similapi "github.com/simila-io/simila/api/genpublic/v1"
+	"github.com/simila-io/simila/pkg/auth"
 	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"net/http"
 	"path"
+	"strconv"
 	"strings"
 )
 
@@ -33,10 +42,21 @@ func NewRest(svc *Service) *Rest {
 }
 
 func (r *Rest) RegisterEPs(g *gin.Engine) error {
-	similapi.RegisterHandlersWithOptions(g, r, similapi.GinServerOptions{BaseURL: "v1"})
+	// the handlers pass the gin context to the service, so the incoming metadata of the request context must be visible
+	g.ContextWithFallback = true
+	similapi.RegisterHandlersWithOptions(g, r, similapi.GinServerOptions{BaseURL: "v1", Middlewares: []similapi.MiddlewareFunc{authMetadata}})
 	return nil
 }
 
+// authMetadata puts the Authorization header to the incoming gRPC metadata of the request context,
+// so the REST requests are authenticated the same way as the gRPC ones
+func authMetadata(c *gin.Context) {
+	if v := c.GetHeader(auth.MetadataKey); v != "" {
+		md := metadata.Pairs(auth.MetadataKey, v)
+		c.Request = c.Request.WithContext(metadata.NewIncomingContext(c.Request.Context(), md))
+	}
+}
+
 func (r *Rest) ListNodes(c *gin.Context, params similapi.ListNodesParams) {
 	nodes, err := r.svc.IndexServiceServer().ListNodes(c, &index.ListNodesRequest{FilterConditions: cast.Value(params.Condition, ""),
 		Offset: int64(cast.Value(params.Offset, 0)), Limit: int64(cast.Value(params.Limit, 100))})
@@ -46,17 +66,90 @@ func (r *Rest) ListNodes(c *gin.Context, params similapi.ListNodesParams) {
 	c.JSON(http.StatusOK, similapi.ListNodesResult{Items: nodes2Rest(nodes.Nodes)})
 }
 
-func (r *Rest) UpdateNode(c *gin.Context, path similapi.Path) {
+func (r *Rest) UpdateNode(c *gin.Context, path similapi.Path, params similapi.UpdateNodeParams) {
 	var n similapi.Node
 	if r.errorRespnse(c, BindAppJson(c, &n), "") {
 		return
 	}
-	_, err := r.svc.IndexServiceServer().UpdateNode(c,
-		&index.UpdateNodeRequest{Path: persistence.ConcatPath(path, ""), Node: &index.Node{Tags: n.Tags}})
+	ev, err := parseIfMatch(params.IfMatch)
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	attrs, err := rest2Attrs(n.Attrs)
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	n1, err := r.svc.IndexServiceServer().UpdateNode(c, &index.UpdateNodeRequest{Path: persistence.ConcatPath(path, ""),
+		Node: &index.Node{Tags: n.Tags, Attrs: attrs}, RemoveAttrs: cast.Value(params.RemoveAttrs, nil), ExpectedVersion: ev})
 	if r.errorRespnse(c, err, "") {
 		return
 	}
-	c.Status(http.StatusOK)
+	c.Header("ETag", etag(n1.Version))
+	c.JSON(http.StatusOK, node2Rest(n1))
+}
+
+func (r *Rest) PatchNode(c *gin.Context, path similapi.Path, params similapi.PatchNodeParams) {
+	var tp similapi.TagsPatch
+	if r.errorRespnse(c, BindAppJson(c, &tp), "") {
+		return
+	}
+	ev, err := parseIfMatch(params.IfMatch)
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	n1, err := r.svc.IndexServiceServer().UpdateNode(c,
+		&index.UpdateNodeRequest{Path: persistence.ConcatPath(path, ""), TagsPatch: rest2TagsPatch(tp), ExpectedVersion: ev})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.Header("ETag", etag(n1.Version))
+	c.JSON(http.StatusOK, node2Rest(n1))
+}
+
+func (r *Rest) UpdateNodes(c *gin.Context) {
+	var unr similapi.UpdateNodesRequest
+	if r.errorRespnse(c, BindAppJson(c, &unr), "") {
+		return
+	}
+	res, err := r.svc.IndexServiceServer().UpdateNodes(c, &index.UpdateNodesRequest{FilterConditions: unr.FilterConditions, TagsPatch: rest2TagsPatch(unr.TagsPatch)})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusOK, similapi.UpdateNodesResult{Updated: res.Updated})
+}
+
+func (r *Rest) MoveNode(c *gin.Context, path similapi.Path) {
+	var mnr similapi.MoveNodeRequest
+	if r.errorRespnse(c, BindAppJson(c, &mnr), "") {
+		return
+	}
+	res, err := r.svc.IndexServiceServer().MoveNode(c, &index.MoveNodeRequest{From: persistence.ConcatPath(path, ""), To: mnr.To})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	var nc []similapi.Node
+	if res.NodesCreated != nil {
+		nc = nodes2Rest(res.NodesCreated.Nodes)
+	}
+	c.JSON(http.StatusOK, similapi.MoveNodeResult{Node: node2Rest(res.Node), NodesCreated: nc})
+}
+
+func (r *Rest) CopyNodes(c *gin.Context, path similapi.Path) {
+	var cnr similapi.CopyNodesRequest
+	if r.errorRespnse(c, BindAppJson(c, &cnr), "") {
+		return
+	}
+	res, err := r.svc.IndexServiceServer().CopyNodes(c, &index.CopyNodesRequest{From: persistence.ConcatPath(path, ""),
+		To: cnr.To, Tags: cast.Value(cnr.Tags, nil)})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	var nc []similapi.Node
+	if res.NodesCreated != nil {
+		nc = nodes2Rest(res.NodesCreated.Nodes)
+	}
+	c.JSON(http.StatusCreated, similapi.CopyNodesResult{Node: node2Rest(res.Node), NodesCreated: nc,
+		NodesCopied: int(res.NodesCopied), RecordsCopied: int(res.RecordsCopied)})
 }
 
 func (r *Rest) DeleteNodes(c *gin.Context) {
@@ -64,21 +157,80 @@ func (r *Rest) DeleteNodes(c *gin.Context) {
 	if r.errorRespnse(c, BindAppJson(c, &dnr), "") {
 		return
 	}
-	_, err := r.svc.IndexServiceServer().DeleteNodes(c, &index.DeleteNodesRequest{FilterConditions: dnr.FilterConditions, Force: cast.Ptr(dnr.Force)})
+	res, err := r.svc.IndexServiceServer().DeleteNodes(c, &index.DeleteNodesRequest{FilterConditions: dnr.FilterConditions, Force: cast.Ptr(dnr.Force),
+		Trash: dnr.Trash, DryRun: dnr.DryRun, Offset: int64(cast.Value(dnr.Offset, 0)), Limit: int64(cast.Value(dnr.Limit, 100))})
 	if r.errorRespnse(c, err, "") {
 		return
 	}
+	if cast.Value(dnr.DryRun, false) {
+		c.JSON(http.StatusOK, similapi.DeleteNodesPreview{Items: nodes2Rest(res.Nodes), Total: int(res.Total),
+			Records: int(res.Records), ForceRequired: res.ForceRequired})
+		return
+	}
 	c.Status(http.StatusNoContent)
 }
 
 func (r *Rest) DeleteNode(c *gin.Context, path similapi.Path, params similapi.DeleteNodeParams) {
-	_, err := r.svc.IndexServiceServer().DeleteNodes(c, &index.DeleteNodesRequest{FilterConditions: fmt.Sprintf("path like '%s%%'", path), Force: params.Force})
+	_, err := r.svc.IndexServiceServer().DeleteNodes(c, &index.DeleteNodesRequest{FilterConditions: fmt.Sprintf("path like '%s%%'", path), Force: params.Force, Trash: params.Trash})
 	if r.errorRespnse(c, err, "") {
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
 
+func (r *Rest) ListTrash(c *gin.Context, params similapi.ListTrashParams) {
+	nodes, err := r.svc.IndexServiceServer().ListTrash(c, &index.ListNodesRequest{FilterConditions: cast.Value(params.Condition, ""),
+		Offset: int64(cast.Value(params.Offset, 0)), Limit: int64(cast.Value(params.Limit, 100))})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusOK, similapi.ListNodesResult{Items: nodes2Rest(nodes.Nodes)})
+}
+
+func (r *Rest) RestoreNodes(c *gin.Context) {
+	var rnr similapi.RestoreNodesRequest
+	if r.errorRespnse(c, BindAppJson(c, &rnr), "") {
+		return
+	}
+	res, err := r.svc.IndexServiceServer().RestoreNodes(c, &index.RestoreNodesRequest{FilterConditions: rnr.FilterConditions})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusOK, similapi.RestoreNodesResult{Restored: int(res.Restored)})
+}
+
+func (r *Rest) GetNodeACL(c *gin.Context, path similapi.Path) {
+	acl, err := r.svc.IndexServiceServer().GetAccess(c, &index.GetAccessRequest{Path: persistence.ConcatPath(path, "")})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusOK, nodeACL2Rest(acl))
+}
+
+func (r *Rest) GrantAccess(c *gin.Context, path similapi.Path, principal similapi.Principal) {
+	var gar similapi.GrantAccessRequest
+	if r.errorRespnse(c, BindAppJson(c, &gar), "") {
+		return
+	}
+	perm, err := rest2Permission(gar.Permission)
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	acl, err := r.svc.IndexServiceServer().GrantAccess(c, &index.GrantAccessRequest{Path: persistence.ConcatPath(path, ""), Principal: principal, Permission: perm})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusOK, nodeACL2Rest(acl))
+}
+
+func (r *Rest) RevokeAccess(c *gin.Context, path similapi.Path, principal similapi.Principal) {
+	acl, err := r.svc.IndexServiceServer().RevokeAccess(c, &index.RevokeAccessRequest{Path: persistence.ConcatPath(path, ""), Principal: principal})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusOK, nodeACL2Rest(acl))
+}
+
 func (r *Rest) ListNodeRecords(c *gin.Context, path similapi.Path, params similapi.ListNodeRecordsParams) {
 	lrr := &index.ListRequest{}
 	lrr.Path = persistence.ConcatPath(path, "")
@@ -92,6 +244,9 @@ func (r *Rest) ListNodeRecords(c *gin.Context, path similapi.Path, params simila
 	if params.CreatedBefore != nil {
 		lrr.CreatedBefore = timestamppb.New(*params.CreatedBefore)
 	}
+	if params.AsOf != nil {
+		lrr.AsOf = timestamppb.New(*params.AsOf)
+	}
 	lrr.PageId = params.PageId
 	lr, err := r.svc.IndexServiceServer().ListRecords(c, lrr)
 	if r.errorRespnse(c, err, "") {
@@ -100,28 +255,85 @@ func (r *Rest) ListNodeRecords(c *gin.Context, path similapi.Path, params simila
 	c.JSON(http.StatusOK, similapi.ListRecordsResult{Records: cast.Ptr(records2Rest(lr.Records)), Total: int(lr.Total), NextPageId: lr.NextPageId})
 }
 
-func (r *Rest) PatchNodeRecords(c *gin.Context, path similapi.Path) {
+func (r *Rest) ListNodeRecordRevisions(c *gin.Context, path similapi.Path, params similapi.ListNodeRecordRevisionsParams) {
+	revs, err := r.svc.IndexServiceServer().ListRecordRevisions(c, &index.ListRecordRevisionsRequest{Path: persistence.ConcatPath(path, ""),
+		RecordId: params.RecordId, Offset: int64(cast.Value(params.Offset, 0)), Limit: int64(cast.Value(params.Limit, 100))})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusOK, similapi.ListRecordRevisionsResult{Items: recordRevisions2Rest(revs.Revisions)})
+}
+
+func (r *Rest) DiffNodeRecords(c *gin.Context, path similapi.Path, params similapi.DiffNodeRecordsParams) {
+	drr := &index.DiffRecordsRequest{Path: persistence.ConcatPath(path, ""), From: timestamppb.New(params.From)}
+	if params.To != nil {
+		drr.To = timestamppb.New(*params.To)
+	}
+	d, err := r.svc.IndexServiceServer().DiffRecords(c, drr)
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusOK, diffRecordsResult2Rest(d))
+}
+
+func (r *Rest) WatchChanges(c *gin.Context, params similapi.WatchChangesParams) {
+	wr := &index.WatchRequest{Path: cast.Value(params.Path, ""), FilterConditions: cast.Value(params.Condition, ""), Cursor: params.Cursor}
+	if wr.Cursor == nil {
+		wr.Cursor = params.LastEventID
+	}
+	err := r.svc.watch(c, wr, func() {
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Status(http.StatusOK)
+		c.Writer.WriteHeaderNow()
+		c.Writer.Flush()
+	}, func(ch *index.Change) error {
+		c.Render(-1, sse.Event{Id: strconv.FormatInt(ch.Cursor, 10), Event: "change", Data: change2Rest(ch)})
+		c.Writer.Flush()
+		return c.Request.Context().Err()
+	})
+	if c.Writer.Written() {
+		if err != nil {
+			r.logger.Warnf("the changes watch is interrupted: %v", err)
+		}
+		return
+	}
+	r.errorRespnse(c, err, "")
+}
+
+func (r *Rest) PatchNodeRecords(c *gin.Context, path similapi.Path, params similapi.PatchNodeRecordsParams) {
 	var pr similapi.PatchRecordsRequest
 	if r.errorRespnse(c, BindAppJson(c, &pr), "") {
 		return
 	}
-	prr, err := r.svc.IndexServiceServer().PatchRecords(c, &index.PatchRecordsRequest{
-		Path:          path,
-		DeleteRecords: rest2Records(pr.DeleteRecords),
-		UpsertRecords: rest2Records(pr.UpsertRecords),
+	ev, err := parseIfMatch(params.IfMatch)
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	prr, err := r.svc.idempotentPatchIndexRecords(c, cast.Value(params.IdempotencyKey, ""), &index.PatchRecordsRequest{
+		Path:            path,
+		DeleteRecords:   rest2Records(pr.DeleteRecords),
+		UpsertRecords:   rest2Records(pr.UpsertRecords),
+		ExpectedVersion: ev,
 	})
 	if r.errorRespnse(c, err, "") {
 		return
 	}
-	c.JSON(http.StatusOK, similapi.PatchRecordsResult{Deleted: int(prr.Deleted), Upserted: int(prr.Upserted)})
+	c.Header("ETag", etag(prr.Version))
+	c.JSON(http.StatusOK, similapi.PatchRecordsResult{Deleted: int(prr.Deleted), Upserted: int(prr.Upserted), Version: cast.Ptr(prr.Version)})
 }
 
-func (r *Rest) CreateNodeRecords(c *gin.Context, path similapi.Path) {
+func (r *Rest) CreateNodeRecords(c *gin.Context, path similapi.Path, params similapi.CreateNodeRecordsParams) {
 	var crr similapi.CreateRecordsRequest
 	var res *index.CreateRecordsResult
 	if err := BindAppJson(c, &crr); err == nil {
 		r.logger.Infof("creating new node records %v", crr)
-		res, err = r.svc.createRecords(c, rest2CreateRecordsRequest(path, crr), nil)
+		req, err := rest2CreateRecordsRequest(path, crr)
+		if r.errorRespnse(c, err, "") {
+			return
+		}
+		res, err = r.svc.idempotentCreateRecords(c, cast.Value(params.IdempotencyKey, ""), req, nil)
 		if r.errorRespnse(c, err, "") {
 			return
 		}
@@ -153,7 +365,11 @@ func (r *Rest) CreateNodeRecords(c *gin.Context, path similapi.Path) {
 		}
 		defer file.Close()
 
-		res, err = r.svc.createRecords(c, rest2CreateRecordsRequest(path, crr), file)
+		req, err := rest2CreateRecordsRequest(path, crr)
+		if r.errorRespnse(c, err, "") {
+			return
+		}
+		res, err = r.svc.idempotentCreateRecords(c, cast.Value(params.IdempotencyKey, ""), req, file)
 		if r.errorRespnse(c, err, "") {
 			return
 		}
@@ -162,7 +378,77 @@ func (r *Rest) CreateNodeRecords(c *gin.Context, path similapi.Path) {
 	if res.NodesCreated != nil {
 		nc = nodes2Rest(res.NodesCreated.Nodes)
 	}
-	c.JSON(http.StatusCreated, similapi.CreateRecordsResult{RecordsCreated: int(res.RecordsCreated), NodesCreated: nc})
+	if res.Job != nil {
+		c.JSON(http.StatusAccepted, similapi.CreateRecordsResult{NodesCreated: nc, Job: cast.Ptr(job2Rest(res.Job))})
+		return
+	}
+	c.JSON(http.StatusCreated, similapi.CreateRecordsResult{RecordsCreated: int(res.RecordsCreated), NodesCreated: nc,
+		RecordsUpdated: cast.Ptr(int(res.RecordsUpdated)), RecordsDeleted: cast.Ptr(int(res.RecordsDeleted))})
+}
+
+func (r *Rest) ListJobs(c *gin.Context, params similapi.ListJobsParams) {
+	req := &index.ListJobsRequest{Offset: int64(cast.Value(params.Offset, 0)), Limit: int64(cast.Value(params.Limit, 100))}
+	if params.Status != nil {
+		st, ok := index.JobStatus_value[strings.ToUpper(string(*params.Status))]
+		if !ok {
+			r.errorRespnse(c, errors.ErrInvalid, fmt.Sprintf("unknown job status %q", *params.Status))
+			return
+		}
+		req.Status = cast.Ptr(index.JobStatus(st))
+	}
+	js, err := r.svc.IndexServiceServer().ListJobs(c, req)
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusOK, jobs2Rest(js))
+}
+
+func (r *Rest) GetJob(c *gin.Context, jobId similapi.JobId) {
+	job, err := r.svc.IndexServiceServer().GetJob(c, &index.JobId{Id: jobId})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusOK, job2Rest(job))
+}
+
+func (r *Rest) CancelJob(c *gin.Context, jobId similapi.JobId) {
+	job, err := r.svc.IndexServiceServer().CancelJob(c, &index.JobId{Id: jobId})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusOK, job2Rest(job))
+}
+
+func (r *Rest) ListAttrSchemas(c *gin.Context) {
+	ass, err := r.svc.IndexServiceServer().ListAttrSchemas(c, nil)
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusOK, attrSchemas2Rest(ass))
+}
+
+func (r *Rest) CreateAttrSchema(c *gin.Context) {
+	var as similapi.AttrSchema
+	if r.errorRespnse(c, BindAppJson(c, &as), "") {
+		return
+	}
+	req, err := rest2AttrSchema(as)
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	as1, err := r.svc.IndexServiceServer().CreateAttrSchema(c, req)
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusCreated, attrSchema2Rest(as1))
+}
+
+func (r *Rest) DeleteAttrSchema(c *gin.Context, attrName similapi.AttrName, params similapi.DeleteAttrSchemaParams) {
+	_, err := r.svc.IndexServiceServer().DeleteAttrSchema(c, &index.DeleteAttrSchemaRequest{Prefix: params.Prefix, Name: attrName})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.Status(http.StatusNoContent)
 }
 
 func (r *Rest) Search(c *gin.Context) {
@@ -170,7 +456,11 @@ func (r *Rest) Search(c *gin.Context) {
 	if r.errorRespnse(c, BindAppJson(c, &sr), "") {
 		return
 	}
-	res, err := r.svc.IndexServiceServer().Search(c, searchRecordsRequest2Proto(sr))
+	req, err := searchRecordsRequest2Proto(sr)
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	res, err := r.svc.IndexServiceServer().Search(c, req)
 	if r.errorRespnse(c, err, "") {
 		return
 	}
@@ -190,7 +480,7 @@ func (r *Rest) CreateFormat(c *gin.Context) {
 	if r.errorRespnse(c, BindAppJson(c, &f), "") {
 		return
 	}
-	f1, err := r.svc.FormatServiceServer().Create(c, &format.Format{Name: f.Name, Basis: f.Basis})
+	f1, err := r.svc.FormatServiceServer().Create(c, &format.Format{Name: f.Name, Basis: f.Basis, KeepHistory: cast.Value(f.KeepHistory, false)})
 	if r.errorRespnse(c, err, "") {
 		return
 	}
@@ -214,6 +504,80 @@ func (r *Rest) GetFormat(c *gin.Context, formatId similapi.FormatId) {
 	c.JSON(http.StatusOK, format2Rest(f))
 }
 
+func (r *Rest) ListWebhooks(c *gin.Context) {
+	whs, err := r.svc.WebhookServiceServer().List(c, nil)
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusOK, webhooks2Rest(whs))
+}
+
+func (r *Rest) CreateWebhook(c *gin.Context) {
+	var wh similapi.Webhook
+	if r.errorRespnse(c, BindAppJson(c, &wh), "") {
+		return
+	}
+	wh1, err := r.svc.WebhookServiceServer().Create(c, rest2Webhook(wh))
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.Header("Location", ComposeURI(c.Request, wh1.Id))
+	c.JSON(http.StatusCreated, webhook2Rest(wh1))
+}
+
+func (r *Rest) DeleteWebhook(c *gin.Context, webhookId similapi.WebhookId) {
+	_, err := r.svc.WebhookServiceServer().Delete(c, &webhook.Id{Id: webhookId})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (r *Rest) GetWebhook(c *gin.Context, webhookId similapi.WebhookId) {
+	wh, err := r.svc.WebhookServiceServer().Get(c, &webhook.Id{Id: webhookId})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusOK, webhook2Rest(wh))
+}
+
+func (r *Rest) ListWebhookDeadLetters(c *gin.Context, webhookId similapi.WebhookId, params similapi.ListWebhookDeadLettersParams) {
+	dls, err := r.svc.WebhookServiceServer().ListDeadLetters(c, &webhook.ListDeadLettersRequest{WebhookId: webhookId,
+		Offset: int64(cast.Value(params.Offset, 0)), Limit: cast.Ptr(int64(cast.Value(params.Limit, 100)))})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusOK, deadLetters2Rest(dls))
+}
+
+func (r *Rest) SwitchEngine(c *gin.Context) {
+	var ser similapi.SwitchEngineRequest
+	if r.errorRespnse(c, BindAppJson(c, &ser), "") {
+		return
+	}
+	es, err := r.svc.AdminServiceServer().SwitchEngine(c, &admin.SwitchEngineRequest{Engine: ser.Engine})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusAccepted, engineSwitch2Rest(es))
+}
+
+func (r *Rest) GetEngineSwitch(c *gin.Context) {
+	es, err := r.svc.AdminServiceServer().GetEngineSwitch(c, &emptypb.Empty{})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusOK, engineSwitch2Rest(es))
+}
+
+func (r *Rest) CancelEngineSwitch(c *gin.Context) {
+	es, err := r.svc.AdminServiceServer().CancelEngineSwitch(c, &emptypb.Empty{})
+	if r.errorRespnse(c, err, "") {
+		return
+	}
+	c.JSON(http.StatusOK, engineSwitch2Rest(es))
+}
+
 func (r *Rest) Ping(c *gin.Context) {
 	r.logger.Debugf("ping")
 	c.String(http.StatusOK, "pong")
@@ -233,7 +597,11 @@ func (r *Rest) errorRespnse(c *gin.Context, err error, msg string) bool {
 		r.logger.Debugf("original error: %s", err)
 	}()
 
-	if errors.Is(err, errors.ErrNotExist) {
+	if unauthenticated(err) {
+		status = http.StatusUnauthorized
+	} else if errors.Is(err, errors.ErrNotAuthorized) {
+		status = http.StatusForbidden
+	} else if errors.Is(err, errors.ErrNotExist) {
 		status = http.StatusNotFound
 	} else if errors.Is(err, errors.ErrInvalid) {
 		status = http.StatusBadRequest
@@ -243,10 +611,36 @@ func (r *Rest) errorRespnse(c *gin.Context, err error, msg string) bool {
 		status = http.StatusConflict
 	} else if errors.Is(err, errors.ErrUnimplemented) {
 		status = http.StatusUnsupportedMediaType
+	} else if errors.Is(err, errors.ErrExhausted) {
+		status = http.StatusTooManyRequests
 	}
 	return true
 }
 
+// unauthenticated returns true if the err is the gRPC Unauthenticated status error
+func unauthenticated(err error) bool {
+	return status.Code(err) == codes.Unauthenticated
+}
+
+// etag returns the ETag header value for the node version
+func etag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// parseIfMatch returns the node version expected by the If-Match header value, the version
+// is nil if the header is not provided or it is "*"
+func parseIfMatch(ifMatch *string) (*int64, error) {
+	v := strings.TrimPrefix(strings.TrimSpace(cast.Value(ifMatch, "")), "W/")
+	if v == "" || v == "*" {
+		return nil, nil
+	}
+	version, err := strconv.ParseInt(strings.Trim(v, "\""), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid If-Match header value %q, the node version is expected: %w", cast.Value(ifMatch, ""), errors.ErrInvalid)
+	}
+	return &version, nil
+}
+
 // BindAppJson turns the request body to inf, but for "application/json" contents only
 func BindAppJson(c *gin.Context, inf interface{}) error {
 	ct := c.ContentType()
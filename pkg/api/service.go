@@ -17,28 +17,52 @@ package api
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"github.com/acquirecloud/golibs/cast"
 	"github.com/acquirecloud/golibs/errors"
 	"github.com/acquirecloud/golibs/logging"
+	"github.com/simila-io/simila/api/gen/admin/v1"
 	"github.com/simila-io/simila/api/gen/format/v1"
 	"github.com/simila-io/simila/api/gen/index/v1"
+	"github.com/simila-io/simila/api/gen/webhook/v1"
+	"github.com/simila-io/simila/pkg/auth"
+	"github.com/simila-io/simila/pkg/embedding"
+	"github.com/simila-io/simila/pkg/indexer/idempotency"
+	"github.com/simila-io/simila/pkg/indexer/jobs"
 	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/indexer/webhooks"
 	"github.com/simila-io/simila/pkg/parser"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"io"
+	"maps"
+	"net/url"
+	"os"
 	"strings"
+	"time"
 )
 
 // Service implements the gRPC API endpoints v1
 type (
 	Service struct {
-		PProvider parser.Provider `inject:""`
-		Db        persistence.Db  `inject:""`
+		PProvider   parser.Provider    `inject:""`
+		Db          persistence.Db     `inject:""`
+		Embedder    embedding.Provider `inject:",optional"`
+		Idempotency *idempotency.Store `inject:""`
+		Jobs        *jobs.Runner       `inject:""`
+		// Auth authenticates the callers, the authentication and the nodes access control are turned off if it is nil
+		Auth *auth.Authenticator `inject:",optional"`
 
 		idxService idxService
 		fmtService fmtService
+		admService admService
+		whkService whkService
 		logger     logging.Logger
+		// watchInterval is the changelog polling interval of the Watch
+		watchInterval time.Duration
 	}
 
 	idxService struct {
@@ -50,15 +74,32 @@ type (
 		format.UnimplementedServiceServer
 		s *Service
 	}
+
+	admService struct {
+		admin.UnimplementedServiceServer
+		s *Service
+	}
+
+	whkService struct {
+		webhook.UnimplementedServiceServer
+		s *Service
+	}
 )
 
+// IdempotencyKeyHeader is the gRPC metadata key and the HTTP header, which contains the idempotency key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
 var _ index.ServiceServer = idxService{}
 var _ format.ServiceServer = fmtService{}
+var _ admin.ServiceServer = admService{}
+var _ webhook.ServiceServer = whkService{}
 
 func NewService() *Service {
-	s := &Service{logger: logging.NewLogger("api.Service")}
+	s := &Service{logger: logging.NewLogger("api.Service"), watchInterval: time.Second}
 	s.idxService = idxService{s: s}
 	s.fmtService = fmtService{s: s}
+	s.admService = admService{s: s}
+	s.whkService = whkService{s: s}
 	return s
 }
 
@@ -71,6 +112,14 @@ func (s *Service) FormatServiceServer() format.ServiceServer {
 	return s.fmtService
 }
 
+func (s *Service) AdminServiceServer() admin.ServiceServer {
+	return s.admService
+}
+
+func (s *Service) WebhookServiceServer() webhook.ServiceServer {
+	return s.whkService
+}
+
 // createRecords allows to create a new index. The body represents a file stream,
 // if presents, body may be nil, then the body may be taken from the request.
 func (s *Service) createRecords(ctx context.Context, request *index.CreateRecordsRequest, body io.Reader) (*index.CreateRecordsResult, error) {
@@ -95,6 +144,33 @@ func (s *Service) createRecords(ctx context.Context, request *index.CreateRecord
 			return &index.CreateRecordsResult{}, errors.GRPCWrap(fmt.Errorf("the parser=%q is not supported: %w", parser, errors.ErrInvalid))
 		}
 	}
+	mode := cast.Value(request.Mode, index.CreateMode_APPEND)
+	if request.Async && (p == nil || len(request.Records) > 0) {
+		return &index.CreateRecordsResult{}, errors.GRPCWrap(fmt.Errorf("the async request must have the document and no records: %w", errors.ErrInvalid))
+	}
+	if request.Async && mode != index.CreateMode_APPEND {
+		return &index.CreateRecordsResult{}, errors.GRPCWrap(fmt.Errorf("the mode=%s is not supported for the async request: %w", mode, errors.ErrInvalid))
+	}
+	pths := persistence.SplitPath(request.Path)
+	if len(pths) == 0 {
+		return &index.CreateRecordsResult{}, errors.GRPCWrap(fmt.Errorf("the path=%q should not be empty: %w", request.Path, errors.ErrInvalid))
+	}
+	// the records are read and embedded before the transaction is started, so the transaction
+	// and the node lock are not held while the embedding provider is called. The access is checked
+	// before that as well, so the records of the callers with no access are not embedded.
+	var recs []persistence.IndexRecord
+	if !request.Async {
+		nodes, err := s.Db.NewModelTx(ctx).ListAllNodesByPath(request.Path)
+		if err == nil {
+			err = s.checkCreateAccess(ctx, nodes)
+		}
+		if err == nil {
+			recs, err = s.readRecords(ctx, request, mode, p, body)
+		}
+		if err != nil {
+			return &index.CreateRecordsResult{}, errors.GRPCWrap(err)
+		}
+	}
 
 	mtx := s.Db.NewModelTx(ctx)
 	mtx.MustBegin()
@@ -103,16 +179,18 @@ func (s *Service) createRecords(ctx context.Context, request *index.CreateRecord
 	}()
 	res := &index.CreateRecordsResult{}
 
-	pths := persistence.SplitPath(request.Path)
-	if len(pths) == 0 {
-		return &index.CreateRecordsResult{}, errors.GRPCWrap(fmt.Errorf("the path=%q should not be empty: %w", request.Path, errors.ErrInvalid))
-	}
 	nodes, err := mtx.ListAllNodesByPath(request.Path)
 	if err != nil {
 		return &index.CreateRecordsResult{}, errors.GRPCWrap(err)
 	}
+	if err = s.checkCreateAccess(ctx, nodes); err != nil {
+		return &index.CreateRecordsResult{}, errors.GRPCWrap(err)
+	}
 	n2c := nodes2Create(pths, nodes, request.Tags, cast.Value(request.NodeType, index.NodeType_FOLDER))
 	if len(n2c) > 0 {
+		if n2c[len(n2c)-1].Attrs, err = checkAttrs(mtx, request.Path, toModelAttrs(request.Attrs)); err != nil {
+			return &index.CreateRecordsResult{}, errors.GRPCWrap(err)
+		}
 		nodes, err = mtx.CreateNodes(n2c...)
 		if err != nil {
 			return &index.CreateRecordsResult{}, errors.GRPCWrap(err)
@@ -122,23 +200,301 @@ func (s *Service) createRecords(ctx context.Context, request *index.CreateRecord
 		}
 	}
 	node := nodes[len(nodes)-1]
-	if p != nil {
-		count, err := p.ScanRecords(ctx, mtx, node.ID, body)
-		if err != nil {
-			return nil, errors.GRPCWrap(fmt.Errorf("could not read records for %q format: %w", cast.String(request.Parser, ""), err))
+	if request.Async {
+		// the nodes are committed before the job is submitted, so the parsing runs in its own transactions
+		if err = mtx.Commit(); err != nil {
+			return &index.CreateRecordsResult{}, errors.GRPCWrap(err)
 		}
-		s.logger.Infof("createRecords(): read %d records by parser %s for the node %q(%d)", count, p, persistence.ConcatPath(node.Path, node.Name), node.ID)
-	} else {
-		rc, err := mtx.UpsertIndexRecords(toModelIndexRecordsFromApiRecords(node.ID, request.Records, 1.0)...)
+		job, err := s.Jobs.Submit(ctx, persistence.Job{NodeID: node.ID, Path: persistence.ConcatPath(node.Path, node.Name), Parser: cast.Value(request.Parser, "")}, body)
 		if err != nil {
+			return &index.CreateRecordsResult{}, errors.GRPCWrap(fmt.Errorf("could not submit the job for %q format: %w", cast.String(request.Parser, ""), err))
+		}
+		s.logger.Infof("createRecords(): the job %d is submitted for the node %q(%d)", job.ID, job.Path, node.ID)
+		res.Job = toApiJob(job)
+		return res, nil
+	}
+	for i := range recs {
+		recs[i].NodeID = node.ID
+	}
+	if mode != index.CreateMode_APPEND {
+		if err = s.replaceRecords(mtx, node, recs, mode, res); err != nil {
 			return &index.CreateRecordsResult{}, errors.GRPCWrap(err)
 		}
+		if err = mtx.Commit(); err != nil {
+			return &index.CreateRecordsResult{}, errors.GRPCWrap(err)
+		}
+		return res, nil
+	}
+	rc, err := mtx.UpsertIndexRecords(recs...)
+	if err != nil {
+		return &index.CreateRecordsResult{}, errors.GRPCWrap(err)
+	}
+	if p != nil {
+		s.logger.Infof("createRecords(): read %d records by parser %s for the node %q(%d)", len(recs), p, persistence.ConcatPath(node.Path, node.Name), node.ID)
+	} else {
 		res.RecordsCreated = rc
 	}
 	_ = mtx.Commit()
 	return res, nil
 }
 
+// readRecords returns the records read by the parser p, or the request records if p is nil, with
+// their embeddings. The function is called before the transaction is started, so the node ID of
+// the records is not set. In SYNC mode the records equal to the existing node records are not
+// embedded, because they are not written.
+func (s *Service) readRecords(ctx context.Context, request *index.CreateRecordsRequest, mode index.CreateMode, p parser.Parser,
+	body io.Reader) ([]persistence.IndexRecord, error) {
+	if p != nil {
+		// the parser embeds the records itself
+		cmtx := &collectingTx{ModelTx: s.Db.NewModelTx(ctx)}
+		if _, err := p.ScanRecords(ctx, cmtx, 0, body); err != nil {
+			return nil, fmt.Errorf("could not read records for %q format: %w", cast.String(request.Parser, ""), err)
+		}
+		return cmtx.records, nil
+	}
+	recs := toModelIndexRecordsFromApiRecords(0, request.Records, 1.0)
+	if s.Embedder == nil || len(recs) == 0 {
+		return recs, nil
+	}
+	embed := recs
+	if mode == index.CreateMode_SYNC {
+		mtx := s.Db.NewModelTx(ctx)
+		node, err := mtx.GetNode(request.Path)
+		if err != nil && !errors.Is(err, errors.ErrNotExist) {
+			return nil, err
+		}
+		if err == nil {
+			existing, err := nodeRecords(mtx, node.ID)
+			if err != nil {
+				return nil, err
+			}
+			embed = nil
+			for i := range recs {
+				if er, ok := existing[recs[i].ID]; !ok || !sameRecord(er, recs[i]) {
+					embed = append(embed, recs[i])
+				}
+			}
+		}
+	}
+	if err := embedding.EmbedRecords(ctx, s.Embedder, embed); err != nil {
+		return nil, err
+	}
+	if len(embed) < len(recs) {
+		embs := make(map[string][]float32, len(embed))
+		for _, r := range embed {
+			embs[r.ID] = r.Embedding
+		}
+		for i := range recs {
+			if e, ok := embs[recs[i].ID]; ok {
+				recs[i].Embedding = e
+			}
+		}
+	}
+	return recs, nil
+}
+
+// replaceRecords writes the records to the node in REPLACE or SYNC mode. The node records, which
+// are not provided, are deleted. In SYNC mode the records equal to the existing ones are not written.
+// The function must be called within the transaction.
+func (s *Service) replaceRecords(mtx persistence.ModelTx, node persistence.Node, recs []persistence.IndexRecord,
+	mode index.CreateMode, res *index.CreateRecordsResult) error {
+	fqnp := persistence.ConcatPath(node.Path, node.Name)
+	if _, err := mtx.LockNode(fqnp); err != nil {
+		return err
+	}
+
+	existing, err := nodeRecords(mtx, node.ID)
+	if err != nil {
+		return err
+	}
+	// the last record wins, if there are several records with the same ID
+	idx := make(map[string]int, len(recs))
+	var write []persistence.IndexRecord
+	for _, r := range recs {
+		if i, ok := idx[r.ID]; ok {
+			write[i] = r
+			continue
+		}
+		idx[r.ID] = len(write)
+		write = append(write, r)
+	}
+	if mode == index.CreateMode_SYNC {
+		changed := write[:0]
+		for _, r := range write {
+			if er, ok := existing[r.ID]; !ok || !sameRecord(er, r) {
+				changed = append(changed, r)
+			}
+		}
+		write = changed
+	}
+	var del []persistence.IndexRecord
+	for id, r := range existing {
+		if _, ok := idx[id]; !ok {
+			del = append(del, r)
+		}
+	}
+
+	if len(write) > 0 {
+		if s.Embedder != nil {
+			for _, r := range write {
+				// the record was not embedded by readRecords, as it was equal to the existing one then
+				if len(r.Embedding) == 0 {
+					return fmt.Errorf("the record ID=%s of the node %q is changed concurrently: %w", r.ID, fqnp, errors.ErrConflict)
+				}
+			}
+		}
+		if _, err = mtx.UpsertIndexRecords(write...); err != nil {
+			return err
+		}
+		for _, r := range write {
+			if _, ok := existing[r.ID]; ok {
+				res.RecordsUpdated++
+			} else {
+				res.RecordsCreated++
+			}
+		}
+	}
+	if len(del) > 0 {
+		if res.RecordsDeleted, err = mtx.DeleteIndexRecords(del...); err != nil {
+			return err
+		}
+	}
+	s.logger.Infof("replaceRecords(): %d records created, %d updated and %d deleted for the node %q(%d) in %s mode", res.RecordsCreated,
+		res.RecordsUpdated, res.RecordsDeleted, fqnp, node.ID, mode)
+	return nil
+}
+
+// nodeRecords returns all the node records by their IDs
+func nodeRecords(mtx persistence.ModelTx, nodeID int64) (map[string]persistence.IndexRecord, error) {
+	res := map[string]persistence.IndexRecord{}
+	q := persistence.IndexRecordQuery{NodeID: nodeID, Limit: 1000}
+	for {
+		qr, err := mtx.QueryIndexRecords(q)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range qr.Items {
+			res[r.ID] = r
+		}
+		if qr.NextID == "" {
+			return res, nil
+		}
+		q.FromID = qr.NextID
+	}
+}
+
+// sameRecord returns true if the existing record er has the same content as the record r
+func sameRecord(er, r persistence.IndexRecord) bool {
+	// the defaults are stored for the empty vector and rank multiplier
+	vec, rm := r.Vector, r.RankMult
+	if len(vec) == 0 {
+		vec = []byte("{}")
+	}
+	if rm <= 0 {
+		rm = 1.0
+	}
+	return er.Segment == r.Segment && er.Format == r.Format && er.RankMult == rm && bytes.Equal(er.Vector, vec) &&
+		maps.Equal(er.Meta, r.Meta)
+}
+
+// collectingTx collects the records written by a parser instead of writing them, so the
+// records could be compared with the existing ones before they are written
+type collectingTx struct {
+	persistence.ModelTx
+	records []persistence.IndexRecord
+}
+
+func (c *collectingTx) UpsertIndexRecords(records ...persistence.IndexRecord) (int64, error) {
+	c.records = append(c.records, records...)
+	return int64(len(records)), nil
+}
+
+// idempotentCreateRecords is the same as createRecords, but the request is executed once for the
+// idempotency key, the retries with the same key get the result of the first request.
+func (s *Service) idempotentCreateRecords(ctx context.Context, key string, request *index.CreateRecordsRequest, body io.Reader) (*index.CreateRecordsResult, error) {
+	if key == "" {
+		return s.createRecords(ctx, request, body)
+	}
+	var digest []byte
+	if body != nil {
+		// the document is hashed while it is spooled to a temporary file, so it is not kept in memory
+		f, err := os.CreateTemp("", "simila-upload-*")
+		if err != nil {
+			return &index.CreateRecordsResult{}, errors.GRPCWrap(fmt.Errorf("could not create a temporary file for the document: %w", err))
+		}
+		defer func() {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}()
+		h := sha256.New()
+		if _, err = io.Copy(f, io.TeeReader(body, h)); err != nil {
+			return &index.CreateRecordsResult{}, errors.GRPCWrap(fmt.Errorf("could not read the document: %w", err))
+		}
+		if _, err = f.Seek(0, io.SeekStart); err != nil {
+			return &index.CreateRecordsResult{}, errors.GRPCWrap(fmt.Errorf("could not read the document: %w", err))
+		}
+		body, digest = f, h.Sum(nil)
+	}
+	return idempotent(ctx, s, key, fingerprint("create", request, digest), &index.CreateRecordsResult{}, func() (*index.CreateRecordsResult, error) {
+		return s.createRecords(ctx, request, body)
+	})
+}
+
+// idempotent calls the call function once for the idempotency key, the stored result of the
+// first call is returned to res for the requests retries
+func idempotent[T proto.Message](ctx context.Context, s *Service, key, fp string, res T, call func() (T, error)) (T, error) {
+	// the retries of another caller do not match the fingerprint, so the stored result is not disclosed to it
+	id, ok, err := s.identity(ctx)
+	if err != nil {
+		return res, errors.GRPCWrap(err)
+	}
+	if ok {
+		fp += ":" + id.Principal
+	}
+	var out T
+	called := false
+	data, err := s.Idempotency.Do(ctx, key, fp, func() ([]byte, error) {
+		var err error
+		if out, err = call(); err != nil {
+			return nil, err
+		}
+		called = true
+		return proto.Marshal(out)
+	})
+	if err != nil {
+		return res, errors.GRPCWrap(err)
+	}
+	if called {
+		return out, nil
+	}
+	if err = proto.Unmarshal(data, res); err != nil {
+		return res, errors.GRPCWrap(fmt.Errorf("could not read the stored response: %w", err))
+	}
+	return res, nil
+}
+
+// fingerprint returns the hash of the request method, the request and the digest of its data
+func fingerprint(method string, request proto.Message, digest []byte) string {
+	b, _ := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write(b)
+	h.Write(digest)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// idempotencyKey returns the idempotency key from the gRPC metadata, if provided
+func idempotencyKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if vals := md.Get(IdempotencyKeyHeader); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
 func nodes2Create(pths []string, nodes []persistence.Node, tags persistence.Tags, lastNodeType index.NodeType) []persistence.Node {
 	nodes2Create := []persistence.Node{}
 	if len(nodes) < len(pths) {
@@ -159,43 +515,342 @@ func nodes2Create(pths []string, nodes []persistence.Node, tags persistence.Tags
 	return nodes2Create
 }
 
-func (s *Service) updateNode(ctx context.Context, request *index.UpdateNodeRequest) (*emptypb.Empty, error) {
-	res := &emptypb.Empty{}
+func (s *Service) updateNode(ctx context.Context, request *index.UpdateNodeRequest) (*index.Node, error) {
+	res := &index.Node{}
 	if request == nil {
 		return res, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
 	}
 	tags := cast.Value(request.Node, index.Node{}).Tags
-	s.logger.Infof("updateNode(): path=%q, tags=%v", request.Path, tags)
+	attrs := toModelAttrs(cast.Value(request.Node, index.Node{}).Attrs)
+	s.logger.Infof("updateNode(): path=%q, tags=%v, tagsPatch=%s, attrs=%v, removeAttrs=%v, expectedVersion=%v", request.Path, tags, request.TagsPatch,
+		attrs, request.RemoveAttrs, cast.Value(request.ExpectedVersion, 0))
+	if request.TagsPatch != nil && len(tags) > 0 {
+		return res, errors.GRPCWrap(fmt.Errorf("either the node tags or the tags patch may be specified: %w", errors.ErrInvalid))
+	}
+	patch := toModelTagsPatch(request.TagsPatch)
+	if request.TagsPatch != nil && patch.IsEmpty() {
+		return res, errors.GRPCWrap(fmt.Errorf("the tags patch must not be empty: %w", errors.ErrInvalid))
+	}
 
 	mtx := s.Db.NewModelTx(ctx)
+	mtx.MustBegin()
 	defer func() {
 		_ = mtx.Rollback()
 	}()
-	n, err := mtx.GetNode(request.Path)
+	a, err := s.access(ctx, persistence.PermissionWrite)
 	if err != nil {
 		return res, errors.GRPCWrap(err)
 	}
-	if err = mtx.UpdateNode(persistence.Node{ID: n.ID, Tags: tags}); err != nil {
+	n, err := mtx.LockNode(request.Path)
+	if err != nil {
 		return res, errors.GRPCWrap(err)
 	}
-	mtx.Commit()
+	if err = checkAccess(a, n); err != nil {
+		return res, errors.GRPCWrap(err)
+	}
+	if err = checkVersion(n, request.ExpectedVersion); err != nil {
+		return res, errors.GRPCWrap(err)
+	}
+	if len(attrs) > 0 || len(request.RemoveAttrs) > 0 {
+		if attrs, err = checkAttrs(mtx, request.Path, attrs); err != nil {
+			return res, errors.GRPCWrap(err)
+		}
+		// the attributes provided are merged into the node ones
+		na := n.Attrs.Copy()
+		if na == nil {
+			na = make(persistence.Attrs)
+		}
+		for _, k := range request.RemoveAttrs {
+			delete(na, k)
+		}
+		for k, v := range attrs {
+			na[k] = v
+		}
+		attrs = na
+	}
+	// the attributes are written together with the tags patch, so the node version is incremented once
+	if request.TagsPatch != nil {
+		_, err = mtx.PatchNodes(persistence.PatchNodesQuery{ID: n.ID, Patch: patch, Attrs: attrs})
+	} else {
+		err = mtx.UpdateNode(persistence.Node{ID: n.ID, Tags: tags, Attrs: attrs})
+	}
+	if err != nil {
+		return res, errors.GRPCWrap(err)
+	}
+	if n, err = mtx.GetNode(request.Path); err != nil {
+		return res, errors.GRPCWrap(err)
+	}
+	if err = mtx.Commit(); err != nil {
+		return res, errors.GRPCWrap(err)
+	}
+	return toApiNode(n), nil
+}
+
+func (s *Service) updateNodes(ctx context.Context, request *index.UpdateNodesRequest) (*index.UpdateNodesResult, error) {
+	res := &index.UpdateNodesResult{}
+	if request == nil {
+		return res, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
+	}
+	s.logger.Infof("updateNodes(): filter=%q, tagsPatch=%s", request.FilterConditions, request.TagsPatch)
+	if strings.Trim(request.FilterConditions, " ") == "" {
+		return res, errors.GRPCWrap(fmt.Errorf("the filter request cannot be empty: %w", errors.ErrInvalid))
+	}
+	a, err := s.access(ctx, persistence.PermissionWrite)
+	if err != nil {
+		return res, errors.GRPCWrap(err)
+	}
+	mtx := s.Db.NewModelTx(ctx)
+	mtx.MustBegin()
+	defer func() {
+		_ = mtx.Rollback()
+	}()
+	n, err := mtx.PatchNodes(persistence.PatchNodesQuery{FilterConditions: request.FilterConditions, Patch: toModelTagsPatch(request.TagsPatch), Access: a})
+	if err != nil {
+		return res, errors.GRPCWrap(err)
+	}
+	if err = mtx.Commit(); err != nil {
+		return res, errors.GRPCWrap(err)
+	}
+	res.Updated = n
 	return res, nil
 }
 
-func (s *Service) deleteNodes(ctx context.Context, dnr *index.DeleteNodesRequest) (*emptypb.Empty, error) {
-	res := &emptypb.Empty{}
+// checkAttrs checks the attributes of the node with the fqnp against the attributes schemas, it returns
+// the attributes values converted to their types
+func checkAttrs(mtx persistence.ModelTx, fqnp string, attrs persistence.Attrs) (persistence.Attrs, error) {
+	if len(attrs) == 0 {
+		return attrs, nil
+	}
+	schemas, err := mtx.ListAttrSchemas()
+	if err != nil {
+		return nil, err
+	}
+	return persistence.CheckAttrs(schemas, fqnp, attrs)
+}
+
+// checkVersion returns ErrConflict if the expected version is provided and the node version is different
+func checkVersion(n persistence.Node, expected *int64) error {
+	if expected != nil && *expected != n.Version {
+		return fmt.Errorf("the node %s version=%d does not match the expected version=%d: %w",
+			persistence.ConcatPath(n.Path, n.Name), n.Version, *expected, errors.ErrConflict)
+	}
+	return nil
+}
+
+// identity returns the caller identity, ok is false if the authentication is turned off. The gRPC
+// Unauthenticated error is returned if the caller is not authenticated.
+func (s *Service) identity(ctx context.Context) (id auth.Identity, ok bool, err error) {
+	if s.Auth == nil {
+		return id, false, nil
+	}
+	id, err = s.Auth.Authenticate(ctx)
+	return id, err == nil, err
+}
+
+// access returns the access of the caller with the permission perm. It is nil if the caller may access all
+// the nodes: the authentication is turned off or the caller is the admin.
+func (s *Service) access(ctx context.Context, perm persistence.Permission) (*persistence.Access, error) {
+	id, ok, err := s.identity(ctx)
+	if err != nil || !ok || id.Admin {
+		return nil, err
+	}
+	return &persistence.Access{Principals: id.Principals(), Permission: perm}, nil
+}
+
+// checkAdmin returns ErrNotAuthorized if the caller is not the admin, it is used for the cluster-wide
+// endpoints, which are not bound to the nodes
+func (s *Service) checkAdmin(ctx context.Context) error {
+	id, ok, err := s.identity(ctx)
+	if err != nil {
+		return err
+	}
+	if ok && !id.Admin {
+		return fmt.Errorf("the caller %q must be the admin: %w", id.Principal, errors.ErrNotAuthorized)
+	}
+	return nil
+}
+
+// checkAccess returns an error if the access a is not allowed for the node. ErrNotExist is returned if
+// the node cannot be read, so its existence is not disclosed, and ErrNotAuthorized otherwise.
+func checkAccess(a *persistence.Access, n persistence.Node) error {
+	if a == nil || a.Allows(n.EffectiveACL) {
+		return nil
+	}
+	fqnp := persistence.ConcatPath(n.Path, n.Name)
+	if !(persistence.Access{Principals: a.Principals, Permission: persistence.PermissionRead}).Allows(n.EffectiveACL) {
+		return fmt.Errorf("the node %q is not found: %w", fqnp, errors.ErrNotExist)
+	}
+	return fmt.Errorf("the %s permission is required for the node %q: %w", a.Permission, fqnp, errors.ErrNotAuthorized)
+}
+
+// readableNode returns the node with the fqnp, ErrNotExist is returned if the caller cannot read it
+func (s *Service) readableNode(ctx context.Context, mtx persistence.ModelTx, fqnp string) (persistence.Node, error) {
+	a, err := s.access(ctx, persistence.PermissionRead)
+	if err != nil {
+		return persistence.Node{}, err
+	}
+	n, err := mtx.GetNode(fqnp)
+	if err != nil {
+		return persistence.Node{}, err
+	}
+	return n, checkAccess(a, n)
+}
+
+// checkCreateAccess checks whether the caller may write to the nearest existing node of the path, the
+// nodes returned by the ListAllNodesByPath for it. The new nodes inherit the ACL of that node, so the nodes
+// in the root may be created by the admin only.
+func (s *Service) checkCreateAccess(ctx context.Context, nodes []persistence.Node) error {
+	a, err := s.access(ctx, persistence.PermissionWrite)
+	if err != nil || a == nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return fmt.Errorf("the nodes in the root may be created by the admin only: %w", errors.ErrNotAuthorized)
+	}
+	return checkAccess(a, nodes[len(nodes)-1])
+}
+
+// checkPlaceAccess checks whether the caller has the permission perm for the node with the from fqnp,
+// and whether it may create the node with the to fqnp
+func (s *Service) checkPlaceAccess(ctx context.Context, mtx persistence.ModelTx, from string, perm persistence.Permission, to string) error {
+	a, err := s.access(ctx, perm)
+	if err != nil || a == nil {
+		return err
+	}
+	n, err := mtx.GetNode(persistence.ConcatPath(from, ""))
+	if err != nil {
+		return err
+	}
+	if err = checkAccess(a, n); err != nil {
+		return err
+	}
+	nodes, err := mtx.ListAllNodesByPath(to)
+	if err != nil {
+		return err
+	}
+	return s.checkCreateAccess(ctx, nodes)
+}
+
+func (s *Service) moveNode(ctx context.Context, request *index.MoveNodeRequest) (*index.MoveNodeResult, error) {
+	if request == nil {
+		return &index.MoveNodeResult{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
+	}
+	s.logger.Infof("moveNode(): from=%q, to=%q", request.From, request.To)
+
+	mtx := s.Db.NewModelTx(ctx)
+	mtx.MustBegin()
+	defer func() {
+		_ = mtx.Rollback()
+	}()
+	if err := s.checkPlaceAccess(ctx, mtx, request.From, persistence.PermissionWrite, request.To); err != nil {
+		return &index.MoveNodeResult{}, errors.GRPCWrap(err)
+	}
+	nc, err := createParents(mtx, request.To)
+	if err != nil {
+		return &index.MoveNodeResult{}, errors.GRPCWrap(err)
+	}
+	n, err := mtx.MoveNode(request.From, request.To)
+	if err != nil {
+		return &index.MoveNodeResult{}, errors.GRPCWrap(err)
+	}
+	_ = mtx.Commit()
+	return &index.MoveNodeResult{Node: toApiNode(n), NodesCreated: nc}, nil
+}
+
+func (s *Service) copyNodes(ctx context.Context, request *index.CopyNodesRequest) (*index.CopyNodesResult, error) {
+	if request == nil {
+		return &index.CopyNodesResult{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
+	}
+	s.logger.Infof("copyNodes(): from=%q, to=%q, tags=%v", request.From, request.To, request.Tags)
+
+	mtx := s.Db.NewModelTx(ctx)
+	mtx.MustBegin()
+	defer func() {
+		_ = mtx.Rollback()
+	}()
+	if err := s.checkPlaceAccess(ctx, mtx, request.From, persistence.PermissionRead, request.To); err != nil {
+		return &index.CopyNodesResult{}, errors.GRPCWrap(err)
+	}
+	nc, err := createParents(mtx, request.To)
+	if err != nil {
+		return &index.CopyNodesResult{}, errors.GRPCWrap(err)
+	}
+	var tags persistence.Tags
+	if len(request.Tags) > 0 {
+		tags = request.Tags
+	}
+	cr, err := mtx.CopyNodes(persistence.CopyNodesQuery{From: request.From, To: request.To, Tags: tags})
+	if err != nil {
+		return &index.CopyNodesResult{}, errors.GRPCWrap(err)
+	}
+	_ = mtx.Commit()
+	return &index.CopyNodesResult{Node: toApiNode(cr.Node), NodesCreated: nc, NodesCopied: cr.Nodes, RecordsCopied: cr.Records}, nil
+}
+
+// createParents creates the missing parent folders of the fqnp the same way as they are created for the new
+// records. It returns nil, if all the parent folders exist.
+func createParents(mtx persistence.ModelTx, fqnp string) (*index.Nodes, error) {
+	pths := persistence.SplitPath(fqnp)
+	if len(pths) == 0 {
+		return nil, fmt.Errorf("the path=%q should not be empty: %w", fqnp, errors.ErrInvalid)
+	}
+	pths = pths[:len(pths)-1]
+	nodes, err := mtx.ListAllNodesByPath(persistence.Path(pths))
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		if n.Flags&persistence.NodeFlagDocument != 0 {
+			return nil, fmt.Errorf("the node cannot be placed into the document %q: %w",
+				persistence.ConcatPath(n.Path, n.Name), errors.ErrInvalid)
+		}
+	}
+	n2c := nodes2Create(pths, nodes, nil, index.NodeType_FOLDER)
+	if len(n2c) == 0 {
+		return nil, nil
+	}
+	if nodes, err = mtx.CreateNodes(n2c...); err != nil {
+		return nil, err
+	}
+	return &index.Nodes{Nodes: toApiNodes(nodes)}, nil
+}
+
+func (s *Service) deleteNodes(ctx context.Context, dnr *index.DeleteNodesRequest) (*index.DeleteNodesResult, error) {
+	res := &index.DeleteNodesResult{}
 	force := cast.Value(dnr.Force, false)
-	s.logger.Infof("deleteNodes(): filter=%q, force=%t", dnr.FilterConditions, force)
+	trash := cast.Value(dnr.Trash, false)
+	dryRun := cast.Value(dnr.DryRun, false)
+	s.logger.Infof("deleteNodes(): filter=%q, force=%t, trash=%t, dryRun=%t", dnr.FilterConditions, force, trash, dryRun)
 	if strings.Trim(dnr.FilterConditions, " ") == "" {
 		return res, errors.GRPCWrap(fmt.Errorf("the filter request cannot be empty: %w", errors.ErrInvalid))
 	}
+	a, err := s.access(ctx, persistence.PermissionWrite)
+	if err != nil {
+		return res, errors.GRPCWrap(err)
+	}
+	q := persistence.DeleteNodesQuery{FilterConditions: dnr.FilterConditions, Force: force, Trash: trash, Access: a}
+	if dryRun {
+		q.Offset, q.Limit = dnr.Offset, dnr.Limit
+		if q.Limit < 1 {
+			q.Limit = 100
+		}
+		if q.Limit > 1000 {
+			q.Limit = 1000
+		}
+		p, err := s.Db.NewModelTx(ctx).PreviewDeleteNodes(q)
+		if err != nil {
+			return res, errors.GRPCWrap(err)
+		}
+		res.Nodes = toApiNodes(p.Nodes)
+		res.Total, res.Records, res.ForceRequired = p.Total, p.Records, p.ForceRequired
+		return res, nil
+	}
 	mtx := s.Db.NewModelTx(ctx)
 	mtx.MustBegin()
 	defer func() {
 		_ = mtx.Rollback()
 	}()
-	err := mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: dnr.FilterConditions, Force: force})
-	if err != nil {
+	if err = mtx.DeleteNodes(q); err != nil {
 		return res, errors.GRPCWrap(err)
 	}
 	mtx.Commit()
@@ -207,8 +862,12 @@ func (s *Service) listNodes(ctx context.Context, lnr *index.ListNodesRequest) (*
 	if strings.Trim(lnr.FilterConditions, " ") == "" {
 		return res, errors.GRPCWrap(fmt.Errorf("filter conditions cannot be empty: %w", errors.ErrInvalid))
 	}
+	a, err := s.access(ctx, persistence.PermissionRead)
+	if err != nil {
+		return res, errors.GRPCWrap(err)
+	}
 	mtx := s.Db.NewModelTx(ctx)
-	nodes, err := mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: lnr.FilterConditions, Offset: lnr.Offset, Limit: lnr.Limit})
+	nodes, err := mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: lnr.FilterConditions, Offset: lnr.Offset, Limit: lnr.Limit, Access: a})
 	if err != nil {
 		return res, errors.GRPCWrap(err)
 	}
@@ -216,6 +875,41 @@ func (s *Service) listNodes(ctx context.Context, lnr *index.ListNodesRequest) (*
 	return res, nil
 }
 
+func (s *Service) listTrash(ctx context.Context, lnr *index.ListNodesRequest) (*index.Nodes, error) {
+	res := &index.Nodes{}
+	if err := s.checkAdmin(ctx); err != nil {
+		return res, errors.GRPCWrap(err)
+	}
+	mtx := s.Db.NewModelTx(ctx)
+	nodes, err := mtx.ListTrash(persistence.ListNodesQuery{FilterConditions: lnr.FilterConditions, Offset: lnr.Offset, Limit: lnr.Limit})
+	if err != nil {
+		return res, errors.GRPCWrap(err)
+	}
+	res.Nodes = toApiNodes(nodes)
+	return res, nil
+}
+
+func (s *Service) restoreNodes(ctx context.Context, rnr *index.RestoreNodesRequest) (*index.RestoreNodesResult, error) {
+	s.logger.Infof("restoreNodes(): filter=%q", rnr.FilterConditions)
+	if strings.Trim(rnr.FilterConditions, " ") == "" {
+		return nil, errors.GRPCWrap(fmt.Errorf("the filter request cannot be empty: %w", errors.ErrInvalid))
+	}
+	if err := s.checkAdmin(ctx); err != nil {
+		return nil, errors.GRPCWrap(err)
+	}
+	mtx := s.Db.NewModelTx(ctx)
+	mtx.MustBegin()
+	defer func() {
+		_ = mtx.Rollback()
+	}()
+	cnt, err := mtx.RestoreNodes(persistence.RestoreNodesQuery{FilterConditions: rnr.FilterConditions})
+	if err != nil {
+		return nil, errors.GRPCWrap(err)
+	}
+	_ = mtx.Commit()
+	return &index.RestoreNodesResult{Restored: cnt}, nil
+}
+
 func (s *Service) listRecords(ctx context.Context, request *index.ListRequest) (*index.ListRecordsResult, error) {
 	mtx := s.Db.NewModelTx(ctx)
 	res := &index.ListRecordsResult{}
@@ -224,12 +918,13 @@ func (s *Service) listRecords(ctx context.Context, request *index.ListRequest) (
 		CreatedAfter:  protoTime2Time(request.CreatedAfter),
 		CreatedBefore: protoTime2Time(request.CreatedBefore),
 		FromID:        cast.Value(request.PageId, ""),
+		AsOf:          protoTime2Time(request.AsOf),
 	}
 	q.Limit = int(cast.Value(request.Limit, 100))
 	if q.Limit < 1 || q.Limit > 1000 {
 		q.Limit = 1000
 	}
-	node, err := mtx.GetNode(request.Path)
+	node, err := s.readableNode(ctx, mtx, request.Path)
 	if err != nil {
 		return res, errors.GRPCWrap(err)
 	}
@@ -246,31 +941,161 @@ func (s *Service) listRecords(ctx context.Context, request *index.ListRequest) (
 	return res, nil
 }
 
-func (s *Service) search(ctx context.Context, request *index.SearchRecordsRequest) (*index.SearchRecordsResult, error) {
+func (s *Service) listRecordRevisions(ctx context.Context, request *index.ListRecordRevisionsRequest) (*index.RecordRevisions, error) {
+	mtx := s.Db.NewModelTx(ctx)
+	q := persistence.IndexRecordRevisionsQuery{RecordID: cast.Value(request.RecordId, ""), Offset: request.Offset, Limit: request.Limit}
+	if q.Limit == 0 {
+		q.Limit = 100
+	}
+	if q.Limit < 1 || q.Limit > 1000 {
+		q.Limit = 1000
+	}
+	node, err := s.readableNode(ctx, mtx, request.Path)
+	if err != nil {
+		return nil, errors.GRPCWrap(err)
+	}
+	q.NodeID = node.ID
+	revs, err := mtx.ListIndexRecordRevisions(q)
+	if err != nil {
+		return nil, errors.GRPCWrap(err)
+	}
+	return &index.RecordRevisions{Revisions: toApiRecordRevisions(revs)}, nil
+}
+
+func (s *Service) diffRecords(ctx context.Context, request *index.DiffRecordsRequest) (*index.DiffRecordsResult, error) {
 	mtx := s.Db.NewModelTx(ctx)
 	mtx.MustBegin()
 	defer func() {
 		_ = mtx.Rollback()
 	}()
+	node, err := s.readableNode(ctx, mtx, request.Path)
+	if err != nil {
+		return nil, errors.GRPCWrap(err)
+	}
+	d, err := persistence.DiffIndexRecords(mtx, node.ID, protoTime2Time(request.From), protoTime2Time(request.To))
+	if err != nil {
+		return nil, errors.GRPCWrap(err)
+	}
+	return toApiRecordsDiff(d), nil
+}
+
+// watch polls the changelog and sends the changes matching the request to the send function, until the
+// ctx is closed or the send function returns an error. The started function, if provided, is called when
+// the request is checked and the changelog is read successfully the first time, before any change is sent.
+func (s *Service) watch(ctx context.Context, request *index.WatchRequest, started func(), send func(*index.Change) error) error {
+	// the changelog keeps no nodes ACLs, so the changes are watched by the admin only
+	if err := s.checkAdmin(ctx); err != nil {
+		return errors.GRPCWrap(err)
+	}
+	fc, err := watchConditions(request)
+	if err != nil {
+		return errors.GRPCWrap(err)
+	}
+	mtx := s.Db.NewModelTx(ctx)
+	var cursor int64
+	if request.Cursor != nil {
+		cursor = *request.Cursor
+	} else if cursor, err = mtx.LastChangeID(); err != nil {
+		return errors.GRPCWrap(err)
+	}
+	s.logger.Infof("watch(): path=%s, filterConditions=%s, cursor=%d", request.Path, request.FilterConditions, cursor)
+
+	const limit = 100
+	for {
+		res, err := mtx.ListChanges(persistence.ChangesQuery{FromID: cursor, FilterConditions: fc, Limit: limit})
+		if err != nil {
+			return errors.GRPCWrap(err)
+		}
+		if started != nil {
+			started()
+			started = nil
+		}
+		for _, c := range res.Items {
+			if err = send(toApiChange(c)); err != nil {
+				return err
+			}
+		}
+		cursor = res.NextID
+		if len(res.Items) == limit {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(s.watchInterval):
+		}
+	}
+}
+
+// watchConditions returns the filter conditions for the changes of the nodes under the request path,
+// which match the request filter conditions
+func watchConditions(request *index.WatchRequest) (string, error) {
+	fc, err := persistence.SubtreeConditions(request.Path)
+	if err != nil || fc == "" {
+		return request.FilterConditions, err
+	}
+	fc = "(" + fc + ")"
+	if strings.TrimSpace(request.FilterConditions) != "" {
+		fc += " and (" + request.FilterConditions + ")"
+	}
+	return fc, nil
+}
+
+func (s *Service) search(ctx context.Context, request *index.SearchRecordsRequest) (*index.SearchRecordsResult, error) {
 	res := &index.SearchRecordsResult{}
+	a, err := s.access(ctx, persistence.PermissionRead)
+	if err != nil {
+		return res, errors.GRPCWrap(err)
+	}
 	q := persistence.SearchQuery{
 		TextQuery:        request.TextQuery,
 		FilterConditions: request.FilterConditions,
 		GroupByPathOff:   cast.Value(request.GroupByPathOff, false),
 		Offset:           int(cast.Value(request.Offset, 0)),
 		Limit:            int(cast.Value(request.Limit, 0)),
+		Mode:             toModelSearchMode(request.Mode),
+		Fusion:           toModelFusionMethod(request.Fusion),
+		SemanticWeight:   float64(cast.Value(request.SemanticWeight, 0)),
+		Engine:           cast.Value(request.Engine, ""),
+		Access:           a,
 	}
 	if q.Limit < 1 || q.Limit > 1000 {
 		q.Limit = 1000
 	}
+	// the query is embedded before the transaction is started, so it is not held by the embedding provider call
+	if err := s.embedQuery(ctx, &q); err != nil {
+		return res, errors.GRPCWrap(err)
+	}
+	mtx := s.Db.NewModelTx(ctx)
+	mtx.MustBegin()
+	defer func() {
+		_ = mtx.Rollback()
+	}()
 	qr, err := mtx.Search(q)
 	if err != nil {
-		return res, errors.GRPCWrap(err)
+		return res, errors.GRPCWrap(err)
+	}
+	res.Total = qr.Total
+	res.Items = toApiSearchRecords(qr.Items)
+	_ = mtx.Commit()
+	return res, nil
+}
+
+// embedQuery fills the q.Embedding by the q.TextQuery if the embedding provider is
+// configured and the search mode may need it, unless the q.TextQuery is the embedding itself.
+func (s *Service) embedQuery(ctx context.Context, q *persistence.SearchQuery) error {
+	if s.Embedder == nil || len(q.Embedding) > 0 || q.Mode == persistence.SearchModeLexical {
+		return nil
+	}
+	if _, err := persistence.ParseEmbedding(q.TextQuery); err == nil {
+		return nil
+	}
+	embs, err := s.Embedder.Embed(ctx, []string{q.TextQuery})
+	if err != nil {
+		return fmt.Errorf("could not embed the text query: %w", err)
 	}
-	res.Total = qr.Total
-	res.Items = toApiSearchRecords(qr.Items)
-	_ = mtx.Commit()
-	return res, nil
+	q.Embedding = embs[0]
+	return nil
 }
 
 func (s *Service) patchIndexRecords(ctx context.Context, request *index.PatchRecordsRequest) (*index.PatchRecordsResult, error) {
@@ -278,18 +1103,45 @@ func (s *Service) patchIndexRecords(ctx context.Context, request *index.PatchRec
 	if request == nil {
 		return &index.PatchRecordsResult{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
 	}
+	res := &index.PatchRecordsResult{}
+	a, err := s.access(ctx, persistence.PermissionWrite)
+	if err != nil {
+		return res, errors.GRPCWrap(err)
+	}
+	// the records are embedded before the node is locked, so the lock is not held by the embedding provider call
+	addRecs := toModelIndexRecordsFromApiRecords(0, request.UpsertRecords, 1.0)
+	if s.Embedder != nil && len(addRecs) > 0 {
+		node, err := s.Db.NewModelTx(ctx).GetNode(request.Path)
+		if err == nil {
+			err = checkAccess(a, node)
+		}
+		if err == nil {
+			err = embedding.EmbedRecords(ctx, s.Embedder, addRecs)
+		}
+		if err != nil {
+			return res, errors.GRPCWrap(err)
+		}
+	}
+
 	mtx := s.Db.NewModelTx(ctx)
 	mtx.MustBegin()
 	defer func() {
 		_ = mtx.Rollback()
 	}()
-	res := &index.PatchRecordsResult{}
-	node, err := mtx.GetNode(request.Path)
+	node, err := mtx.LockNode(request.Path)
 	if err != nil {
 		return res, errors.GRPCWrap(err)
 	}
+	if err = checkAccess(a, node); err != nil {
+		return res, errors.GRPCWrap(err)
+	}
+	if err = checkVersion(node, request.ExpectedVersion); err != nil {
+		return res, errors.GRPCWrap(err)
+	}
 
-	addRecs := toModelIndexRecordsFromApiRecords(node.ID, request.UpsertRecords, 1.0)
+	for i := range addRecs {
+		addRecs[i].NodeID = node.ID
+	}
 	delRecs := toModelIndexRecordsFromApiRecords(node.ID, request.DeleteRecords, 1.0)
 
 	n, err := mtx.UpsertIndexRecords(addRecs...)
@@ -302,15 +1154,35 @@ func (s *Service) patchIndexRecords(ctx context.Context, request *index.PatchRec
 		return res, errors.GRPCWrap(fmt.Errorf("index records patch(delete) failed: %w", err))
 	}
 	res.Deleted = n
-	_ = mtx.Commit()
+	if node, err = mtx.GetNode(request.Path); err != nil {
+		return res, errors.GRPCWrap(err)
+	}
+	res.Version = node.Version
+	if err = mtx.Commit(); err != nil {
+		return res, errors.GRPCWrap(err)
+	}
 	return res, nil
 }
 
+// idempotentPatchIndexRecords is the same as patchIndexRecords, but the request is executed once for the
+// idempotency key, the retries with the same key get the result of the first request.
+func (s *Service) idempotentPatchIndexRecords(ctx context.Context, key string, request *index.PatchRecordsRequest) (*index.PatchRecordsResult, error) {
+	if key == "" {
+		return s.patchIndexRecords(ctx, request)
+	}
+	return idempotent(ctx, s, key, fingerprint("patch", request, nil), &index.PatchRecordsResult{}, func() (*index.PatchRecordsResult, error) {
+		return s.patchIndexRecords(ctx, request)
+	})
+}
+
 func (s *Service) createFormat(ctx context.Context, req *format.Format) (*format.Format, error) {
 	s.logger.Infof("createFormat(): request=%s", req)
 	if req == nil {
 		return &format.Format{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
 	}
+	if err := s.checkAdmin(ctx); err != nil {
+		return &format.Format{}, errors.GRPCWrap(err)
+	}
 	mtx := s.Db.NewModelTx(ctx)
 	frmt, err := mtx.CreateFormat(toModelFormat(req))
 	if err != nil {
@@ -324,6 +1196,9 @@ func (s *Service) getFormat(ctx context.Context, id *format.Id) (*format.Format,
 	if id == nil {
 		return &format.Format{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
 	}
+	if _, _, err := s.identity(ctx); err != nil {
+		return &format.Format{}, errors.GRPCWrap(err)
+	}
 	mtx := s.Db.NewModelTx(ctx)
 	frmt, err := mtx.GetFormat((*id).Id)
 	if err != nil {
@@ -337,6 +1212,9 @@ func (s *Service) deleteFormat(ctx context.Context, id *format.Id) (*emptypb.Emp
 	if id == nil {
 		return &emptypb.Empty{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
 	}
+	if err := s.checkAdmin(ctx); err != nil {
+		return &emptypb.Empty{}, errors.GRPCWrap(err)
+	}
 	mtx := s.Db.NewModelTx(ctx)
 	if err := mtx.DeleteFormat((*id).Id); err != nil {
 		return &emptypb.Empty{}, errors.GRPCWrap(fmt.Errorf("could not delete format with ID=%v: %w", (*id).Id, err))
@@ -346,6 +1224,9 @@ func (s *Service) deleteFormat(ctx context.Context, id *format.Id) (*emptypb.Emp
 
 func (s *Service) listFormat(ctx context.Context, _ *emptypb.Empty) (*format.Formats, error) {
 	s.logger.Debugf("listFormat()")
+	if _, _, err := s.identity(ctx); err != nil {
+		return &format.Formats{}, errors.GRPCWrap(err)
+	}
 	mtx := s.Db.NewModelTx(ctx)
 	mFrmts, err := mtx.ListFormats()
 	if err != nil {
@@ -358,17 +1239,394 @@ func (s *Service) listFormat(ctx context.Context, _ *emptypb.Empty) (*format.For
 	return &format.Formats{Formats: aFrmts}, nil
 }
 
+func (s *Service) createWebhook(ctx context.Context, req *webhook.Webhook) (*webhook.Webhook, error) {
+	if req == nil {
+		return &webhook.Webhook{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
+	}
+	s.logger.Infof("createWebhook(): id=%s, url=%s, path=%s, events=%v", req.Id, req.Url, req.Path, req.Events)
+	if err := s.checkAdmin(ctx); err != nil {
+		return &webhook.Webhook{}, errors.GRPCWrap(err)
+	}
+	if u, err := url.Parse(req.Url); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return &webhook.Webhook{}, errors.GRPCWrap(fmt.Errorf("the webhook url %q must be an absolute http(s) URL: %w", req.Url, errors.ErrInvalid))
+	}
+	if err := webhooks.CheckEvents(req.Events); err != nil {
+		return &webhook.Webhook{}, errors.GRPCWrap(err)
+	}
+	if _, err := persistence.SubtreeConditions(req.Path); err != nil {
+		return &webhook.Webhook{}, errors.GRPCWrap(err)
+	}
+	mtx := s.Db.NewModelTx(ctx)
+	mtx.MustBegin()
+	defer func() {
+		_ = mtx.Rollback()
+	}()
+	// the webhook receives the events, which happen after its creation only
+	cursor, err := mtx.LastChangeID()
+	if err != nil {
+		return &webhook.Webhook{}, errors.GRPCWrap(err)
+	}
+	wh := toModelWebhook(req)
+	wh.Cursor = cursor
+	if wh, err = mtx.CreateWebhook(wh); err != nil {
+		return &webhook.Webhook{}, errors.GRPCWrap(fmt.Errorf("could not create webhook with ID=%s: %w", req.Id, err))
+	}
+	if err = mtx.Commit(); err != nil {
+		return &webhook.Webhook{}, errors.GRPCWrap(err)
+	}
+	return toApiWebhook(wh), nil
+}
+
+func (s *Service) getWebhook(ctx context.Context, id *webhook.Id) (*webhook.Webhook, error) {
+	s.logger.Debugf("getWebhook(): id=%s", id)
+	if id == nil {
+		return &webhook.Webhook{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
+	}
+	if err := s.checkAdmin(ctx); err != nil {
+		return &webhook.Webhook{}, errors.GRPCWrap(err)
+	}
+	wh, err := s.Db.NewModelTx(ctx).GetWebhook(id.Id)
+	if err != nil {
+		return &webhook.Webhook{}, errors.GRPCWrap(fmt.Errorf("could not get webhook with ID=%v: %w", id.Id, err))
+	}
+	return toApiWebhook(wh), nil
+}
+
+func (s *Service) deleteWebhook(ctx context.Context, id *webhook.Id) (*emptypb.Empty, error) {
+	s.logger.Infof("deleteWebhook(): id=%s", id)
+	if id == nil {
+		return &emptypb.Empty{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
+	}
+	if err := s.checkAdmin(ctx); err != nil {
+		return &emptypb.Empty{}, errors.GRPCWrap(err)
+	}
+	if err := s.Db.NewModelTx(ctx).DeleteWebhook(id.Id); err != nil {
+		return &emptypb.Empty{}, errors.GRPCWrap(fmt.Errorf("could not delete webhook with ID=%v: %w", id.Id, err))
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Service) listWebhooks(ctx context.Context) (*webhook.Webhooks, error) {
+	s.logger.Debugf("listWebhooks()")
+	if err := s.checkAdmin(ctx); err != nil {
+		return &webhook.Webhooks{}, errors.GRPCWrap(err)
+	}
+	whs, err := s.Db.NewModelTx(ctx).ListWebhooks()
+	if err != nil {
+		return &webhook.Webhooks{}, errors.GRPCWrap(err)
+	}
+	res := make([]*webhook.Webhook, len(whs))
+	for i, wh := range whs {
+		res[i] = toApiWebhook(wh)
+	}
+	return &webhook.Webhooks{Webhooks: res}, nil
+}
+
+func (s *Service) listDeadLetters(ctx context.Context, req *webhook.ListDeadLettersRequest) (*webhook.DeadLetters, error) {
+	s.logger.Debugf("listDeadLetters(): request=%s", req)
+	if req == nil {
+		return &webhook.DeadLetters{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
+	}
+	if err := s.checkAdmin(ctx); err != nil {
+		return &webhook.DeadLetters{}, errors.GRPCWrap(err)
+	}
+	limit := cast.Value(req.Limit, 100)
+	if limit < 1 || limit > 1000 {
+		limit = 1000
+	}
+	mtx := s.Db.NewModelTx(ctx)
+	if _, err := mtx.GetWebhook(req.WebhookId); err != nil {
+		return &webhook.DeadLetters{}, errors.GRPCWrap(fmt.Errorf("could not get webhook with ID=%v: %w", req.WebhookId, err))
+	}
+	dls, err := mtx.ListDeadLetters(persistence.DeadLettersQuery{WebhookID: req.WebhookId, Offset: req.Offset, Limit: limit})
+	if err != nil {
+		return &webhook.DeadLetters{}, errors.GRPCWrap(err)
+	}
+	res := make([]*webhook.DeadLetter, len(dls))
+	for i, dl := range dls {
+		res[i] = toApiDeadLetter(dl)
+	}
+	return &webhook.DeadLetters{DeadLetters: res}, nil
+}
+
+func (s *Service) getJob(ctx context.Context, id *index.JobId) (*index.Job, error) {
+	s.logger.Debugf("getJob(): id=%s", id)
+	if id == nil {
+		return &index.Job{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
+	}
+	job, err := s.accessibleJob(ctx, id.Id, persistence.PermissionRead)
+	if err != nil {
+		return &index.Job{}, errors.GRPCWrap(fmt.Errorf("could not get job with ID=%v: %w", id.Id, err))
+	}
+	return toApiJob(job), nil
+}
+
+func (s *Service) listJobs(ctx context.Context, req *index.ListJobsRequest) (*index.Jobs, error) {
+	s.logger.Debugf("listJobs(): request=%s", req)
+	if req == nil {
+		return &index.Jobs{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
+	}
+	if err := s.checkAdmin(ctx); err != nil {
+		return &index.Jobs{}, errors.GRPCWrap(err)
+	}
+	limit := req.Limit
+	if limit < 1 || limit > 1000 {
+		limit = 1000
+	}
+	q := persistence.JobsQuery{Offset: req.Offset, Limit: limit}
+	if req.Status != nil {
+		q.Status = toModelJobStatus(*req.Status)
+	}
+	js, err := s.Db.NewModelTx(ctx).ListJobs(q)
+	if err != nil {
+		return &index.Jobs{}, errors.GRPCWrap(err)
+	}
+	res := make([]*index.Job, len(js))
+	for i, job := range js {
+		res[i] = toApiJob(job)
+	}
+	return &index.Jobs{Jobs: res}, nil
+}
+
+func (s *Service) cancelJob(ctx context.Context, id *index.JobId) (*index.Job, error) {
+	s.logger.Infof("cancelJob(): id=%s", id)
+	if id == nil {
+		return &index.Job{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
+	}
+	if _, err := s.accessibleJob(ctx, id.Id, persistence.PermissionWrite); err != nil {
+		return &index.Job{}, errors.GRPCWrap(fmt.Errorf("could not cancel job with ID=%v: %w", id.Id, err))
+	}
+	job, err := s.Jobs.Cancel(ctx, id.Id)
+	if err != nil {
+		return &index.Job{}, errors.GRPCWrap(fmt.Errorf("could not cancel job with ID=%v: %w", id.Id, err))
+	}
+	return toApiJob(job), nil
+}
+
+// accessibleJob returns the job by its ID, if the caller has the permission perm for the job node
+func (s *Service) accessibleJob(ctx context.Context, ID int64, perm persistence.Permission) (persistence.Job, error) {
+	a, err := s.access(ctx, perm)
+	if err != nil {
+		return persistence.Job{}, err
+	}
+	mtx := s.Db.NewModelTx(ctx)
+	job, err := mtx.GetJob(ID)
+	if err != nil || a == nil {
+		return job, err
+	}
+	n, err := mtx.GetNode(job.Path)
+	if err != nil {
+		return persistence.Job{}, err
+	}
+	return job, checkAccess(a, n)
+}
+
+func (s *Service) createAttrSchema(ctx context.Context, req *index.AttrSchema) (*index.AttrSchema, error) {
+	s.logger.Infof("createAttrSchema(): request=%s", req)
+	if req == nil {
+		return &index.AttrSchema{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
+	}
+	if err := s.checkAdmin(ctx); err != nil {
+		return &index.AttrSchema{}, errors.GRPCWrap(err)
+	}
+	mtx := s.Db.NewModelTx(ctx)
+	mtx.MustBegin()
+	defer func() {
+		_ = mtx.Rollback()
+	}()
+	as, err := mtx.CreateAttrSchema(toModelAttrSchema(req))
+	if err != nil {
+		return &index.AttrSchema{}, errors.GRPCWrap(fmt.Errorf("could not create attribute schema=%v: %w", req, err))
+	}
+	if err = mtx.Commit(); err != nil {
+		return &index.AttrSchema{}, errors.GRPCWrap(err)
+	}
+	return toApiAttrSchema(as), nil
+}
+
+func (s *Service) deleteAttrSchema(ctx context.Context, req *index.DeleteAttrSchemaRequest) (*emptypb.Empty, error) {
+	s.logger.Infof("deleteAttrSchema(): request=%s", req)
+	if req == nil {
+		return &emptypb.Empty{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
+	}
+	if err := s.checkAdmin(ctx); err != nil {
+		return &emptypb.Empty{}, errors.GRPCWrap(err)
+	}
+	mtx := s.Db.NewModelTx(ctx)
+	mtx.MustBegin()
+	defer func() {
+		_ = mtx.Rollback()
+	}()
+	if err := mtx.DeleteAttrSchema(req.Prefix, req.Name); err != nil {
+		return &emptypb.Empty{}, errors.GRPCWrap(fmt.Errorf("could not delete attribute %q schema for prefix %q: %w", req.Name, req.Prefix, err))
+	}
+	if err := mtx.Commit(); err != nil {
+		return &emptypb.Empty{}, errors.GRPCWrap(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Service) listAttrSchemas(ctx context.Context) (*index.AttrSchemas, error) {
+	s.logger.Debugf("listAttrSchemas()")
+	if _, _, err := s.identity(ctx); err != nil {
+		return &index.AttrSchemas{}, errors.GRPCWrap(err)
+	}
+	schemas, err := s.Db.NewModelTx(ctx).ListAttrSchemas()
+	if err != nil {
+		return &index.AttrSchemas{}, errors.GRPCWrap(err)
+	}
+	res := make([]*index.AttrSchema, len(schemas))
+	for i, as := range schemas {
+		res[i] = toApiAttrSchema(as)
+	}
+	return &index.AttrSchemas{AttrSchemas: res}, nil
+}
+
+func (s *Service) grantAccess(ctx context.Context, req *index.GrantAccessRequest) (*index.NodeACL, error) {
+	if req == nil {
+		return &index.NodeACL{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
+	}
+	s.logger.Infof("grantAccess(): path=%q, principal=%q, permission=%s", req.Path, req.Principal, req.Permission)
+	return s.setNodeACL(ctx, req.Path, req.Principal, toModelPermission(req.Permission))
+}
+
+func (s *Service) revokeAccess(ctx context.Context, req *index.RevokeAccessRequest) (*index.NodeACL, error) {
+	if req == nil {
+		return &index.NodeACL{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
+	}
+	s.logger.Infof("revokeAccess(): path=%q, principal=%q", req.Path, req.Principal)
+	return s.setNodeACL(ctx, req.Path, req.Principal, "")
+}
+
+// setNodeACL sets the permission of the principal for the node, the principal is removed from the node
+// ACL if perm is empty. The caller must have the admin permission for the node.
+func (s *Service) setNodeACL(ctx context.Context, fqnp, principal string, perm persistence.Permission) (*index.NodeACL, error) {
+	mtx := s.Db.NewModelTx(ctx)
+	mtx.MustBegin()
+	defer func() {
+		_ = mtx.Rollback()
+	}()
+	if _, err := s.adminNode(ctx, mtx, fqnp); err != nil {
+		return &index.NodeACL{}, errors.GRPCWrap(err)
+	}
+	n, err := mtx.SetNodeACL(persistence.ConcatPath(fqnp, ""), principal, perm)
+	if err != nil {
+		return &index.NodeACL{}, errors.GRPCWrap(err)
+	}
+	if err = mtx.Commit(); err != nil {
+		return &index.NodeACL{}, errors.GRPCWrap(err)
+	}
+	return toApiNodeACL(n), nil
+}
+
+func (s *Service) getAccess(ctx context.Context, req *index.GetAccessRequest) (*index.NodeACL, error) {
+	if req == nil {
+		return &index.NodeACL{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
+	}
+	s.logger.Debugf("getAccess(): path=%q", req.Path)
+	n, err := s.adminNode(ctx, s.Db.NewModelTx(ctx), req.Path)
+	if err != nil {
+		return &index.NodeACL{}, errors.GRPCWrap(err)
+	}
+	return toApiNodeACL(n), nil
+}
+
+// adminNode returns the node with the fqnp, if the caller has the admin permission for it
+func (s *Service) adminNode(ctx context.Context, mtx persistence.ModelTx, fqnp string) (persistence.Node, error) {
+	a, err := s.access(ctx, persistence.PermissionAdmin)
+	if err != nil {
+		return persistence.Node{}, err
+	}
+	n, err := mtx.GetNode(persistence.ConcatPath(fqnp, ""))
+	if err != nil {
+		return persistence.Node{}, err
+	}
+	return n, checkAccess(a, n)
+}
+
+// engineSwitcher returns the Db as the persistence.EngineSwitcher, if the Db supports the online search engine switch
+func (s *Service) engineSwitcher() (persistence.EngineSwitcher, error) {
+	es, ok := s.Db.(persistence.EngineSwitcher)
+	if !ok {
+		return nil, fmt.Errorf("the online search engine switch is not supported by the database: %w", errors.ErrUnimplemented)
+	}
+	return es, nil
+}
+
+func (s *Service) switchEngine(ctx context.Context, req *admin.SwitchEngineRequest) (*admin.EngineSwitch, error) {
+	s.logger.Infof("switchEngine(): request=%s", req)
+	if req == nil {
+		return &admin.EngineSwitch{}, errors.GRPCWrap(fmt.Errorf("invalid nil request: %w", errors.ErrInvalid))
+	}
+	if err := s.checkAdmin(ctx); err != nil {
+		return &admin.EngineSwitch{}, errors.GRPCWrap(err)
+	}
+	es, err := s.engineSwitcher()
+	if err != nil {
+		return &admin.EngineSwitch{}, errors.GRPCWrap(err)
+	}
+	sw, err := es.SwitchEngine(ctx, req.Engine)
+	if err != nil {
+		return &admin.EngineSwitch{}, errors.GRPCWrap(fmt.Errorf("could not switch the search engine to %q: %w", req.Engine, err))
+	}
+	return toApiEngineSwitch(sw), nil
+}
+
+func (s *Service) getEngineSwitch(ctx context.Context) (*admin.EngineSwitch, error) {
+	s.logger.Debugf("getEngineSwitch()")
+	if err := s.checkAdmin(ctx); err != nil {
+		return &admin.EngineSwitch{}, errors.GRPCWrap(err)
+	}
+	es, err := s.engineSwitcher()
+	if err != nil {
+		return &admin.EngineSwitch{}, errors.GRPCWrap(err)
+	}
+	sw, err := es.GetEngineSwitch(ctx)
+	if err != nil {
+		return &admin.EngineSwitch{}, errors.GRPCWrap(err)
+	}
+	return toApiEngineSwitch(sw), nil
+}
+
+func (s *Service) cancelEngineSwitch(ctx context.Context) (*admin.EngineSwitch, error) {
+	s.logger.Infof("cancelEngineSwitch()")
+	if err := s.checkAdmin(ctx); err != nil {
+		return &admin.EngineSwitch{}, errors.GRPCWrap(err)
+	}
+	es, err := s.engineSwitcher()
+	if err != nil {
+		return &admin.EngineSwitch{}, errors.GRPCWrap(err)
+	}
+	sw, err := es.CancelEngineSwitch(ctx)
+	if err != nil {
+		return &admin.EngineSwitch{}, errors.GRPCWrap(err)
+	}
+	return toApiEngineSwitch(sw), nil
+}
+
 // -------------------------- index.Service ---------------------------
 
 func (ids idxService) Create(ctx context.Context, request *index.CreateRecordsRequest) (*index.CreateRecordsResult, error) {
-	return ids.s.createRecords(ctx, request, nil)
+	return ids.s.idempotentCreateRecords(ctx, idempotencyKey(ctx), request, nil)
 }
 
-func (ids idxService) UpdateNode(ctx context.Context, request *index.UpdateNodeRequest) (*emptypb.Empty, error) {
+func (ids idxService) UpdateNode(ctx context.Context, request *index.UpdateNodeRequest) (*index.Node, error) {
 	return ids.s.updateNode(ctx, request)
 }
 
-func (ids idxService) DeleteNodes(ctx context.Context, dnr *index.DeleteNodesRequest) (*emptypb.Empty, error) {
+func (ids idxService) UpdateNodes(ctx context.Context, request *index.UpdateNodesRequest) (*index.UpdateNodesResult, error) {
+	return ids.s.updateNodes(ctx, request)
+}
+
+func (ids idxService) MoveNode(ctx context.Context, request *index.MoveNodeRequest) (*index.MoveNodeResult, error) {
+	return ids.s.moveNode(ctx, request)
+}
+
+func (ids idxService) CopyNodes(ctx context.Context, request *index.CopyNodesRequest) (*index.CopyNodesResult, error) {
+	return ids.s.copyNodes(ctx, request)
+}
+
+func (ids idxService) DeleteNodes(ctx context.Context, dnr *index.DeleteNodesRequest) (*index.DeleteNodesResult, error) {
 	return ids.s.deleteNodes(ctx, dnr)
 }
 
@@ -376,16 +1634,72 @@ func (ids idxService) ListNodes(ctx context.Context, lnr *index.ListNodesRequest
 	return ids.s.listNodes(ctx, lnr)
 }
 
+func (ids idxService) ListTrash(ctx context.Context, lnr *index.ListNodesRequest) (*index.Nodes, error) {
+	return ids.s.listTrash(ctx, lnr)
+}
+
+func (ids idxService) RestoreNodes(ctx context.Context, rnr *index.RestoreNodesRequest) (*index.RestoreNodesResult, error) {
+	return ids.s.restoreNodes(ctx, rnr)
+}
+
 func (ids idxService) ListRecords(ctx context.Context, request *index.ListRequest) (*index.ListRecordsResult, error) {
 	return ids.s.listRecords(ctx, request)
 }
 
+func (ids idxService) ListRecordRevisions(ctx context.Context, request *index.ListRecordRevisionsRequest) (*index.RecordRevisions, error) {
+	return ids.s.listRecordRevisions(ctx, request)
+}
+
+func (ids idxService) DiffRecords(ctx context.Context, request *index.DiffRecordsRequest) (*index.DiffRecordsResult, error) {
+	return ids.s.diffRecords(ctx, request)
+}
+
 func (ids idxService) Search(ctx context.Context, request *index.SearchRecordsRequest) (*index.SearchRecordsResult, error) {
 	return ids.s.search(ctx, request)
 }
 
+func (ids idxService) Watch(request *index.WatchRequest, server index.Service_WatchServer) error {
+	return ids.s.watch(server.Context(), request, nil, server.Send)
+}
+
 func (ids idxService) PatchRecords(ctx context.Context, request *index.PatchRecordsRequest) (*index.PatchRecordsResult, error) {
-	return ids.s.patchIndexRecords(ctx, request)
+	return ids.s.idempotentPatchIndexRecords(ctx, idempotencyKey(ctx), request)
+}
+
+func (ids idxService) GetJob(ctx context.Context, id *index.JobId) (*index.Job, error) {
+	return ids.s.getJob(ctx, id)
+}
+
+func (ids idxService) ListJobs(ctx context.Context, req *index.ListJobsRequest) (*index.Jobs, error) {
+	return ids.s.listJobs(ctx, req)
+}
+
+func (ids idxService) CancelJob(ctx context.Context, id *index.JobId) (*index.Job, error) {
+	return ids.s.cancelJob(ctx, id)
+}
+
+func (ids idxService) CreateAttrSchema(ctx context.Context, req *index.AttrSchema) (*index.AttrSchema, error) {
+	return ids.s.createAttrSchema(ctx, req)
+}
+
+func (ids idxService) DeleteAttrSchema(ctx context.Context, req *index.DeleteAttrSchemaRequest) (*emptypb.Empty, error) {
+	return ids.s.deleteAttrSchema(ctx, req)
+}
+
+func (ids idxService) ListAttrSchemas(ctx context.Context, _ *emptypb.Empty) (*index.AttrSchemas, error) {
+	return ids.s.listAttrSchemas(ctx)
+}
+
+func (ids idxService) GrantAccess(ctx context.Context, req *index.GrantAccessRequest) (*index.NodeACL, error) {
+	return ids.s.grantAccess(ctx, req)
+}
+
+func (ids idxService) RevokeAccess(ctx context.Context, req *index.RevokeAccessRequest) (*index.NodeACL, error) {
+	return ids.s.revokeAccess(ctx, req)
+}
+
+func (ids idxService) GetAccess(ctx context.Context, req *index.GetAccessRequest) (*index.NodeACL, error) {
+	return ids.s.getAccess(ctx, req)
 }
 
 func (ids idxService) CreateWithStreamData(server index.Service_CreateWithStreamDataServer) error {
@@ -423,7 +1737,7 @@ func (ids idxService) CreateWithStreamData(server index.Service_CreateWithStream
 			err = nil
 		}
 	}()
-	idx, err := ids.s.createRecords(server.Context(), req.Meta, r)
+	idx, err := ids.s.idempotentCreateRecords(server.Context(), idempotencyKey(server.Context()), req.Meta, r)
 	if err == nil {
 		server.SendAndClose(idx)
 	}
@@ -447,3 +1761,39 @@ func (fs fmtService) Delete(ctx context.Context, id *format.Id) (*emptypb.Empty,
 func (fs fmtService) List(ctx context.Context, empty *emptypb.Empty) (*format.Formats, error) {
 	return fs.s.listFormat(ctx, empty)
 }
+
+// ----------------------------- admin.Service ---------------------------------
+
+func (as admService) SwitchEngine(ctx context.Context, req *admin.SwitchEngineRequest) (*admin.EngineSwitch, error) {
+	return as.s.switchEngine(ctx, req)
+}
+
+func (as admService) GetEngineSwitch(ctx context.Context, _ *emptypb.Empty) (*admin.EngineSwitch, error) {
+	return as.s.getEngineSwitch(ctx)
+}
+
+func (as admService) CancelEngineSwitch(ctx context.Context, _ *emptypb.Empty) (*admin.EngineSwitch, error) {
+	return as.s.cancelEngineSwitch(ctx)
+}
+
+// ----------------------------- webhook.Service ---------------------------------
+
+func (ws whkService) Create(ctx context.Context, wh *webhook.Webhook) (*webhook.Webhook, error) {
+	return ws.s.createWebhook(ctx, wh)
+}
+
+func (ws whkService) Get(ctx context.Context, id *webhook.Id) (*webhook.Webhook, error) {
+	return ws.s.getWebhook(ctx, id)
+}
+
+func (ws whkService) Delete(ctx context.Context, id *webhook.Id) (*emptypb.Empty, error) {
+	return ws.s.deleteWebhook(ctx, id)
+}
+
+func (ws whkService) List(ctx context.Context, _ *emptypb.Empty) (*webhook.Webhooks, error) {
+	return ws.s.listWebhooks(ctx)
+}
+
+func (ws whkService) ListDeadLetters(ctx context.Context, req *webhook.ListDeadLettersRequest) (*webhook.DeadLetters, error) {
+	return ws.s.listDeadLetters(ctx, req)
+}
@@ -1,10 +1,28 @@
 package api
 
 import (
+	"context"
+	"fmt"
+	"github.com/acquirecloud/golibs/cast"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/simila-io/simila/api/gen/admin/v1"
+	"github.com/simila-io/simila/api/gen/format/v1"
 	"github.com/simila-io/simila/api/gen/index/v1"
+	"github.com/simila-io/simila/api/gen/webhook/v1"
+	"github.com/simila-io/simila/pkg/auth"
+	"github.com/simila-io/simila/pkg/embedding"
+	"github.com/simila-io/simila/pkg/indexer/idempotency"
+	"github.com/simila-io/simila/pkg/indexer/jobs"
 	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/indexer/persistence/inmem"
+	"github.com/simila-io/simila/pkg/parser"
+	"github.com/simila-io/simila/pkg/parser/txt"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"testing"
+	"time"
 )
 
 func TestNodes2Create(t *testing.T) {
@@ -16,3 +34,806 @@ func TestNodes2Create(t *testing.T) {
 	assert.Equal(t, []persistence.Node{},
 		nodes2Create([]string{"aaa", "bbb"}, []persistence.Node{{Path: "/", Name: "aaa"}, {Path: "/aaa", Name: "bbb"}}, nil, index.NodeType_FOLDER))
 }
+
+func newTestService(t *testing.T) *Service {
+	return newTestServiceWithEmbedder(t, nil)
+}
+
+func newTestServiceWithEmbedder(t *testing.T, ep embedding.Provider) *Service {
+	pp := parser.NewParserProvider()
+	tp := txt.New()
+	tp.PProvider = pp
+	tp.Embedder = ep
+	assert.Nil(t, tp.Init(context.Background()))
+	s := NewService()
+	s.PProvider = pp
+	s.Db = inmem.NewDb()
+	s.Embedder = ep
+	s.Idempotency = idempotency.NewStore(idempotency.DefaultConfig())
+	s.Idempotency.Db = s.Db
+	s.Jobs = jobs.NewRunner(jobs.Config{Workers: 1, QueueSize: 10, SpoolDir: t.TempDir(), Lease: jobs.DefaultLease})
+	s.Jobs.Db = s.Db
+	s.Jobs.PProvider = pp
+	assert.Nil(t, s.Jobs.Init(context.Background()))
+	t.Cleanup(s.Jobs.Shutdown)
+	return s
+}
+
+func TestServiceCreateSearchDelete(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	res, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/a/b/doc.txt", NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+		Parser: cast.Ptr("txt"), Document: []byte("hello world\n\nanother line")}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(res.NodesCreated.Nodes))
+
+	lr, err := s.listRecords(ctx, &index.ListRequest{Path: "/a/b/doc.txt"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), lr.Total)
+
+	sr, err := s.search(ctx, &index.SearchRecordsRequest{TextQuery: "world", FilterConditions: "prefix(path, '/a/')"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), sr.Total)
+	assert.Equal(t, "/a/b/doc.txt", sr.Items[0].Path)
+
+	_, err = s.deleteNodes(ctx, &index.DeleteNodesRequest{FilterConditions: "node = '/a'"})
+	assert.NotNil(t, err)
+	_, err = s.deleteNodes(ctx, &index.DeleteNodesRequest{FilterConditions: "node = '/a'", Force: cast.Ptr(true)})
+	assert.Nil(t, err)
+
+	sr, err = s.search(ctx, &index.SearchRecordsRequest{TextQuery: "world"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), sr.Total)
+}
+
+func TestServiceEmbeddings(t *testing.T) {
+	s := newTestServiceWithEmbedder(t, embedding.NewHashing(8))
+	ctx := context.Background()
+
+	_, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/doc.txt", Parser: cast.Ptr("txt"), Document: []byte("hello world")}, nil)
+	assert.Nil(t, err)
+	_, err = s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/recs", Records: []*index.Record{{Id: "1", Segment: "red apple", Format: "txt"}}}, nil)
+	assert.Nil(t, err)
+	_, err = s.patchIndexRecords(ctx, &index.PatchRecordsRequest{Path: "/recs", UpsertRecords: []*index.Record{{Id: "2", Segment: "green pear", Format: "txt"}}})
+	assert.Nil(t, err)
+
+	mtx := s.Db.NewModelTx(ctx)
+	for _, path := range []string{"/doc.txt", "/recs"} {
+		n, err := mtx.GetNode(path)
+		assert.Nil(t, err)
+		qr, err := mtx.QueryIndexRecords(persistence.IndexRecordQuery{NodeID: n.ID, Limit: 10})
+		assert.Nil(t, err)
+		assert.True(t, len(qr.Items) > 0)
+		for _, r := range qr.Items {
+			exp, _ := embedding.NewHashing(8).Embed(ctx, []string{r.Segment})
+			assert.Equal(t, persistence.Embedding(exp[0]), r.Embedding)
+		}
+	}
+
+	q := persistence.SearchQuery{TextQuery: "apple"}
+	assert.Nil(t, s.embedQuery(ctx, &q))
+	assert.Equal(t, 8, len(q.Embedding))
+	q = persistence.SearchQuery{TextQuery: "[1,2]"}
+	assert.Nil(t, s.embedQuery(ctx, &q))
+	assert.Nil(t, q.Embedding)
+}
+
+// txCheckingEmbedder fails, if it is called while a transaction holds the in-memory Db lock
+type txCheckingEmbedder struct {
+	embedding.Provider
+	db    persistence.Db
+	texts []string
+}
+
+func (e *txCheckingEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	done := make(chan struct{})
+	go func() {
+		_, _ = e.db.NewModelTx(ctx).ListWebhooks()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		return nil, fmt.Errorf("the embedder is called within the transaction: %w", errors.ErrInternal)
+	}
+	e.texts = append(e.texts, texts...)
+	return e.Provider.Embed(ctx, texts)
+}
+
+func TestServiceEmbeddingsNoTx(t *testing.T) {
+	emb := &txCheckingEmbedder{Provider: embedding.NewHashing(8)}
+	s := newTestServiceWithEmbedder(t, emb)
+	emb.db = s.Db
+	ctx := context.Background()
+
+	_, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/doc.txt", Parser: cast.Ptr("txt"), Document: []byte("hello world"),
+		Mode: cast.Ptr(index.CreateMode_REPLACE)}, nil)
+	assert.Nil(t, err)
+	_, err = s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/recs", Records: []*index.Record{{Id: "1", Segment: "red apple", Format: "txt"},
+		{Id: "2", Segment: "green pear", Format: "txt"}}}, nil)
+	assert.Nil(t, err)
+	// the unchanged record is not embedded again
+	_, err = s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/recs", Records: []*index.Record{{Id: "1", Segment: "red apple", Format: "txt"},
+		{Id: "2", Segment: "yellow pear", Format: "txt"}}, Mode: cast.Ptr(index.CreateMode_SYNC)}, nil)
+	assert.Nil(t, err)
+	_, err = s.patchIndexRecords(ctx, &index.PatchRecordsRequest{Path: "/recs", UpsertRecords: []*index.Record{{Id: "3", Segment: "plum", Format: "txt"}}})
+	assert.Nil(t, err)
+	_, err = s.search(ctx, &index.SearchRecordsRequest{TextQuery: "apple"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"hello world", "red apple", "green pear", "yellow pear", "plum", "apple"}, emb.texts)
+}
+
+func TestServiceEngineSwitchUnsupported(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+	_, err := s.switchEngine(ctx, &admin.SwitchEngineRequest{Engine: "pgfts"})
+	assert.True(t, errors.Is(err, errors.ErrUnimplemented))
+	_, err = s.getEngineSwitch(ctx)
+	assert.True(t, errors.Is(err, errors.ErrUnimplemented))
+	_, err = s.cancelEngineSwitch(ctx)
+	assert.True(t, errors.Is(err, errors.ErrUnimplemented))
+}
+
+func TestServiceMoveNode(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	_, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/a/b/doc.txt", NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+		Parser: cast.Ptr("txt"), Document: []byte("hello world")}, nil)
+	assert.Nil(t, err)
+
+	_, err = s.moveNode(ctx, &index.MoveNodeRequest{From: "/a", To: "/a/b/doc.txt/a"})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	_, err = s.moveNode(ctx, &index.MoveNodeRequest{From: "/a", To: "/a/c/a"})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	_, err = s.Db.NewModelTx(ctx).GetNode("/a/c")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	_, err = s.moveNode(ctx, &index.MoveNodeRequest{From: "/a/b", To: "/"})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+
+	res, err := s.moveNode(ctx, &index.MoveNodeRequest{From: "/a/b", To: "/x/y/b2"})
+	assert.Nil(t, err)
+	assert.Equal(t, "b2", res.Node.Name)
+	assert.Equal(t, "/x/y/", res.Node.Path)
+	assert.Equal(t, 2, len(res.NodesCreated.Nodes))
+
+	lr, err := s.listRecords(ctx, &index.ListRequest{Path: "/x/y/b2/doc.txt"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), lr.Total)
+	sr, err := s.search(ctx, &index.SearchRecordsRequest{TextQuery: "world"})
+	assert.Nil(t, err)
+	assert.Equal(t, "/x/y/b2/doc.txt", sr.Items[0].Path)
+}
+
+func TestServiceCopyNodes(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	_, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/templates/policies/doc.txt", NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+		Parser: cast.Ptr("txt"), Document: []byte("hello world\n\nanother line")}, nil)
+	assert.Nil(t, err)
+
+	res, err := s.copyNodes(ctx, &index.CopyNodesRequest{From: "/templates/policies", To: "/tenants/t1/policies", Tags: map[string]string{"tenant": "t1"}})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(res.NodesCreated.Nodes))
+	assert.Equal(t, int64(2), res.NodesCopied)
+	assert.Equal(t, int64(2), res.RecordsCopied)
+	assert.Equal(t, "t1", res.Node.Tags["tenant"])
+
+	sr, err := s.search(ctx, &index.SearchRecordsRequest{TextQuery: "world", FilterConditions: "tag('tenant') = 't1'"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), sr.Total)
+	assert.Equal(t, "/tenants/t1/policies/doc.txt", sr.Items[0].Path)
+
+	_, err = s.copyNodes(ctx, &index.CopyNodesRequest{From: "/templates/policies", To: "/tenants/t1/policies"})
+	assert.True(t, errors.Is(err, errors.ErrExist))
+}
+
+func TestServiceTrash(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	_, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/a/b/doc.txt", NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+		Parser: cast.Ptr("txt"), Document: []byte("hello world\n\nanother line")}, nil)
+	assert.Nil(t, err)
+
+	_, err = s.deleteNodes(ctx, &index.DeleteNodesRequest{FilterConditions: "node = '/a'", Force: cast.Ptr(true), Trash: cast.Ptr(true)})
+	assert.Nil(t, err)
+	_, err = s.listRecords(ctx, &index.ListRequest{Path: "/a/b/doc.txt"})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	sr, err := s.search(ctx, &index.SearchRecordsRequest{TextQuery: "world"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), sr.Total)
+
+	tr, err := s.listTrash(ctx, &index.ListNodesRequest{FilterConditions: "path like '/%'", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(tr.Nodes))
+	assert.NotNil(t, tr.Nodes[0].DeletedAt)
+
+	_, err = s.restoreNodes(ctx, &index.RestoreNodesRequest{})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	rr, err := s.restoreNodes(ctx, &index.RestoreNodesRequest{FilterConditions: "node = '/a'"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), rr.Restored)
+	lr, err := s.listRecords(ctx, &index.ListRequest{Path: "/a/b/doc.txt"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), lr.Total)
+}
+
+func TestServiceDeleteNodesDryRun(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	_, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/a/b/doc.txt", NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+		Parser: cast.Ptr("txt"), Document: []byte("hello world\n\nanother line")}, nil)
+	assert.Nil(t, err)
+
+	res, err := s.deleteNodes(ctx, &index.DeleteNodesRequest{FilterConditions: "node = '/a'", DryRun: cast.Ptr(true)})
+	assert.Nil(t, err)
+	assert.True(t, res.ForceRequired)
+	assert.Equal(t, int64(3), res.Total)
+	assert.Equal(t, int64(2), res.Records)
+	assert.Equal(t, 3, len(res.Nodes))
+
+	lr, err := s.listRecords(ctx, &index.ListRequest{Path: "/a/b/doc.txt"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), lr.Total)
+}
+
+func TestServiceRecordHistory(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	_, err := s.createFormat(ctx, &format.Format{Name: "hist", KeepHistory: true})
+	assert.Nil(t, err)
+	f, err := s.getFormat(ctx, &format.Id{Id: "hist"})
+	assert.Nil(t, err)
+	assert.True(t, f.KeepHistory)
+
+	_, err = s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/doc", NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+		Records: []*index.Record{{Id: "1", Segment: "v1", Format: "hist"}}}, nil)
+	assert.Nil(t, err)
+	time.Sleep(10 * time.Millisecond)
+	t1 := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	_, err = s.patchIndexRecords(ctx, &index.PatchRecordsRequest{Path: "/doc",
+		UpsertRecords: []*index.Record{{Id: "1", Segment: "v2", Format: "hist"}, {Id: "2", Segment: "new", Format: "hist"}}})
+	assert.Nil(t, err)
+
+	revs, err := s.listRecordRevisions(ctx, &index.ListRecordRevisionsRequest{Path: "/doc"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(revs.Revisions))
+	assert.Equal(t, "v1", revs.Revisions[0].Record.Segment)
+	assert.False(t, revs.Revisions[0].Deleted)
+
+	lr, err := s.listRecords(ctx, &index.ListRequest{Path: "/doc", AsOf: timestamppb.New(t1)})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), lr.Total)
+	assert.Equal(t, "v1", lr.Records[0].Segment)
+
+	d, err := s.diffRecords(ctx, &index.DiffRecordsRequest{Path: "/doc", From: timestamppb.New(t1)})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(d.Added))
+	assert.Equal(t, "2", d.Added[0].Id)
+	assert.Equal(t, 0, len(d.Removed))
+	assert.Equal(t, 1, len(d.Changed))
+	assert.Equal(t, "v2", d.Changed[0].To.Segment)
+
+	_, err = s.diffRecords(ctx, &index.DiffRecordsRequest{Path: "/doc"})
+	assert.NotNil(t, err)
+	_, err = s.listRecordRevisions(ctx, &index.ListRecordRevisionsRequest{Path: "/unknown"})
+	assert.NotNil(t, err)
+}
+
+func TestServiceVersions(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+	_, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/doc", NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+		Records: []*index.Record{{Id: "1", Segment: "v1", Format: "txt"}}}, nil)
+	assert.Nil(t, err)
+	mn, err := s.Db.NewModelTx(ctx).GetNode("/doc")
+	assert.Nil(t, err)
+	v := mn.Version
+
+	// the concurrent writer is rejected when the node was changed after it was read
+	n, err := s.updateNode(ctx, &index.UpdateNodeRequest{Path: "/doc", Node: &index.Node{Tags: map[string]string{"k": "v1"}}, ExpectedVersion: cast.Ptr(v)})
+	assert.Nil(t, err)
+	assert.Equal(t, v+1, n.Version)
+	_, err = s.updateNode(ctx, &index.UpdateNodeRequest{Path: "/doc", Node: &index.Node{Tags: map[string]string{"k": "v2"}}, ExpectedVersion: cast.Ptr(v)})
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	mn, err = s.Db.NewModelTx(ctx).GetNode("/doc")
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.Tags{"k": "v1"}, mn.Tags)
+
+	_, err = s.patchIndexRecords(ctx, &index.PatchRecordsRequest{Path: "/doc",
+		UpsertRecords: []*index.Record{{Id: "1", Segment: "v2", Format: "txt"}}, ExpectedVersion: cast.Ptr(v)})
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	pr, err := s.patchIndexRecords(ctx, &index.PatchRecordsRequest{Path: "/doc",
+		UpsertRecords: []*index.Record{{Id: "1", Segment: "v2", Format: "txt"}}, ExpectedVersion: cast.Ptr(v + 1)})
+	assert.Nil(t, err)
+	assert.Equal(t, v+2, pr.Version)
+
+	// no version check if the expected version is not provided
+	n, err = s.updateNode(ctx, &index.UpdateNodeRequest{Path: "/doc", Node: &index.Node{Tags: map[string]string{"k": "v3"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, v+3, n.Version)
+}
+
+func TestServiceIdempotency(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+	req := &index.CreateRecordsRequest{Path: "/doc", NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+		Records: []*index.Record{{Id: "1", Segment: "v1", Format: "txt"}}}
+	res, err := s.idempotentCreateRecords(ctx, "k1", req, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.RecordsCreated)
+	assert.Equal(t, 1, len(res.NodesCreated.Nodes))
+
+	// the retry gets the same result, though the node exists already
+	res, err = s.idempotentCreateRecords(ctx, "k1", req, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.RecordsCreated)
+	assert.Equal(t, 1, len(res.NodesCreated.Nodes))
+	_, err = s.idempotentCreateRecords(ctx, "k1", &index.CreateRecordsRequest{Path: "/doc2"}, nil)
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+
+	// the gRPC metadata key
+	mctx := metadata.NewIncomingContext(ctx, metadata.Pairs("idempotency-key", "k2"))
+	preq := &index.PatchRecordsRequest{Path: "/doc", UpsertRecords: []*index.Record{{Id: "2", Segment: "v2", Format: "txt"}}}
+	pr, err := s.IndexServiceServer().PatchRecords(mctx, preq)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), pr.Upserted)
+	pr2, err := s.IndexServiceServer().PatchRecords(mctx, preq)
+	assert.Nil(t, err)
+	assert.Equal(t, pr.Version, pr2.Version)
+	n, err := s.Db.NewModelTx(ctx).GetNode("/doc")
+	assert.Nil(t, err)
+	assert.Equal(t, pr.Version, n.Version)
+
+	// no key, no replay
+	pr2, err = s.IndexServiceServer().PatchRecords(ctx, preq)
+	assert.Nil(t, err)
+	assert.Equal(t, pr.Version+1, pr2.Version)
+}
+
+func TestServiceWatch(t *testing.T) {
+	s := newTestService(t)
+	s.watchInterval = time.Millisecond
+	ctx := context.Background()
+
+	_, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/a/doc", NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+		Records: []*index.Record{{Id: "1", Segment: "v1", Format: "txt"}}}, nil)
+	assert.Nil(t, err)
+	_, err = s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/ab", NodeType: cast.Ptr(index.NodeType_DOCUMENT)}, nil)
+	assert.Nil(t, err)
+
+	watch := func(req *index.WatchRequest, n int, timeout time.Duration) []*index.Change {
+		wctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		var res []*index.Change
+		err := s.watch(wctx, req, nil, func(c *index.Change) error {
+			if res = append(res, c); len(res) == n {
+				cancel()
+			}
+			return nil
+		})
+		assert.Nil(t, err)
+		return res
+	}
+	changes := watch(&index.WatchRequest{Path: "/a", Cursor: cast.Ptr(int64(0))}, 3, 5*time.Second)
+	assert.Equal(t, 3, len(changes))
+	assert.Equal(t, index.ChangeObject_NODE, changes[0].Object)
+	assert.Equal(t, "a", changes[0].Node.Name)
+	assert.Equal(t, index.ChangeOp_CREATE, changes[1].Op)
+	assert.Equal(t, "doc", changes[1].Node.Name)
+	assert.Equal(t, index.ChangeObject_RECORD, changes[2].Object)
+	assert.Equal(t, "1", changes[2].RecordId)
+
+	changes = watch(&index.WatchRequest{FilterConditions: "format = 'txt'", Cursor: cast.Ptr(changes[2].Cursor)}, 1, 50*time.Millisecond)
+	assert.Equal(t, 0, len(changes))
+
+	// the watch without the cursor streams the new changes only
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_, _ = s.patchIndexRecords(ctx, &index.PatchRecordsRequest{Path: "/a/doc",
+			UpsertRecords: []*index.Record{{Id: "1", Segment: "v2", Format: "txt"}}})
+	}()
+	changes = watch(&index.WatchRequest{Path: "/a/doc"}, 1, 5*time.Second)
+	assert.Equal(t, 1, len(changes))
+	assert.Equal(t, index.ChangeOp_UPDATE, changes[0].Op)
+
+	started := false
+	err = s.watch(ctx, &index.WatchRequest{FilterConditions: "tag(1) = 'a'"}, func() { started = true }, nil)
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	assert.False(t, started)
+	_, err = watchConditions(&index.WatchRequest{Path: "/it's \"quoted\""})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+}
+
+func TestServiceWebhooks(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+	_, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/a", NodeType: cast.Ptr(index.NodeType_DOCUMENT)}, nil)
+	assert.Nil(t, err)
+
+	_, err = s.createWebhook(ctx, &webhook.Webhook{Id: "w", Url: "localhost/hook"})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	_, err = s.createWebhook(ctx, &webhook.Webhook{Id: "w", Url: "http://localhost/hook", Events: []string{"node.created"}})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	wh, err := s.createWebhook(ctx, &webhook.Webhook{Id: "w", Url: "http://localhost/hook", Secret: "s", Path: "/a", Events: []string{"node.update"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "", wh.Secret)
+	assert.Equal(t, "/a", wh.Path)
+
+	// the webhook receives the changes made after its creation only
+	mwh, err := s.Db.NewModelTx(ctx).GetWebhook("w")
+	assert.Nil(t, err)
+	last, err := s.Db.NewModelTx(ctx).LastChangeID()
+	assert.Nil(t, err)
+	assert.Equal(t, last, mwh.Cursor)
+	assert.True(t, last > 0)
+
+	whs, err := s.listWebhooks(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(whs.Webhooks))
+	assert.Equal(t, []string{"node.update"}, whs.Webhooks[0].Events)
+
+	dls, err := s.listDeadLetters(ctx, &webhook.ListDeadLettersRequest{WebhookId: "w"})
+	assert.Nil(t, err)
+	assert.Empty(t, dls.DeadLetters)
+	_, err = s.listDeadLetters(ctx, &webhook.ListDeadLettersRequest{WebhookId: "unknown"})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+
+	_, err = s.deleteWebhook(ctx, &webhook.Id{Id: "w"})
+	assert.Nil(t, err)
+	_, err = s.getWebhook(ctx, &webhook.Id{Id: "w"})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+}
+
+func TestServiceJobs(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	_, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/a/doc.txt", NodeType: cast.Ptr(index.NodeType_DOCUMENT), Async: true,
+		Records: []*index.Record{{Id: "1", Segment: "hello", Format: "txt"}}}, nil)
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+
+	res, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/a/doc.txt", NodeType: cast.Ptr(index.NodeType_DOCUMENT), Async: true,
+		Parser: cast.Ptr("txt"), Document: []byte("hello world\n\nanother line")}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(res.NodesCreated.Nodes))
+	assert.Equal(t, "/a/doc.txt", res.Job.Path)
+	assert.Equal(t, index.JobStatus_PENDING, res.Job.Status)
+
+	var job *index.Job
+	assert.Eventually(t, func() bool {
+		job, err = s.getJob(ctx, &index.JobId{Id: res.Job.Id})
+		return err == nil && job.Status == index.JobStatus_DONE
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(2), job.Records)
+	lrr, err := s.listRecords(ctx, &index.ListRequest{Path: "/a/doc.txt"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(lrr.Records))
+
+	js, err := s.listJobs(ctx, &index.ListJobsRequest{Status: cast.Ptr(index.JobStatus_DONE)})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(js.Jobs))
+	js, err = s.listJobs(ctx, &index.ListJobsRequest{Status: cast.Ptr(index.JobStatus_FAILED)})
+	assert.Nil(t, err)
+	assert.Empty(t, js.Jobs)
+
+	_, err = s.cancelJob(ctx, &index.JobId{Id: res.Job.Id})
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	_, err = s.getJob(ctx, &index.JobId{Id: res.Job.Id + 1})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+}
+
+func TestServiceCreateModes(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+	recs := func(segs ...string) []*index.Record {
+		var res []*index.Record
+		for i, sg := range segs {
+			if sg != "" {
+				res = append(res, &index.Record{Id: fmt.Sprint(i + 1), Segment: sg, Format: "txt", RankMultiplier: 1.0})
+			}
+		}
+		return res
+	}
+	create := func(mode index.CreateMode, rs []*index.Record) *index.CreateRecordsResult {
+		res, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/doc", NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+			Records: rs, Mode: cast.Ptr(mode)}, nil)
+		assert.Nil(t, err)
+		return res
+	}
+	segments := func() []string {
+		lrr, err := s.listRecords(ctx, &index.ListRequest{Path: "/doc"})
+		assert.Nil(t, err)
+		var res []string
+		for _, r := range lrr.Records {
+			res = append(res, r.Segment)
+		}
+		return res
+	}
+
+	create(index.CreateMode_APPEND, recs("a", "b", "c"))
+	res := create(index.CreateMode_APPEND, recs("a2"))
+	assert.Equal(t, int64(1), res.RecordsCreated)
+	assert.Equal(t, []string{"a2", "b", "c"}, segments())
+
+	res = create(index.CreateMode_REPLACE, recs("a2", "", "c2", "d"))
+	assert.Equal(t, int64(1), res.RecordsCreated)
+	assert.Equal(t, int64(2), res.RecordsUpdated)
+	assert.Equal(t, int64(1), res.RecordsDeleted)
+	assert.Equal(t, []string{"a2", "c2", "d"}, segments())
+
+	// only the changed records are written
+	res = create(index.CreateMode_SYNC, recs("a2", "b", "", "d2"))
+	assert.Equal(t, int64(1), res.RecordsCreated)
+	assert.Equal(t, int64(1), res.RecordsUpdated)
+	assert.Equal(t, int64(1), res.RecordsDeleted)
+	assert.Equal(t, []string{"a2", "b", "d2"}, segments())
+	lrr, err := s.listRecords(ctx, &index.ListRequest{Path: "/doc"})
+	assert.Nil(t, err)
+	// the record 1 is written by the appends and the replace only
+	assert.Equal(t, int64(3), lrr.Records[0].Version)
+
+	// the document shrinks
+	_, err = s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/doc", NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+		Parser: cast.Ptr("txt"), Document: []byte("hello"), Mode: cast.Ptr(index.CreateMode_SYNC)}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"hello"}, segments())
+
+	_, err = s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/doc", NodeType: cast.Ptr(index.NodeType_DOCUMENT), Async: true,
+		Parser: cast.Ptr("txt"), Document: []byte("hello"), Mode: cast.Ptr(index.CreateMode_REPLACE)}, nil)
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+}
+
+func TestServiceTagsPatch(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+	_, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/a/doc1", NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+		Tags: map[string]string{"k": "v", "x": "1"}}, nil)
+	assert.Nil(t, err)
+	_, err = s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/a/doc2", NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+		Tags: map[string]string{"k": "v"}}, nil)
+	assert.Nil(t, err)
+
+	n, err := s.updateNode(ctx, &index.UpdateNodeRequest{Path: "/a/doc1", TagsPatch: &index.TagsPatch{Remove: []string{"x"}, Set: map[string]string{"y": "2"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"k": "v", "y": "2"}, n.Tags)
+	n, err = s.updateNode(ctx, &index.UpdateNodeRequest{Path: "/a/doc1", TagsPatch: &index.TagsPatch{Clear: true}})
+	assert.Nil(t, err)
+	assert.Empty(t, n.Tags)
+	_, err = s.updateNode(ctx, &index.UpdateNodeRequest{Path: "/a/doc1", TagsPatch: &index.TagsPatch{}})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	_, err = s.updateNode(ctx, &index.UpdateNodeRequest{Path: "/a/doc1", Node: &index.Node{Tags: map[string]string{"k": "v"}},
+		TagsPatch: &index.TagsPatch{Clear: true}})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+
+	// the tags patch and the attributes are written by one update
+	_, err = s.createAttrSchema(ctx, &index.AttrSchema{Prefix: "/a", Name: "pages", Type: index.AttrType_NUMBER})
+	assert.Nil(t, err)
+	v := n.Version
+	n, err = s.updateNode(ctx, &index.UpdateNodeRequest{Path: "/a/doc1", Node: &index.Node{Attrs: map[string]*structpb.Value{"pages": structpb.NewNumberValue(3)}},
+		TagsPatch: &index.TagsPatch{Set: map[string]string{"k": "v1"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, v+1, n.Version)
+	assert.Equal(t, map[string]string{"k": "v1"}, n.Tags)
+	assert.Equal(t, float64(3), n.Attrs["pages"].GetNumberValue())
+
+	res, err := s.updateNodes(ctx, &index.UpdateNodesRequest{FilterConditions: "prefix(path, '/a')", TagsPatch: &index.TagsPatch{Set: map[string]string{"z": "3"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), res.Updated)
+	mn, err := s.Db.NewModelTx(ctx).GetNode("/a/doc2")
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.Tags{"k": "v", "z": "3"}, mn.Tags)
+
+	_, err = s.updateNodes(ctx, &index.UpdateNodesRequest{FilterConditions: "tag('k') = 'unknown'", TagsPatch: &index.TagsPatch{Clear: true}})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	_, err = s.updateNodes(ctx, &index.UpdateNodesRequest{TagsPatch: &index.TagsPatch{Clear: true}})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+}
+
+func TestServiceAttrs(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+	_, err := s.createAttrSchema(ctx, &index.AttrSchema{Prefix: "/docs", Name: "published", Type: index.AttrType_TIMESTAMP})
+	assert.Nil(t, err)
+	_, err = s.createAttrSchema(ctx, &index.AttrSchema{Prefix: "/docs", Name: "pages", Type: index.AttrType_NUMBER})
+	assert.Nil(t, err)
+	_, err = s.createAttrSchema(ctx, &index.AttrSchema{Prefix: "/", Name: "pages", Type: index.AttrType_STRING})
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	ass, err := s.listAttrSchemas(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(ass.AttrSchemas))
+	assert.Equal(t, index.AttrType_NUMBER, ass.AttrSchemas[0].Type)
+
+	res, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/docs/doc1", NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+		Attrs: map[string]*structpb.Value{"published": structpb.NewStringValue("2024-02-01"), "pages": structpb.NewNumberValue(12)}}, nil)
+	assert.Nil(t, err)
+	doc := res.NodesCreated.Nodes[len(res.NodesCreated.Nodes)-1]
+	assert.Equal(t, "2024-02-01T00:00:00.000Z", doc.Attrs["published"].GetStringValue())
+	_, err = s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/other/doc2", NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+		Attrs: map[string]*structpb.Value{"pages": structpb.NewNumberValue(1)}}, nil)
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	_, err = s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/docs/doc2", NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+		Attrs: map[string]*structpb.Value{"pages": structpb.NewStringValue("many")}}, nil)
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+
+	ns, err := s.listNodes(ctx, &index.ListNodesRequest{FilterConditions: "attr('published') >= date('2024-01-01') and attr('pages') > 10", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(ns.Nodes))
+
+	n, err := s.updateNode(ctx, &index.UpdateNodeRequest{Path: "/docs/doc1", Node: &index.Node{Attrs: map[string]*structpb.Value{"pages": structpb.NewNumberValue(5)}},
+		RemoveAttrs: []string{"published"}})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(n.Attrs))
+	assert.Equal(t, float64(5), n.Attrs["pages"].GetNumberValue())
+
+	_, err = s.deleteAttrSchema(ctx, &index.DeleteAttrSchemaRequest{Prefix: "/docs/", Name: "pages"})
+	assert.Nil(t, err)
+	_, err = s.deleteAttrSchema(ctx, &index.DeleteAttrSchemaRequest{Prefix: "/docs/", Name: "pages"})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+}
+
+func TestServiceRecordMeta(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+	recs := []*index.Record{{Id: "1", Segment: "hello world", Format: "txt", RankMultiplier: 1.0, Meta: map[string]string{"section": "abstract"}},
+		{Id: "2", Segment: "hello world again", Format: "txt", RankMultiplier: 1.0, Meta: map[string]string{"section": "body"}}}
+	_, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/doc", NodeType: cast.Ptr(index.NodeType_DOCUMENT), Records: recs}, nil)
+	assert.Nil(t, err)
+
+	sr, err := s.search(ctx, &index.SearchRecordsRequest{TextQuery: "world", FilterConditions: "rmeta('section') = 'abstract'"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), sr.Total)
+	assert.Equal(t, "1", sr.Items[0].Record.Id)
+	assert.Equal(t, map[string]string{"section": "abstract"}, sr.Items[0].Record.Meta)
+
+	// the records with the changed meta only are updated in the sync mode
+	recs[1].Meta = map[string]string{"section": "abstract"}
+	res, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: "/doc", NodeType: cast.Ptr(index.NodeType_DOCUMENT), Records: recs,
+		Mode: cast.Ptr(index.CreateMode_SYNC)}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.RecordsUpdated)
+	sr, err = s.search(ctx, &index.SearchRecordsRequest{TextQuery: "world", FilterConditions: "rmeta('section') = 'abstract'", GroupByPathOff: cast.Ptr(true)})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), sr.Total)
+}
+
+func TestServiceInheritedTags(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+	for _, p := range []string{"/t1/doc", "/t2/doc"} {
+		_, err := s.createRecords(ctx, &index.CreateRecordsRequest{Path: p, NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+			Records: []*index.Record{{Id: "1", Segment: "hello world", Format: "txt", RankMultiplier: 1.0}}}, nil)
+		assert.Nil(t, err)
+	}
+	n, err := s.updateNode(ctx, &index.UpdateNodeRequest{Path: "/t1", Node: &index.Node{Tags: map[string]string{"team": "x"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"team": "x"}, n.EffectiveTags)
+
+	sr, err := s.search(ctx, &index.SearchRecordsRequest{TextQuery: "world", FilterConditions: "inheritedTag('team') = 'x'"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), sr.Total)
+	assert.Equal(t, "/t1/doc", sr.Items[0].Path)
+
+	ns, err := s.listNodes(ctx, &index.ListNodesRequest{FilterConditions: "inheritedTag('team') = 'x' and node = '/t1/doc'", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(ns.Nodes))
+	assert.Equal(t, map[string]string{"team": "x"}, ns.Nodes[0].EffectiveTags)
+	assert.Equal(t, 0, len(ns.Nodes[0].Tags))
+}
+
+func TestServiceACL(t *testing.T) {
+	s := newTestService(t)
+	s.Auth = auth.NewAuthenticator(auth.Config{Tokens: []auth.Token{
+		{Token: "root", Identity: auth.Identity{Principal: "root", Admin: true}},
+		{Token: "alice", Identity: auth.Identity{Principal: "alice", Groups: []string{"team1"}}},
+		{Token: "bob", Identity: auth.Identity{Principal: "bob"}},
+	}})
+	caller := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.MetadataKey, "Bearer "+token))
+	}
+	root, alice, bob := caller("root"), caller("alice"), caller("bob")
+
+	_, err := s.listNodes(context.Background(), &index.ListNodesRequest{FilterConditions: "path like '/%'", Limit: 10})
+	assert.True(t, errors.Is(err, errors.ErrNotAuthorized))
+	_, err = s.createRecords(alice, &index.CreateRecordsRequest{Path: "/t1/doc", NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+		Records: []*index.Record{{Id: "1", Segment: "hello world", Format: "txt", RankMultiplier: 1.0}}}, nil)
+	assert.True(t, errors.Is(err, errors.ErrNotAuthorized))
+	for _, p := range []string{"/t1/doc", "/t2/doc"} {
+		_, err = s.createRecords(root, &index.CreateRecordsRequest{Path: p, NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+			Records: []*index.Record{{Id: "1", Segment: "hello world", Format: "txt", RankMultiplier: 1.0}}}, nil)
+		assert.Nil(t, err)
+	}
+
+	acl, err := s.grantAccess(root, &index.GrantAccessRequest{Path: "/t1", Principal: "team1", Permission: index.Permission_WRITE})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]index.Permission{"team1": index.Permission_WRITE}, acl.Acl)
+	_, err = s.grantAccess(alice, &index.GrantAccessRequest{Path: "/t1", Principal: "bob", Permission: index.Permission_READ})
+	assert.True(t, errors.Is(err, errors.ErrNotAuthorized))
+	_, err = s.getAccess(bob, &index.GetAccessRequest{Path: "/t1"})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+
+	sr, err := s.search(alice, &index.SearchRecordsRequest{TextQuery: "world"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), sr.Total)
+	assert.Equal(t, "/t1/doc", sr.Items[0].Path)
+	sr, err = s.search(bob, &index.SearchRecordsRequest{TextQuery: "world"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), sr.Total)
+	ns, err := s.listNodes(alice, &index.ListNodesRequest{FilterConditions: "path like '/%'", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(ns.Nodes))
+	_, err = s.listRecords(bob, &index.ListRequest{Path: "/t1/doc"})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+
+	_, err = s.patchIndexRecords(alice, &index.PatchRecordsRequest{Path: "/t1/doc", UpsertRecords: []*index.Record{{Id: "2", Segment: "green pear", Format: "txt"}}})
+	assert.Nil(t, err)
+	_, err = s.patchIndexRecords(alice, &index.PatchRecordsRequest{Path: "/t2/doc", UpsertRecords: []*index.Record{{Id: "2", Segment: "green pear", Format: "txt"}}})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+
+	_, err = s.deleteNodes(bob, &index.DeleteNodesRequest{FilterConditions: "node = '/t1/doc'"})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	_, err = s.grantAccess(root, &index.GrantAccessRequest{Path: "/t1/doc", Principal: "bob", Permission: index.Permission_READ})
+	assert.Nil(t, err)
+	_, err = s.deleteNodes(bob, &index.DeleteNodesRequest{FilterConditions: "node = '/t1/doc'"})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	_, err = s.deleteNodes(alice, &index.DeleteNodesRequest{FilterConditions: "node = '/t1/doc'"})
+	assert.Nil(t, err)
+
+	acl, err = s.revokeAccess(root, &index.RevokeAccessRequest{Path: "/t1", Principal: "team1"})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(acl.Acl))
+	ns, err = s.listNodes(alice, &index.ListNodesRequest{FilterConditions: "path like '/%'", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(ns.Nodes))
+
+	_, err = s.listWebhooks(alice)
+	assert.True(t, errors.Is(err, errors.ErrNotAuthorized))
+}
+
+func TestServiceCopyNodesACL(t *testing.T) {
+	s := newTestService(t)
+	s.Auth = auth.NewAuthenticator(auth.Config{Tokens: []auth.Token{
+		{Token: "root", Identity: auth.Identity{Principal: "root", Admin: true}},
+		{Token: "bob", Identity: auth.Identity{Principal: "bob"}},
+		{Token: "eve", Identity: auth.Identity{Principal: "eve"}},
+	}})
+	caller := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.MetadataKey, "Bearer "+token))
+	}
+	root, bob, eve := caller("root"), caller("bob"), caller("eve")
+
+	for _, p := range []string{"/src/doc", "/dst/doc"} {
+		_, err := s.createRecords(root, &index.CreateRecordsRequest{Path: p, NodeType: cast.Ptr(index.NodeType_DOCUMENT),
+			Records: []*index.Record{{Id: "1", Segment: "hello world", Format: "txt", RankMultiplier: 1.0}}}, nil)
+		assert.Nil(t, err)
+	}
+	_, err := s.grantAccess(root, &index.GrantAccessRequest{Path: "/src/doc", Principal: "bob", Permission: index.Permission_READ})
+	assert.Nil(t, err)
+	_, err = s.grantAccess(root, &index.GrantAccessRequest{Path: "/src/doc", Principal: "eve", Permission: index.Permission_ADMIN})
+	assert.Nil(t, err)
+	_, err = s.grantAccess(root, &index.GrantAccessRequest{Path: "/dst", Principal: "bob", Permission: index.Permission_WRITE})
+	assert.Nil(t, err)
+
+	// the read-only copier doesn't hand its copy over to the principals of the original ACL
+	res, err := s.copyNodes(bob, &index.CopyNodesRequest{From: "/src/doc", To: "/dst/copy"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.RecordsCopied)
+	acl, err := s.getAccess(root, &index.GetAccessRequest{Path: "/dst/copy"})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(acl.Acl))
+	_, err = s.listRecords(eve, &index.ListRequest{Path: "/dst/copy"})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	_, err = s.getAccess(eve, &index.GetAccessRequest{Path: "/dst/copy"})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	lr, err := s.listRecords(bob, &index.ListRequest{Path: "/dst/copy"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(lr.Records))
+	_, err = s.deleteNodes(bob, &index.DeleteNodesRequest{FilterConditions: "node = '/dst/copy'"})
+	assert.Nil(t, err)
+}
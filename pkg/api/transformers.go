@@ -15,24 +15,103 @@
 package api
 
 import (
+	"fmt"
 	"github.com/acquirecloud/golibs/cast"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/simila-io/simila/api/gen/admin/v1"
 	"github.com/simila-io/simila/api/gen/format/v1"
 	"github.com/simila-io/simila/api/gen/index/v1"
+	"github.com/simila-io/simila/api/gen/webhook/v1"
 	similapi "github.com/simila-io/simila/api/genpublic/v1"
 	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"strings"
 	"time"
 )
 
 func toApiFormat(mFmt persistence.Format) *format.Format {
-	return &format.Format{Name: mFmt.ID, Basis: mFmt.Basis}
+	return &format.Format{Name: mFmt.ID, Basis: mFmt.Basis, KeepHistory: mFmt.KeepHistory}
 }
 
 func toModelFormat(aFmt *format.Format) persistence.Format {
 	if aFmt == nil {
 		return persistence.Format{}
 	}
-	return persistence.Format{ID: aFmt.Name, Basis: aFmt.Basis}
+	return persistence.Format{ID: aFmt.Name, Basis: aFmt.Basis, KeepHistory: aFmt.KeepHistory}
+}
+
+func toApiWebhook(wh persistence.Webhook) *webhook.Webhook {
+	// the secret is never returned
+	return &webhook.Webhook{Id: wh.ID, Url: wh.URL, Path: wh.Path, Events: wh.Events, CreatedAt: timestamppb.New(wh.CreatedAt)}
+}
+
+func toModelWebhook(wh *webhook.Webhook) persistence.Webhook {
+	if wh == nil {
+		return persistence.Webhook{}
+	}
+	return persistence.Webhook{ID: wh.Id, URL: wh.Url, Secret: wh.Secret, Path: wh.Path, Events: wh.Events}
+}
+
+func toApiDeadLetter(dl persistence.DeadLetter) *webhook.DeadLetter {
+	return &webhook.DeadLetter{
+		Id:        dl.ID,
+		WebhookId: dl.WebhookID,
+		Cursor:    dl.ChangeID,
+		Event:     dl.Event,
+		Payload:   string(dl.Payload),
+		Error:     dl.Error,
+		Attempts:  int32(dl.Attempts),
+		CreatedAt: timestamppb.New(dl.CreatedAt),
+	}
+}
+
+func toModelTagsPatch(tp *index.TagsPatch) persistence.TagsPatch {
+	if tp == nil {
+		return persistence.TagsPatch{}
+	}
+	return persistence.TagsPatch{Set: tp.Set, Remove: tp.Remove, Clear: tp.Clear}
+}
+
+func toApiJob(job persistence.Job) *index.Job {
+	return &index.Job{
+		Id:        job.ID,
+		Path:      job.Path,
+		Parser:    job.Parser,
+		Status:    toApiJobStatus(job.Status),
+		Records:   job.Records,
+		Error:     job.Error,
+		CreatedAt: timestamppb.New(job.CreatedAt),
+		UpdatedAt: timestamppb.New(job.UpdatedAt),
+	}
+}
+
+func toApiJobStatus(js persistence.JobStatus) index.JobStatus {
+	switch js {
+	case persistence.JobRunning:
+		return index.JobStatus_RUNNING
+	case persistence.JobDone:
+		return index.JobStatus_DONE
+	case persistence.JobFailed:
+		return index.JobStatus_FAILED
+	case persistence.JobCanceled:
+		return index.JobStatus_CANCELED
+	}
+	return index.JobStatus_PENDING
+}
+
+func toModelJobStatus(js index.JobStatus) persistence.JobStatus {
+	switch js {
+	case index.JobStatus_RUNNING:
+		return persistence.JobRunning
+	case index.JobStatus_DONE:
+		return persistence.JobDone
+	case index.JobStatus_FAILED:
+		return persistence.JobFailed
+	case index.JobStatus_CANCELED:
+		return persistence.JobCanceled
+	}
+	return persistence.JobPending
 }
 
 func toModelIndexRecordFromApiRecord(nID int64, aRec *index.Record, defRankMul float64) persistence.IndexRecord {
@@ -50,6 +129,7 @@ func toModelIndexRecordFromApiRecord(nID int64, aRec *index.Record, defRankMul f
 		Format:   aRec.Format,
 		RankMult: rm,
 		Vector:   aRec.Vector,
+		Meta:     aRec.Meta,
 	}
 }
 
@@ -77,12 +157,79 @@ func toApiNode(node persistence.Node) *index.Node {
 	if node.Flags&persistence.NodeFlagDocument != 0 {
 		t = index.NodeType_DOCUMENT
 	}
-	return &index.Node{
-		Path: node.Path,
-		Name: node.Name,
-		Tags: node.Tags,
-		Type: t,
+	res := &index.Node{
+		Path:          node.Path,
+		Name:          node.Name,
+		Tags:          node.Tags,
+		Type:          t,
+		Version:       node.Version,
+		Attrs:         toApiAttrs(node.Attrs),
+		EffectiveTags: node.EffectiveTags,
+	}
+	if node.DeletedAt != nil {
+		res.DeletedAt = timestamppb.New(*node.DeletedAt)
+	}
+	return res
+}
+
+func toApiAttrs(attrs persistence.Attrs) map[string]*structpb.Value {
+	if len(attrs) == 0 {
+		return nil
+	}
+	res := make(map[string]*structpb.Value, len(attrs))
+	for k, v := range attrs {
+		// structpb supports the lists of any values only
+		if ss, ok := v.([]string); ok {
+			l := make([]any, len(ss))
+			for i, s := range ss {
+				l[i] = s
+			}
+			v = l
+		}
+		if pv, err := structpb.NewValue(v); err == nil {
+			res[k] = pv
+		}
 	}
+	return res
+}
+
+func toModelAttrs(attrs map[string]*structpb.Value) persistence.Attrs {
+	if len(attrs) == 0 {
+		return nil
+	}
+	res := make(persistence.Attrs, len(attrs))
+	for k, v := range attrs {
+		res[k] = v.AsInterface()
+	}
+	return res
+}
+
+func toApiAttrSchema(as persistence.AttrSchema) *index.AttrSchema {
+	t := index.AttrType_value[strings.ToUpper(string(as.Type))]
+	return &index.AttrSchema{Prefix: as.Prefix, Name: as.Name, Type: index.AttrType(t), CreatedAt: timestamppb.New(as.CreatedAt)}
+}
+
+func toModelAttrSchema(as *index.AttrSchema) persistence.AttrSchema {
+	if as == nil {
+		return persistence.AttrSchema{}
+	}
+	return persistence.AttrSchema{Prefix: as.Prefix, Name: as.Name, Type: persistence.AttrType(strings.ToLower(as.Type.String()))}
+}
+
+func toModelPermission(p index.Permission) persistence.Permission {
+	return persistence.Permission(strings.ToLower(p.String()))
+}
+
+func toApiACL(acl persistence.ACL) map[string]index.Permission {
+	res := make(map[string]index.Permission, len(acl))
+	for k, v := range acl {
+		res[k] = index.Permission(index.Permission_value[strings.ToUpper(string(v))])
+	}
+	return res
+}
+
+func toApiNodeACL(n persistence.Node) *index.NodeACL {
+	return &index.NodeACL{Path: persistence.ConcatPath(n.Path, n.Name), Acl: toApiACL(n.ACL), EffectiveAcl: toApiACL(n.EffectiveACL)}
 }
 
 func toApiRecord(mRec persistence.IndexRecord) *index.Record {
@@ -92,6 +239,8 @@ func toApiRecord(mRec persistence.IndexRecord) *index.Record {
 		Vector:         mRec.Vector,
 		Format:         mRec.Format,
 		RankMultiplier: float32(mRec.RankMult),
+		Version:        mRec.Version,
+		Meta:           mRec.Meta,
 	}
 }
 
@@ -103,12 +252,52 @@ func toApiRecords(mRecs []persistence.IndexRecord) []*index.Record {
 	return res
 }
 
+func toApiRecordRevisions(revs []persistence.IndexRecordRevision) []*index.RecordRevision {
+	res := make([]*index.RecordRevision, len(revs))
+	for i, r := range revs {
+		res[i] = &index.RecordRevision{
+			Revision:   r.Revision,
+			Record:     toApiRecord(r.IndexRecord),
+			ValidFrom:  timestamppb.New(r.UpdatedAt),
+			ReplacedAt: timestamppb.New(r.ReplacedAt),
+			Deleted:    r.Deleted,
+		}
+	}
+	return res
+}
+
+func toApiRecordsDiff(d persistence.IndexRecordsDiff) *index.DiffRecordsResult {
+	res := &index.DiffRecordsResult{Added: toApiRecords(d.Added), Removed: toApiRecords(d.Removed)}
+	for _, c := range d.Changed {
+		res.Changed = append(res.Changed, &index.RecordChange{From: toApiRecord(c.From), To: toApiRecord(c.To)})
+	}
+	return res
+}
+
+func toApiChange(c persistence.Change) *index.Change {
+	res := &index.Change{
+		Cursor:    c.ID,
+		Object:    index.ChangeObject_NODE,
+		Op:        index.ChangeOp(index.ChangeOp_value[strings.ToUpper(string(c.Op))]),
+		Node:      toApiNode(persistence.Node{Path: c.Path, Name: c.Name[len(c.Path):], Tags: c.Tags, EffectiveTags: c.EffectiveTags, Flags: c.Flags}),
+		RecordId:  c.RecordID,
+		Format:    c.Format,
+		CreatedAt: timestamppb.New(c.CreatedAt),
+	}
+	if c.Object == persistence.ChangeObjectRecord {
+		res.Object = index.ChangeObject_RECORD
+	}
+	return res
+}
+
 func toApiSearchRecord(sr persistence.SearchQueryResultItem) *index.SearchRecordsResultItem {
 	res := &index.SearchRecordsResultItem{}
 	res.Record = toApiRecord(sr.IndexRecord)
 	res.Path = sr.Path
 	res.MatchedKeywords = sr.MatchedKeywordsList
 	res.Score = &sr.Score
+	res.LexicalScore = sr.LexicalScore
+	res.SemanticScore = sr.SemanticScore
 	return res
 }
 
@@ -121,7 +310,7 @@ func toApiSearchRecords(srs []persistence.SearchQueryResultItem) []*index.Search
 }
 
 func format2Rest(f *format.Format) similapi.Format {
-	return similapi.Format{Name: f.Name, Basis: f.Basis}
+	return similapi.Format{Name: f.Name, Basis: f.Basis, KeepHistory: cast.Ptr(f.KeepHistory)}
 }
 
 func formats2Rest(fs *format.Formats) similapi.Formats {
@@ -136,14 +325,94 @@ func formats2Rest(fs *format.Formats) similapi.Formats {
 	return res
 }
 
-func searchRecordsRequest2Proto(sr similapi.SearchRecordsRequest) *index.SearchRecordsRequest {
-	return &index.SearchRecordsRequest{
+func webhook2Rest(wh *webhook.Webhook) similapi.Webhook {
+	res := similapi.Webhook{Id: wh.Id, Url: wh.Url, Path: cast.Ptr(wh.Path), CreatedAt: cast.Ptr(wh.CreatedAt.AsTime())}
+	events := make([]similapi.WebhookEvents, len(wh.Events))
+	for i, e := range wh.Events {
+		events[i] = similapi.WebhookEvents(e)
+	}
+	res.Events = &events
+	return res
+}
+
+func rest2Webhook(wh similapi.Webhook) *webhook.Webhook {
+	res := &webhook.Webhook{Id: wh.Id, Url: wh.Url, Secret: cast.Value(wh.Secret, ""), Path: cast.Value(wh.Path, "")}
+	for _, e := range cast.Value(wh.Events, nil) {
+		res.Events = append(res.Events, string(e))
+	}
+	return res
+}
+
+func webhooks2Rest(whs *webhook.Webhooks) similapi.Webhooks {
+	res := similapi.Webhooks{Webhooks: make([]similapi.Webhook, len(whs.Webhooks))}
+	for i, wh := range whs.Webhooks {
+		res.Webhooks[i] = webhook2Rest(wh)
+	}
+	return res
+}
+
+func deadLetters2Rest(dls *webhook.DeadLetters) similapi.DeadLetters {
+	res := similapi.DeadLetters{DeadLetters: make([]similapi.DeadLetter, len(dls.DeadLetters))}
+	for i, dl := range dls.DeadLetters {
+		res.DeadLetters[i] = similapi.DeadLetter{
+			Id:        dl.Id,
+			WebhookId: dl.WebhookId,
+			Cursor:    dl.Cursor,
+			Event:     dl.Event,
+			Payload:   dl.Payload,
+			Error:     dl.Error,
+			Attempts:  int(dl.Attempts),
+			CreatedAt: dl.CreatedAt.AsTime(),
+		}
+	}
+	return res
+}
+
+func toModelSearchMode(m *index.SearchMode) persistence.SearchMode {
+	if m == nil {
+		return ""
+	}
+	switch *m {
+	case index.SearchMode_SEMANTIC:
+		return persistence.SearchModeSemantic
+	case index.SearchMode_HYBRID:
+		return persistence.SearchModeHybrid
+	}
+	return persistence.SearchModeLexical
+}
+
+func toModelFusionMethod(f *index.FusionMethod) persistence.FusionMethod {
+	if f != nil && *f == index.FusionMethod_WEIGHTED {
+		return persistence.FusionWeighted
+	}
+	return persistence.FusionRRF
+}
+
+func searchRecordsRequest2Proto(sr similapi.SearchRecordsRequest) (*index.SearchRecordsRequest, error) {
+	res := &index.SearchRecordsRequest{
 		TextQuery:        sr.TextQuery,
 		FilterConditions: sr.FilterConditions,
 		GroupByPathOff:   cast.Ptr(sr.GroupByPathOff),
 		Offset:           cast.Ptr(int64(sr.Offset)),
 		Limit:            cast.Ptr(int64(sr.Limit)),
+		SemanticWeight:   sr.SemanticWeight,
+		Engine:           sr.Engine,
+	}
+	if sr.Mode != nil {
+		m, ok := index.SearchMode_value[strings.ToUpper(string(*sr.Mode))]
+		if !ok {
+			return nil, fmt.Errorf("unknown search mode %q: %w", *sr.Mode, errors.ErrInvalid)
+		}
+		res.Mode = cast.Ptr(index.SearchMode(m))
+	}
+	if sr.Fusion != nil {
+		f, ok := index.FusionMethod_value[strings.ToUpper(string(*sr.Fusion))]
+		if !ok {
+			return nil, fmt.Errorf("unknown fusion method %q: %w", *sr.Fusion, errors.ErrInvalid)
+		}
+		res.Fusion = cast.Ptr(index.FusionMethod(f))
 	}
+	return res, nil
 }
 
 func searchRecordsResult2Rest(srr *index.SearchRecordsResult) similapi.SearchRecordsResult {
@@ -164,6 +433,8 @@ func searchRecordsResultItems2Rest(srr *index.SearchRecordsResultItem) similapi.
 		Record:          record2Rest(srr.Record),
 		Path:            srr.Path,
 		Score:           cast.Value(srr.Score, -1.0),
+		LexicalScore:    srr.LexicalScore,
+		SemanticScore:   srr.SemanticScore,
 		MatchedKeywords: srr.MatchedKeywords,
 	}
 }
@@ -180,13 +451,46 @@ func records2Rest(rs []*index.Record) []similapi.Record {
 }
 
 func record2Rest(r *index.Record) similapi.Record {
-	return similapi.Record{
+	res := similapi.Record{
 		Id:             r.Id,
 		Segment:        r.Segment,
 		Vector:         r.Vector,
 		RankMultiplier: r.RankMultiplier,
 		Format:         r.Format,
+		Version:        cast.Ptr(r.Version),
+	}
+	if len(r.Meta) > 0 {
+		res.Meta = cast.Ptr(r.Meta)
+	}
+	return res
+}
+
+func recordRevisions2Rest(revs []*index.RecordRevision) []similapi.RecordRevision {
+	res := make([]similapi.RecordRevision, len(revs))
+	for i, r := range revs {
+		res[i] = similapi.RecordRevision{
+			Revision:   r.Revision,
+			Record:     record2Rest(r.Record),
+			ValidFrom:  r.ValidFrom.AsTime(),
+			ReplacedAt: r.ReplacedAt.AsTime(),
+			Deleted:    r.Deleted,
+		}
+	}
+	return res
+}
+
+func diffRecordsResult2Rest(d *index.DiffRecordsResult) similapi.DiffRecordsResult {
+	res := similapi.DiffRecordsResult{Added: []similapi.Record{}, Removed: []similapi.Record{}, Changed: []similapi.RecordChange{}}
+	for _, r := range d.Added {
+		res.Added = append(res.Added, record2Rest(r))
 	}
+	for _, r := range d.Removed {
+		res.Removed = append(res.Removed, record2Rest(r))
+	}
+	for _, c := range d.Changed {
+		res.Changed = append(res.Changed, similapi.RecordChange{From: record2Rest(c.From), To: record2Rest(c.To)})
+	}
+	return res
 }
 
 func node2Rest(n *index.Node) similapi.Node {
@@ -197,12 +501,106 @@ func node2Rest(n *index.Node) similapi.Node {
 	if n.Type == index.NodeType_DOCUMENT {
 		tp = similapi.Document
 	}
-	return similapi.Node{
-		Path: n.Path,
-		Name: n.Name,
-		Tags: n.Tags,
-		Type: tp,
+	res := similapi.Node{
+		Path:    n.Path,
+		Name:    n.Name,
+		Tags:    n.Tags,
+		Type:    tp,
+		Version: cast.Ptr(n.Version),
+	}
+	if len(n.Attrs) > 0 {
+		res.Attrs = cast.Ptr(attrs2Rest(n.Attrs))
+	}
+	if len(n.EffectiveTags) > 0 {
+		res.EffectiveTags = cast.Ptr(n.EffectiveTags)
 	}
+	if n.DeletedAt != nil {
+		res.DeletedAt = cast.Ptr(n.DeletedAt.AsTime())
+	}
+	return res
+}
+
+func attrs2Rest(attrs map[string]*structpb.Value) similapi.Attrs {
+	res := make(similapi.Attrs, len(attrs))
+	for k, v := range attrs {
+		res[k] = v.AsInterface()
+	}
+	return res
+}
+
+func rest2Attrs(attrs *similapi.Attrs) (map[string]*structpb.Value, error) {
+	if attrs == nil || len(*attrs) == 0 {
+		return nil, nil
+	}
+	res := make(map[string]*structpb.Value, len(*attrs))
+	for k, v := range *attrs {
+		pv, err := structpb.NewValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("the attribute %q value %v is not supported: %w", k, v, errors.ErrInvalid)
+		}
+		res[k] = pv
+	}
+	return res, nil
+}
+
+func attrSchema2Rest(as *index.AttrSchema) similapi.AttrSchema {
+	return similapi.AttrSchema{
+		Prefix:    as.Prefix,
+		Name:      as.Name,
+		Type:      similapi.AttrType(strings.ToLower(as.Type.String())),
+		CreatedAt: cast.Ptr(protoTime2Time(as.CreatedAt)),
+	}
+}
+
+func attrSchemas2Rest(ass *index.AttrSchemas) similapi.AttrSchemas {
+	res := similapi.AttrSchemas{AttrSchemas: make([]similapi.AttrSchema, len(ass.AttrSchemas))}
+	for i, as := range ass.AttrSchemas {
+		res.AttrSchemas[i] = attrSchema2Rest(as)
+	}
+	return res
+}
+
+func rest2AttrSchema(as similapi.AttrSchema) (*index.AttrSchema, error) {
+	t, ok := index.AttrType_value[strings.ToUpper(string(as.Type))]
+	if !ok {
+		return nil, fmt.Errorf("unknown attribute type %q: %w", as.Type, errors.ErrInvalid)
+	}
+	return &index.AttrSchema{Prefix: as.Prefix, Name: as.Name, Type: index.AttrType(t)}, nil
+}
+
+func acl2Rest(acl map[string]index.Permission) similapi.ACL {
+	res := make(similapi.ACL, len(acl))
+	for k, v := range acl {
+		res[k] = similapi.Permission(strings.ToLower(v.String()))
+	}
+	return res
+}
+
+func nodeACL2Rest(acl *index.NodeACL) similapi.NodeACL {
+	return similapi.NodeACL{Path: acl.Path, Acl: acl2Rest(acl.Acl), EffectiveAcl: acl2Rest(acl.EffectiveAcl)}
+}
+
+func rest2Permission(p similapi.Permission) (index.Permission, error) {
+	v, ok := index.Permission_value[strings.ToUpper(string(p))]
+	if !ok {
+		return 0, fmt.Errorf("unknown permission %q: %w", p, errors.ErrInvalid)
+	}
+	return index.Permission(v), nil
+}
+
+func change2Rest(c *index.Change) similapi.Change {
+	res := similapi.Change{
+		Cursor:    c.Cursor,
+		Object:    similapi.ChangeObject(strings.ToLower(c.Object.String())),
+		Op:        similapi.ChangeOp(strings.ToLower(c.Op.String())),
+		Node:      node2Rest(c.Node),
+		CreatedAt: c.CreatedAt.AsTime(),
+	}
+	if c.Object == index.ChangeObject_RECORD {
+		res.RecordId = cast.Ptr(c.RecordId)
+		res.Format = cast.Ptr(c.Format)
+	}
+	return res
 }
 
 func nodes2Rest(ns []*index.Node) []similapi.Node {
@@ -228,16 +626,27 @@ func rest2Record(r similapi.Record) *index.Record {
 		Segment:        r.Segment,
 		Vector:         r.Vector,
 		RankMultiplier: r.RankMultiplier,
+		Meta:           cast.Value(r.Meta, nil),
 	}
 }
 
-func rest2CreateRecordsRequest(path string, crr similapi.CreateRecordsRequest) *index.CreateRecordsRequest {
+func rest2TagsPatch(tp similapi.TagsPatch) *index.TagsPatch {
+	return &index.TagsPatch{Set: cast.Value(tp.Set, nil), Remove: cast.Value(tp.Remove, nil), Clear: cast.Value(tp.Clear, false)}
+}
+
+func rest2CreateRecordsRequest(path string, crr similapi.CreateRecordsRequest) (*index.CreateRecordsRequest, error) {
 	res := &index.CreateRecordsRequest{}
 	res.Records = rest2Records(crr.Records)
 	res.Path = path
 	res.Tags = crr.Tags
 	res.Document = crr.Document
 	res.RankMultiplier = crr.RankMultiplier
+	res.Async = cast.Value(crr.Async, false)
+	attrs, err := rest2Attrs(crr.Attrs)
+	if err != nil {
+		return nil, err
+	}
+	res.Attrs = attrs
 	if crr.Parser != "" {
 		res.Parser = cast.Ptr(crr.Parser)
 	}
@@ -245,6 +654,71 @@ func rest2CreateRecordsRequest(path string, crr similapi.CreateRecordsRequest) *
 	if crr.NodeType == similapi.Folder {
 		res.NodeType = cast.Ptr(index.NodeType_FOLDER)
 	}
+	if crr.Mode != nil {
+		m, ok := index.CreateMode_value[strings.ToUpper(string(*crr.Mode))]
+		if !ok {
+			return nil, fmt.Errorf("unknown create mode %q: %w", *crr.Mode, errors.ErrInvalid)
+		}
+		res.Mode = cast.Ptr(index.CreateMode(m))
+	}
+	return res, nil
+}
+
+func toApiEngineSwitch(es persistence.EngineSwitch) *admin.EngineSwitch {
+	st := admin.EngineSwitchState_RUNNING
+	switch es.State {
+	case persistence.EngineSwitchDone:
+		st = admin.EngineSwitchState_DONE
+	case persistence.EngineSwitchFailed:
+		st = admin.EngineSwitchState_FAILED
+	case persistence.EngineSwitchCanceled:
+		st = admin.EngineSwitchState_CANCELED
+	}
+	return &admin.EngineSwitch{
+		Id:        es.ID,
+		From:      es.From,
+		To:        es.To,
+		State:     st,
+		Phase:     es.Phase,
+		Progress:  es.Progress,
+		Error:     es.Error,
+		CreatedAt: timestamppb.New(es.CreatedAt),
+		UpdatedAt: timestamppb.New(es.UpdatedAt),
+	}
+}
+
+func engineSwitch2Rest(es *admin.EngineSwitch) similapi.EngineSwitch {
+	return similapi.EngineSwitch{
+		Id:        es.Id,
+		From:      es.From,
+		To:        es.To,
+		State:     similapi.EngineSwitchState(strings.ToLower(es.State.String())),
+		Phase:     es.Phase,
+		Progress:  es.Progress,
+		Error:     es.Error,
+		CreatedAt: protoTime2Time(es.CreatedAt),
+		UpdatedAt: protoTime2Time(es.UpdatedAt),
+	}
+}
+
+func job2Rest(job *index.Job) similapi.Job {
+	return similapi.Job{
+		Id:        job.Id,
+		Path:      job.Path,
+		Parser:    job.Parser,
+		Status:    similapi.JobStatus(strings.ToLower(job.Status.String())),
+		Records:   job.Records,
+		Error:     job.Error,
+		CreatedAt: protoTime2Time(job.CreatedAt),
+		UpdatedAt: protoTime2Time(job.UpdatedAt),
+	}
+}
+
+func jobs2Rest(js *index.Jobs) similapi.Jobs {
+	res := similapi.Jobs{Jobs: make([]similapi.Job, len(js.Jobs))}
+	for i, job := range js.Jobs {
+		res.Jobs[i] = job2Rest(job)
+	}
 	return res
 }
 
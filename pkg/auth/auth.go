@@ -0,0 +1,130 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/acquirecloud/golibs/logging"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+type (
+	// Identity is the authenticated API caller
+	Identity struct {
+		// Principal is the caller name, the nodes ACLs grant the permissions to it
+		Principal string
+		// Groups are the groups the caller is a member of, the nodes ACLs grant the permissions to them
+		// the same way as to the Principal
+		Groups []string
+		// Admin is whether the caller has the full access to all the nodes and to the cluster endpoints
+		Admin bool
+	}
+
+	// Token is the API bearer token with the identity of its caller
+	Token struct {
+		Token string
+		Identity
+	}
+
+	// Config defines the API authentication settings
+	Config struct {
+		// Tokens are the bearer tokens the callers are authenticated by
+		Tokens []Token
+	}
+
+	// Authenticator authenticates the API callers by the bearer tokens, which are passed in
+	// the "authorization" gRPC metadata (the Authorization HTTP header) as "Bearer <token>"
+	Authenticator struct {
+		cfg    Config
+		tokens map[string]Identity
+		logger logging.Logger
+	}
+)
+
+// MetadataKey is the gRPC metadata key and the HTTP header, which contains the caller token
+const MetadataKey = "authorization"
+
+const bearerPrefix = "bearer "
+
+// Principals returns the principal and the groups names, the nodes ACLs are checked for
+func (id Identity) Principals() []string {
+	return append([]string{id.Principal}, id.Groups...)
+}
+
+// Check returns an error if the config is not valid
+func (c Config) Check() error {
+	if len(c.Tokens) == 0 {
+		return fmt.Errorf("at least one auth token must be specified: %w", errors.ErrInvalid)
+	}
+	seen := make(map[string]struct{}, len(c.Tokens))
+	for i, t := range c.Tokens {
+		if t.Token == "" {
+			return fmt.Errorf("the auth token for item=%d must be specified: %w", i, errors.ErrInvalid)
+		}
+		if _, ok := seen[t.Token]; ok {
+			return fmt.Errorf("the auth token for item=%d is not unique: %w", i, errors.ErrInvalid)
+		}
+		seen[t.Token] = struct{}{}
+		for _, p := range t.Principals() {
+			if err := persistence.CheckPrincipal(p); err != nil {
+				return fmt.Errorf("invalid auth token for item=%d: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// NewAuthenticator creates the new Authenticator
+func NewAuthenticator(cfg Config) *Authenticator {
+	a := &Authenticator{cfg: cfg, tokens: make(map[string]Identity, len(cfg.Tokens)), logger: logging.NewLogger("auth.Authenticator")}
+	for _, t := range cfg.Tokens {
+		a.tokens[t.Token] = t.Identity
+	}
+	return a
+}
+
+// Init implements linker.Initializer interface
+func (a *Authenticator) Init(ctx context.Context) error {
+	if err := a.cfg.Check(); err != nil {
+		return err
+	}
+	a.logger.Infof("Initializing... tokens=%d", len(a.tokens))
+	return nil
+}
+
+// Authenticate returns the identity of the caller by the token in the incoming gRPC metadata of
+// the ctx. The gRPC Unauthenticated status error is returned if the token is missing or unknown.
+func (a *Authenticator) Authenticate(ctx context.Context) (Identity, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	vals := md.Get(MetadataKey)
+	if len(vals) == 0 {
+		return Identity{}, status.Error(codes.Unauthenticated, "the bearer token must be provided in the authorization header")
+	}
+	v := strings.TrimSpace(vals[0])
+	if len(v) < len(bearerPrefix) || !strings.EqualFold(v[:len(bearerPrefix)], bearerPrefix) {
+		return Identity{}, status.Error(codes.Unauthenticated, "the authorization header must contain the bearer token")
+	}
+	id, ok := a.tokens[strings.TrimSpace(v[len(bearerPrefix):])]
+	if !ok {
+		return Identity{}, status.Error(codes.Unauthenticated, "the bearer token is not valid")
+	}
+	return id, nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestConfigCheck(t *testing.T) {
+	assert.Nil(t, Config{Tokens: []Token{{Token: "t1", Identity: Identity{Principal: "alice", Groups: []string{"eng"}}}}}.Check())
+	assert.True(t, errors.Is(Config{}.Check(), errors.ErrInvalid))
+	assert.True(t, errors.Is(Config{Tokens: []Token{{Identity: Identity{Principal: "alice"}}}}.Check(), errors.ErrInvalid))
+	assert.True(t, errors.Is(Config{Tokens: []Token{{Token: "t1"}}}.Check(), errors.ErrInvalid))
+	assert.True(t, errors.Is(Config{Tokens: []Token{{Token: "t1", Identity: Identity{Principal: "alice", Groups: []string{"a b"}}}}}.Check(), errors.ErrInvalid))
+	assert.True(t, errors.Is(Config{Tokens: []Token{{Token: "t1", Identity: Identity{Principal: "alice"}},
+		{Token: "t1", Identity: Identity{Principal: "bob"}}}}.Check(), errors.ErrInvalid))
+}
+
+func TestAuthenticate(t *testing.T) {
+	a := NewAuthenticator(Config{Tokens: []Token{{Token: "t1", Identity: Identity{Principal: "alice", Groups: []string{"eng"}}}}})
+	assert.Nil(t, a.Init(context.Background()))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "Bearer t1"))
+	id, err := a.Authenticate(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "alice", id.Principal)
+	assert.Equal(t, []string{"alice", "eng"}, id.Principals())
+
+	for _, v := range []string{"t1", "Basic t1", "Bearer t2", "Bearer "} {
+		_, err = a.Authenticate(metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, v)))
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), v)
+	}
+	_, err = a.Authenticate(context.Background())
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.True(t, errors.Is(err, errors.ErrNotAuthorized))
+}
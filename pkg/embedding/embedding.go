@@ -0,0 +1,213 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embedding
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	gcontext "github.com/acquirecloud/golibs/context"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/acquirecloud/golibs/logging"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"time"
+)
+
+type (
+	// Provider turns the text segments into the embedding vectors.
+	Provider interface {
+		// Embed returns the embeddings for the texts provided, the result
+		// contains one embedding per text in the same order. The errors
+		// wrapping errors.ErrCommunication are considered temporary, so the
+		// call may be retried.
+		Embed(ctx context.Context, texts []string) ([][]float32, error)
+		// Dims returns the number of the embedding dimensions
+		Dims() int
+	}
+
+	// Config contains the embedding provider settings
+	Config struct {
+		// Type is the provider type, either TypeOpenAI or TypeHashing
+		Type string
+		// Dims is the number of the embedding dimensions
+		Dims int
+		// BatchSize is the maximum number of texts sent to the provider in one call
+		BatchSize int
+		// MaxRetries is the number of times a failed call is retried on temporary errors
+		MaxRetries int
+		// RetryDelay is the delay before the first retry, it is doubled for every next one
+		RetryDelay time.Duration
+		// OpenAI contains the settings of the TypeOpenAI provider
+		OpenAI OpenAIConfig
+	}
+
+	// batcher is the Provider wrapper which splits the texts into batches
+	// and retries the failed calls
+	batcher struct {
+		p      Provider
+		cfg    Config
+		logger logging.Logger
+	}
+)
+
+const (
+	// TypeOpenAI is the OpenAI-compatible HTTP API provider
+	TypeOpenAI = "openai"
+	// TypeHashing is the local feature hashing provider, which doesn't require
+	// any external service
+	TypeHashing = "hashing"
+
+	// DefaultDims is the number of the embedding dimensions used if it is not specified,
+	// it matches the pgvector module default
+	DefaultDims       = 1536
+	DefaultBatchSize  = 100
+	DefaultMaxRetries = 3
+	DefaultRetryDelay = 500 * time.Millisecond
+)
+
+// DefaultConfig returns the default provider settings
+func DefaultConfig() Config {
+	return Config{
+		Type:       TypeHashing,
+		Dims:       DefaultDims,
+		BatchSize:  DefaultBatchSize,
+		MaxRetries: DefaultMaxRetries,
+		RetryDelay: DefaultRetryDelay,
+		OpenAI:     DefaultOpenAIConfig(),
+	}
+}
+
+// Check returns an error if the config settings are not valid
+func (c Config) Check() error {
+	if c.Type != TypeOpenAI && c.Type != TypeHashing {
+		return fmt.Errorf("the embedding provider type must be %q or %q, but %q: %w", TypeOpenAI, TypeHashing, c.Type, errors.ErrInvalid)
+	}
+	if c.Dims <= 0 {
+		return fmt.Errorf("the embedding dimensions must be positive, but %d: %w", c.Dims, errors.ErrInvalid)
+	}
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("the batch size must be positive, but %d: %w", c.BatchSize, errors.ErrInvalid)
+	}
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("the max retries must not be negative, but %d: %w", c.MaxRetries, errors.ErrInvalid)
+	}
+	if c.Type == TypeOpenAI {
+		return c.OpenAI.Check()
+	}
+	return nil
+}
+
+// New returns the Provider of the cfg.Type, the provider splits the texts
+// into the cfg.BatchSize batches and retries the calls failed with temporary errors.
+func New(cfg Config) (Provider, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	var p Provider
+	switch cfg.Type {
+	case TypeOpenAI:
+		p = NewOpenAI(cfg.OpenAI, cfg.Dims)
+	default:
+		p = NewHashing(cfg.Dims)
+	}
+	return &batcher{p: p, cfg: cfg, logger: logging.NewLogger("embedding." + cfg.Type)}, nil
+}
+
+// MustNew does the same as New, but panics on error
+func MustNew(cfg Config) Provider {
+	p, err := New(cfg)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// EmbedRecords fills the Embedding field of the records using the Provider p.
+// The records with the embeddings already set are skipped. The function does
+// nothing if p is nil.
+func EmbedRecords(ctx context.Context, p Provider, recs []persistence.IndexRecord) error {
+	if p == nil {
+		return nil
+	}
+	var idxs []int
+	var texts []string
+	for i := range recs {
+		if len(recs[i].Embedding) == 0 {
+			idxs = append(idxs, i)
+			texts = append(texts, recs[i].Segment)
+		}
+	}
+	if len(texts) == 0 {
+		return nil
+	}
+	embs, err := p.Embed(ctx, texts)
+	if err != nil {
+		return fmt.Errorf("could not embed %d records: %w", len(texts), err)
+	}
+	for i, idx := range idxs {
+		recs[idx].Embedding = embs[i]
+	}
+	return nil
+}
+
+// Embed implements Provider
+func (b *batcher) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	res := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += b.cfg.BatchSize {
+		end := min(start+b.cfg.BatchSize, len(texts))
+		embs, err := b.embedWithRetries(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, embs...)
+	}
+	return res, nil
+}
+
+// Dims implements Provider
+func (b *batcher) Dims() int {
+	return b.p.Dims()
+}
+
+func (b *batcher) String() string {
+	return fmt.Sprintf("embedding.Provider{type=%s, dims=%d}", b.cfg.Type, b.cfg.Dims)
+}
+
+func (b *batcher) embedWithRetries(ctx context.Context, texts []string) ([][]float32, error) {
+	delay := b.cfg.RetryDelay
+	for attempt := 0; ; attempt++ {
+		embs, err := b.p.Embed(ctx, texts)
+		if err == nil {
+			if len(embs) != len(texts) {
+				return nil, fmt.Errorf("expected %d embeddings, but got %d: %w", len(texts), len(embs), errors.ErrInternal)
+			}
+			for _, e := range embs {
+				if len(e) != b.cfg.Dims {
+					return nil, fmt.Errorf("expected %d embedding dimensions, but got %d: %w", b.cfg.Dims, len(e), errors.ErrInternal)
+				}
+			}
+			return embs, nil
+		}
+		// golibs errors.Is matches any non-gRPC error with the communication one
+		if !stderrors.Is(err, errors.ErrCommunication) || attempt >= b.cfg.MaxRetries {
+			return nil, err
+		}
+		b.logger.Warnf("embedding of %d texts failed (attempt %d), will retry in %s: %s", len(texts), attempt+1, delay, err)
+		if err := gcontext.Sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+		delay *= 2
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embedding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/acquirecloud/golibs/logging"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type (
+	// testProvider returns the fixed embeddings and fails the first failures calls
+	testProvider struct {
+		dims     int
+		failures int
+		err      error
+		calls    [][]string
+	}
+)
+
+func (tp *testProvider) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	tp.calls = append(tp.calls, texts)
+	if len(tp.calls) <= tp.failures {
+		return nil, tp.err
+	}
+	res := make([][]float32, len(texts))
+	for i := range texts {
+		res[i] = make([]float32, tp.dims)
+		res[i][0] = float32(len(texts[i]))
+	}
+	return res, nil
+}
+
+func (tp *testProvider) Dims() int {
+	return tp.dims
+}
+
+func testConfig(dims int) Config {
+	cfg := DefaultConfig()
+	cfg.Dims = dims
+	cfg.BatchSize = 2
+	cfg.RetryDelay = time.Millisecond
+	return cfg
+}
+
+func cosine(a, b []float32) float64 {
+	var res float64
+	for i := range a {
+		res += float64(a[i] * b[i])
+	}
+	return res
+}
+
+func TestConfig_Check(t *testing.T) {
+	assert.Nil(t, DefaultConfig().Check())
+	cfg := DefaultConfig()
+	cfg.Type = TypeOpenAI
+	assert.Nil(t, cfg.Check())
+	cfg.OpenAI.Model = ""
+	assert.ErrorIs(t, cfg.Check(), errors.ErrInvalid)
+
+	for _, f := range []func(c *Config){
+		func(c *Config) { c.Type = "abc" },
+		func(c *Config) { c.Dims = 0 },
+		func(c *Config) { c.BatchSize = 0 },
+		func(c *Config) { c.MaxRetries = -1 },
+	} {
+		cfg = DefaultConfig()
+		f(&cfg)
+		assert.ErrorIs(t, cfg.Check(), errors.ErrInvalid)
+		_, err := New(cfg)
+		assert.ErrorIs(t, err, errors.ErrInvalid)
+	}
+}
+
+func TestHashing(t *testing.T) {
+	h := NewHashing(64)
+	assert.Equal(t, 64, h.Dims())
+
+	embs, err := h.Embed(context.Background(), []string{"Red apples", "red, APPLES!", "yellow bananas", ""})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(embs))
+	assert.Equal(t, embs[0], embs[1])
+	assert.InDelta(t, 1.0, cosine(embs[0], embs[0]), 1e-6)
+	assert.True(t, cosine(embs[0], embs[2]) < 0.99)
+	assert.Equal(t, make([]float32, 64), embs[3])
+
+	embs2, err := NewHashing(64).Embed(context.Background(), []string{"Red apples"})
+	assert.Nil(t, err)
+	assert.Equal(t, embs[0], embs2[0])
+}
+
+func TestBatcher_Embed(t *testing.T) {
+	tp := &testProvider{dims: 3}
+	b := &batcher{p: tp, cfg: testConfig(3)}
+	embs, err := b.Embed(context.Background(), []string{"a", "bb", "ccc", "dddd", "eeeee"})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{{"a", "bb"}, {"ccc", "dddd"}, {"eeeee"}}, tp.calls)
+	assert.Equal(t, 5, len(embs))
+	for i, e := range embs {
+		assert.Equal(t, float32(i+1), e[0])
+	}
+
+	// the dimensions mismatch
+	b = &batcher{p: &testProvider{dims: 2}, cfg: testConfig(3)}
+	_, err = b.Embed(context.Background(), []string{"a"})
+	assert.ErrorIs(t, err, errors.ErrInternal)
+}
+
+func TestBatcher_Retries(t *testing.T) {
+	tp := &testProvider{dims: 3, failures: 2, err: fmt.Errorf("unavailable: %w", errors.ErrCommunication)}
+	b := &batcher{p: tp, cfg: testConfig(3), logger: logging.NewLogger("test")}
+	_, err := b.Embed(context.Background(), []string{"a"})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(tp.calls))
+
+	tp = &testProvider{dims: 3, failures: 10, err: fmt.Errorf("unavailable: %w", errors.ErrCommunication)}
+	b = &batcher{p: tp, cfg: testConfig(3), logger: logging.NewLogger("test")}
+	_, err = b.Embed(context.Background(), []string{"a"})
+	assert.ErrorIs(t, err, errors.ErrCommunication)
+	assert.Equal(t, DefaultMaxRetries+1, len(tp.calls))
+
+	// not temporary errors are not retried
+	tp = &testProvider{dims: 3, failures: 1, err: fmt.Errorf("bad input: %w", errors.ErrInvalid)}
+	b = &batcher{p: tp, cfg: testConfig(3), logger: logging.NewLogger("test")}
+	_, err = b.Embed(context.Background(), []string{"a"})
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	assert.Equal(t, 1, len(tp.calls))
+}
+
+func TestEmbedRecords(t *testing.T) {
+	recs := []persistence.IndexRecord{{ID: "1", Segment: "a"}, {ID: "2", Segment: "bb", Embedding: persistence.Embedding{7, 7, 7}}, {ID: "3", Segment: "ccc"}}
+	assert.Nil(t, EmbedRecords(context.Background(), nil, recs))
+	assert.Nil(t, recs[0].Embedding)
+
+	tp := &testProvider{dims: 3}
+	assert.Nil(t, EmbedRecords(context.Background(), tp, recs))
+	assert.Equal(t, [][]string{{"a", "ccc"}}, tp.calls)
+	assert.Equal(t, persistence.Embedding{1, 0, 0}, recs[0].Embedding)
+	assert.Equal(t, persistence.Embedding{7, 7, 7}, recs[1].Embedding)
+	assert.Equal(t, persistence.Embedding{3, 0, 0}, recs[2].Embedding)
+}
+
+func TestOpenAI_Embed(t *testing.T) {
+	var reqs []openAIRequest
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/embeddings", r.URL.Path)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		var req openAIRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		reqs = append(reqs, req)
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		// the items are returned in the reverse order to check the index is respected
+		var resp openAIResponse
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			emb := make([]float32, req.Dimensions)
+			emb[0] = float32(i)
+			resp.Data = append(resp.Data, struct {
+				Index     int       `json:"index"`
+				Embedding []float32 `json:"embedding"`
+			}{Index: i, Embedding: emb})
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	cfg := testConfig(2)
+	cfg.Type = TypeOpenAI
+	cfg.OpenAI.URL = srv.URL + "/v1/"
+	cfg.OpenAI.APIKey = "key"
+	p := MustNew(cfg)
+	embs, err := p.Embed(context.Background(), []string{"a", "b", "c"})
+	assert.Nil(t, err)
+	assert.Equal(t, [][]float32{{0, 0}, {1, 0}, {0, 0}}, embs)
+	assert.Equal(t, 2, len(reqs))
+	assert.Equal(t, openAIRequest{Model: cfg.OpenAI.Model, Input: []string{"a", "b"}, Dimensions: 2}, reqs[0])
+
+	reqs = nil
+	status = http.StatusServiceUnavailable
+	_, err = p.Embed(context.Background(), []string{"a"})
+	assert.ErrorIs(t, err, errors.ErrCommunication)
+	assert.Equal(t, DefaultMaxRetries+1, len(reqs))
+
+	reqs = nil
+	status = http.StatusBadRequest
+	_, err = p.Embed(context.Background(), []string{"a"})
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	assert.Equal(t, 1, len(reqs))
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embedding
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+type (
+	// hashing is the feature hashing (the "hashing trick") embedder, every
+	// lowercased word of the text is hashed to one of the dims dimensions,
+	// the hash sign bit defines whether the dimension is incremented or
+	// decremented. The result is L2 normalized, so the texts sharing the
+	// same words have the higher cosine similarity.
+	hashing struct {
+		dims int
+	}
+)
+
+// NewHashing returns the deterministic Provider which doesn't need any
+// external service and may be used offline
+func NewHashing(dims int) Provider {
+	return &hashing{dims: dims}
+}
+
+// Embed implements Provider
+func (h *hashing) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	res := make([][]float32, len(texts))
+	for i, t := range texts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		res[i] = h.embed(t)
+	}
+	return res, nil
+}
+
+// Dims implements Provider
+func (h *hashing) Dims() int {
+	return h.dims
+}
+
+func (h *hashing) embed(text string) []float32 {
+	vec := make([]float32, h.dims)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		hs := fnv.New64a()
+		_, _ = hs.Write([]byte(w))
+		sum := hs.Sum64()
+		idx := int((sum >> 1) % uint64(h.dims))
+		if sum&1 == 0 {
+			vec[idx]++
+		} else {
+			vec[idx]--
+		}
+	}
+	var norm float64
+	for _, v := range vec {
+		norm += float64(v * v)
+	}
+	if norm == 0 {
+		return vec
+	}
+	norm = math.Sqrt(norm)
+	for i := range vec {
+		vec[i] = float32(float64(vec[i]) / norm)
+	}
+	return vec
+}
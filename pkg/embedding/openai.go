@@ -0,0 +1,151 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type (
+	// OpenAIConfig contains the settings of the OpenAI-compatible embeddings API client
+	OpenAIConfig struct {
+		// URL is the API base address, the embeddings are requested from {URL}/embeddings
+		URL string
+		// APIKey is sent as the Bearer token, if not empty
+		APIKey string
+		// Model is the embedding model name
+		Model string
+		// Timeout is the single API call timeout
+		Timeout time.Duration
+	}
+
+	openAI struct {
+		cfg  OpenAIConfig
+		dims int
+		cl   *http.Client
+	}
+
+	openAIRequest struct {
+		Model      string   `json:"model"`
+		Input      []string `json:"input"`
+		Dimensions int      `json:"dimensions,omitempty"`
+	}
+
+	openAIResponse struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+)
+
+// DefaultOpenAIConfig returns the default OpenAI client settings
+func DefaultOpenAIConfig() OpenAIConfig {
+	return OpenAIConfig{
+		URL:     "https://api.openai.com/v1",
+		Model:   "text-embedding-3-small",
+		Timeout: 30 * time.Second,
+	}
+}
+
+// Check returns an error if the config settings are not valid
+func (c OpenAIConfig) Check() error {
+	if c.URL == "" {
+		return fmt.Errorf("the embeddings API URL must be specified: %w", errors.ErrInvalid)
+	}
+	if c.Model == "" {
+		return fmt.Errorf("the embedding model must be specified: %w", errors.ErrInvalid)
+	}
+	return nil
+}
+
+// NewOpenAI returns the Provider which requests the embeddings of dims dimensions
+// from the OpenAI-compatible embeddings API. The result is not batched and
+// the calls are not retried, use New to have them.
+func NewOpenAI(cfg OpenAIConfig, dims int) Provider {
+	return &openAI{cfg: cfg, dims: dims, cl: &http.Client{Timeout: cfg.Timeout}}
+}
+
+// Embed implements Provider
+func (o *openAI) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(openAIRequest{Model: o.cfg.Model, Input: texts, Dimensions: o.dims})
+	if err != nil {
+		return nil, err
+	}
+	url := strings.TrimRight(o.cfg.URL, "/") + "/embeddings"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("could not create the embeddings request: %w", errors.ErrInvalid)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.cfg.APIKey)
+	}
+
+	resp, err := o.cl.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("the embeddings request to %s failed: %s: %w", url, err, errors.ErrCommunication)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	buf, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the embeddings response: %s: %w", err, errors.ErrCommunication)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return nil, fmt.Errorf("the embeddings API responded with %d: %s: %w", resp.StatusCode, buf, errors.ErrCommunication)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, fmt.Errorf("the embeddings API responded with %d: %s: %w", resp.StatusCode, buf, errors.ErrNotAuthorized)
+	case resp.StatusCode >= 400:
+		return nil, fmt.Errorf("the embeddings API responded with %d: %s: %w", resp.StatusCode, buf, errors.ErrInvalid)
+	}
+
+	var r openAIResponse
+	if err := json.Unmarshal(buf, &r); err != nil {
+		return nil, fmt.Errorf("could not unmarshal the embeddings response: %s: %w", err, errors.ErrInternal)
+	}
+	res := make([][]float32, len(texts))
+	for _, d := range r.Data {
+		if d.Index < 0 || d.Index >= len(res) {
+			return nil, fmt.Errorf("the embedding index=%d is out of range [0, %d): %w", d.Index, len(res), errors.ErrInternal)
+		}
+		res[d.Index] = d.Embedding
+	}
+	for i, e := range res {
+		if len(e) == 0 {
+			return nil, fmt.Errorf("the embedding for the input #%d is not returned: %w", i, errors.ErrInternal)
+		}
+	}
+	return res, nil
+}
+
+// Dims implements Provider
+func (o *openAI) Dims() int {
+	return o.dims
+}
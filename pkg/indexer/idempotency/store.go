@@ -0,0 +1,243 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/acquirecloud/golibs/logging"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"sync"
+	"time"
+)
+
+type (
+	// Config defines the idempotency keys settings
+	Config struct {
+		// TTL is how long the responses are kept for the idempotency keys, the request is executed
+		// again if it is retried with the same key after the TTL.
+		TTL time.Duration
+		// Interval is how often the expired idempotency keys are purged
+		Interval time.Duration
+		// Lease is how long the request in progress holds its key, the lease is renewed while the
+		// request is executed. The retry takes the key over, if the lease is expired (e.g. the
+		// process executing the request has crashed).
+		Lease time.Duration
+	}
+
+	// Store executes the requests made with the idempotency keys once, the responses of the completed
+	// requests are stored for the TTL and they are replayed on the requests retries. The expired keys
+	// are purged in the background.
+	Store struct {
+		Db persistence.Db `inject:""`
+
+		cfg    Config
+		logger logging.Logger
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+	}
+)
+
+const (
+	DefaultTTL      = 24 * time.Hour
+	DefaultInterval = time.Hour
+	DefaultLease    = time.Minute
+
+	// MaxKeyLength is the maximum length of the idempotency key
+	MaxKeyLength = 255
+)
+
+// DefaultConfig returns the default idempotency keys settings
+func DefaultConfig() Config {
+	return Config{TTL: DefaultTTL, Interval: DefaultInterval, Lease: DefaultLease}
+}
+
+// Check returns an error if the config is not valid
+func (c Config) Check() error {
+	if c.TTL <= 0 {
+		return fmt.Errorf("the idempotency keys TTL %s must be positive: %w", c.TTL, errors.ErrInvalid)
+	}
+	if c.Interval <= 0 {
+		return fmt.Errorf("the idempotency keys purge interval %s must be positive: %w", c.Interval, errors.ErrInvalid)
+	}
+	if c.Lease <= 0 {
+		return fmt.Errorf("the idempotency keys lease %s must be positive: %w", c.Lease, errors.ErrInvalid)
+	}
+	return nil
+}
+
+// NewStore creates the new Store
+func NewStore(cfg Config) *Store {
+	return &Store{cfg: cfg, logger: logging.NewLogger("idempotency.Store")}
+}
+
+// Init implements linker.Initializer interface
+func (s *Store) Init(ctx context.Context) error {
+	if err := s.cfg.Check(); err != nil {
+		return err
+	}
+	s.logger.Infof("Initializing... ttl=%s, interval=%s, lease=%s", s.cfg.TTL, s.cfg.Interval, s.cfg.Lease)
+	var pctx context.Context
+	pctx, s.cancel = context.WithCancel(context.Background())
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			if _, err := s.Purge(pctx); err != nil && pctx.Err() == nil {
+				s.logger.Warnf("could not purge the idempotency keys: %v", err)
+			}
+			select {
+			case <-pctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown implements linker.Shutdowner interface
+func (s *Store) Shutdown() {
+	s.logger.Infof("Shutdown")
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// Do calls the call function once for the idempotency key and returns its response. If the key
+// was used before, the stored response is returned without the call. The fingerprint identifies the
+// request, the function returns ErrConflict if the key was used for a request with another fingerprint,
+// or if the request with the key is still in progress. The request in progress, which lease is expired,
+// is taken over by its retry. The call is not stored if it fails, so the request may be retried with the
+// same key then. If the key is empty, the call is made every time.
+func (s *Store) Do(ctx context.Context, key, fingerprint string, call func() ([]byte, error)) ([]byte, error) {
+	if key == "" {
+		return call()
+	}
+	if len(key) > MaxKeyLength {
+		return nil, fmt.Errorf("the idempotency key length must not exceed %d: %w", MaxKeyLength, errors.ErrInvalid)
+	}
+	mtx := s.Db.NewModelTx(ctx)
+	lockedUntil := s.leaseEnd()
+	err := mtx.CreateIdempotencyKey(persistence.IdempotencyKey{Key: key, Fingerprint: fingerprint, LockedUntil: lockedUntil})
+	if errors.Is(err, errors.ErrExist) {
+		var ik persistence.IdempotencyKey
+		if ik, err = mtx.GetIdempotencyKey(key); err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		switch {
+		case ik.CreatedAt.Before(now.Add(-s.cfg.TTL)):
+			// the key is expired, but not purged yet
+			if err = mtx.DeleteIdempotencyKey(key); err != nil && !errors.Is(err, errors.ErrNotExist) {
+				return nil, err
+			}
+			err = mtx.CreateIdempotencyKey(persistence.IdempotencyKey{Key: key, Fingerprint: fingerprint, LockedUntil: lockedUntil})
+		case ik.Fingerprint == fingerprint && !ik.Done && !ik.LockedUntil.After(now):
+			// the request lease is expired, so it is not executed anymore and the retry takes it over
+			err = mtx.LockIdempotencyKey(key, now, lockedUntil)
+			if errors.Is(err, errors.ErrNotExist) {
+				err = errors.ErrExist
+			}
+			if err == nil {
+				s.logger.Infof("the request with the idempotency key %q is taken over after its lease expired at %s", key, ik.LockedUntil)
+			}
+		default:
+			return replay(ik, fingerprint)
+		}
+		if errors.Is(err, errors.ErrExist) {
+			return nil, fmt.Errorf("the request with the idempotency key %q is in progress: %w", key, errors.ErrConflict)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stop := s.keepLease(ctx, key, lockedUntil)
+	res, err := call()
+	stop()
+	if err != nil {
+		if derr := mtx.DeleteIdempotencyKey(key); derr != nil {
+			s.logger.Warnf("could not delete the idempotency key %q of the failed request: %v", key, derr)
+		}
+		return nil, err
+	}
+	if err = mtx.CompleteIdempotencyKey(key, res); err != nil {
+		s.logger.Warnf("could not store the response for the idempotency key %q: %v", key, err)
+	}
+	return res, nil
+}
+
+// keepLease renews the lease of the request with the key until the returned function is called,
+// the renewal stops if the request is taken over (the lease was expired before the renewal)
+func (s *Store) keepLease(ctx context.Context, key string, lockedUntil time.Time) func() {
+	lctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(s.cfg.Lease / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-lctx.Done():
+				return
+			case <-ticker.C:
+			}
+			next := s.leaseEnd()
+			if err := s.Db.NewModelTx(lctx).LockIdempotencyKey(key, lockedUntil, next); err != nil {
+				if lctx.Err() == nil {
+					s.logger.Warnf("could not renew the lease of the idempotency key %q: %v", key, err)
+				}
+				return
+			}
+			lockedUntil = next
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// leaseEnd returns the end of the lease starting now, it is truncated to be stored precisely
+func (s *Store) leaseEnd() time.Time {
+	return time.Now().Add(s.cfg.Lease).Truncate(time.Millisecond)
+}
+
+// Purge deletes the expired idempotency keys, it returns the number of the keys deleted
+func (s *Store) Purge(ctx context.Context) (int64, error) {
+	cnt, err := s.Db.NewModelTx(ctx).PurgeIdempotencyKeys(time.Now().Add(-s.cfg.TTL))
+	if err != nil {
+		return 0, err
+	}
+	if cnt > 0 {
+		s.logger.Infof("%d expired idempotency keys purged", cnt)
+	}
+	return cnt, nil
+}
+
+func replay(ik persistence.IdempotencyKey, fingerprint string) ([]byte, error) {
+	if ik.Fingerprint != fingerprint {
+		return nil, fmt.Errorf("the idempotency key %q was used for another request: %w", ik.Key, errors.ErrConflict)
+	}
+	if !ik.Done {
+		return nil, fmt.Errorf("the request with the idempotency key %q is in progress: %w", ik.Key, errors.ErrConflict)
+	}
+	return ik.Response, nil
+}
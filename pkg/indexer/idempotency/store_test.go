@@ -0,0 +1,137 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/indexer/persistence/inmem"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigCheck(t *testing.T) {
+	assert.Nil(t, DefaultConfig().Check())
+	assert.True(t, errors.Is(Config{}.Check(), errors.ErrInvalid))
+	assert.True(t, errors.Is(Config{TTL: time.Hour}.Check(), errors.ErrInvalid))
+	assert.True(t, errors.Is(Config{TTL: time.Hour, Interval: time.Hour}.Check(), errors.ErrInvalid))
+}
+
+func TestDo(t *testing.T) {
+	s := NewStore(DefaultConfig())
+	s.Db = inmem.NewDb()
+	ctx := context.Background()
+	calls := 0
+	call := func() ([]byte, error) {
+		calls++
+		return []byte(fmt.Sprintf("res%d", calls)), nil
+	}
+
+	res, err := s.Do(ctx, "", "f1", call)
+	assert.Nil(t, err)
+	assert.Equal(t, "res1", string(res))
+	res, err = s.Do(ctx, "k1", "f1", call)
+	assert.Nil(t, err)
+	assert.Equal(t, "res2", string(res))
+
+	// the retry gets the stored response
+	res, err = s.Do(ctx, "k1", "f1", call)
+	assert.Nil(t, err)
+	assert.Equal(t, "res2", string(res))
+	assert.Equal(t, 2, calls)
+
+	_, err = s.Do(ctx, "k1", "f2", call)
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	_, err = s.Do(ctx, strings.Repeat("k", MaxKeyLength+1), "f1", call)
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+
+	// the failed request is not stored
+	_, err = s.Do(ctx, "k2", "f1", func() ([]byte, error) { return nil, errors.ErrInternal })
+	assert.True(t, errors.Is(err, errors.ErrInternal))
+	res, err = s.Do(ctx, "k2", "f1", call)
+	assert.Nil(t, err)
+	assert.Equal(t, "res3", string(res))
+
+	// the request in progress
+	_, err = s.Do(ctx, "k3", "f1", func() ([]byte, error) {
+		_, err := s.Do(ctx, "k3", "f1", call)
+		assert.True(t, errors.Is(err, errors.ErrConflict))
+		return []byte("res"), nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestDoExpired(t *testing.T) {
+	s := NewStore(Config{TTL: time.Millisecond, Interval: time.Hour, Lease: time.Minute})
+	s.Db = inmem.NewDb()
+	ctx := context.Background()
+	calls := 0
+	call := func() ([]byte, error) {
+		calls++
+		return []byte(fmt.Sprintf("res%d", calls)), nil
+	}
+	_, err := s.Do(ctx, "k1", "f1", call)
+	assert.Nil(t, err)
+	time.Sleep(2 * time.Millisecond)
+	res, err := s.Do(ctx, "k1", "f2", call)
+	assert.Nil(t, err)
+	assert.Equal(t, "res2", string(res))
+
+	time.Sleep(2 * time.Millisecond)
+	cnt, err := s.Purge(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), cnt)
+	_, err = s.Db.NewModelTx(ctx).GetIdempotencyKey("k1")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+}
+
+func TestDoLease(t *testing.T) {
+	s := NewStore(Config{TTL: time.Hour, Interval: time.Hour, Lease: 40 * time.Millisecond})
+	s.Db = inmem.NewDb()
+	ctx := context.Background()
+	call := func() ([]byte, error) {
+		return []byte("res"), nil
+	}
+
+	// the request crashed holding the key, so its retry takes it over after the lease
+	mtx := s.Db.NewModelTx(ctx)
+	assert.Nil(t, mtx.CreateIdempotencyKey(persistence.IdempotencyKey{Key: "k1", Fingerprint: "f1", LockedUntil: time.Now().Add(time.Hour)}))
+	assert.Nil(t, mtx.CreateIdempotencyKey(persistence.IdempotencyKey{Key: "k2", Fingerprint: "f1", LockedUntil: time.Now().Add(-time.Second)}))
+	_, err := s.Do(ctx, "k1", "f1", call)
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	_, err = s.Do(ctx, "k2", "f2", call)
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	res, err := s.Do(ctx, "k2", "f1", call)
+	assert.Nil(t, err)
+	assert.Equal(t, "res", string(res))
+	ik, err := mtx.GetIdempotencyKey("k2")
+	assert.Nil(t, err)
+	assert.True(t, ik.Done)
+
+	// the lease is renewed while the request is executed
+	res, err = s.Do(ctx, "k3", "f1", func() ([]byte, error) {
+		time.Sleep(100 * time.Millisecond)
+		_, err := s.Do(ctx, "k3", "f1", call)
+		assert.True(t, errors.Is(err, errors.ErrConflict))
+		return []byte("res3"), nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "res3", string(res))
+}
@@ -0,0 +1,496 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/acquirecloud/golibs/logging"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/parser"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type (
+	// Config defines the asynchronous jobs settings
+	Config struct {
+		// Workers is the number of the jobs executed concurrently
+		Workers int
+		// QueueSize is the maximum number of the jobs waiting for a worker
+		QueueSize int
+		// SpoolDir is the directory where the documents are stored till their jobs are completed
+		SpoolDir string
+		// Lease is how long the jobs are locked by the runner, the locks are renewed every half of it
+		Lease time.Duration
+	}
+
+	// Runner executes the jobs, which parse the documents asynchronously. The document is spooled
+	// to the SpoolDir, and the job is queued and executed by a worker from the bounded pool. The parsed
+	// records are written by small transactions, the job progress is updated with every of them.
+	// The queued and running jobs are locked by the runner, and the locks are renewed while it is
+	// running. The jobs interrupted by the shutdown or by a crash are resumed, when their locks are
+	// released or expired, by this or another runner sharing the database and the SpoolDir.
+	Runner struct {
+		Db        persistence.Db  `inject:""`
+		PProvider parser.Provider `inject:""`
+
+		cfg     Config
+		logger  logging.Logger
+		owner   string
+		queue   chan int64
+		lock    sync.Mutex
+		owned   map[int64]bool
+		running map[int64]context.CancelFunc
+		ctx     context.Context
+		cancel  context.CancelFunc
+		wg      sync.WaitGroup
+	}
+
+	// progressTx writes the parsed records in separate transactions and counts them in the job
+	progressTx struct {
+		persistence.ModelTx
+		ctx context.Context
+		job *persistence.Job
+	}
+)
+
+const (
+	DefaultWorkers   = 4
+	DefaultQueueSize = 1000
+	DefaultLease     = time.Minute
+)
+
+// DefaultConfig returns the default jobs settings
+func DefaultConfig() Config {
+	return Config{Workers: DefaultWorkers, QueueSize: DefaultQueueSize, SpoolDir: filepath.Join(os.TempDir(), "simila-jobs"), Lease: DefaultLease}
+}
+
+// Check returns an error if the config is not valid
+func (c Config) Check() error {
+	if c.Workers <= 0 {
+		return fmt.Errorf("the jobs workers number %d must be positive: %w", c.Workers, errors.ErrInvalid)
+	}
+	if c.QueueSize <= 0 {
+		return fmt.Errorf("the jobs queue size %d must be positive: %w", c.QueueSize, errors.ErrInvalid)
+	}
+	if c.SpoolDir == "" {
+		return fmt.Errorf("the jobs spool directory must be specified: %w", errors.ErrInvalid)
+	}
+	if c.Lease <= 0 {
+		return fmt.Errorf("the jobs lease %s must be positive: %w", c.Lease, errors.ErrInvalid)
+	}
+	return nil
+}
+
+// NewRunner creates the new Runner
+func NewRunner(cfg Config) *Runner {
+	return &Runner{cfg: cfg, logger: logging.NewLogger("jobs.Runner"), owner: newOwner(), owned: make(map[int64]bool),
+		running: make(map[int64]context.CancelFunc)}
+}
+
+// Init implements linker.Initializer interface
+func (r *Runner) Init(ctx context.Context) error {
+	if err := r.cfg.Check(); err != nil {
+		return err
+	}
+	r.logger.Infof("Initializing... workers=%d, queueSize=%d, spoolDir=%s, lease=%s, owner=%s", r.cfg.Workers, r.cfg.QueueSize,
+		r.cfg.SpoolDir, r.cfg.Lease, r.owner)
+	if err := os.MkdirAll(r.cfg.SpoolDir, 0o700); err != nil {
+		return fmt.Errorf("could not create the jobs spool directory %s: %w", r.cfg.SpoolDir, err)
+	}
+	r.queue = make(chan int64, r.cfg.QueueSize)
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+	for i := 0; i < r.cfg.Workers; i++ {
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			for {
+				select {
+				case <-r.ctx.Done():
+					return
+				case id := <-r.queue:
+					r.run(id)
+				}
+			}
+		}()
+	}
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.keepLocks()
+	}()
+	return nil
+}
+
+// Shutdown implements linker.Shutdowner interface
+func (r *Runner) Shutdown() {
+	r.logger.Infof("Shutdown")
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	// the locks of the interrupted jobs are released, so the jobs are resumed without waiting for the locks expiration
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for ID := range r.owned {
+		if _, err := r.Db.NewModelTx(context.Background()).LockJob(ID, r.owner, time.Now(), time.Time{}); err != nil && !errors.Is(err, errors.ErrNotExist) {
+			r.logger.Warnf("could not release the lock of the job %d: %v", ID, err)
+		}
+	}
+}
+
+// Submit spools the body and queues the job, which parses it to the job node records. The job
+// NodeID, Path and Parser must be set. The function returns ErrExhausted if the jobs queue is full.
+func (r *Runner) Submit(ctx context.Context, job persistence.Job, body io.Reader) (persistence.Job, error) {
+	if r.PProvider.Parser(job.Parser) == nil {
+		return persistence.Job{}, fmt.Errorf("the parser=%q is not supported: %w", job.Parser, errors.ErrInvalid)
+	}
+	if len(r.queue) >= cap(r.queue) {
+		return persistence.Job{}, fmt.Errorf("the jobs queue is full, %d jobs are waiting: %w", len(r.queue), errors.ErrExhausted)
+	}
+	f, err := os.CreateTemp(r.cfg.SpoolDir, "upload-*")
+	if err != nil {
+		return persistence.Job{}, fmt.Errorf("could not create the spool file: %w", err)
+	}
+	_, err = io.Copy(f, body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return persistence.Job{}, fmt.Errorf("could not spool the document: %w", err)
+	}
+
+	job.Status = persistence.JobPending
+	job.Records = 0
+	job.Error = ""
+	job.LockedBy = r.owner
+	job.LockedUntil = r.lockedUntil()
+	mtx := r.Db.NewModelTx(ctx)
+	if job, err = mtx.CreateJob(job); err != nil {
+		_ = os.Remove(f.Name())
+		return persistence.Job{}, err
+	}
+	r.lock.Lock()
+	r.owned[job.ID] = true
+	r.lock.Unlock()
+	if err = os.Rename(f.Name(), r.spoolFile(job.ID)); err != nil {
+		_ = os.Remove(f.Name())
+		return persistence.Job{}, r.abort(mtx, job, fmt.Errorf("could not spool the document: %w", err))
+	}
+	select {
+	case r.queue <- job.ID:
+	default:
+		return persistence.Job{}, r.abort(mtx, job, fmt.Errorf("the jobs queue is full: %w", errors.ErrExhausted))
+	}
+	r.logger.Infof("the job %d is submitted for %s", job.ID, job.Path)
+	return job, nil
+}
+
+// Cancel cancels the job. The pending job is canceled immediately, and the running one is
+// canceled when the parser stops. The function returns ErrConflict if the job is finished already.
+func (r *Runner) Cancel(ctx context.Context, ID int64) (persistence.Job, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	mtx := r.Db.NewModelTx(ctx)
+	job, err := mtx.GetJob(ID)
+	if err != nil {
+		return job, err
+	}
+	if job.Status.Finished() {
+		return job, fmt.Errorf("the job %d is %s already: %w", ID, job.Status, errors.ErrConflict)
+	}
+	if cancel, ok := r.running[ID]; ok {
+		cancel()
+		return job, nil
+	}
+	// the running job of another runner is stopped by it, when it fails to renew the job lock
+	job.Status = persistence.JobCanceled
+	if err = mtx.UpdateJob(job); err != nil {
+		return job, err
+	}
+	delete(r.owned, ID)
+	_ = os.Remove(r.spoolFile(ID))
+	return job, nil
+}
+
+// keepLocks renews the locks of the owned jobs and resumes the jobs with the expired locks, it
+// repeats that every half of the lease until the shutdown
+func (r *Runner) keepLocks() {
+	ticker := time.NewTicker(r.cfg.Lease / 2)
+	defer ticker.Stop()
+	for {
+		r.renew()
+		r.resume()
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// renew renews the locks of the owned jobs. The job is not owned anymore, if its lock is lost, the
+// job is canceled by another runner then, or the lock was expired and the job is resumed by it.
+func (r *Runner) renew() {
+	r.lock.Lock()
+	ids := make([]int64, 0, len(r.owned))
+	for ID := range r.owned {
+		ids = append(ids, ID)
+	}
+	r.lock.Unlock()
+	mtx := r.Db.NewModelTx(r.ctx)
+	for _, ID := range ids {
+		_, err := mtx.LockJob(ID, r.owner, time.Now(), r.lockedUntil())
+		if errors.Is(err, errors.ErrNotExist) {
+			r.lock.Lock()
+			delete(r.owned, ID)
+			cancel, running := r.running[ID]
+			r.lock.Unlock()
+			if running {
+				cancel()
+			} else if j, err := mtx.GetJob(ID); err == nil && j.Status.Finished() {
+				_ = os.Remove(r.spoolFile(ID))
+			}
+		} else if err != nil && r.ctx.Err() == nil {
+			r.logger.Warnf("could not renew the lock of the job %d: %v", ID, err)
+		}
+	}
+}
+
+// resume locks and queues the pending jobs, which locks are expired, and the running ones, which were
+// interrupted by a crash. The oldest jobs go first, they are resumed while the queue is not full.
+func (r *Runner) resume() {
+	mtx := r.Db.NewModelTx(r.ctx)
+	now := time.Now()
+	var jobs []persistence.Job
+	for _, s := range []persistence.JobStatus{persistence.JobRunning, persistence.JobPending} {
+		js, err := listJobs(mtx, persistence.JobsQuery{Status: s, LockedBefore: now})
+		if err != nil {
+			if r.ctx.Err() == nil {
+				r.logger.Warnf("could not read the jobs to resume: %v", err)
+			}
+			return
+		}
+		jobs = append(jobs, js...)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID < jobs[j].ID })
+	cnt := 0
+	for _, j := range jobs {
+		r.lock.Lock()
+		owned := r.owned[j.ID]
+		r.lock.Unlock()
+		if owned {
+			continue
+		}
+		if len(r.queue) >= cap(r.queue) {
+			break
+		}
+		j, err := mtx.LockJob(j.ID, r.owner, now, r.lockedUntil())
+		if err != nil {
+			// the job is resumed by another runner
+			continue
+		}
+		if j.Status == persistence.JobRunning {
+			j.Status = persistence.JobPending
+			if err = mtx.UpdateJob(j); err != nil {
+				r.logger.Warnf("could not resume the job %d: %v", j.ID, err)
+				continue
+			}
+		}
+		r.lock.Lock()
+		r.owned[j.ID] = true
+		r.lock.Unlock()
+		select {
+		case r.queue <- j.ID:
+			cnt++
+		default:
+			r.lock.Lock()
+			delete(r.owned, j.ID)
+			r.lock.Unlock()
+		}
+	}
+	if cnt > 0 {
+		r.logger.Infof("%d jobs are resumed", cnt)
+	}
+}
+
+// run executes the job, if it is still pending and owned. The job is started by the conditional
+// status change, so the job canceled by another runner after it was queued is not run.
+func (r *Runner) run(ID int64) {
+	mtx := r.Db.NewModelTx(r.ctx)
+	r.lock.Lock()
+	if !r.owned[ID] {
+		r.lock.Unlock()
+		return
+	}
+	job, err := mtx.StartJob(ID, r.owner)
+	if err != nil {
+		r.lock.Unlock()
+		if !errors.Is(err, errors.ErrNotExist) && r.ctx.Err() == nil {
+			r.logger.Warnf("could not start the job %d: %v", ID, err)
+		}
+		return
+	}
+	jctx, cancel := context.WithCancel(r.ctx)
+	defer cancel()
+	r.running[ID] = cancel
+	r.lock.Unlock()
+
+	err = r.parse(jctx, &job)
+
+	r.lock.Lock()
+	delete(r.running, ID)
+	owned := r.owned[ID]
+	r.lock.Unlock()
+	if r.ctx.Err() != nil {
+		// the job is interrupted by the shutdown, it will be resumed when its lock is released
+		job.Status = persistence.JobPending
+		if err = r.Db.NewModelTx(context.Background()).UpdateJob(job); err != nil {
+			r.logger.Warnf("could not update the interrupted job %d: %v", ID, err)
+		}
+		return
+	}
+	if !owned {
+		// the job is canceled or resumed by another runner, its status is left to it
+		r.logger.Warnf("the lock of the job %d is lost, %d records written to %s", ID, job.Records, job.Path)
+		if j, err := r.Db.NewModelTx(context.Background()).GetJob(ID); err == nil && j.Status.Finished() {
+			_ = os.Remove(r.spoolFile(ID))
+		}
+		return
+	}
+	if jctx.Err() != nil {
+		err = errors.ErrCanceled
+	}
+	if err = r.finish(r.Db.NewModelTx(context.Background()), job, err); err != nil {
+		r.logger.Warnf("could not complete the job %d: %v", ID, err)
+	}
+}
+
+// parse scans the spooled document of the job with its parser
+func (r *Runner) parse(ctx context.Context, job *persistence.Job) error {
+	p := r.PProvider.Parser(job.Parser)
+	if p == nil {
+		return fmt.Errorf("the parser=%q is not supported: %w", job.Parser, errors.ErrInvalid)
+	}
+	f, err := os.Open(r.spoolFile(job.ID))
+	if err != nil {
+		return fmt.Errorf("could not open the spooled document: %w", err)
+	}
+	defer f.Close()
+	// the batches are written with the background context, so the job is canceled between them only
+	ptx := &progressTx{ModelTx: r.Db.NewModelTx(context.Background()), ctx: ctx, job: job}
+	if _, err = p.ScanRecords(ctx, ptx, job.NodeID, f); err != nil {
+		return fmt.Errorf("could not read records for %q format: %w", job.Parser, err)
+	}
+	return nil
+}
+
+// finish sets the final status of the job according to the error and deletes its spooled document
+func (r *Runner) finish(mtx persistence.ModelTx, job persistence.Job, err error) error {
+	switch {
+	case err == nil:
+		job.Status = persistence.JobDone
+		r.logger.Infof("the job %d is done, %d records written to %s", job.ID, job.Records, job.Path)
+	case errors.Is(err, errors.ErrCanceled):
+		job.Status = persistence.JobCanceled
+		r.logger.Infof("the job %d is canceled, %d records written to %s", job.ID, job.Records, job.Path)
+	default:
+		job.Status = persistence.JobFailed
+		job.Error = err.Error()
+		r.logger.Warnf("the job %d is failed, %d records written to %s: %v", job.ID, job.Records, job.Path, err)
+	}
+	r.lock.Lock()
+	delete(r.owned, job.ID)
+	r.lock.Unlock()
+	_ = os.Remove(r.spoolFile(job.ID))
+	return mtx.UpdateJob(job)
+}
+
+// abort finishes the job, which could not be queued, with the error and returns the error
+func (r *Runner) abort(mtx persistence.ModelTx, job persistence.Job, err error) error {
+	if ferr := r.finish(mtx, job, err); ferr != nil {
+		r.logger.Warnf("could not finish the job %d: %v", job.ID, ferr)
+	}
+	return err
+}
+
+func listJobs(mtx persistence.ModelTx, query persistence.JobsQuery) ([]persistence.Job, error) {
+	var res []persistence.Job
+	for offset := int64(0); ; offset += 100 {
+		query.Offset, query.Limit = offset, 100
+		jobs, err := mtx.ListJobs(query)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, jobs...)
+		if len(jobs) < 100 {
+			return res, nil
+		}
+	}
+}
+
+func (r *Runner) spoolFile(ID int64) string {
+	return filepath.Join(r.cfg.SpoolDir, strconv.FormatInt(ID, 10))
+}
+
+func (r *Runner) lockedUntil() time.Time {
+	return time.Now().Add(r.cfg.Lease)
+}
+
+// newOwner returns the unique name of the runner, which locks the jobs
+func newOwner() string {
+	host, _ := os.Hostname()
+	if len(host) > 64 {
+		host = host[:64]
+	}
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return host + "-" + hex.EncodeToString(b)
+}
+
+// UpsertIndexRecords writes the records and updates the job progress in one transaction
+func (p *progressTx) UpsertIndexRecords(records ...persistence.IndexRecord) (int64, error) {
+	if err := p.ctx.Err(); err != nil {
+		return 0, errors.ErrCanceled
+	}
+	p.MustBegin()
+	defer func() {
+		_ = p.Rollback()
+	}()
+	n, err := p.ModelTx.UpsertIndexRecords(records...)
+	if err != nil {
+		return n, err
+	}
+	job := *p.job
+	job.Records += int64(len(records))
+	if err = p.UpdateJob(job); err != nil {
+		return n, err
+	}
+	if err = p.Commit(); err != nil {
+		return n, err
+	}
+	*p.job = job
+	return n, nil
+}
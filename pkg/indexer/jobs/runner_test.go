@@ -0,0 +1,276 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jobs
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/indexer/persistence/inmem"
+	"github.com/simila-io/simila/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testParser writes a record per line, it waits for the release before every line, if it is set
+type testParser struct {
+	release chan struct{}
+}
+
+func (tp *testParser) ScanRecords(ctx context.Context, mtx persistence.ModelTx, nodeID int64, body io.Reader) (int64, error) {
+	scanner := bufio.NewScanner(body)
+	var cnt int64
+	for scanner.Scan() {
+		if scanner.Text() == "fail" {
+			return cnt, fmt.Errorf("bad line %d", cnt+1)
+		}
+		if tp.release != nil {
+			select {
+			case <-ctx.Done():
+				return cnt, ctx.Err()
+			case <-tp.release:
+			}
+		}
+		cnt++
+		if _, err := mtx.UpsertIndexRecords(persistence.IndexRecord{ID: fmt.Sprint(cnt), NodeID: nodeID, Format: "txt", Segment: scanner.Text()}); err != nil {
+			return cnt, err
+		}
+	}
+	return cnt, nil
+}
+
+// cancelingDb cancels every job right before it is started, as another runner could do it
+type cancelingDb struct {
+	persistence.Db
+}
+
+type cancelingTx struct {
+	persistence.ModelTx
+}
+
+func (d cancelingDb) NewModelTx(ctx context.Context) persistence.ModelTx {
+	return cancelingTx{ModelTx: d.Db.NewModelTx(ctx)}
+}
+
+func (tx cancelingTx) StartJob(ID int64, owner string) (persistence.Job, error) {
+	j, err := tx.GetJob(ID)
+	if err != nil {
+		return j, err
+	}
+	j.Status = persistence.JobCanceled
+	if err = tx.UpdateJob(j); err != nil {
+		return j, err
+	}
+	return tx.ModelTx.StartJob(ID, owner)
+}
+
+func newTestRunner(t *testing.T, db persistence.Db, tp *testParser) *Runner {
+	pp := parser.NewParserProvider()
+	pp.RegisterParser("txt", tp)
+	r := NewRunner(Config{Workers: 1, QueueSize: 10, SpoolDir: t.TempDir(), Lease: time.Minute})
+	r.Db = db
+	r.PProvider = pp
+	return r
+}
+
+func newTestNodes(t *testing.T, db persistence.Db) []persistence.Node {
+	nodes, err := db.NewModelTx(context.Background()).CreateNodes(persistence.Node{Path: "/", Name: "doc1", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "doc2", Flags: persistence.NodeFlagDocument})
+	assert.Nil(t, err)
+	return nodes
+}
+
+func waitJob(t *testing.T, db persistence.Db, ID int64, status persistence.JobStatus) persistence.Job {
+	var job persistence.Job
+	assert.Eventually(t, func() bool {
+		var err error
+		job, err = db.NewModelTx(context.Background()).GetJob(ID)
+		return err == nil && job.Status == status
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestConfigCheck(t *testing.T) {
+	assert.Nil(t, DefaultConfig().Check())
+	assert.True(t, errors.Is(Config{QueueSize: 1, SpoolDir: "/tmp", Lease: time.Minute}.Check(), errors.ErrInvalid))
+	assert.True(t, errors.Is(Config{Workers: 1, SpoolDir: "/tmp", Lease: time.Minute}.Check(), errors.ErrInvalid))
+	assert.True(t, errors.Is(Config{Workers: 1, QueueSize: 1, Lease: time.Minute}.Check(), errors.ErrInvalid))
+	assert.True(t, errors.Is(Config{Workers: 1, QueueSize: 1, SpoolDir: "/tmp"}.Check(), errors.ErrInvalid))
+}
+
+func TestSubmit(t *testing.T) {
+	db := inmem.NewDb()
+	nodes := newTestNodes(t, db)
+	r := newTestRunner(t, db, &testParser{})
+	assert.Nil(t, r.Init(context.Background()))
+	defer r.Shutdown()
+
+	_, err := r.Submit(context.Background(), persistence.Job{NodeID: nodes[0].ID, Path: "/doc1", Parser: "pdf"}, strings.NewReader("a"))
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	job, err := r.Submit(context.Background(), persistence.Job{NodeID: nodes[0].ID, Path: "/doc1", Parser: "txt"}, strings.NewReader("a\nb\nc"))
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.JobPending, job.Status)
+	job = waitJob(t, db, job.ID, persistence.JobDone)
+	assert.Equal(t, int64(3), job.Records)
+	_, err = os.Stat(r.spoolFile(job.ID))
+	assert.True(t, os.IsNotExist(err))
+	qr, err := db.NewModelTx(context.Background()).QueryIndexRecords(persistence.IndexRecordQuery{NodeID: nodes[0].ID, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(qr.Items))
+
+	job, err = r.Submit(context.Background(), persistence.Job{NodeID: nodes[0].ID, Path: "/doc1", Parser: "txt"}, strings.NewReader("a\nfail\nc"))
+	assert.Nil(t, err)
+	job = waitJob(t, db, job.ID, persistence.JobFailed)
+	assert.Equal(t, int64(1), job.Records)
+	assert.Contains(t, job.Error, "bad line 2")
+	_, err = r.Cancel(context.Background(), job.ID)
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	_, err = r.Cancel(context.Background(), job.ID+1)
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+}
+
+func TestCancel(t *testing.T) {
+	db := inmem.NewDb()
+	nodes := newTestNodes(t, db)
+	tp := &testParser{release: make(chan struct{})}
+	r := newTestRunner(t, db, tp)
+	assert.Nil(t, r.Init(context.Background()))
+	defer r.Shutdown()
+
+	j1, err := r.Submit(context.Background(), persistence.Job{NodeID: nodes[0].ID, Path: "/doc1", Parser: "txt"}, strings.NewReader("a\nb\nc"))
+	assert.Nil(t, err)
+	j2, err := r.Submit(context.Background(), persistence.Job{NodeID: nodes[1].ID, Path: "/doc2", Parser: "txt"}, strings.NewReader("a"))
+	assert.Nil(t, err)
+	tp.release <- struct{}{}
+	j1 = waitJob(t, db, j1.ID, persistence.JobRunning)
+
+	// the second job waits for the only worker
+	j2, err = r.Cancel(context.Background(), j2.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.JobCanceled, j2.Status)
+	_, err = os.Stat(r.spoolFile(j2.ID))
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = r.Cancel(context.Background(), j1.ID)
+	assert.Nil(t, err)
+	j1 = waitJob(t, db, j1.ID, persistence.JobCanceled)
+	assert.Equal(t, int64(1), j1.Records)
+}
+
+func TestResume(t *testing.T) {
+	db := inmem.NewDb()
+	nodes := newTestNodes(t, db)
+	r := newTestRunner(t, db, &testParser{})
+	mtx := db.NewModelTx(context.Background())
+	j1, err := mtx.CreateJob(persistence.Job{NodeID: nodes[0].ID, Path: "/doc1", Parser: "txt", Status: persistence.JobRunning, Records: 1})
+	assert.Nil(t, err)
+	j2, err := mtx.CreateJob(persistence.Job{NodeID: nodes[1].ID, Path: "/doc2", Parser: "txt", Status: persistence.JobPending})
+	assert.Nil(t, err)
+	assert.Nil(t, os.WriteFile(r.spoolFile(j1.ID), []byte("a\nb"), 0o600))
+	assert.Nil(t, os.WriteFile(r.spoolFile(j2.ID), []byte("a"), 0o600))
+
+	assert.Nil(t, r.Init(context.Background()))
+	defer r.Shutdown()
+	j1 = waitJob(t, db, j1.ID, persistence.JobDone)
+	assert.Equal(t, int64(2), j1.Records)
+	j2 = waitJob(t, db, j2.ID, persistence.JobDone)
+	assert.Equal(t, int64(1), j2.Records)
+}
+
+func TestResumeLocked(t *testing.T) {
+	db := inmem.NewDb()
+	nodes := newTestNodes(t, db)
+	r := newTestRunner(t, db, &testParser{})
+	r.cfg.Lease = 100 * time.Millisecond
+	mtx := db.NewModelTx(context.Background())
+	// the first job is run by another live runner, the lock of the second one is expired
+	j1, err := mtx.CreateJob(persistence.Job{NodeID: nodes[0].ID, Path: "/doc1", Parser: "txt", Status: persistence.JobRunning,
+		LockedBy: "other", LockedUntil: time.Now().Add(time.Hour)})
+	assert.Nil(t, err)
+	j2, err := mtx.CreateJob(persistence.Job{NodeID: nodes[1].ID, Path: "/doc2", Parser: "txt", Status: persistence.JobRunning,
+		LockedBy: "other", LockedUntil: time.Now().Add(-time.Second)})
+	assert.Nil(t, err)
+	assert.Nil(t, os.WriteFile(r.spoolFile(j1.ID), []byte("a"), 0o600))
+	assert.Nil(t, os.WriteFile(r.spoolFile(j2.ID), []byte("a"), 0o600))
+
+	assert.Nil(t, r.Init(context.Background()))
+	defer r.Shutdown()
+	j2 = waitJob(t, db, j2.ID, persistence.JobDone)
+	assert.Equal(t, r.owner, j2.LockedBy)
+	time.Sleep(200 * time.Millisecond)
+	j1, err = mtx.GetJob(j1.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.JobRunning, j1.Status)
+	assert.Equal(t, "other", j1.LockedBy)
+
+	_, err = mtx.LockJob(j1.ID, "another", time.Now(), time.Now().Add(time.Hour))
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	_, err = mtx.LockJob(j2.ID, "another", time.Now(), time.Now().Add(time.Hour))
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+}
+
+func TestLockLost(t *testing.T) {
+	db := inmem.NewDb()
+	nodes := newTestNodes(t, db)
+	tp := &testParser{release: make(chan struct{})}
+	r := newTestRunner(t, db, tp)
+	r.cfg.Lease = 100 * time.Millisecond
+	assert.Nil(t, r.Init(context.Background()))
+	defer r.Shutdown()
+
+	job, err := r.Submit(context.Background(), persistence.Job{NodeID: nodes[0].ID, Path: "/doc1", Parser: "txt"}, strings.NewReader("a\nb"))
+	assert.Nil(t, err)
+	assert.Equal(t, r.owner, job.LockedBy)
+	tp.release <- struct{}{}
+	job = waitJob(t, db, job.ID, persistence.JobRunning)
+
+	// the job is canceled by another runner, the runner stops it when it fails to renew the lock
+	job.Status = persistence.JobCanceled
+	assert.Nil(t, db.NewModelTx(context.Background()).UpdateJob(job))
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(r.spoolFile(job.ID))
+		return os.IsNotExist(err)
+	}, 5*time.Second, 10*time.Millisecond)
+	job = waitJob(t, db, job.ID, persistence.JobCanceled)
+	assert.Equal(t, int64(1), job.Records)
+}
+
+func TestCancelBeforeStart(t *testing.T) {
+	db := inmem.NewDb()
+	nodes := newTestNodes(t, db)
+	r := newTestRunner(t, cancelingDb{Db: db}, &testParser{})
+	r.cfg.Lease = 100 * time.Millisecond
+	assert.Nil(t, r.Init(context.Background()))
+	defer r.Shutdown()
+
+	// the job canceled after it was queued is not run, its spooled document is deleted with the lock lost
+	job, err := r.Submit(context.Background(), persistence.Job{NodeID: nodes[0].ID, Path: "/doc1", Parser: "txt"}, strings.NewReader("a\nb"))
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(r.spoolFile(job.ID))
+		return os.IsNotExist(err)
+	}, 5*time.Second, 10*time.Millisecond)
+	job = waitJob(t, db, job.ID, persistence.JobCanceled)
+	assert.Equal(t, int64(0), job.Records)
+	qr, err := db.NewModelTx(context.Background()).QueryIndexRecords(persistence.IndexRecordQuery{NodeID: nodes[0].ID, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(qr.Items))
+}
@@ -0,0 +1,191 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistence
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MaxPrincipalLength is the maximum length of the principal name
+const MaxPrincipalLength = 255
+
+var principalRegExp = regexp.MustCompile(`^[a-zA-Z0-9_.@:\-]+$`)
+
+// permissions are ordered from the lowest to the highest one
+var permissions = []Permission{PermissionRead, PermissionWrite, PermissionAdmin}
+
+func (a ACL) Value() (driver.Value, error) {
+	if a == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(a)
+}
+
+func (a *ACL) Scan(value any) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, &a)
+	case string:
+		return json.Unmarshal([]byte(v), &a)
+	}
+	return fmt.Errorf("not a []byte value in scan")
+}
+
+// JSON returns the ACL JSON object as a string, the empty object is returned for nil
+func (a ACL) JSON() string {
+	if a == nil {
+		return "{}"
+	}
+	b, _ := json.Marshal(a)
+	return string(b)
+}
+
+// Check returns ErrInvalid if the permission is unknown
+func (p Permission) Check() error {
+	if p.level() == 0 {
+		return fmt.Errorf("the permission %q must be one of read, write or admin: %w", p, errors.ErrInvalid)
+	}
+	return nil
+}
+
+// Includes returns whether the permission p includes the permission other
+func (p Permission) Includes(other Permission) bool {
+	l := other.level()
+	return l > 0 && p.level() >= l
+}
+
+// level returns the position of the permission in the permissions list starting from 1, or 0 if it is unknown
+func (p Permission) level() int {
+	for i, pp := range permissions {
+		if pp == p {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// CheckPrincipal returns ErrInvalid if the principal name is empty, too long, or it contains the
+// characters other than the letters, digits and the "_.@:-" ones
+func CheckPrincipal(principal string) error {
+	if len(principal) > MaxPrincipalLength || !principalRegExp.MatchString(principal) {
+		return fmt.Errorf("the principal %q must be 1..%d letters, digits or _.@:- characters: %w", principal, MaxPrincipalLength, errors.ErrInvalid)
+	}
+	return nil
+}
+
+// CheckACLEntry returns ErrInvalid if the principal name is not valid, or the permission is unknown.
+// The empty permission is valid, it removes the principal from the ACL.
+func CheckACLEntry(principal string, perm Permission) error {
+	if err := CheckPrincipal(principal); err != nil {
+		return err
+	}
+	if perm == "" {
+		return nil
+	}
+	return perm.Check()
+}
+
+// Allows returns whether the ACL grants the access
+func (a Access) Allows(acl ACL) bool {
+	for _, p := range a.Principals {
+		if perm, ok := acl[p]; ok && perm.Includes(a.Permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// Grants returns the "permission:principal" entries of the ACL, every permission is expanded to
+// the ones it includes. The search engines keeping their own index store the grants of the nodes
+// and select the accessible ones by the Access.Grants entries.
+func (a ACL) Grants() []string {
+	var res []string
+	for principal, perm := range a {
+		for _, p := range permissions {
+			if perm.Includes(p) {
+				res = append(res, grant(p, principal))
+			}
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Grants returns the "permission:principal" entries, the access is allowed if any of them is
+// among the ACL.Grants. The empty result is returned for the unknown permission.
+func (a Access) Grants() []string {
+	if a.Permission.Check() != nil {
+		return nil
+	}
+	res := make([]string, 0, len(a.Principals))
+	for _, p := range a.Principals {
+		res = append(res, grant(a.Permission, p))
+	}
+	return res
+}
+
+func grant(perm Permission, principal string) string {
+	return string(perm) + ":" + principal
+}
+
+// PqAccessCondition returns the Postgres condition selecting the nodes "n" accessible with the access a
+func PqAccessCondition(a Access) string {
+	return accessCondition("jsonb_each_text", a)
+}
+
+// SqliteAccessCondition returns the SQLite condition selecting the nodes "n" accessible with the access a
+func SqliteAccessCondition(a Access) string {
+	return accessCondition("json_each", a)
+}
+
+// accessCondition returns the condition for the jsonEach function, which expands the JSON object to
+// the key and value rows. The principals are written as the string constants, the quotes are escaped.
+func accessCondition(jsonEach string, a Access) string {
+	var perms []string
+	for _, p := range permissions {
+		if p.Includes(a.Permission) {
+			perms = append(perms, string(p))
+		}
+	}
+	if len(a.Principals) == 0 || len(perms) == 0 {
+		return "false"
+	}
+	var sb strings.Builder
+	sb.WriteString("exists (select 1 from ")
+	sb.WriteString(jsonEach)
+	sb.WriteString("(n.effective_acl) as acl where acl.key in (")
+	writeSqlStrings(&sb, a.Principals)
+	sb.WriteString(") and acl.value in (")
+	writeSqlStrings(&sb, perms)
+	sb.WriteString("))")
+	return sb.String()
+}
+
+func writeSqlStrings(sb *strings.Builder, ss []string) {
+	for i, s := range ss {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("'")
+		sb.WriteString(strings.ReplaceAll(s, "'", "''"))
+		sb.WriteString("'")
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistence
+
+import (
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestPermission(t *testing.T) {
+	assert.Nil(t, PermissionWrite.Check())
+	assert.True(t, errors.Is(Permission("owner").Check(), errors.ErrInvalid))
+	assert.True(t, errors.Is(Permission("").Check(), errors.ErrInvalid))
+
+	assert.True(t, PermissionAdmin.Includes(PermissionRead))
+	assert.True(t, PermissionWrite.Includes(PermissionWrite))
+	assert.False(t, PermissionRead.Includes(PermissionWrite))
+	assert.False(t, PermissionAdmin.Includes(""))
+}
+
+func TestCheckPrincipal(t *testing.T) {
+	assert.Nil(t, CheckPrincipal("alice@example.com"))
+	assert.Nil(t, CheckPrincipal("group:eng-1_a"))
+	assert.True(t, errors.Is(CheckPrincipal(""), errors.ErrInvalid))
+	assert.True(t, errors.Is(CheckPrincipal("a'b"), errors.ErrInvalid))
+	assert.True(t, errors.Is(CheckPrincipal(strings.Repeat("a", MaxPrincipalLength+1)), errors.ErrInvalid))
+}
+
+func TestAccess(t *testing.T) {
+	acl := ACL{"alice": PermissionRead, "eng": PermissionWrite}
+	assert.True(t, Access{Principals: []string{"alice"}, Permission: PermissionRead}.Allows(acl))
+	assert.False(t, Access{Principals: []string{"alice"}, Permission: PermissionWrite}.Allows(acl))
+	assert.True(t, Access{Principals: []string{"alice", "eng"}, Permission: PermissionWrite}.Allows(acl))
+	assert.False(t, Access{Principals: []string{"bob"}, Permission: PermissionRead}.Allows(acl))
+	assert.False(t, Access{Permission: PermissionRead}.Allows(acl))
+
+	assert.Equal(t, "exists (select 1 from jsonb_each_text(n.effective_acl) as acl where acl.key in ('alice', 'o''neil') "+
+		"and acl.value in ('write', 'admin'))", PqAccessCondition(Access{Principals: []string{"alice", "o'neil"}, Permission: PermissionWrite}))
+	assert.Equal(t, "exists (select 1 from json_each(n.effective_acl) as acl where acl.key in ('eng') "+
+		"and acl.value in ('admin'))", SqliteAccessCondition(Access{Principals: []string{"eng"}, Permission: PermissionAdmin}))
+	assert.Equal(t, "false", SqliteAccessCondition(Access{Permission: PermissionRead}))
+
+	assert.Equal(t, []string{"read:alice", "read:eng", "write:eng"}, acl.Grants())
+	assert.Nil(t, ACL{}.Grants())
+	assert.Equal(t, []string{"write:alice", "write:eng"}, Access{Principals: []string{"alice", "eng"}, Permission: PermissionWrite}.Grants())
+	assert.Nil(t, Access{Principals: []string{"alice"}, Permission: "owner"}.Grants())
+}
+
+func TestACLScan(t *testing.T) {
+	v, err := ACL(nil).Value()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("{}"), v)
+
+	var a ACL
+	assert.Nil(t, a.Scan(`{"alice": "read"}`))
+	assert.Equal(t, ACL{"alice": PermissionRead}, a)
+	assert.Equal(t, `{"alice":"read"}`, a.JSON())
+}
@@ -0,0 +1,210 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistence
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/simila-io/simila/pkg/ql"
+	"math"
+	"strings"
+	"time"
+)
+
+func (a Attrs) Value() (driver.Value, error) {
+	if a == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(a)
+}
+
+func (a *Attrs) Scan(value any) error {
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("not a []byte value in scan")
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	// the strings lists are read as []any
+	for k, v := range m {
+		if arr, ok := v.([]any); ok {
+			ss := make([]string, 0, len(arr))
+			for _, e := range arr {
+				s, _ := e.(string)
+				ss = append(ss, s)
+			}
+			m[k] = ss
+		}
+	}
+	*a = m
+	return nil
+}
+
+// JSON returns the attributes JSON object as a string, the empty object is returned for nil
+func (a Attrs) JSON() string {
+	if a == nil {
+		return "{}"
+	}
+	b, _ := json.Marshal(a)
+	return string(b)
+}
+
+// Copy returns the copy of the attributes, the strings lists are copied as well
+func (a Attrs) Copy() Attrs {
+	if a == nil {
+		return nil
+	}
+	res := make(Attrs, len(a))
+	for k, v := range a {
+		if ss, ok := v.([]string); ok {
+			v = append([]string{}, ss...)
+		}
+		res[k] = v
+	}
+	return res
+}
+
+// Kind returns the kind of the values the attribute of the type is compared with in the filter conditions
+func (t AttrType) Kind() ql.ValueKind {
+	switch t {
+	case AttrNumber:
+		return ql.KindNumber
+	case AttrBool:
+		return ql.KindBool
+	case AttrTimestamp:
+		return ql.KindDate
+	}
+	return ql.KindString
+}
+
+// Check returns ErrInvalid if the type is unknown
+func (t AttrType) Check() error {
+	switch t {
+	case AttrString, AttrNumber, AttrBool, AttrTimestamp, AttrStrings:
+		return nil
+	}
+	return fmt.Errorf("the attribute type %q must be one of string, number, bool, timestamp or strings: %w", t, errors.ErrInvalid)
+}
+
+// Check returns ErrInvalid if the attribute declaration is not valid
+func (as AttrSchema) Check() error {
+	if !strings.HasPrefix(as.Prefix, "/") {
+		return fmt.Errorf("the attribute prefix %q must start with /: %w", as.Prefix, errors.ErrInvalid)
+	}
+	if err := ql.CheckAttrName(as.Name); err != nil {
+		return err
+	}
+	return as.Type.Check()
+}
+
+// CheckAttrs checks the attributes of the node with the fqnp against the schemas, it returns the
+// attributes values converted to their types. ErrInvalid is returned if an attribute is not declared
+// for the node, or its value doesn't match the attribute type.
+func CheckAttrs(schemas []AttrSchema, fqnp string, attrs Attrs) (Attrs, error) {
+	path, _ := ToNodePathName(fqnp)
+	res := make(Attrs, len(attrs))
+	for k, v := range attrs {
+		var as *AttrSchema
+		for i := range schemas {
+			if schemas[i].Name == k && strings.HasPrefix(path, schemas[i].Prefix) {
+				as = &schemas[i]
+				break
+			}
+		}
+		if as == nil {
+			return nil, fmt.Errorf("the attribute %q is not declared for the node %q: %w", k, fqnp, errors.ErrInvalid)
+		}
+		av, err := attrValue(as.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("the attribute %q value %v must be of the %s type: %w", k, v, as.Type, err)
+		}
+		res[k] = av
+	}
+	return res, nil
+}
+
+func attrValue(t AttrType, v any) (any, error) {
+	switch t {
+	case AttrString:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case AttrNumber:
+		var f float64
+		switch n := v.(type) {
+		case float64:
+			f = n
+		case float32:
+			f = float64(n)
+		case int:
+			f = float64(n)
+		case int32:
+			f = float64(n)
+		case int64:
+			f = float64(n)
+		case json.Number:
+			var err error
+			if f, err = n.Float64(); err != nil {
+				return nil, errors.ErrInvalid
+			}
+		default:
+			return nil, errors.ErrInvalid
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, errors.ErrInvalid
+		}
+		return f, nil
+	case AttrBool:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case AttrTimestamp:
+		switch ts := v.(type) {
+		case time.Time:
+			return ql.FormatDate(ts), nil
+		case string:
+			d, err := ql.ParseDate(ts)
+			if err != nil {
+				return nil, err
+			}
+			return ql.FormatDate(d), nil
+		}
+	case AttrStrings:
+		switch ss := v.(type) {
+		case []string:
+			return append([]string{}, ss...), nil
+		case []any:
+			res := make([]string, 0, len(ss))
+			for _, e := range ss {
+				s, ok := e.(string)
+				if !ok {
+					return nil, errors.ErrInvalid
+				}
+				res = append(res, s)
+			}
+			return res, nil
+		}
+	}
+	return nil, errors.ErrInvalid
+}
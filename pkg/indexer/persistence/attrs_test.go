@@ -0,0 +1,58 @@
+package persistence
+
+import (
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCheckAttrs(t *testing.T) {
+	schemas := []AttrSchema{
+		{Prefix: "/", Name: "pages", Type: AttrNumber},
+		{Prefix: "/docs/", Name: "published", Type: AttrTimestamp},
+		{Prefix: "/docs/", Name: "authors", Type: AttrStrings},
+		{Prefix: "/docs/", Name: "draft", Type: AttrBool},
+		{Prefix: "/docs/", Name: "lang", Type: AttrString},
+	}
+	attrs, err := CheckAttrs(schemas, "/docs/a.pdf", Attrs{"pages": 10, "published": "2024-01-02", "authors": []any{"a", "b"},
+		"draft": false, "lang": "en"})
+	assert.Nil(t, err)
+	assert.Equal(t, Attrs{"pages": 10.0, "published": "2024-01-02T00:00:00.000Z", "authors": []string{"a", "b"}, "draft": false, "lang": "en"}, attrs)
+	attrs, err = CheckAttrs(schemas, "/a.pdf", Attrs{"pages": 1.5})
+	assert.Nil(t, err)
+	assert.Equal(t, Attrs{"pages": 1.5}, attrs)
+	attrs, err = CheckAttrs(schemas, "/docs/b.pdf", Attrs{"published": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)})
+	assert.Nil(t, err)
+	assert.Equal(t, Attrs{"published": "2024-01-02T03:04:05.000Z"}, attrs)
+
+	for _, a := range []Attrs{{"published": "2024-01-02"}, {"pages": "10"}, {"unknown": 1}} {
+		_, err = CheckAttrs(schemas, "/a.pdf", a)
+		assert.True(t, errors.Is(err, errors.ErrInvalid), a)
+	}
+	for _, a := range []Attrs{{"published": "yesterday"}, {"authors": []any{"a", 1}}, {"draft": "true"}, {"lang": 1}, {"pages": nil}} {
+		_, err = CheckAttrs(schemas, "/docs/a.pdf", a)
+		assert.True(t, errors.Is(err, errors.ErrInvalid), a)
+	}
+}
+
+func TestAttrsScan(t *testing.T) {
+	v, err := Attrs(nil).Value()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("{}"), v)
+	v, err = Attrs{"a": []string{"x"}, "b": 1.5}.Value()
+	assert.Nil(t, err)
+
+	var a Attrs
+	assert.Nil(t, a.Scan(v))
+	assert.Equal(t, Attrs{"a": []string{"x"}, "b": 1.5}, a)
+	assert.Nil(t, a.Scan(`{"c": true}`))
+	assert.Equal(t, Attrs{"c": true}, a)
+}
+
+func TestAttrSchemaCheck(t *testing.T) {
+	assert.Nil(t, AttrSchema{Prefix: "/", Name: "a", Type: AttrStrings}.Check())
+	assert.True(t, errors.Is(AttrSchema{Prefix: "a/", Name: "a", Type: AttrNumber}.Check(), errors.ErrInvalid))
+	assert.True(t, errors.Is(AttrSchema{Prefix: "/", Name: "a-b", Type: AttrNumber}.Check(), errors.ErrInvalid))
+	assert.True(t, errors.Is(AttrSchema{Prefix: "/", Name: "a", Type: "date"}.Check(), errors.ErrInvalid))
+}
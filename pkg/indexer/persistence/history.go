@@ -0,0 +1,85 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistence
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"maps"
+	"sort"
+	"time"
+)
+
+// DiffIndexRecords returns the difference between the node records as they were at the from
+// and the to times. The zero to time stands for the current node records.
+func DiffIndexRecords(mtx ModelTx, nodeID int64, from, to time.Time) (IndexRecordsDiff, error) {
+	if from.IsZero() {
+		return IndexRecordsDiff{}, fmt.Errorf("the from time must be specified: %w", errors.ErrInvalid)
+	}
+	fromRecs, err := queryAllIndexRecords(mtx, IndexRecordQuery{NodeID: nodeID, AsOf: from})
+	if err != nil {
+		return IndexRecordsDiff{}, err
+	}
+	toRecs, err := queryAllIndexRecords(mtx, IndexRecordQuery{NodeID: nodeID, AsOf: to})
+	if err != nil {
+		return IndexRecordsDiff{}, err
+	}
+	return diffIndexRecords(fromRecs, toRecs), nil
+}
+
+func queryAllIndexRecords(mtx ModelTx, query IndexRecordQuery) ([]IndexRecord, error) {
+	var res []IndexRecord
+	query.Limit = 1000
+	for {
+		qr, err := mtx.QueryIndexRecords(query)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, qr.Items...)
+		if qr.NextID == "" {
+			return res, nil
+		}
+		query.FromID = qr.NextID
+	}
+}
+
+// diffIndexRecords compares the records by their IDs, the result lists are ordered by the records IDs
+func diffIndexRecords(from, to []IndexRecord) IndexRecordsDiff {
+	var res IndexRecordsDiff
+	fromByID := make(map[string]IndexRecord, len(from))
+	for _, r := range from {
+		fromByID[r.ID] = r
+	}
+	for _, r := range to {
+		old, ok := fromByID[r.ID]
+		if !ok {
+			res.Added = append(res.Added, r)
+			continue
+		}
+		delete(fromByID, r.ID)
+		if old.Segment != r.Segment || !bytes.Equal(old.Vector, r.Vector) || old.Format != r.Format || old.RankMult != r.RankMult ||
+			!maps.Equal(old.Meta, r.Meta) {
+			res.Changed = append(res.Changed, IndexRecordChange{From: old, To: r})
+		}
+	}
+	for _, r := range fromByID {
+		res.Removed = append(res.Removed, r)
+	}
+	sort.Slice(res.Added, func(i, j int) bool { return res.Added[i].ID < res.Added[j].ID })
+	sort.Slice(res.Removed, func(i, j int) bool { return res.Removed[i].ID < res.Removed[j].ID })
+	sort.Slice(res.Changed, func(i, j int) bool { return res.Changed[i].To.ID < res.Changed[j].To.ID })
+	return res
+}
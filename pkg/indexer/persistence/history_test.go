@@ -0,0 +1,44 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistence
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDiffIndexRecords(t *testing.T) {
+	from := []IndexRecord{
+		{ID: "1", Segment: "aaa", Format: "txt", RankMult: 1.0},
+		{ID: "2", Segment: "bbb", Format: "txt", RankMult: 1.0},
+		{ID: "3", Segment: "ccc", Format: "txt", RankMult: 1.0},
+		{ID: "4", Segment: "ddd", Format: "txt", RankMult: 1.0},
+	}
+	to := []IndexRecord{
+		{ID: "5", Segment: "eee", Format: "txt", RankMult: 1.0},
+		{ID: "4", Segment: "ddd", Format: "txt", RankMult: 2.0},
+		{ID: "2", Segment: "bbb", Format: "txt", RankMult: 1.0},
+		{ID: "1", Segment: "aa", Format: "txt", RankMult: 1.0},
+	}
+	d := diffIndexRecords(from, to)
+	assert.Equal(t, []IndexRecord{to[0]}, d.Added)
+	assert.Equal(t, []IndexRecord{from[2]}, d.Removed)
+	assert.Equal(t, []IndexRecordChange{{From: from[0], To: to[3]}, {From: from[3], To: to[1]}}, d.Changed)
+
+	d = diffIndexRecords(from, from)
+	assert.Nil(t, d.Added)
+	assert.Nil(t, d.Removed)
+	assert.Nil(t, d.Changed)
+}
@@ -0,0 +1,378 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmem
+
+import (
+	"fmt"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/ql"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type (
+	// filter evaluates the filter conditions AST against the in-memory objects. The
+	// evaluation follows the SQL three-valued logic, so a missing tag or the format of a node
+	// without records are NULL values, which never match a comparison.
+	filter struct {
+		expr *ql.Expression
+	}
+
+	// fcObject is the object the filter conditions are evaluated for. The rec
+	// is nil when the node doesn't have index records
+	fcObject struct {
+		node persistence.Node
+		rec  *persistence.IndexRecord
+	}
+
+	// value is the result of a param evaluation
+	value struct {
+		null  bool
+		isNum bool
+		num   float64
+		str   string
+		arr   []value
+		// b is the result of the functions returning a condition (prefix())
+		b tri
+	}
+
+	// tri is a three-valued logic value
+	tri int
+)
+
+const (
+	triFalse tri = iota
+	triNull
+	triTrue
+)
+
+// fcValidator is used to check the filter conditions the same way they are checked by the
+// SQL implementations
+var fcValidator = ql.NewTranslator(ql.PqFilterConditionsDialect)
+
+func newFilter(fc string) (filter, error) {
+	e, err := ql.Parse(fc)
+	if err != nil || e == nil {
+		return filter{}, err
+	}
+	var sb strings.Builder
+	if err = fcValidator.Expression2Sql(&sb, e); err != nil {
+		return filter{}, fmt.Errorf("failed to translate expression=%q: %w", fc, err)
+	}
+	return filter{expr: e}, nil
+}
+
+// match returns true if the object matches the filter. Empty filter matches everything.
+func (f filter) match(o fcObject) bool {
+	if f.expr == nil {
+		return true
+	}
+	return evalExpression(f.expr, o) == triTrue
+}
+
+func evalExpression(e *ql.Expression, o fcObject) tri {
+	res := triFalse
+	for _, oc := range e.Or {
+		res = or(res, evalOrCondition(oc, o))
+		if res == triTrue {
+			break
+		}
+	}
+	return res
+}
+
+func evalOrCondition(oc *ql.OrCondition, o fcObject) tri {
+	res := triTrue
+	for _, xc := range oc.And {
+		res = and(res, evalXCondition(xc, o))
+		if res == triFalse {
+			break
+		}
+	}
+	return res
+}
+
+func evalXCondition(xc *ql.XCondition, o fcObject) tri {
+	var res tri
+	if xc.Expr != nil {
+		res = evalExpression(xc.Expr, o)
+	} else {
+		res = evalCondition(xc.Cond, o)
+	}
+	if xc.Not {
+		return not(res)
+	}
+	return res
+}
+
+func evalCondition(c *ql.Condition, o fcObject) tri {
+	if f := c.FirstParam.Function; f != nil && f.Name == "attr" {
+		return evalAttrCondition(c, o)
+	}
+	v1 := evalParam(&c.FirstParam, o)
+	if c.Op == "" {
+		if v1.null {
+			return triNull
+		}
+		return v1.b
+	}
+	return evalOp(strings.ToUpper(c.Op), v1, evalParam(c.SecondParam, o))
+}
+
+// evalAttrCondition evaluates the attr() condition the same way as the SQL dialects do: the attribute
+// value is compared, if it has the type of the condition kind, and the strings lists match the =, !=
+// and IN operations, if they contain the value.
+func evalAttrCondition(c *ql.Condition, o fcObject) tri {
+	ac, err := ql.ParseAttrCondition(*c)
+	if err != nil {
+		return triNull
+	}
+	if ac.Op == "IN" && len(ac.Values) == 0 {
+		return triFalse
+	}
+	av, ok := o.node.Attrs[ac.Name]
+	if !ok {
+		return triNull
+	}
+	if ac.Kind == ql.KindString && (ac.Op == "=" || ac.Op == "!=" || ac.Op == "IN") {
+		var ss []string
+		switch v := av.(type) {
+		case string:
+			ss = []string{v}
+		case []string:
+			ss = v
+		}
+		res := triFalse
+		for _, s := range ss {
+			if slices.Contains(ac.Values, s) {
+				res = triTrue
+				break
+			}
+		}
+		if ac.Op == "!=" {
+			return not(res)
+		}
+		return res
+	}
+
+	var v1 value
+	var kind ql.ValueKind
+	switch v := av.(type) {
+	case float64:
+		v1, kind = value{isNum: true, num: v}, ql.KindNumber
+	case bool:
+		v1, kind = value{str: strconv.FormatBool(v)}, ql.KindBool
+	case string:
+		v1, kind = value{str: v}, ql.KindString
+	}
+	// the timestamps are kept as strings
+	if kind != ac.Kind && (kind != ql.KindString || ac.Kind != ql.KindDate) {
+		return triNull
+	}
+	vals := make([]value, 0, len(ac.Values))
+	for _, s := range ac.Values {
+		if ac.Kind == ql.KindNumber {
+			n, _ := strconv.ParseFloat(s, 64)
+			vals = append(vals, value{isNum: true, num: n})
+		} else {
+			vals = append(vals, value{str: s})
+		}
+	}
+	if ac.Op == "IN" {
+		return evalOp(ac.Op, v1, value{arr: vals})
+	}
+	return evalOp(ac.Op, v1, vals[0])
+}
+
+// evalOp evaluates the operation op (in upper case) for the values v1 and v2
+func evalOp(op string, v1, v2 value) tri {
+	if v1.null || v2.null {
+		return triNull
+	}
+	switch op {
+	case "=":
+		return toTri(compare(v1, v2) == 0)
+	case "!=":
+		return toTri(compare(v1, v2) != 0)
+	case "<":
+		return toTri(compare(v1, v2) < 0)
+	case ">":
+		return toTri(compare(v1, v2) > 0)
+	case "<=":
+		return toTri(compare(v1, v2) <= 0)
+	case ">=":
+		return toTri(compare(v1, v2) >= 0)
+	case "IN":
+		for _, v := range v2.arr {
+			if compare(v1, v) == 0 {
+				return triTrue
+			}
+		}
+		return triFalse
+	case "LIKE":
+		return toTri(likeToRegexp(v2.str).MatchString(v1.str))
+	}
+	return triNull
+}
+
+func evalParam(p *ql.Param, o fcObject) value {
+	if p.Const != nil {
+		return constValue(p.Const)
+	}
+	if p.Array != nil {
+		arr := make([]value, 0, len(p.Array))
+		for _, c := range p.Array {
+			arr = append(arr, constValue(c))
+		}
+		return value{arr: arr}
+	}
+	if p.Function != nil {
+		switch p.Function.Name {
+		case "tag":
+			v, ok := o.node.Tags[p.Function.Params[0].Const.String]
+			if !ok {
+				return value{null: true}
+			}
+			return value{str: v}
+		case "inheritedTag":
+			v, ok := o.node.EffectiveTags[p.Function.Params[0].Const.String]
+			if !ok {
+				return value{null: true}
+			}
+			return value{str: v}
+		case "rmeta":
+			if o.rec == nil {
+				return value{null: true}
+			}
+			v, ok := o.rec.Meta[p.Function.Params[0].Const.String]
+			if !ok {
+				return value{null: true}
+			}
+			return value{str: v}
+		case "prefix":
+			s := evalParam(p.Function.Params[0], o)
+			pfx := evalParam(p.Function.Params[1], o)
+			if s.null || pfx.null {
+				return value{null: true}
+			}
+			return value{b: toTri(strings.HasPrefix(s.String(), pfx.String()))}
+		case "date":
+			d, err := ql.ParseDate(p.Function.Params[0].Const.String)
+			if err != nil {
+				return value{null: true}
+			}
+			return value{str: ql.FormatDate(d)}
+		}
+		return value{null: true}
+	}
+	switch p.Identifier {
+	case "path":
+		return value{str: o.node.Path}
+	case "node":
+		return value{str: o.node.Name}
+	case "true", "false":
+		return value{str: p.Identifier}
+	case "format":
+		if o.rec == nil {
+			return value{null: true}
+		}
+		return value{str: o.rec.Format}
+	}
+	return value{null: true}
+}
+
+func constValue(c *ql.Const) value {
+	if c.IsString() {
+		return value{str: c.String}
+	}
+	return value{isNum: true, num: float64(c.Number)}
+}
+
+// String returns the string representation of the value
+func (v value) String() string {
+	if v.isNum {
+		return strconv.FormatFloat(v.num, 'f', -1, 64)
+	}
+	return v.str
+}
+
+// compare compares the values numerically if both of them are numbers, or
+// lexicographically otherwise
+func compare(v1, v2 value) int {
+	n1, ok1 := v1.number()
+	n2, ok2 := v2.number()
+	if ok1 && ok2 && (v1.isNum || v2.isNum) {
+		switch {
+		case n1 < n2:
+			return -1
+		case n1 > n2:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(v1.String(), v2.String())
+}
+
+func (v value) number() (float64, bool) {
+	if v.isNum {
+		return v.num, true
+	}
+	n, err := strconv.ParseFloat(v.str, 64)
+	return n, err == nil
+}
+
+func likeToRegexp(pattern string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("(?s)^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			sb.WriteString(".*")
+		case '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
+
+func toTri(b bool) tri {
+	if b {
+		return triTrue
+	}
+	return triFalse
+}
+
+func and(a, b tri) tri {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func or(a, b tri) tri {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func not(a tri) tri {
+	return triTrue - a
+}
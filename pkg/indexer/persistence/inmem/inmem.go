@@ -0,0 +1,1745 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmem
+
+import (
+	"context"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/acquirecloud/golibs/logging"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"maps"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SearchEngine is the name of the in-memory search engine, which
+// may be used in the server configuration
+const SearchEngine = "inmem"
+
+type (
+	// Db implements persistence.Db keeping all the objects in memory. The Db is
+	// supposed to be used in embedded configurations and tests, where no external
+	// database is available.
+	//
+	// All the transactions are serialized by the Db lock, which is not re-entrant:
+	// while a transaction is active, any other transaction or a call made through
+	// another ModelTx blocks until the active one is committed or rolled back. So,
+	// the code must not use a second ModelTx in the goroutine with an active
+	// transaction, it deadlocks otherwise.
+	Db struct {
+		logger logging.Logger
+
+		// lock guards the st, it is held by an active transaction until
+		// the transaction is committed or rolled back
+		lock sync.Mutex
+		st   *state
+	}
+
+	state struct {
+		formats map[string]persistence.Format
+		// nodes keeps the nodes by their IDs. The node Name field contains the fqnp,
+		// the same way as it is stored in SQL databases
+		nodes map[int64]persistence.Node
+		// names maps the fqnp to the node ID
+		names map[string]int64
+		// records contains the index records of the nodes by the node IDs
+		records map[int64]map[string]persistence.IndexRecord
+		// history contains the index records revisions of the nodes by the node IDs
+		history map[int64][]persistence.IndexRecordRevision
+		// changes is the changelog ordered by the change IDs
+		changes  []persistence.Change
+		webhooks map[string]persistence.Webhook
+		// idempotencyKeys contains the idempotency keys by their values
+		idempotencyKeys map[string]persistence.IdempotencyKey
+		// jobs contains the jobs by their IDs
+		jobs map[int64]persistence.Job
+		// attrSchemas contains the attributes declarations by their prefixes and names
+		attrSchemas map[[2]string]persistence.AttrSchema
+		// deadLetters contains the dead letters ordered by their IDs
+		deadLetters      []persistence.DeadLetter
+		lastID           int64
+		lastRevision     int64
+		lastChangeID     int64
+		lastDeadLetterID int64
+		lastJobID        int64
+	}
+
+	// tx implements the Tx interface
+	tx struct {
+		ctx context.Context // context for all the operations within the tx
+		db  *Db             // never nil
+		// active is true when the transaction is started, the db.lock is held then
+		active bool
+		// undo keeps the functions to be called in the reverse order when the
+		// changes made within the transaction should be rolled back
+		undo []func()
+	}
+
+	// modelTx is a helper to persist persistence objects ModelTx
+	modelTx struct {
+		*tx // the active transaction, never nil for the object
+	}
+)
+
+// NewDb creates the new in-memory Db with the default "txt" format
+func NewDb() *Db {
+	now := time.Now()
+	return &Db{
+		logger: logging.NewLogger("db.inmem"),
+		st: &state{
+			formats:         map[string]persistence.Format{"txt": {ID: "txt", Basis: []byte("{}"), CreatedAt: now, UpdatedAt: now}},
+			nodes:           make(map[int64]persistence.Node),
+			names:           make(map[string]int64),
+			records:         make(map[int64]map[string]persistence.IndexRecord),
+			history:         make(map[int64][]persistence.IndexRecordRevision),
+			webhooks:        make(map[string]persistence.Webhook),
+			idempotencyKeys: make(map[string]persistence.IdempotencyKey),
+			jobs:            make(map[int64]persistence.Job),
+			attrSchemas:     make(map[[2]string]persistence.AttrSchema),
+		},
+	}
+}
+
+// Init implements linker.Initializer interface
+func (d *Db) Init(ctx context.Context) error {
+	d.logger.Infof("Initializing...")
+	return nil
+}
+
+// Shutdown implements linker.Shutdowner interface
+func (d *Db) Shutdown() {
+	d.logger.Infof("Shutdown")
+}
+
+// NewModelTx returns the new ModelTx object. See the Db lock notes, the nested
+// transactions in the same goroutine are not supported.
+func (d *Db) NewModelTx(ctx context.Context) persistence.ModelTx {
+	return &modelTx{tx: d.NewTx(ctx).(*tx)}
+}
+
+// NewTx returns the new Tx object
+func (d *Db) NewTx(ctx context.Context) persistence.Tx {
+	return &tx{ctx: ctx, db: d}
+}
+
+// ============================== tx ====================================
+
+// MustBegin is a part of the Tx interface
+func (t *tx) MustBegin() {
+	_ = t.Commit()
+	t.db.lock.Lock()
+	t.active = true
+}
+
+// MustBeginSerializable is a part of the Tx interface. All the in-memory
+// transactions are serializable, so it is the same as MustBegin
+func (t *tx) MustBeginSerializable() {
+	t.MustBegin()
+}
+
+// Rollback rolls the transaction back (if started)
+func (t *tx) Rollback() error {
+	if !t.active {
+		return nil
+	}
+	t.rollback()
+	t.active = false
+	t.db.lock.Unlock()
+	return nil
+}
+
+// Commit commits the transaction (if started)
+func (t *tx) Commit() error {
+	if !t.active {
+		return nil
+	}
+	t.undo = nil
+	t.active = false
+	t.db.lock.Unlock()
+	return nil
+}
+
+// ExecScript is not supported by the in-memory implementation
+func (t *tx) ExecScript(sqlScript string) error {
+	return fmt.Errorf("could not execute %s, scripts are not supported: %w", sqlScript, errors.ErrUnimplemented)
+}
+
+// exec runs fn against the state. If the transaction is not started, fn is
+// executed atomically as a separate transaction.
+func (t *tx) exec(fn func(st *state) error) error {
+	if t.active {
+		return fn(t.db.st)
+	}
+	t.db.lock.Lock()
+	defer t.db.lock.Unlock()
+	if err := fn(t.db.st); err != nil {
+		t.rollback()
+		return err
+	}
+	t.undo = nil
+	return nil
+}
+
+func (t *tx) rollback() {
+	for i := len(t.undo) - 1; i >= 0; i-- {
+		t.undo[i]()
+	}
+	t.undo = nil
+}
+
+func (t *tx) onRollback(fn func()) {
+	t.undo = append(t.undo, fn)
+}
+
+// ============================== modelTx ====================================
+
+func (m *modelTx) CreateFormat(format persistence.Format) (persistence.Format, error) {
+	if len(format.ID) == 0 {
+		return persistence.Format{}, fmt.Errorf("format ID must be non-empty: %w", errors.ErrInvalid)
+	}
+	if len(format.Basis) == 0 {
+		format.Basis = []byte("{}")
+	}
+	format.CreatedAt = time.Now()
+	format.UpdatedAt = format.CreatedAt
+	err := m.exec(func(st *state) error {
+		if _, ok := st.formats[format.ID]; ok {
+			return fmt.Errorf("format with ID=%s already exists: %w", format.ID, errors.ErrExist)
+		}
+		m.putFormat(st, format)
+		return nil
+	})
+	if err != nil {
+		return persistence.Format{}, err
+	}
+	return format, nil
+}
+
+func (m *modelTx) GetFormat(ID string) (persistence.Format, error) {
+	var f persistence.Format
+	err := m.exec(func(st *state) error {
+		var ok bool
+		if f, ok = st.formats[ID]; !ok {
+			return errors.ErrNotExist
+		}
+		return nil
+	})
+	return f, err
+}
+
+func (m *modelTx) DeleteFormat(ID string) error {
+	return m.exec(func(st *state) error {
+		f, ok := st.formats[ID]
+		if !ok {
+			return errors.ErrNotExist
+		}
+		for _, recs := range st.records {
+			for _, r := range recs {
+				if r.Format == ID {
+					return fmt.Errorf("format with ID=%s is referenced by index records: %w", ID, errors.ErrConflict)
+				}
+			}
+		}
+		for _, revs := range st.history {
+			for _, r := range revs {
+				if r.Format == ID {
+					return fmt.Errorf("format with ID=%s is referenced by index records revisions: %w", ID, errors.ErrConflict)
+				}
+			}
+		}
+		delete(st.formats, ID)
+		m.onRollback(func() { st.formats[ID] = f })
+		return nil
+	})
+}
+
+func (m *modelTx) ListFormats() ([]persistence.Format, error) {
+	var res []persistence.Format
+	err := m.exec(func(st *state) error {
+		for _, f := range st.formats {
+			res = append(res, f)
+		}
+		sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+		return nil
+	})
+	return res, err
+}
+
+func (m *modelTx) CreateNodes(nodes ...persistence.Node) ([]persistence.Node, error) {
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+	var res []persistence.Node
+	now := time.Now()
+	err := m.exec(func(st *state) error {
+		for i, n := range nodes {
+			if len(n.Path) == 0 {
+				return fmt.Errorf("node path for item=%d must be specified: %w", i, errors.ErrInvalid)
+			}
+			n.Path = persistence.ToNodePath(n.Path)
+			var err error
+			n.Name, err = persistence.CleanName(n.Name)
+			if err != nil {
+				return err
+			}
+			if n.Tags == nil {
+				n.Tags = make(persistence.Tags)
+			}
+			n.Attrs = n.Attrs.Copy()
+			if n.Attrs == nil {
+				n.Attrs = make(persistence.Attrs)
+			}
+			n.ACL = copyACL(n.ACL)
+			n.Name = persistence.ConcatPath(n.Path, n.Name)
+			if id, ok := st.names[n.Name]; ok {
+				// the trashed node keeps its name until it is restored or purged
+				if st.nodes[id].DeletedAt != nil {
+					return fmt.Errorf("node with name=%s is in the trash, it must be restored or purged first: %w", n.Name, errors.ErrExist)
+				}
+				return fmt.Errorf("node with name=%s already exists: %w", n.Name, errors.ErrExist)
+			}
+			lastID := st.lastID
+			st.lastID++
+			m.onRollback(func() { st.lastID = lastID })
+			n.ID = st.lastID
+			n = st.inherit(n)
+			n.CreatedAt = now
+			n.UpdatedAt = now
+			n.Version = 1
+			m.putNode(st, n)
+			m.logNodeChange(st, persistence.ChangeOpCreate, n, now)
+			res = append(res, nodeAfterRead(n))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (m *modelTx) ListNodes(query persistence.ListNodesQuery) ([]persistence.Node, error) {
+	f, err := newFilter(query.FilterConditions)
+	if err != nil {
+		return nil, err
+	}
+	var res []persistence.Node
+	err = m.exec(func(st *state) error {
+		for _, n := range st.sortedNodes() {
+			if n.DeletedAt == nil && accessible(query.Access, n) && f.match(fcObject{node: n}) {
+				res = append(res, nodeAfterRead(n))
+			}
+		}
+		return nil
+	})
+	return page(res, query.Offset, query.Limit), err
+}
+
+// ListAllNodesByPath returns all nodes for the path. For example for the path="/a/b/doc.txt"
+// the result nodes will be {<"/", "a">, {<"/a/", "b">, <"/a/b/", "doc.txt">}
+func (m *modelTx) ListAllNodesByPath(path string) ([]persistence.Node, error) {
+	var res []persistence.Node
+	err := m.exec(func(st *state) error {
+		pathSoFar := "/"
+		for _, n := range persistence.SplitPath(path) {
+			pathSoFar = persistence.ConcatPath(pathSoFar, n)
+			if n, ok := st.liveNode(pathSoFar); ok {
+				res = append(res, nodeAfterRead(n))
+			}
+		}
+		return nil
+	})
+	return res, err
+}
+
+func (m *modelTx) GetNode(fqnp string) (persistence.Node, error) {
+	var node persistence.Node
+	err := m.exec(func(st *state) error {
+		n, ok := st.liveNode(fqnp)
+		if !ok {
+			return errors.ErrNotExist
+		}
+		node = nodeAfterRead(n)
+		return nil
+	})
+	return node, err
+}
+
+func (m *modelTx) LockNode(fqnp string) (persistence.Node, error) {
+	return m.GetNode(fqnp)
+}
+
+func (m *modelTx) UpdateNode(node persistence.Node) error {
+	if node.ID == 0 {
+		return fmt.Errorf("node ID must be specified: %w", errors.ErrInvalid)
+	}
+	if len(node.Tags) == 0 && node.Attrs == nil {
+		return nil
+	}
+	return m.exec(func(st *state) error {
+		n, ok := st.nodes[node.ID]
+		if !ok || n.DeletedAt != nil {
+			return errors.ErrNotExist
+		}
+		if len(node.Tags) > 0 {
+			n.Tags = copyTags(node.Tags)
+		}
+		if node.Attrs != nil {
+			n.Attrs = node.Attrs.Copy()
+		}
+		n.UpdatedAt = time.Now()
+		n.Version++
+		m.putNode(st, n)
+		if len(node.Tags) > 0 {
+			m.updateInherited(st, n.Name)
+		}
+		m.logNodeChange(st, persistence.ChangeOpUpdate, st.nodes[n.ID], n.UpdatedAt)
+		return nil
+	})
+}
+
+func (m *modelTx) PatchNodes(query persistence.PatchNodesQuery) (int64, error) {
+	if err := query.Check(); err != nil {
+		return 0, err
+	}
+	f, err := newFilter(query.FilterConditions)
+	if err != nil {
+		return 0, err
+	}
+	var cnt int64
+	err = m.exec(func(st *state) error {
+		patched := make(map[int64]persistence.Node)
+		for _, n := range st.nodes {
+			if n.DeletedAt != nil || (query.ID != 0 && n.ID != query.ID) || (query.ID == 0 && !st.matchNode(f, n)) ||
+				!accessible(query.Access, n) {
+				continue
+			}
+			patched[n.ID] = n
+		}
+		if len(patched) == 0 {
+			return errors.ErrNotExist
+		}
+		now := time.Now()
+		for _, n := range sortedByID(patched) {
+			n.Tags = query.Patch.Apply(n.Tags)
+			if query.Attrs != nil {
+				n.Attrs = query.Attrs.Copy()
+			}
+			n.UpdatedAt = now
+			n.Version++
+			m.putNode(st, n)
+		}
+		// the effective tags are updated when all the nodes are patched, because the patched nodes may be nested
+		for _, n := range sortedByID(patched) {
+			m.updateInherited(st, n.Name)
+			m.logNodeChange(st, persistence.ChangeOpUpdate, st.nodes[n.ID], now)
+		}
+		cnt = int64(len(patched))
+		return nil
+	})
+	return cnt, err
+}
+
+func (m *modelTx) SetNodeACL(fqnp, principal string, perm persistence.Permission) (persistence.Node, error) {
+	if err := persistence.CheckACLEntry(principal, perm); err != nil {
+		return persistence.Node{}, err
+	}
+	var res persistence.Node
+	err := m.exec(func(st *state) error {
+		n, ok := st.liveNode(fqnp)
+		if !ok {
+			return errors.ErrNotExist
+		}
+		n.ACL = copyACL(n.ACL)
+		if perm == "" {
+			delete(n.ACL, principal)
+		} else {
+			n.ACL[principal] = perm
+		}
+		m.putNode(st, n)
+		m.updateInherited(st, n.Name)
+		res = nodeAfterRead(st.nodes[n.ID])
+		return nil
+	})
+	if err != nil {
+		return persistence.Node{}, err
+	}
+	return res, nil
+}
+
+func (m *modelTx) MoveNode(from, to string) (persistence.Node, error) {
+	from, to = persistence.ConcatPath(from, ""), persistence.ConcatPath(to, "")
+	var res persistence.Node
+	err := m.exec(func(st *state) error {
+		node, ok := st.liveNode(from)
+		if !ok {
+			return errors.ErrNotExist
+		}
+		id := node.ID
+		res = nodeAfterRead(node)
+		if from == to {
+			return nil
+		}
+		path, err := st.targetPath(from, to)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, n := range st.sortedNodes() {
+			if n.ID != id && !strings.HasPrefix(n.Path, from+"/") {
+				continue
+			}
+			delete(st.names, n.Name)
+			if n.ID == id {
+				n.Path = path
+			} else {
+				n.Path = to + n.Path[len(from):]
+			}
+			n.Name = to + n.Name[len(from):]
+			// the nodes are ordered by their fqnps, so the node ancestors are already moved
+			n = st.inherit(n)
+			n.UpdatedAt = now
+			n.Version++
+			m.putNode(st, n)
+			m.logNodeChange(st, persistence.ChangeOpUpdate, n, now)
+			if n.ID == id {
+				res = nodeAfterRead(n)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return persistence.Node{}, err
+	}
+	return res, nil
+}
+
+func (m *modelTx) CopyNodes(query persistence.CopyNodesQuery) (persistence.CopyNodesResult, error) {
+	from, to := persistence.ConcatPath(query.From, ""), persistence.ConcatPath(query.To, "")
+	var res persistence.CopyNodesResult
+	err := m.exec(func(st *state) error {
+		node, ok := st.liveNode(from)
+		if !ok {
+			return errors.ErrNotExist
+		}
+		id := node.ID
+		path, err := st.targetPath(from, to)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, n := range st.sortedNodes() {
+			if (n.ID != id && !strings.HasPrefix(n.Path, from+"/")) || n.DeletedAt != nil {
+				continue
+			}
+			recs := st.records[n.ID]
+			if n.ID == id {
+				n.Path = path
+			} else {
+				n.Path = to + n.Path[len(from):]
+			}
+			n.Name = to + n.Name[len(from):]
+			n.Tags = copyTags(n.Tags)
+			n.Attrs = n.Attrs.Copy()
+			// the copies inherit the destination ACL instead of the grants of the originals
+			n.ACL = nil
+			if query.Tags != nil {
+				n.Tags = copyTags(query.Tags)
+			}
+			lastID := st.lastID
+			st.lastID++
+			m.onRollback(func() { st.lastID = lastID })
+			n.ID = st.lastID
+			// the nodes are ordered by their fqnps, so the node ancestors are already copied
+			n = st.inherit(n)
+			n.CreatedAt = now
+			n.UpdatedAt = now
+			n.Version = 1
+			m.putNode(st, n)
+			m.logNodeChange(st, persistence.ChangeOpCreate, n, now)
+			for _, r := range sortedRecords(recs) {
+				r.NodeID = n.ID
+				r.CreatedAt = now
+				r.UpdatedAt = now
+				r.Version = 1
+				m.putRecord(st, r)
+				m.logRecordChange(st, persistence.ChangeOpCreate, r, now)
+				res.Records++
+			}
+			if n.Name == to {
+				res.Node = nodeAfterRead(n)
+			}
+			res.Nodes++
+		}
+		return nil
+	})
+	if err != nil {
+		return persistence.CopyNodesResult{}, err
+	}
+	return res, nil
+}
+
+func (m *modelTx) DeleteNodes(query persistence.DeleteNodesQuery) error {
+	f, err := newFilter(query.FilterConditions)
+	if err != nil {
+		return err
+	}
+	return m.exec(func(st *state) error {
+		toDelete, forceRequired, err := st.deleteTargets(f, query)
+		if err != nil {
+			return err
+		}
+		if forceRequired && !query.Force {
+			return fmt.Errorf("matched nodes have children that do not match the condition (force=%t): %w",
+				query.Force, errors.ErrConflict)
+		}
+		if len(toDelete) == 0 {
+			return errors.ErrNotExist
+		}
+		now := time.Now()
+		// the records of the deleted nodes are deleted with them, so the node changes only are logged
+		for _, n := range sortedByID(toDelete) {
+			m.logNodeChange(st, persistence.ChangeOpDelete, n, now)
+			if query.Trash {
+				n.DeletedAt = &now
+				m.putNode(st, n)
+				continue
+			}
+			m.deleteNode(st, n)
+		}
+		return nil
+	})
+}
+
+func (m *modelTx) PreviewDeleteNodes(query persistence.DeleteNodesQuery) (persistence.DeleteNodesPreview, error) {
+	f, err := newFilter(query.FilterConditions)
+	if err != nil {
+		return persistence.DeleteNodesPreview{}, err
+	}
+	var res persistence.DeleteNodesPreview
+	err = m.exec(func(st *state) error {
+		toDelete, forceRequired, err := st.deleteTargets(f, query)
+		if err != nil {
+			return err
+		}
+		res.ForceRequired = forceRequired
+		nodes := make([]persistence.Node, 0, len(toDelete))
+		for _, n := range toDelete {
+			nodes = append(nodes, n)
+			res.Records += int64(len(st.records[n.ID]))
+		}
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+		for _, n := range page(nodes, query.Offset, query.Limit) {
+			res.Nodes = append(res.Nodes, nodeAfterRead(n))
+		}
+		res.Total = int64(len(nodes))
+		return nil
+	})
+	if err != nil {
+		return persistence.DeleteNodesPreview{}, err
+	}
+	return res, nil
+}
+
+func (m *modelTx) ListTrash(query persistence.ListNodesQuery) ([]persistence.Node, error) {
+	f, err := newFilter(query.FilterConditions)
+	if err != nil {
+		return nil, err
+	}
+	var res []persistence.Node
+	err = m.exec(func(st *state) error {
+		for _, n := range st.sortedNodes() {
+			if n.DeletedAt != nil && f.match(fcObject{node: n}) {
+				res = append(res, nodeAfterRead(n))
+			}
+		}
+		return nil
+	})
+	return page(res, query.Offset, query.Limit), err
+}
+
+func (m *modelTx) RestoreNodes(query persistence.RestoreNodesQuery) (int64, error) {
+	if len(strings.TrimSpace(query.FilterConditions)) == 0 {
+		return 0, fmt.Errorf("the filter conditions must be specified: %w", errors.ErrInvalid)
+	}
+	f, err := newFilter(query.FilterConditions)
+	if err != nil {
+		return 0, err
+	}
+	var cnt int64
+	err = m.exec(func(st *state) error {
+		// the trashed descendants of the matched folders and the trashed ancestors of the matched nodes are restored as well
+		toRestore := make(map[int64]persistence.Node)
+		for _, n1 := range st.nodes {
+			if n1.DeletedAt == nil || !st.matchNode(f, n1) {
+				continue
+			}
+			toRestore[n1.ID] = n1
+			for _, n2 := range st.nodes {
+				if n2.DeletedAt == nil {
+					continue
+				}
+				if (n1.Flags&persistence.NodeFlagDocument == 0 && strings.HasPrefix(n2.Path, n1.Name+"/")) ||
+					strings.HasPrefix(n1.Path, n2.Name+"/") {
+					toRestore[n2.ID] = n2
+				}
+			}
+		}
+		if len(toRestore) == 0 {
+			return errors.ErrNotExist
+		}
+		now := time.Now()
+		for _, n := range sortedByID(toRestore) {
+			n.DeletedAt = nil
+			n.UpdatedAt = now
+			m.putNode(st, n)
+			m.logNodeChange(st, persistence.ChangeOpCreate, n, now)
+		}
+		cnt = int64(len(toRestore))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return cnt, nil
+}
+
+func (m *modelTx) PurgeTrash(before time.Time) (int64, error) {
+	var cnt int64
+	err := m.exec(func(st *state) error {
+		for _, n := range st.nodes {
+			if n.DeletedAt != nil && n.DeletedAt.Before(before) {
+				m.deleteNode(st, n)
+				cnt++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return cnt, nil
+}
+
+func (m *modelTx) UpsertIndexRecords(records ...persistence.IndexRecord) (int64, error) {
+	if len(records) == 0 {
+		return 0, nil
+	}
+	now := time.Now()
+	err := m.exec(func(st *state) error {
+		for i, r := range records {
+			if len(r.ID) == 0 {
+				return fmt.Errorf("record ID for item=%d  must be specified: %w", i, errors.ErrInvalid)
+			}
+			if r.NodeID == 0 {
+				return fmt.Errorf("record node ID for item=%d must be specified: %w", i, errors.ErrInvalid)
+			}
+			if len(r.Format) == 0 {
+				return fmt.Errorf("record format for item=%d must be specified: %w", i, errors.ErrInvalid)
+			}
+			if _, ok := st.nodes[r.NodeID]; !ok {
+				return fmt.Errorf("record node ID=%d for item=%d is not found: %w", r.NodeID, i, errors.ErrConflict)
+			}
+			if _, ok := st.formats[r.Format]; !ok {
+				return fmt.Errorf("record format=%s for item=%d is not found: %w", r.Format, i, errors.ErrConflict)
+			}
+			if r.RankMult <= 0 {
+				r.RankMult = 1.0
+			}
+			if len(r.Vector) == 0 {
+				r.Vector = []byte("{}")
+			}
+			r.Meta = copyTags(r.Meta)
+			r.CreatedAt = now
+			r.Version = 1
+			op := persistence.ChangeOpCreate
+			if old, ok := st.records[r.NodeID][r.ID]; ok {
+				r.CreatedAt = old.CreatedAt
+				r.Version = old.Version + 1
+				op = persistence.ChangeOpUpdate
+				m.keepRecordHistory(st, old, now, false)
+			}
+			r.UpdatedAt = now
+			m.putRecord(st, r)
+			m.logRecordChange(st, op, r, now)
+		}
+		m.touchNodes(st, records)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(records)), nil
+}
+
+func (m *modelTx) DeleteIndexRecords(records ...persistence.IndexRecord) (int64, error) {
+	if len(records) == 0 {
+		return 0, nil
+	}
+	var cnt int64
+	now := time.Now()
+	err := m.exec(func(st *state) error {
+		var deleted []persistence.IndexRecord
+		for _, r := range records {
+			if old, ok := st.records[r.NodeID][r.ID]; ok {
+				m.keepRecordHistory(st, old, now, true)
+				m.logRecordChange(st, persistence.ChangeOpDelete, old, now)
+				m.deleteRecord(st, old)
+				deleted = append(deleted, old)
+				cnt++
+			}
+		}
+		if cnt == 0 {
+			return errors.ErrNotExist
+		}
+		m.touchNodes(st, deleted)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return cnt, nil
+}
+
+func (m *modelTx) QueryIndexRecords(query persistence.IndexRecordQuery) (persistence.QueryResult[persistence.IndexRecord, string], error) {
+	if query.NodeID == 0 {
+		return persistence.QueryResult[persistence.IndexRecord, string]{}, fmt.Errorf("node ID must be specified: %w", errors.ErrInvalid)
+	}
+	var res []persistence.IndexRecord
+	err := m.exec(func(st *state) error {
+		for _, r := range st.recordsAsOf(query.NodeID, query.AsOf) {
+			if len(query.FromID) > 0 && r.ID < query.FromID {
+				continue
+			}
+			if len(query.Format) > 0 && r.Format != query.Format {
+				continue
+			}
+			if !query.CreatedBefore.IsZero() && !r.CreatedAt.Before(query.CreatedBefore) {
+				continue
+			}
+			if !query.CreatedAfter.IsZero() && !r.CreatedAt.After(query.CreatedAfter) {
+				continue
+			}
+			r.Meta = copyTags(r.Meta)
+			res = append(res, r)
+		}
+		return nil
+	})
+	if err != nil {
+		return persistence.QueryResult[persistence.IndexRecord, string]{}, err
+	}
+	total := int64(len(res))
+	if query.Limit <= 0 {
+		return persistence.QueryResult[persistence.IndexRecord, string]{Total: total}, nil
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	var nextID string
+	if len(res) > query.Limit {
+		nextID = res[query.Limit].ID
+		res = res[:query.Limit]
+	}
+	return persistence.QueryResult[persistence.IndexRecord, string]{Items: res, NextID: nextID, Total: total}, nil
+}
+
+func (m *modelTx) ListIndexRecordRevisions(query persistence.IndexRecordRevisionsQuery) ([]persistence.IndexRecordRevision, error) {
+	if query.NodeID == 0 {
+		return nil, fmt.Errorf("node ID must be specified: %w", errors.ErrInvalid)
+	}
+	var res []persistence.IndexRecordRevision
+	err := m.exec(func(st *state) error {
+		revs := st.history[query.NodeID]
+		for i := len(revs) - 1; i >= 0; i-- {
+			if len(query.RecordID) == 0 || revs[i].ID == query.RecordID {
+				res = append(res, revs[i])
+			}
+		}
+		return nil
+	})
+	return page(res, query.Offset, query.Limit), err
+}
+
+func (m *modelTx) ListChanges(query persistence.ChangesQuery) (persistence.QueryResult[persistence.Change, int64], error) {
+	if query.Limit <= 0 {
+		return persistence.QueryResult[persistence.Change, int64]{}, fmt.Errorf("the limit must be positive: %w", errors.ErrInvalid)
+	}
+	f, err := newFilter(query.FilterConditions)
+	if err != nil {
+		return persistence.QueryResult[persistence.Change, int64]{}, err
+	}
+	var res []persistence.Change
+	nextID := query.FromID
+	err = m.exec(func(st *state) error {
+		nextID = max(nextID, st.lastChangeID)
+		i := sort.Search(len(st.changes), func(i int) bool { return st.changes[i].ID > query.FromID })
+		for ; i < len(st.changes) && len(res) < query.Limit; i++ {
+			c := st.changes[i]
+			o := fcObject{node: persistence.Node{ID: c.NodeID, Path: c.Path, Name: c.Name, Tags: c.Tags, EffectiveTags: c.EffectiveTags,
+				Attrs: c.Attrs, Flags: c.Flags}}
+			if c.Object == persistence.ChangeObjectRecord {
+				o.rec = &persistence.IndexRecord{ID: c.RecordID, NodeID: c.NodeID, Format: c.Format, Meta: c.Meta}
+			}
+			if f.match(o) {
+				c.Tags = copyTags(c.Tags)
+				c.EffectiveTags = copyTags(c.EffectiveTags)
+				c.Attrs = c.Attrs.Copy()
+				c.Meta = copyTags(c.Meta)
+				res = append(res, c)
+			}
+		}
+		if len(res) == query.Limit {
+			nextID = res[len(res)-1].ID
+		}
+		return nil
+	})
+	if err != nil {
+		return persistence.QueryResult[persistence.Change, int64]{}, err
+	}
+	return persistence.QueryResult[persistence.Change, int64]{Items: res, NextID: nextID}, nil
+}
+
+func (m *modelTx) LastChangeID() (int64, error) {
+	var id int64
+	err := m.exec(func(st *state) error {
+		id = st.lastChangeID
+		return nil
+	})
+	return id, err
+}
+
+func (m *modelTx) PurgeChanges(before time.Time) (int64, error) {
+	var cnt int64
+	err := m.exec(func(st *state) error {
+		changes := st.changes
+		for cnt < int64(len(changes)) && changes[cnt].CreatedAt.Before(before) {
+			cnt++
+		}
+		st.changes = changes[cnt:]
+		m.onRollback(func() { st.changes = changes })
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return cnt, nil
+}
+
+func (m *modelTx) CreateWebhook(webhook persistence.Webhook) (persistence.Webhook, error) {
+	if len(webhook.ID) == 0 {
+		return persistence.Webhook{}, fmt.Errorf("webhook ID must be non-empty: %w", errors.ErrInvalid)
+	}
+	if len(webhook.URL) == 0 {
+		return persistence.Webhook{}, fmt.Errorf("webhook URL must be non-empty: %w", errors.ErrInvalid)
+	}
+	webhook.Path = persistence.ConcatPath(webhook.Path, "")
+	webhook.Events = append(persistence.Strings{}, webhook.Events...)
+	webhook.CreatedAt = time.Now()
+	webhook.UpdatedAt = webhook.CreatedAt
+	err := m.exec(func(st *state) error {
+		if _, ok := st.webhooks[webhook.ID]; ok {
+			return fmt.Errorf("webhook with ID=%s already exists: %w", webhook.ID, errors.ErrExist)
+		}
+		m.putWebhook(st, webhook)
+		return nil
+	})
+	if err != nil {
+		return persistence.Webhook{}, err
+	}
+	return webhook, nil
+}
+
+func (m *modelTx) GetWebhook(ID string) (persistence.Webhook, error) {
+	var w persistence.Webhook
+	err := m.exec(func(st *state) error {
+		var ok bool
+		if w, ok = st.webhooks[ID]; !ok {
+			return errors.ErrNotExist
+		}
+		return nil
+	})
+	return w, err
+}
+
+func (m *modelTx) DeleteWebhook(ID string) error {
+	return m.exec(func(st *state) error {
+		w, ok := st.webhooks[ID]
+		if !ok {
+			return errors.ErrNotExist
+		}
+		delete(st.webhooks, ID)
+		dls := st.deadLetters
+		st.deadLetters = nil
+		for _, dl := range dls {
+			if dl.WebhookID != ID {
+				st.deadLetters = append(st.deadLetters, dl)
+			}
+		}
+		m.onRollback(func() {
+			st.webhooks[ID] = w
+			st.deadLetters = dls
+		})
+		return nil
+	})
+}
+
+func (m *modelTx) ListWebhooks() ([]persistence.Webhook, error) {
+	var res []persistence.Webhook
+	err := m.exec(func(st *state) error {
+		for _, w := range st.webhooks {
+			res = append(res, w)
+		}
+		sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+		return nil
+	})
+	return res, err
+}
+
+func (m *modelTx) LockWebhook(ID, owner string, expired, lockedUntil time.Time) (persistence.Webhook, error) {
+	var w persistence.Webhook
+	err := m.exec(func(st *state) error {
+		var ok bool
+		if w, ok = st.webhooks[ID]; !ok || (w.LockedBy != owner && w.LockedUntil.After(expired)) {
+			return errors.ErrNotExist
+		}
+		w.LockedBy = owner
+		w.LockedUntil = lockedUntil
+		m.putWebhook(st, w)
+		return nil
+	})
+	return w, err
+}
+
+func (m *modelTx) UpdateWebhookCursor(ID, owner string, cursor int64) error {
+	return m.exec(func(st *state) error {
+		w, ok := st.webhooks[ID]
+		if !ok || w.LockedBy != owner {
+			return errors.ErrNotExist
+		}
+		w.Cursor = cursor
+		w.Attempts = 0
+		w.NextAttemptAt = time.Time{}
+		w.UpdatedAt = time.Now()
+		m.putWebhook(st, w)
+		return nil
+	})
+}
+
+func (m *modelTx) UpdateWebhookAttempts(ID, owner string, attempts int, nextAttemptAt time.Time) error {
+	return m.exec(func(st *state) error {
+		w, ok := st.webhooks[ID]
+		if !ok || w.LockedBy != owner {
+			return errors.ErrNotExist
+		}
+		w.Attempts = attempts
+		w.NextAttemptAt = nextAttemptAt
+		w.UpdatedAt = time.Now()
+		m.putWebhook(st, w)
+		return nil
+	})
+}
+
+func (m *modelTx) CreateDeadLetter(dl persistence.DeadLetter) (persistence.DeadLetter, error) {
+	if len(dl.WebhookID) == 0 {
+		return persistence.DeadLetter{}, fmt.Errorf("dead letter webhook ID must be non-empty: %w", errors.ErrInvalid)
+	}
+	dl.CreatedAt = time.Now()
+	err := m.exec(func(st *state) error {
+		if _, ok := st.webhooks[dl.WebhookID]; !ok {
+			return fmt.Errorf("dead letter webhook ID=%s is not found: %w", dl.WebhookID, errors.ErrConflict)
+		}
+		lastDeadLetterID, dls := st.lastDeadLetterID, st.deadLetters
+		st.lastDeadLetterID++
+		dl.ID = st.lastDeadLetterID
+		st.deadLetters = append(dls, dl)
+		m.onRollback(func() {
+			st.lastDeadLetterID = lastDeadLetterID
+			st.deadLetters = dls
+		})
+		return nil
+	})
+	if err != nil {
+		return persistence.DeadLetter{}, err
+	}
+	return dl, nil
+}
+
+func (m *modelTx) ListDeadLetters(query persistence.DeadLettersQuery) ([]persistence.DeadLetter, error) {
+	var res []persistence.DeadLetter
+	err := m.exec(func(st *state) error {
+		for i := len(st.deadLetters) - 1; i >= 0; i-- {
+			if st.deadLetters[i].WebhookID == query.WebhookID {
+				res = append(res, st.deadLetters[i])
+			}
+		}
+		return nil
+	})
+	return page(res, query.Offset, query.Limit), err
+}
+
+func (m *modelTx) CreateIdempotencyKey(ik persistence.IdempotencyKey) error {
+	if len(ik.Key) == 0 {
+		return fmt.Errorf("idempotency key must be non-empty: %w", errors.ErrInvalid)
+	}
+	ik.CreatedAt = time.Now()
+	return m.exec(func(st *state) error {
+		if _, ok := st.idempotencyKeys[ik.Key]; ok {
+			return fmt.Errorf("idempotency key %q already exists: %w", ik.Key, errors.ErrExist)
+		}
+		m.putIdempotencyKey(st, ik)
+		return nil
+	})
+}
+
+func (m *modelTx) GetIdempotencyKey(key string) (persistence.IdempotencyKey, error) {
+	var ik persistence.IdempotencyKey
+	err := m.exec(func(st *state) error {
+		var ok bool
+		if ik, ok = st.idempotencyKeys[key]; !ok {
+			return errors.ErrNotExist
+		}
+		return nil
+	})
+	return ik, err
+}
+
+func (m *modelTx) LockIdempotencyKey(key string, expired, lockedUntil time.Time) error {
+	return m.exec(func(st *state) error {
+		ik, ok := st.idempotencyKeys[key]
+		if !ok || ik.Done || ik.LockedUntil.After(expired) {
+			return errors.ErrNotExist
+		}
+		ik.LockedUntil = lockedUntil
+		m.putIdempotencyKey(st, ik)
+		return nil
+	})
+}
+
+func (m *modelTx) CompleteIdempotencyKey(key string, response []byte) error {
+	return m.exec(func(st *state) error {
+		ik, ok := st.idempotencyKeys[key]
+		if !ok {
+			return errors.ErrNotExist
+		}
+		ik.Done = true
+		ik.Response = append([]byte{}, response...)
+		m.putIdempotencyKey(st, ik)
+		return nil
+	})
+}
+
+func (m *modelTx) DeleteIdempotencyKey(key string) error {
+	return m.exec(func(st *state) error {
+		ik, ok := st.idempotencyKeys[key]
+		if !ok {
+			return errors.ErrNotExist
+		}
+		delete(st.idempotencyKeys, key)
+		m.putIdempotencyKeyOnRollback(st, ik)
+		return nil
+	})
+}
+
+func (m *modelTx) PurgeIdempotencyKeys(before time.Time) (int64, error) {
+	var cnt int64
+	err := m.exec(func(st *state) error {
+		for key, ik := range st.idempotencyKeys {
+			if ik.CreatedAt.Before(before) {
+				delete(st.idempotencyKeys, key)
+				m.putIdempotencyKeyOnRollback(st, ik)
+				cnt++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return cnt, nil
+}
+
+func (m *modelTx) CreateAttrSchema(as persistence.AttrSchema) (persistence.AttrSchema, error) {
+	as.Prefix = persistence.ToNodePath(as.Prefix)
+	if err := as.Check(); err != nil {
+		return persistence.AttrSchema{}, err
+	}
+	as.CreatedAt = time.Now()
+	err := m.exec(func(st *state) error {
+		key := [2]string{as.Prefix, as.Name}
+		if _, ok := st.attrSchemas[key]; ok {
+			return fmt.Errorf("the attribute %q is already declared for %q: %w", as.Name, as.Prefix, errors.ErrExist)
+		}
+		for _, eas := range st.attrSchemas {
+			if eas.Name == as.Name && eas.Type != as.Type {
+				return fmt.Errorf("the attribute %q is declared with the %s type for %q: %w", as.Name, eas.Type, eas.Prefix, errors.ErrConflict)
+			}
+		}
+		st.attrSchemas[key] = as
+		m.onRollback(func() { delete(st.attrSchemas, key) })
+		return nil
+	})
+	if err != nil {
+		return persistence.AttrSchema{}, err
+	}
+	return as, nil
+}
+
+func (m *modelTx) DeleteAttrSchema(prefix, name string) error {
+	key := [2]string{persistence.ToNodePath(prefix), name}
+	return m.exec(func(st *state) error {
+		as, ok := st.attrSchemas[key]
+		if !ok {
+			return errors.ErrNotExist
+		}
+		delete(st.attrSchemas, key)
+		m.onRollback(func() { st.attrSchemas[key] = as })
+		return nil
+	})
+}
+
+func (m *modelTx) ListAttrSchemas() ([]persistence.AttrSchema, error) {
+	var res []persistence.AttrSchema
+	err := m.exec(func(st *state) error {
+		for _, as := range st.attrSchemas {
+			res = append(res, as)
+		}
+		return nil
+	})
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Prefix != res[j].Prefix {
+			return res[i].Prefix < res[j].Prefix
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res, err
+}
+
+func (m *modelTx) CreateJob(job persistence.Job) (persistence.Job, error) {
+	if len(job.Status) == 0 {
+		return persistence.Job{}, fmt.Errorf("job status must be non-empty: %w", errors.ErrInvalid)
+	}
+	job.CreatedAt = time.Now()
+	job.UpdatedAt = job.CreatedAt
+	err := m.exec(func(st *state) error {
+		lastJobID := st.lastJobID
+		st.lastJobID++
+		job.ID = st.lastJobID
+		m.putJob(st, job)
+		m.onRollback(func() { st.lastJobID = lastJobID })
+		return nil
+	})
+	if err != nil {
+		return persistence.Job{}, err
+	}
+	return job, nil
+}
+
+func (m *modelTx) GetJob(ID int64) (persistence.Job, error) {
+	var j persistence.Job
+	err := m.exec(func(st *state) error {
+		var ok bool
+		if j, ok = st.jobs[ID]; !ok {
+			return errors.ErrNotExist
+		}
+		return nil
+	})
+	return j, err
+}
+
+func (m *modelTx) UpdateJob(job persistence.Job) error {
+	return m.exec(func(st *state) error {
+		j, ok := st.jobs[job.ID]
+		if !ok {
+			return errors.ErrNotExist
+		}
+		j.Status = job.Status
+		j.Records = job.Records
+		j.Error = job.Error
+		j.UpdatedAt = time.Now()
+		m.putJob(st, j)
+		return nil
+	})
+}
+
+func (m *modelTx) ListJobs(query persistence.JobsQuery) ([]persistence.Job, error) {
+	var res []persistence.Job
+	err := m.exec(func(st *state) error {
+		for _, j := range st.jobs {
+			if (query.Status == "" || j.Status == query.Status) && (query.LockedBefore.IsZero() || j.LockedUntil.Before(query.LockedBefore)) {
+				res = append(res, j)
+			}
+		}
+		return nil
+	})
+	sort.Slice(res, func(i, j int) bool { return res[i].ID > res[j].ID })
+	return page(res, query.Offset, query.Limit), err
+}
+
+func (m *modelTx) LockJob(ID int64, owner string, expired, lockedUntil time.Time) (persistence.Job, error) {
+	var j persistence.Job
+	err := m.exec(func(st *state) error {
+		var ok bool
+		if j, ok = st.jobs[ID]; !ok || (j.Status != persistence.JobPending && j.Status != persistence.JobRunning) ||
+			(j.LockedBy != owner && j.LockedUntil.After(expired)) {
+			return errors.ErrNotExist
+		}
+		j.LockedBy = owner
+		j.LockedUntil = lockedUntil
+		m.putJob(st, j)
+		return nil
+	})
+	return j, err
+}
+
+func (m *modelTx) StartJob(ID int64, owner string) (persistence.Job, error) {
+	var j persistence.Job
+	err := m.exec(func(st *state) error {
+		var ok bool
+		if j, ok = st.jobs[ID]; !ok || j.Status != persistence.JobPending || j.LockedBy != owner {
+			return errors.ErrNotExist
+		}
+		j.Status = persistence.JobRunning
+		j.Records = 0
+		j.UpdatedAt = time.Now()
+		m.putJob(st, j)
+		return nil
+	})
+	return j, err
+}
+
+func (m *modelTx) Search(query persistence.SearchQuery) (persistence.SearchQueryResult, error) {
+	if len(query.TextQuery) == 0 && len(query.Embedding) == 0 {
+		return persistence.SearchQueryResult{}, fmt.Errorf("text query or embedding must be non-empty: %w", errors.ErrInvalid)
+	}
+	if query.Engine != "" && query.Engine != SearchEngine {
+		return persistence.SearchQueryResult{}, fmt.Errorf("the search engine %q is not supported: %w", query.Engine, errors.ErrInvalid)
+	}
+	f, err := newFilter(query.FilterConditions)
+	if err != nil {
+		return persistence.SearchQueryResult{}, err
+	}
+	lexical := func(q persistence.SearchQuery) (persistence.SearchQueryResult, error) {
+		var res persistence.SearchQueryResult
+		err := m.exec(func(st *state) error {
+			res = search(st, f, q, lexicalRank(q))
+			return nil
+		})
+		return res, err
+	}
+	semantic := func(q persistence.SearchQuery) (persistence.SearchQueryResult, error) {
+		emb := q.Embedding
+		if len(emb) == 0 {
+			var err error
+			if emb, err = persistence.ParseEmbedding(q.TextQuery); err != nil {
+				return persistence.SearchQueryResult{}, fmt.Errorf("the query embedding is not provided: %w", err)
+			}
+		}
+		var res persistence.SearchQueryResult
+		err := m.exec(func(st *state) error {
+			res = search(st, f, q, semanticRank(emb))
+			return nil
+		})
+		return res, err
+	}
+	return persistence.Search(query, lexical, semantic)
+}
+
+// ============================== state ====================================
+
+func (m *modelTx) putFormat(st *state, f persistence.Format) {
+	old, ok := st.formats[f.ID]
+	st.formats[f.ID] = f
+	m.onRollback(func() {
+		if ok {
+			st.formats[f.ID] = old
+		} else {
+			delete(st.formats, f.ID)
+		}
+	})
+}
+
+// touchNodes increments the versions of the records nodes
+func (m *modelTx) touchNodes(st *state, records []persistence.IndexRecord) {
+	touched := make(map[int64]bool)
+	for _, r := range records {
+		if n, ok := st.nodes[r.NodeID]; ok && !touched[n.ID] {
+			touched[n.ID] = true
+			n.Version++
+			m.putNode(st, n)
+		}
+	}
+}
+
+func (m *modelTx) putWebhook(st *state, w persistence.Webhook) {
+	old, ok := st.webhooks[w.ID]
+	st.webhooks[w.ID] = w
+	m.onRollback(func() {
+		if ok {
+			st.webhooks[w.ID] = old
+		} else {
+			delete(st.webhooks, w.ID)
+		}
+	})
+}
+
+func (m *modelTx) putJob(st *state, j persistence.Job) {
+	old, ok := st.jobs[j.ID]
+	st.jobs[j.ID] = j
+	m.onRollback(func() {
+		if ok {
+			st.jobs[j.ID] = old
+		} else {
+			delete(st.jobs, j.ID)
+		}
+	})
+}
+
+func (m *modelTx) putIdempotencyKey(st *state, ik persistence.IdempotencyKey) {
+	old, ok := st.idempotencyKeys[ik.Key]
+	st.idempotencyKeys[ik.Key] = ik
+	m.onRollback(func() {
+		if ok {
+			st.idempotencyKeys[ik.Key] = old
+		} else {
+			delete(st.idempotencyKeys, ik.Key)
+		}
+	})
+}
+
+func (m *modelTx) putIdempotencyKeyOnRollback(st *state, ik persistence.IdempotencyKey) {
+	m.onRollback(func() { st.idempotencyKeys[ik.Key] = ik })
+}
+
+func (m *modelTx) putNode(st *state, n persistence.Node) {
+	old, ok := st.nodes[n.ID]
+	st.nodes[n.ID] = n
+	st.names[n.Name] = n.ID
+	m.onRollback(func() {
+		delete(st.names, n.Name)
+		if ok {
+			st.nodes[n.ID] = old
+			st.names[old.Name] = old.ID
+		} else {
+			delete(st.nodes, n.ID)
+		}
+	})
+}
+
+// updateInherited updates the effective tags and ACLs of the node with the fqnp and of all its descendants
+func (m *modelTx) updateInherited(st *state, fqnp string) {
+	for _, n := range st.sortedNodes() {
+		if n.Name != fqnp && !strings.HasPrefix(n.Path, fqnp+"/") {
+			continue
+		}
+		if in := st.inherit(n); !maps.Equal(in.EffectiveTags, n.EffectiveTags) || !maps.Equal(in.EffectiveACL, n.EffectiveACL) {
+			m.putNode(st, in)
+		}
+	}
+}
+
+func (m *modelTx) deleteNode(st *state, n persistence.Node) {
+	for _, r := range st.records[n.ID] {
+		m.deleteRecord(st, r)
+	}
+	if revs, ok := st.history[n.ID]; ok {
+		delete(st.history, n.ID)
+		m.onRollback(func() { st.history[n.ID] = revs })
+	}
+	delete(st.nodes, n.ID)
+	delete(st.names, n.Name)
+	m.onRollback(func() {
+		st.nodes[n.ID] = n
+		st.names[n.Name] = n.ID
+	})
+}
+
+func (m *modelTx) putRecord(st *state, r persistence.IndexRecord) {
+	recs, ok := st.records[r.NodeID]
+	if !ok {
+		recs = make(map[string]persistence.IndexRecord)
+		st.records[r.NodeID] = recs
+	}
+	old, ok := recs[r.ID]
+	recs[r.ID] = r
+	m.onRollback(func() {
+		if ok {
+			recs[r.ID] = old
+		} else {
+			delete(recs, r.ID)
+		}
+	})
+}
+
+func (m *modelTx) deleteRecord(st *state, r persistence.IndexRecord) {
+	recs := st.records[r.NodeID]
+	delete(recs, r.ID)
+	if len(recs) == 0 {
+		delete(st.records, r.NodeID)
+	}
+	m.onRollback(func() {
+		if _, ok := st.records[r.NodeID]; !ok {
+			st.records[r.NodeID] = recs
+		}
+		recs[r.ID] = r
+	})
+}
+
+// keepRecordHistory adds the r version of the record to the history, if its format keeps the history
+func (m *modelTx) keepRecordHistory(st *state, r persistence.IndexRecord, replacedAt time.Time, deleted bool) {
+	if !st.formats[r.Format].KeepHistory {
+		return
+	}
+	lastRevision, revs := st.lastRevision, st.history[r.NodeID]
+	st.lastRevision++
+	st.history[r.NodeID] = append(revs, persistence.IndexRecordRevision{IndexRecord: r, Revision: st.lastRevision, ReplacedAt: replacedAt, Deleted: deleted})
+	m.onRollback(func() {
+		st.lastRevision = lastRevision
+		if len(revs) == 0 {
+			delete(st.history, r.NodeID)
+		} else {
+			st.history[r.NodeID] = revs
+		}
+	})
+}
+
+// logNodeChange adds the node change to the changelog
+func (m *modelTx) logNodeChange(st *state, op persistence.ChangeOp, n persistence.Node, now time.Time) {
+	m.logChange(st, persistence.Change{Object: persistence.ChangeObjectNode, Op: op, NodeID: n.ID,
+		Path: n.Path, Name: n.Name, Tags: copyTags(n.Tags), EffectiveTags: copyTags(n.EffectiveTags), Attrs: n.Attrs.Copy(), Flags: n.Flags, CreatedAt: now})
+}
+
+// logRecordChange adds the index record change to the changelog
+func (m *modelTx) logRecordChange(st *state, op persistence.ChangeOp, r persistence.IndexRecord, now time.Time) {
+	n := st.nodes[r.NodeID]
+	m.logChange(st, persistence.Change{Object: persistence.ChangeObjectRecord, Op: op, NodeID: n.ID, Path: n.Path,
+		Name: n.Name, Tags: copyTags(n.Tags), EffectiveTags: copyTags(n.EffectiveTags), Attrs: n.Attrs.Copy(), Flags: n.Flags, RecordID: r.ID, Format: r.Format, Meta: copyTags(r.Meta), CreatedAt: now})
+}
+
+func (m *modelTx) logChange(st *state, c persistence.Change) {
+	lastChangeID, changes := st.lastChangeID, st.changes
+	st.lastChangeID++
+	c.ID = st.lastChangeID
+	st.changes = append(changes, c)
+	m.onRollback(func() {
+		st.lastChangeID = lastChangeID
+		st.changes = changes
+	})
+}
+
+// recordsAsOf returns the node records as they were at the asOf time, the current records are
+// returned if the asOf is zero
+func (st *state) recordsAsOf(nodeID int64, asOf time.Time) []persistence.IndexRecord {
+	var res []persistence.IndexRecord
+	for _, r := range st.records[nodeID] {
+		if asOf.IsZero() || !r.UpdatedAt.After(asOf) {
+			res = append(res, r)
+		}
+	}
+	if asOf.IsZero() {
+		return res
+	}
+	for _, r := range st.history[nodeID] {
+		if !r.UpdatedAt.After(asOf) && r.ReplacedAt.After(asOf) {
+			res = append(res, r.IndexRecord)
+		}
+	}
+	return res
+}
+
+// targetPath checks whether the node with the from fqnp can be moved or copied to the fqnp to, and
+// returns the path part of the to fqnp. The parent folder of the to fqnp must exist.
+func (st *state) targetPath(from, to string) (string, error) {
+	if to == "/" {
+		return "", fmt.Errorf("the node cannot be placed to the root: %w", errors.ErrInvalid)
+	}
+	if persistence.InSubtree(from, to) {
+		return "", fmt.Errorf("the node %q cannot be placed into its own subtree %q: %w", from, to, errors.ErrInvalid)
+	}
+	if _, ok := st.names[to]; ok {
+		return "", fmt.Errorf("node with name=%s already exists: %w", to, errors.ErrExist)
+	}
+	path, _ := persistence.ToNodePathName(to)
+	if path == "/" {
+		return path, nil
+	}
+	parent, ok := st.liveNode(persistence.ConcatPath(path, ""))
+	if !ok {
+		return "", fmt.Errorf("the parent folder %q is not found: %w", path, errors.ErrNotExist)
+	}
+	if parent.Flags&persistence.NodeFlagDocument != 0 {
+		return "", fmt.Errorf("the node cannot be placed into the document %q: %w", path, errors.ErrInvalid)
+	}
+	return path, nil
+}
+
+// deleteTargets returns the nodes to be deleted for the filter and the query: the matched nodes and all the
+// children of the matched folders. forceRequired is true if some live children are not matched. The trashed
+// nodes are not matched, they are deleted together with their live ancestors only, and they are skipped if
+// the nodes are trashed. ErrNotAuthorized is returned if some of the nodes are not accessible for the query.
+func (st *state) deleteTargets(f filter, query persistence.DeleteNodesQuery) (res map[int64]persistence.Node, forceRequired bool, err error) {
+	trash := query.Trash
+	matched := make(map[int64]persistence.Node)
+	for _, n := range st.nodes {
+		if n.DeletedAt == nil && accessible(query.Access, n) && st.matchNode(f, n) {
+			matched[n.ID] = n
+		}
+	}
+	res = make(map[int64]persistence.Node)
+	for _, n1 := range matched {
+		res[n1.ID] = n1
+		if n1.Flags&persistence.NodeFlagDocument != 0 {
+			continue
+		}
+		for _, n2 := range st.nodes {
+			if !strings.HasPrefix(n2.Path, n1.Name+"/") {
+				continue
+			}
+			if n2.DeletedAt != nil {
+				if !trash {
+					res[n2.ID] = n2
+				}
+				continue
+			}
+			if _, ok := matched[n2.ID]; !ok {
+				forceRequired = true
+			}
+			res[n2.ID] = n2
+		}
+	}
+	for _, n := range res {
+		if !accessible(query.Access, n) {
+			return nil, false, fmt.Errorf("the node %q is not accessible: %w", n.Name, errors.ErrNotAuthorized)
+		}
+	}
+	return res, forceRequired, nil
+}
+
+// liveNode returns the node by its fqnp, if the node exists and it is not trashed
+func (st *state) liveNode(fqnp string) (persistence.Node, bool) {
+	id, ok := st.names[fqnp]
+	if !ok || st.nodes[id].DeletedAt != nil {
+		return persistence.Node{}, false
+	}
+	return st.nodes[id], true
+}
+
+// inherit returns the node n with its effective tags and ACL set: the tags and the ACL of the node are
+// merged over the ones of its ancestors, the ancestors are found by their fqnps, which are the prefixes
+// of the node path
+func (st *state) inherit(n persistence.Node) persistence.Node {
+	n.EffectiveTags, n.EffectiveACL = make(persistence.Tags), make(persistence.ACL)
+	for i := 1; i < len(n.Path); i++ {
+		if n.Path[i] != '/' {
+			continue
+		}
+		if id, ok := st.names[n.Path[:i]]; ok {
+			maps.Copy(n.EffectiveTags, st.nodes[id].Tags)
+			maps.Copy(n.EffectiveACL, st.nodes[id].ACL)
+		}
+	}
+	maps.Copy(n.EffectiveTags, n.Tags)
+	maps.Copy(n.EffectiveACL, n.ACL)
+	return n
+}
+
+// sortedNodes returns the nodes ordered by their fqnp
+func (st *state) sortedNodes() []persistence.Node {
+	res := make([]persistence.Node, 0, len(st.nodes))
+	for _, n := range st.nodes {
+		res = append(res, n)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+// matchNode returns whether the node, or any of its records, matches the filter. This is
+// the same as the node left join index_record selection in the SQL implementations.
+func (st *state) matchNode(f filter, n persistence.Node) bool {
+	recs := st.records[n.ID]
+	if len(recs) == 0 {
+		return f.match(fcObject{node: n})
+	}
+	for _, r := range recs {
+		if f.match(fcObject{node: n, rec: &r}) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedByID returns the nodes ordered by their IDs
+func sortedByID(nodes map[int64]persistence.Node) []persistence.Node {
+	res := make([]persistence.Node, 0, len(nodes))
+	for _, n := range nodes {
+		res = append(res, n)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res
+}
+
+// sortedRecords returns the records ordered by their IDs
+func sortedRecords(recs map[string]persistence.IndexRecord) []persistence.IndexRecord {
+	res := make([]persistence.IndexRecord, 0, len(recs))
+	for _, r := range recs {
+		res = append(res, r)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+	return res
+}
+
+// nodeAfterRead returns the node copy with the Name field containing the node name only
+func nodeAfterRead(n persistence.Node) persistence.Node {
+	n.Name = n.Name[len(n.Path):]
+	n.Tags = copyTags(n.Tags)
+	n.EffectiveTags = copyTags(n.EffectiveTags)
+	n.ACL = copyACL(n.ACL)
+	n.EffectiveACL = copyACL(n.EffectiveACL)
+	n.Attrs = n.Attrs.Copy()
+	return n
+}
+
+// accessible returns whether the node is accessible with the access a, all the nodes are accessible if a is nil
+func accessible(a *persistence.Access, n persistence.Node) bool {
+	return a == nil || a.Allows(n.EffectiveACL)
+}
+
+func copyTags(t persistence.Tags) persistence.Tags {
+	res := make(persistence.Tags, len(t))
+	for k, v := range t {
+		res[k] = v
+	}
+	return res
+}
+
+func copyACL(a persistence.ACL) persistence.ACL {
+	res := make(persistence.ACL, len(a))
+	maps.Copy(res, a)
+	return res
+}
+
+func page[T any](items []T, offset, limit int64) []T {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= int64(len(items)) || limit <= 0 {
+		return nil
+	}
+	end := offset + limit
+	if end > int64(len(items)) {
+		end = int64(len(items))
+	}
+	return items[offset:end]
+}
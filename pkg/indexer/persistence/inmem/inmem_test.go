@@ -0,0 +1,1166 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmem
+
+import (
+	"context"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFormats(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	_, err := mtx.CreateFormat(persistence.Format{ID: "txt"})
+	assert.True(t, errors.Is(err, errors.ErrExist))
+	f, err := mtx.CreateFormat(persistence.Format{ID: "pdf"})
+	assert.Nil(t, err)
+	assert.Equal(t, "{}", string(f.Basis))
+
+	fs, err := mtx.ListFormats()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(fs))
+	assert.Equal(t, "pdf", fs[0].ID)
+
+	n, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "doc", Flags: persistence.NodeFlagDocument})
+	assert.Nil(t, err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: n[0].ID, Format: "pdf", Segment: "abc"})
+	assert.Nil(t, err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: n[0].ID, Format: "unknown", Segment: "abc"})
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	assert.True(t, errors.Is(mtx.DeleteFormat("pdf"), errors.ErrConflict))
+	assert.True(t, errors.Is(mtx.DeleteFormat("doc"), errors.ErrNotExist))
+}
+
+func TestNodes(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"}, persistence.Node{Path: "/a", Name: "b", Tags: persistence.Tags{"k": "v"}})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(nodes))
+	assert.Equal(t, "/a/", nodes[1].Path)
+	assert.Equal(t, "b", nodes[1].Name)
+	_, err = mtx.CreateNodes(persistence.Node{Path: "/a", Name: "b"})
+	assert.True(t, errors.Is(err, errors.ErrExist))
+
+	nodes, err = mtx.ListAllNodesByPath("/a/b/c")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(nodes))
+
+	n, err := mtx.GetNode("/a/b")
+	assert.Nil(t, err)
+	assert.Equal(t, "v", n.Tags["k"])
+	_, err = mtx.GetNode("/a/c")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+
+	assert.Nil(t, mtx.UpdateNode(persistence.Node{ID: n.ID, Tags: persistence.Tags{"k": "v2"}}))
+	nodes, err = mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: "tag('k') = 'v2'", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(nodes))
+	assert.Equal(t, n.ID, nodes[0].ID)
+
+	nodes, err = mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: "prefix(path, '/')", Offset: 1, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(nodes))
+	assert.Equal(t, "b", nodes[0].Name)
+
+	_, err = mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: "tag(1) = 'v2'", Limit: 10})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+
+	assert.Nil(t, mtx.UpdateNode(persistence.Node{ID: n.ID, Tags: persistence.Tags{"k": "", "z": "0"}}))
+	nodes, err = mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: "tag('k') = ''", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(nodes))
+	nodes, err = mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: "tag('z') = ''", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(nodes))
+}
+
+func TestDeleteNodes(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	_, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"}, persistence.Node{Path: "/a", Name: "b"},
+		persistence.Node{Path: "/", Name: "ab"})
+	assert.Nil(t, err)
+
+	err = mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'"})
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	assert.Nil(t, mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Force: true}))
+	_, err = mtx.GetNode("/a/b")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	_, err = mtx.GetNode("/ab")
+	assert.Nil(t, err)
+
+	err = mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'"})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+}
+
+func TestPreviewDeleteNodes(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"}, persistence.Node{Path: "/a", Name: "b"},
+		persistence.Node{Path: "/a/b", Name: "doc", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "ab", Flags: persistence.NodeFlagDocument})
+	assert.Nil(t, err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[2].ID, Format: "txt", Segment: "abc"},
+		persistence.IndexRecord{ID: "2", NodeID: nodes[2].ID, Format: "txt", Segment: "def"},
+		persistence.IndexRecord{ID: "1", NodeID: nodes[3].ID, Format: "txt", Segment: "ghi"})
+	assert.Nil(t, err)
+
+	p, err := mtx.PreviewDeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Limit: 10})
+	assert.Nil(t, err)
+	assert.True(t, p.ForceRequired)
+	assert.Equal(t, int64(3), p.Total)
+	assert.Equal(t, int64(2), p.Records)
+	assert.Equal(t, 3, len(p.Nodes))
+	assert.Equal(t, "/", p.Nodes[0].Path)
+	assert.Equal(t, "a", p.Nodes[0].Name)
+
+	p, err = mtx.PreviewDeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Offset: 1, Limit: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), p.Total)
+	assert.Equal(t, 1, len(p.Nodes))
+	assert.Equal(t, "b", p.Nodes[0].Name)
+
+	p, err = mtx.PreviewDeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a' or node = '/a/b' or node = '/a/b/doc'", Limit: 10})
+	assert.Nil(t, err)
+	assert.False(t, p.ForceRequired)
+	assert.Equal(t, int64(3), p.Total)
+
+	p, err = mtx.PreviewDeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/x'", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), p.Total)
+	assert.Equal(t, 0, len(p.Nodes))
+
+	// nothing is deleted, the trashed nodes are skipped, if the nodes are trashed
+	_, err = mtx.GetNode("/a/b/doc")
+	assert.Nil(t, err)
+	assert.Nil(t, mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a/b/doc'", Trash: true}))
+	p, err = mtx.PreviewDeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Trash: true, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), p.Total)
+	assert.Equal(t, int64(0), p.Records)
+	p, err = mtx.PreviewDeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), p.Total)
+	assert.Equal(t, int64(2), p.Records)
+}
+
+func TestMoveNode(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	_, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"}, persistence.Node{Path: "/a", Name: "b"},
+		persistence.Node{Path: "/a/b", Name: "doc", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "ab"}, persistence.Node{Path: "/", Name: "c"})
+	assert.Nil(t, err)
+
+	_, err = mtx.MoveNode("/a/b/doc", "/a/b")
+	assert.True(t, errors.Is(err, errors.ErrExist))
+	_, err = mtx.MoveNode("/a", "/a/b/x")
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	_, err = mtx.MoveNode("/c", "/a/b/doc/c")
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	_, err = mtx.MoveNode("/c", "/x/c")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	_, err = mtx.MoveNode("/x", "/y")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+
+	n, err := mtx.MoveNode("/a", "/c/a2")
+	assert.Nil(t, err)
+	assert.Equal(t, "/c/", n.Path)
+	assert.Equal(t, "a2", n.Name)
+	n, err = mtx.GetNode("/c/a2/b/doc")
+	assert.Nil(t, err)
+	assert.Equal(t, "/c/a2/b/", n.Path)
+	_, err = mtx.GetNode("/a/b")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	_, err = mtx.GetNode("/ab")
+	assert.Nil(t, err)
+	nodes, err := mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: "prefix(path, '/c/a2/')", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(nodes))
+
+	n, err = mtx.MoveNode("/c/a2/b/doc", "/doc2")
+	assert.Nil(t, err)
+	assert.Equal(t, "/", n.Path)
+	assert.Equal(t, "doc2", n.Name)
+}
+
+func TestCopyNodes(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "t", Tags: persistence.Tags{"k": "v"}},
+		persistence.Node{Path: "/t", Name: "p1", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/t", Name: "sub"}, persistence.Node{Path: "/t/sub", Name: "p2", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "c"})
+	assert.Nil(t, err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[1].ID, Format: "txt", Segment: "abc"},
+		persistence.IndexRecord{ID: "2", NodeID: nodes[1].ID, Format: "txt", Segment: "def"},
+		persistence.IndexRecord{ID: "1", NodeID: nodes[3].ID, Format: "txt", Segment: "ghi"})
+	assert.Nil(t, err)
+
+	_, err = mtx.CopyNodes(persistence.CopyNodesQuery{From: "/t", To: "/t/x"})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	_, err = mtx.CopyNodes(persistence.CopyNodesQuery{From: "/t", To: "/c"})
+	assert.True(t, errors.Is(err, errors.ErrExist))
+	_, err = mtx.CopyNodes(persistence.CopyNodesQuery{From: "/t", To: "/x/t"})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	_, err = mtx.CopyNodes(persistence.CopyNodesQuery{From: "/t", To: "/t/p1/t"})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+
+	res, err := mtx.CopyNodes(persistence.CopyNodesQuery{From: "/t", To: "/c/t2", Tags: persistence.Tags{"tenant": "1"}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), res.Nodes)
+	assert.Equal(t, int64(3), res.Records)
+	assert.Equal(t, "/c/", res.Node.Path)
+	assert.Equal(t, "t2", res.Node.Name)
+
+	n, err := mtx.GetNode("/c/t2/sub/p2")
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.Tags{"tenant": "1"}, n.Tags)
+	assert.Equal(t, int32(persistence.NodeFlagDocument), n.Flags)
+	qr, err := mtx.QueryIndexRecords(persistence.IndexRecordQuery{NodeID: n.ID, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(qr.Items))
+	assert.Equal(t, "ghi", qr.Items[0].Segment)
+	n, err = mtx.GetNode("/t/sub/p2")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(n.Tags))
+
+	// the tags are copied, if not specified
+	res, err = mtx.CopyNodes(persistence.CopyNodesQuery{From: "/t", To: "/t3"})
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.Tags{"k": "v"}, res.Node.Tags)
+}
+
+func TestTrash(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"}, persistence.Node{Path: "/a", Name: "b"},
+		persistence.Node{Path: "/a/b", Name: "doc", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "ab", Flags: persistence.NodeFlagDocument})
+	assert.Nil(t, err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[2].ID, Format: "txt", Segment: "hello world"},
+		persistence.IndexRecord{ID: "1", NodeID: nodes[3].ID, Format: "txt", Segment: "hello there"})
+	assert.Nil(t, err)
+
+	err = mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Trash: true})
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	assert.Nil(t, mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Force: true, Trash: true}))
+	_, err = mtx.GetNode("/a/b/doc")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	nodes, err = mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: "prefix(path, '/')", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(nodes))
+	assert.Equal(t, "ab", nodes[0].Name)
+	res, err := mtx.Search(persistence.SearchQuery{TextQuery: "hello", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.Total)
+	assert.Equal(t, "/ab", res.Items[0].Path)
+	err = mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Trash: true})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+
+	nodes, err = mtx.ListTrash(persistence.ListNodesQuery{Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(nodes))
+	assert.NotNil(t, nodes[0].DeletedAt)
+	nodes, err = mtx.ListTrash(persistence.ListNodesQuery{FilterConditions: "node = '/a/b/doc'", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(nodes))
+
+	// the parents are restored with the node
+	_, err = mtx.RestoreNodes(persistence.RestoreNodesQuery{})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	cnt, err := mtx.RestoreNodes(persistence.RestoreNodesQuery{FilterConditions: "node = '/a/b/doc'"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), cnt)
+	n, err := mtx.GetNode("/a/b/doc")
+	assert.Nil(t, err)
+	assert.Nil(t, n.DeletedAt)
+	res, err = mtx.Search(persistence.SearchQuery{TextQuery: "hello", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), res.Total)
+	_, err = mtx.RestoreNodes(persistence.RestoreNodesQuery{FilterConditions: "node = '/a/b/doc'"})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+
+	// the trashed node keeps its name, until it is restored
+	assert.Nil(t, mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/ab'", Trash: true}))
+	_, err = mtx.CreateNodes(persistence.Node{Path: "/", Name: "ab"})
+	assert.True(t, errors.Is(err, errors.ErrExist))
+	cnt, err = mtx.RestoreNodes(persistence.RestoreNodesQuery{FilterConditions: "node = '/ab'"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), cnt)
+	nodes, err = mtx.ListTrash(persistence.ListNodesQuery{Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(nodes))
+
+	assert.Nil(t, mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Force: true, Trash: true}))
+	cnt, err = mtx.PurgeTrash(time.Now().Add(-time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), cnt)
+	cnt, err = mtx.PurgeTrash(time.Now().Add(time.Second))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), cnt)
+	nodes, err = mtx.ListTrash(persistence.ListNodesQuery{Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(nodes))
+}
+
+func TestDeleteNodesPrefixSibling(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	_, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"}, persistence.Node{Path: "/a", Name: "doc", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "ab"}, persistence.Node{Path: "/ab", Name: "doc", Flags: persistence.NodeFlagDocument})
+	assert.Nil(t, err)
+
+	// the children of the folder /ab are not the children of the folder /a
+	p, err := mtx.PreviewDeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a' or node = '/a/doc'", Limit: 10})
+	assert.Nil(t, err)
+	assert.False(t, p.ForceRequired)
+	assert.Equal(t, int64(2), p.Total)
+	assert.Nil(t, mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Force: true, Trash: true}))
+	_, err = mtx.GetNode("/ab/doc")
+	assert.Nil(t, err)
+	cnt, err := mtx.RestoreNodes(persistence.RestoreNodesQuery{FilterConditions: "node = '/a'"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), cnt)
+	assert.Nil(t, mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Force: true}))
+	_, err = mtx.GetNode("/ab/doc")
+	assert.Nil(t, err)
+	_, err = mtx.GetNode("/a/doc")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+}
+
+func TestTxRollback(t *testing.T) {
+	db := NewDb()
+	mtx := db.NewModelTx(context.Background())
+	mtx.MustBegin()
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"})
+	assert.Nil(t, err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[0].ID, Format: "txt", Segment: "abc"})
+	assert.Nil(t, err)
+	assert.Nil(t, mtx.Rollback())
+
+	mtx = db.NewModelTx(context.Background())
+	_, err = mtx.GetNode("/a")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	nodes, err = mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), nodes[0].ID)
+	qr, err := mtx.QueryIndexRecords(persistence.IndexRecordQuery{NodeID: nodes[0].ID, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), qr.Total)
+}
+
+func TestTxSerialized(t *testing.T) {
+	db := NewDb()
+	mtx := db.NewModelTx(context.Background())
+	mtx.MustBegin()
+	_, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"})
+	assert.Nil(t, err)
+
+	done := make(chan error)
+	go func() {
+		_, err := db.NewModelTx(context.Background()).GetNode("/a")
+		done <- err
+	}()
+	select {
+	case <-done:
+		t.Fatal("the call must be blocked until the active transaction is committed")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Nil(t, mtx.Commit())
+	select {
+	case err = <-done:
+		assert.Nil(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("the call must be unblocked after the transaction is committed")
+	}
+}
+
+func TestQueryIndexRecords(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"})
+	assert.Nil(t, err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[0].ID, Format: "txt", Segment: "abc"},
+		persistence.IndexRecord{ID: "2", NodeID: nodes[0].ID, Format: "txt", Segment: "def"},
+		persistence.IndexRecord{ID: "3", NodeID: nodes[0].ID, Format: "txt", Segment: "ghi"})
+	assert.Nil(t, err)
+
+	qr, err := mtx.QueryIndexRecords(persistence.IndexRecordQuery{NodeID: nodes[0].ID, Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), qr.Total)
+	assert.Equal(t, 2, len(qr.Items))
+	assert.Equal(t, "3", qr.NextID)
+
+	qr, err = mtx.QueryIndexRecords(persistence.IndexRecordQuery{NodeID: nodes[0].ID, FromID: qr.NextID, Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(qr.Items))
+	assert.Equal(t, "", qr.NextID)
+
+	cnt, err := mtx.DeleteIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[0].ID}, persistence.IndexRecord{ID: "4", NodeID: nodes[0].ID})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), cnt)
+	_, err = mtx.DeleteIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[0].ID})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+}
+
+func TestRecordHistory(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	_, err := mtx.CreateFormat(persistence.Format{ID: "hist", KeepHistory: true})
+	assert.Nil(t, err)
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a", Flags: persistence.NodeFlagDocument})
+	assert.Nil(t, err)
+	id := nodes[0].ID
+
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: id, Format: "hist", Segment: "v1"},
+		persistence.IndexRecord{ID: "2", NodeID: id, Format: "txt", Segment: "b1"})
+	assert.Nil(t, err)
+	time.Sleep(10 * time.Millisecond)
+	t1 := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: id, Format: "hist", Segment: "v2"},
+		persistence.IndexRecord{ID: "2", NodeID: id, Format: "txt", Segment: "b2"},
+		persistence.IndexRecord{ID: "3", NodeID: id, Format: "hist", Segment: "c1"})
+	assert.Nil(t, err)
+	time.Sleep(10 * time.Millisecond)
+	t2 := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	_, err = mtx.DeleteIndexRecords(persistence.IndexRecord{ID: "1", NodeID: id})
+	assert.Nil(t, err)
+
+	revs, err := mtx.ListIndexRecordRevisions(persistence.IndexRecordRevisionsQuery{NodeID: id, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(revs))
+	assert.Equal(t, "v2", revs[0].Segment)
+	assert.True(t, revs[0].Deleted)
+	assert.Equal(t, "v1", revs[1].Segment)
+	assert.False(t, revs[1].Deleted)
+	assert.True(t, revs[0].Revision > revs[1].Revision)
+	revs, err = mtx.ListIndexRecordRevisions(persistence.IndexRecordRevisionsQuery{NodeID: id, RecordID: "3", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(revs))
+
+	// the txt format doesn't keep the history, so the record 2 is not available as of t1
+	qr, err := mtx.QueryIndexRecords(persistence.IndexRecordQuery{NodeID: id, AsOf: t1, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), qr.Total)
+	assert.Equal(t, "v1", qr.Items[0].Segment)
+	qr, err = mtx.QueryIndexRecords(persistence.IndexRecordQuery{NodeID: id, AsOf: t2, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(qr.Items))
+	assert.Equal(t, "v2", qr.Items[0].Segment)
+
+	d, err := persistence.DiffIndexRecords(mtx, id, t1, t2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(d.Added))
+	assert.Equal(t, 0, len(d.Removed))
+	assert.Equal(t, 1, len(d.Changed))
+	assert.Equal(t, "v1", d.Changed[0].From.Segment)
+	assert.Equal(t, "v2", d.Changed[0].To.Segment)
+	d, err = persistence.DiffIndexRecords(mtx, id, t2, time.Time{})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(d.Added))
+	assert.Equal(t, "1", d.Removed[0].ID)
+	assert.Equal(t, 0, len(d.Changed))
+	_, err = persistence.DiffIndexRecords(mtx, id, time.Time{}, t2)
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+
+	assert.True(t, errors.Is(mtx.DeleteFormat("hist"), errors.ErrConflict))
+}
+
+func TestChanges(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	start, err := mtx.LastChangeID()
+	assert.Nil(t, err)
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"})
+	assert.Nil(t, err)
+	a := nodes[0]
+	nodes, err = mtx.CreateNodes(persistence.Node{Path: "/a", Name: "b", Flags: persistence.NodeFlagDocument})
+	assert.Nil(t, err)
+	b := nodes[0]
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: b.ID, Format: "txt", Segment: "v1"})
+	assert.Nil(t, err)
+	time.Sleep(10 * time.Millisecond)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: b.ID, Format: "txt", Segment: "v2"})
+	assert.Nil(t, err)
+	assert.Nil(t, mtx.UpdateNode(persistence.Node{ID: a.ID, Tags: persistence.Tags{"k": "v"}}))
+	_, err = mtx.DeleteIndexRecords(persistence.IndexRecord{ID: "1", NodeID: b.ID})
+	assert.Nil(t, err)
+	assert.Nil(t, mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Force: true}))
+
+	res, err := mtx.ListChanges(persistence.ChangesQuery{FromID: start, Limit: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, 8, len(res.Items))
+	type change struct {
+		object persistence.ChangeObject
+		op     persistence.ChangeOp
+		name   string
+		rec    string
+	}
+	var changes []change
+	for _, c := range res.Items {
+		changes = append(changes, change{c.Object, c.Op, c.Name, c.RecordID})
+	}
+	assert.Equal(t, []change{
+		{persistence.ChangeObjectNode, persistence.ChangeOpCreate, "/a", ""},
+		{persistence.ChangeObjectNode, persistence.ChangeOpCreate, "/a/b", ""},
+		{persistence.ChangeObjectRecord, persistence.ChangeOpCreate, "/a/b", "1"},
+		{persistence.ChangeObjectRecord, persistence.ChangeOpUpdate, "/a/b", "1"},
+		{persistence.ChangeObjectNode, persistence.ChangeOpUpdate, "/a", ""},
+		{persistence.ChangeObjectRecord, persistence.ChangeOpDelete, "/a/b", "1"},
+		{persistence.ChangeObjectNode, persistence.ChangeOpDelete, "/a", ""},
+		{persistence.ChangeObjectNode, persistence.ChangeOpDelete, "/a/b", ""},
+	}, changes)
+	assert.Equal(t, "v", res.Items[4].Tags["k"])
+	last := res.NextID
+	assert.Equal(t, res.Items[7].ID, last)
+
+	res, err = mtx.ListChanges(persistence.ChangesQuery{FromID: start, FilterConditions: "format = 'txt'", Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(res.Items))
+	assert.Equal(t, persistence.ChangeOpUpdate, res.Items[1].Op)
+	res, err = mtx.ListChanges(persistence.ChangesQuery{FromID: res.NextID, FilterConditions: "format = 'txt'", Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(res.Items))
+	assert.Equal(t, persistence.ChangeOpDelete, res.Items[0].Op)
+	assert.Equal(t, last, res.NextID)
+
+	res, err = mtx.ListChanges(persistence.ChangesQuery{FromID: start, FilterConditions: "node = '/a/b' and format = 'txt'", Limit: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(res.Items))
+	res, err = mtx.ListChanges(persistence.ChangesQuery{FromID: start, FilterConditions: "tag(\"k\") = 'v'", Limit: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(res.Items))
+	res, err = mtx.ListChanges(persistence.ChangesQuery{FromID: start, FilterConditions: "node = '/c'", Limit: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(res.Items))
+	assert.Equal(t, last, res.NextID)
+	_, err = mtx.ListChanges(persistence.ChangesQuery{FromID: start})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+
+	cnt, err := mtx.PurgeChanges(time.Now().Add(-time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), cnt)
+	cnt, err = mtx.PurgeChanges(time.Now().Add(time.Second))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(8), cnt)
+}
+
+func TestWebhooks(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	_, err := mtx.CreateWebhook(persistence.Webhook{URL: "http://localhost/hook"})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	wh, err := mtx.CreateWebhook(persistence.Webhook{ID: "w1", URL: "http://localhost/hook", Secret: "s", Path: "a/b/", Events: persistence.Strings{"node.create"}, Cursor: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, "/a/b", wh.Path)
+	_, err = mtx.CreateWebhook(persistence.Webhook{ID: "w1", URL: "http://localhost/hook"})
+	assert.True(t, errors.Is(err, errors.ErrExist))
+	_, err = mtx.CreateWebhook(persistence.Webhook{ID: "w2", URL: "http://localhost/hook2"})
+	assert.Nil(t, err)
+
+	wh1, err := mtx.GetWebhook("w1")
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.Strings{"node.create"}, wh1.Events)
+	assert.Equal(t, "s", wh1.Secret)
+	assert.Equal(t, int64(3), wh1.Cursor)
+	now := time.Now()
+	assert.True(t, errors.Is(mtx.UpdateWebhookCursor("w1", "o1", 10), errors.ErrNotExist))
+	wh1, err = mtx.LockWebhook("w1", "o1", now, now.Add(time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, "o1", wh1.LockedBy)
+	_, err = mtx.LockWebhook("w1", "o2", now, now.Add(time.Minute))
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	_, err = mtx.LockWebhook("w3", "o1", now, now.Add(time.Minute))
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	assert.Nil(t, mtx.UpdateWebhookAttempts("w1", "o1", 2, now.Add(time.Second)))
+	assert.True(t, errors.Is(mtx.UpdateWebhookAttempts("w1", "o2", 3, now), errors.ErrNotExist))
+	wh1, err = mtx.GetWebhook("w1")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, wh1.Attempts)
+	assert.True(t, wh1.NextAttemptAt.After(now))
+	assert.Nil(t, mtx.UpdateWebhookCursor("w1", "o1", 10))
+	wh1, err = mtx.GetWebhook("w1")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), wh1.Cursor)
+	assert.Equal(t, 0, wh1.Attempts)
+	assert.True(t, errors.Is(mtx.UpdateWebhookCursor("w3", "o1", 10), errors.ErrNotExist))
+	// the expired lock is taken by another owner
+	wh1, err = mtx.LockWebhook("w1", "o2", now.Add(time.Minute), now.Add(2*time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, "o2", wh1.LockedBy)
+	assert.True(t, errors.Is(mtx.UpdateWebhookCursor("w1", "o1", 11), errors.ErrNotExist))
+	whs, err := mtx.ListWebhooks()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(whs))
+	assert.Equal(t, "w1", whs[0].ID)
+	assert.Equal(t, persistence.Strings{}, whs[1].Events)
+
+	_, err = mtx.CreateDeadLetter(persistence.DeadLetter{WebhookID: "w3", ChangeID: 1, Event: "node.create", Payload: []byte("{}")})
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	for i := 1; i <= 3; i++ {
+		_, err = mtx.CreateDeadLetter(persistence.DeadLetter{WebhookID: "w1", ChangeID: int64(i), Event: "node.create",
+			Payload: []byte(`{"id":1}`), Error: "failed", Attempts: 2})
+		assert.Nil(t, err)
+	}
+	dls, err := mtx.ListDeadLetters(persistence.DeadLettersQuery{WebhookID: "w1", Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(dls))
+	assert.Equal(t, int64(3), dls[0].ChangeID)
+	assert.Equal(t, `{"id":1}`, string(dls[0].Payload))
+	assert.Equal(t, 2, dls[0].Attempts)
+	dls, err = mtx.ListDeadLetters(persistence.DeadLettersQuery{WebhookID: "w1", Offset: 2, Limit: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(dls))
+	assert.Equal(t, int64(1), dls[0].ChangeID)
+
+	assert.Nil(t, mtx.DeleteWebhook("w1"))
+	_, err = mtx.GetWebhook("w1")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	assert.True(t, errors.Is(mtx.DeleteWebhook("w1"), errors.ErrNotExist))
+	dls, err = mtx.ListDeadLetters(persistence.DeadLettersQuery{WebhookID: "w1", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(dls))
+}
+
+func TestVersions(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	_, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a", Flags: persistence.NodeFlagFolder},
+		persistence.Node{Path: "/", Name: "b", Flags: persistence.NodeFlagFolder},
+		persistence.Node{Path: "/a/", Name: "d", Flags: persistence.NodeFlagDocument})
+	assert.Nil(t, err)
+	n, err := mtx.LockNode("/a/d")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), n.Version)
+	_, err = mtx.LockNode("/a/e")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+
+	n.Tags = persistence.Tags{"k": "v"}
+	assert.Nil(t, mtx.UpdateNode(n))
+	n, err = mtx.GetNode("/a/d")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), n.Version)
+
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: n.ID, Format: "txt", Segment: "s1"},
+		persistence.IndexRecord{ID: "2", NodeID: n.ID, Format: "txt", Segment: "s2"})
+	assert.Nil(t, err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: n.ID, Format: "txt", Segment: "s11"})
+	assert.Nil(t, err)
+	qr, err := mtx.QueryIndexRecords(persistence.IndexRecordQuery{NodeID: n.ID, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(qr.Items))
+	for _, r := range qr.Items {
+		if r.ID == "1" {
+			assert.Equal(t, int64(2), r.Version)
+		} else {
+			assert.Equal(t, int64(1), r.Version)
+		}
+	}
+	n, err = mtx.GetNode("/a/d")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), n.Version)
+
+	_, err = mtx.DeleteIndexRecords(persistence.IndexRecord{ID: "2", NodeID: n.ID})
+	assert.Nil(t, err)
+	n, err = mtx.MoveNode("/a/d", "/b/d")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), n.Version)
+	n, err = mtx.GetNode("/b/d")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(6), n.Version)
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	assert.True(t, errors.Is(mtx.CreateIdempotencyKey(persistence.IdempotencyKey{Fingerprint: "f"}), errors.ErrInvalid))
+	assert.Nil(t, mtx.CreateIdempotencyKey(persistence.IdempotencyKey{Key: "k1", Fingerprint: "f1"}))
+	assert.True(t, errors.Is(mtx.CreateIdempotencyKey(persistence.IdempotencyKey{Key: "k1", Fingerprint: "f2"}), errors.ErrExist))
+	assert.Nil(t, mtx.CreateIdempotencyKey(persistence.IdempotencyKey{Key: "k2", Fingerprint: "f2"}))
+
+	ik, err := mtx.GetIdempotencyKey("k1")
+	assert.Nil(t, err)
+	assert.Equal(t, "f1", ik.Fingerprint)
+	assert.False(t, ik.Done)
+	assert.Nil(t, ik.Response)
+	_, err = mtx.GetIdempotencyKey("k3")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+
+	// the lease is taken only if the current one is expired
+	now := time.Now().Truncate(time.Millisecond)
+	assert.Nil(t, mtx.LockIdempotencyKey("k1", now, now.Add(time.Minute)))
+	assert.True(t, errors.Is(mtx.LockIdempotencyKey("k1", now, now.Add(2*time.Minute)), errors.ErrNotExist))
+	assert.Nil(t, mtx.LockIdempotencyKey("k1", now.Add(time.Minute), now.Add(2*time.Minute)))
+	ik, err = mtx.GetIdempotencyKey("k1")
+	assert.Nil(t, err)
+	assert.True(t, now.Add(2*time.Minute).Equal(ik.LockedUntil))
+	assert.True(t, errors.Is(mtx.LockIdempotencyKey("k3", now, now), errors.ErrNotExist))
+
+	assert.Nil(t, mtx.CompleteIdempotencyKey("k1", []byte("resp")))
+	assert.True(t, errors.Is(mtx.LockIdempotencyKey("k1", now.Add(time.Hour), now), errors.ErrNotExist))
+	assert.True(t, errors.Is(mtx.CompleteIdempotencyKey("k3", []byte("resp")), errors.ErrNotExist))
+	ik, err = mtx.GetIdempotencyKey("k1")
+	assert.Nil(t, err)
+	assert.True(t, ik.Done)
+	assert.Equal(t, []byte("resp"), ik.Response)
+
+	assert.Nil(t, mtx.DeleteIdempotencyKey("k2"))
+	assert.True(t, errors.Is(mtx.DeleteIdempotencyKey("k2"), errors.ErrNotExist))
+	cnt, err := mtx.PurgeIdempotencyKeys(time.Now().Add(-time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), cnt)
+	cnt, err = mtx.PurgeIdempotencyKeys(time.Now().Add(time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), cnt)
+	_, err = mtx.GetIdempotencyKey("k1")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+}
+
+func TestJobs(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	_, err := mtx.CreateJob(persistence.Job{NodeID: 1, Path: "/a"})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	j1, err := mtx.CreateJob(persistence.Job{NodeID: 1, Path: "/a", Parser: "txt", Status: persistence.JobPending})
+	assert.Nil(t, err)
+	j2, err := mtx.CreateJob(persistence.Job{NodeID: 2, Path: "/b", Parser: "txt", Status: persistence.JobPending})
+	assert.Nil(t, err)
+	assert.True(t, j2.ID > j1.ID)
+
+	j1.Status = persistence.JobRunning
+	j1.Records = 10
+	assert.Nil(t, mtx.UpdateJob(j1))
+	assert.True(t, errors.Is(mtx.UpdateJob(persistence.Job{ID: j2.ID + 1, Status: persistence.JobDone}), errors.ErrNotExist))
+	j, err := mtx.GetJob(j1.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.JobRunning, j.Status)
+	assert.Equal(t, int64(10), j.Records)
+	assert.Equal(t, "/a", j.Path)
+	assert.Equal(t, "txt", j.Parser)
+	_, err = mtx.GetJob(j2.ID + 1)
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+
+	jobs, err := mtx.ListJobs(persistence.JobsQuery{Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(jobs))
+	assert.Equal(t, j2.ID, jobs[0].ID)
+	jobs, err = mtx.ListJobs(persistence.JobsQuery{Status: persistence.JobRunning, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(jobs))
+	assert.Equal(t, j1.ID, jobs[0].ID)
+	jobs, err = mtx.ListJobs(persistence.JobsQuery{Offset: 1, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(jobs))
+	assert.Equal(t, j1.ID, jobs[0].ID)
+
+	// the job is locked by its owner, or by another one, when the lock is expired
+	now := time.Now()
+	j, err = mtx.LockJob(j1.ID, "i1", now, now.Add(time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, "i1", j.LockedBy)
+	_, err = mtx.LockJob(j1.ID, "i2", now, now.Add(time.Minute))
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	_, err = mtx.LockJob(j1.ID, "i1", now, now.Add(2*time.Minute))
+	assert.Nil(t, err)
+	jobs, err = mtx.ListJobs(persistence.JobsQuery{LockedBefore: now.Add(time.Minute), Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(jobs))
+	assert.Equal(t, j2.ID, jobs[0].ID)
+	j, err = mtx.LockJob(j1.ID, "i2", now.Add(3*time.Minute), now.Add(4*time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, "i2", j.LockedBy)
+	j, err = mtx.GetJob(j1.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, "i2", j.LockedBy)
+	j.Status = persistence.JobDone
+	assert.Nil(t, mtx.UpdateJob(j))
+	_, err = mtx.LockJob(j1.ID, "i2", now.Add(5*time.Minute), now.Add(6*time.Minute))
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+
+	// the pending job is started by the owner of its lock only
+	_, err = mtx.StartJob(j2.ID, "i1")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	_, err = mtx.LockJob(j2.ID, "i1", now, now.Add(time.Minute))
+	assert.Nil(t, err)
+	j, err = mtx.StartJob(j2.ID, "i1")
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.JobRunning, j.Status)
+	assert.Equal(t, int64(0), j.Records)
+	_, err = mtx.StartJob(j2.ID, "i1")
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+}
+
+func TestPatchNodes(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a", Tags: persistence.Tags{"k": "v", "x": "1"}, Flags: persistence.NodeFlagFolder},
+		persistence.Node{Path: "/a/", Name: "d", Tags: persistence.Tags{"k": "v"}, Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "b", Tags: persistence.Tags{"k": "w"}, Flags: persistence.NodeFlagFolder})
+	assert.Nil(t, err)
+
+	cnt, err := mtx.PatchNodes(persistence.PatchNodesQuery{ID: nodes[0].ID, Patch: persistence.TagsPatch{Remove: []string{"x"}, Set: persistence.Tags{"y": "2"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), cnt)
+	n, err := mtx.GetNode("/a")
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.Tags{"k": "v", "y": "2"}, n.Tags)
+	assert.Equal(t, int64(2), n.Version)
+
+	cnt, err = mtx.PatchNodes(persistence.PatchNodesQuery{FilterConditions: "tag('k') = 'v'", Patch: persistence.TagsPatch{Set: persistence.Tags{"k": "v2", "z": "3"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), cnt)
+	n, err = mtx.GetNode("/a/d")
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.Tags{"k": "v2", "z": "3"}, n.Tags)
+	assert.Equal(t, int64(2), n.Version)
+
+	cnt, err = mtx.PatchNodes(persistence.PatchNodesQuery{FilterConditions: "node = '/b'", Patch: persistence.TagsPatch{Clear: true, Remove: []string{"y"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), cnt)
+	n, err = mtx.GetNode("/b")
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.Tags{}, n.Tags)
+
+	// the attributes are replaced by the same update as the tags
+	cnt, err = mtx.PatchNodes(persistence.PatchNodesQuery{ID: nodes[1].ID, Patch: persistence.TagsPatch{Remove: []string{"z"}},
+		Attrs: persistence.Attrs{"pages": float64(3)}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), cnt)
+	n, err = mtx.GetNode("/a/d")
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.Tags{"k": "v2"}, n.Tags)
+	assert.Equal(t, persistence.Attrs{"pages": float64(3)}, n.Attrs)
+	assert.Equal(t, int64(3), n.Version)
+
+	_, err = mtx.PatchNodes(persistence.PatchNodesQuery{FilterConditions: "tag('k') = 'v'", Patch: persistence.TagsPatch{Clear: true}})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	_, err = mtx.PatchNodes(persistence.PatchNodesQuery{ID: nodes[0].ID})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+}
+
+func TestAttrSchemas(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	as, err := mtx.CreateAttrSchema(persistence.AttrSchema{Prefix: "/docs", Name: "price", Type: persistence.AttrNumber})
+	assert.Nil(t, err)
+	assert.Equal(t, "/docs/", as.Prefix)
+	_, err = mtx.CreateAttrSchema(persistence.AttrSchema{Prefix: "/", Name: "price", Type: persistence.AttrNumber})
+	assert.Nil(t, err)
+	_, err = mtx.CreateAttrSchema(persistence.AttrSchema{Prefix: "/docs/", Name: "price", Type: persistence.AttrNumber})
+	assert.True(t, errors.Is(err, errors.ErrExist))
+	_, err = mtx.CreateAttrSchema(persistence.AttrSchema{Prefix: "/other/", Name: "price", Type: persistence.AttrString})
+	assert.True(t, errors.Is(err, errors.ErrConflict))
+	_, err = mtx.CreateAttrSchema(persistence.AttrSchema{Prefix: "/", Name: "a b", Type: persistence.AttrString})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+
+	schemas, err := mtx.ListAttrSchemas()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(schemas))
+	assert.Equal(t, "/", schemas[0].Prefix)
+	assert.Equal(t, "/docs/", schemas[1].Prefix)
+
+	assert.Nil(t, mtx.DeleteAttrSchema("/docs", "price"))
+	assert.True(t, errors.Is(mtx.DeleteAttrSchema("/docs/", "price"), errors.ErrNotExist))
+	schemas, err = mtx.ListAttrSchemas()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(schemas))
+}
+
+func TestNodeAttrs(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	_, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a", Flags: persistence.NodeFlagDocument, Attrs: persistence.Attrs{"price": 10.0,
+		"published": "2024-02-01T00:00:00.000Z", "draft": false, "authors": []string{"x", "y"}, "lang": "en"}},
+		persistence.Node{Path: "/", Name: "b", Flags: persistence.NodeFlagDocument, Attrs: persistence.Attrs{"price": 25.5,
+			"published": "2023-12-31T00:00:00.000Z", "draft": true, "authors": []string{"y"}}},
+		persistence.Node{Path: "/", Name: "c", Flags: persistence.NodeFlagDocument, Attrs: persistence.Attrs{"price": "cheap"}},
+		persistence.Node{Path: "/", Name: "d", Flags: persistence.NodeFlagDocument})
+	assert.Nil(t, err)
+
+	for fc, names := range map[string][]string{
+		"attr('price') > 9.5":                                {"a", "b"},
+		"attr('price') <= 10":                                {"a"},
+		"attr('price') in [10, 20]":                          {"a"},
+		"attr('published') >= date('2024-01-01')":            {"a"},
+		"attr('published') < date('2024-01-01T00:00:00Z')":   {"b"},
+		"attr('draft') = true":                               {"b"},
+		"attr('draft') != true":                              {"a"},
+		"attr('authors') = 'y'":                              {"a", "b"},
+		"attr('authors') in ['x', 'z']":                      {"a"},
+		"attr('authors') != 'x'":                             {"b"},
+		"attr('lang') like 'e%'":                             {"a"},
+		"attr('price') = 'cheap'":                            {"c"},
+		"not attr('draft') = true":                           {"a"},
+		"attr('price') > 100 or attr('lang') = 'en'":         {"a"},
+		"attr('price') in []":                                nil,
+		"attr('unknown') = 1 or attr('published') > 'a'":     nil,
+		"attr('price') >= 10 and attr('published') > '2024'": {"a"},
+	} {
+		nodes, err := mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: fc, Limit: 10})
+		assert.Nil(t, err, fc)
+		var res []string
+		for _, n := range nodes {
+			res = append(res, n.Name)
+		}
+		assert.ElementsMatch(t, names, res, fc)
+	}
+	_, err = mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: "attr('draft') > true", Limit: 10})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+
+	n, err := mtx.GetNode("/b")
+	assert.Nil(t, err)
+	assert.Nil(t, mtx.UpdateNode(persistence.Node{ID: n.ID, Attrs: persistence.Attrs{"price": 1.0}}))
+	n, err = mtx.GetNode("/b")
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.Attrs{"price": 1.0}, n.Attrs)
+	assert.Equal(t, int64(2), n.Version)
+	n, err = mtx.GetNode("/d")
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.Attrs{}, n.Attrs)
+}
+
+func TestRecordMeta(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"})
+	assert.Nil(t, err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[0].ID, Format: "txt", Segment: "hello world", Meta: persistence.Tags{"section": "abstract"}},
+		persistence.IndexRecord{ID: "2", NodeID: nodes[0].ID, Format: "txt", Segment: "hello there", Meta: persistence.Tags{"section": "body", "lang": "en"}},
+		persistence.IndexRecord{ID: "3", NodeID: nodes[0].ID, Format: "txt", Segment: "hello again"})
+	assert.Nil(t, err)
+
+	qr, err := mtx.QueryIndexRecords(persistence.IndexRecordQuery{NodeID: nodes[0].ID, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.Tags{"section": "abstract"}, qr.Items[0].Meta)
+	assert.Equal(t, persistence.Tags{}, qr.Items[2].Meta)
+
+	for fc, ids := range map[string][]string{
+		"rmeta('section') = 'abstract'":                         {"1"},
+		"rmeta('section') != 'abstract'":                        {"2"},
+		"rmeta('lang') = 'en' or rmeta('section') = 'abstract'": {"1", "2"},
+		"rmeta('section') like 'b%'":                            {"2"},
+		"rmeta('unknown') = 'x'":                                nil,
+	} {
+		res, err := mtx.Search(persistence.SearchQuery{TextQuery: "hello", FilterConditions: fc, GroupByPathOff: true, Limit: 10})
+		assert.Nil(t, err, fc)
+		var resIDs []string
+		for _, it := range res.Items {
+			resIDs = append(resIDs, it.ID)
+		}
+		assert.ElementsMatch(t, ids, resIDs, fc)
+	}
+
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "3", NodeID: nodes[0].ID, Format: "txt", Segment: "hello again", Meta: persistence.Tags{"section": "abstract"}})
+	assert.Nil(t, err)
+	changes, err := mtx.ListChanges(persistence.ChangesQuery{FilterConditions: "rmeta('section') = 'abstract'", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(changes.Items))
+	assert.Equal(t, "3", changes.Items[1].RecordID)
+	assert.Equal(t, persistence.ChangeOpUpdate, changes.Items[1].Op)
+	assert.Equal(t, persistence.Tags{"section": "abstract"}, changes.Items[1].Meta)
+}
+
+func TestInheritedTags(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a", Tags: persistence.Tags{"team": "x", "env": "prod"}},
+		persistence.Node{Path: "/a/", Name: "b", Tags: persistence.Tags{"team": "y"}},
+		persistence.Node{Path: "/a/b/", Name: "doc", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "c", Tags: persistence.Tags{"team": "c"}})
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.Tags{"team": "y", "env": "prod"}, nodes[2].EffectiveTags)
+	assert.Equal(t, persistence.Tags{"team": "c"}, nodes[3].EffectiveTags)
+
+	names := func(fc string) []string {
+		ns, err := mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: fc, Limit: 10})
+		assert.Nil(t, err, fc)
+		var res []string
+		for _, n := range ns {
+			res = append(res, persistence.ConcatPath(n.Path, n.Name))
+		}
+		return res
+	}
+	assert.Equal(t, []string{"/a/b", "/a/b/doc"}, names("inheritedTag('team') = 'y'"))
+	assert.Equal(t, []string{"/a", "/a/b", "/a/b/doc"}, names("inheritedTag('env') = 'prod'"))
+	assert.Nil(t, names("tag('env') = 'prod' and node = '/a/b/doc'"))
+
+	// the folder tags are applied to the subtree right away
+	assert.Nil(t, mtx.UpdateNode(persistence.Node{ID: nodes[0].ID, Tags: persistence.Tags{"team": "z"}}))
+	assert.Nil(t, names("inheritedTag('env') = 'prod'"))
+	_, err = mtx.PatchNodes(persistence.PatchNodesQuery{ID: nodes[1].ID, Patch: persistence.TagsPatch{Remove: []string{"team"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/a", "/a/b", "/a/b/doc"}, names("inheritedTag('team') = 'z'"))
+
+	n, err := mtx.MoveNode("/a/b", "/c/b")
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.Tags{"team": "c"}, n.EffectiveTags)
+	doc, err := mtx.GetNode("/c/b/doc")
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.Tags{"team": "c"}, doc.EffectiveTags)
+
+	cr, err := mtx.CopyNodes(persistence.CopyNodesQuery{From: "/c/b", To: "/a/b"})
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.Tags{"team": "z"}, cr.Node.EffectiveTags)
+	assert.Equal(t, []string{"/a", "/a/b", "/a/b/doc"}, names("inheritedTag('team') = 'z'"))
+
+	changes, err := mtx.ListChanges(persistence.ChangesQuery{FilterConditions: "inheritedTag('team') = 'c' and node = '/c/b/doc'", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(changes.Items))
+	assert.Equal(t, persistence.Tags{"team": "c"}, changes.Items[0].EffectiveTags)
+}
+
+func TestNodeACL(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a", ACL: persistence.ACL{"alice": persistence.PermissionRead}},
+		persistence.Node{Path: "/a/", Name: "doc", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "b"})
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.ACL{"alice": persistence.PermissionRead}, nodes[1].EffectiveACL)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[1].ID, Format: "txt", Segment: "hello world"})
+	assert.Nil(t, err)
+
+	n, err := mtx.SetNodeACL("/a/doc", "bob", persistence.PermissionWrite)
+	assert.Nil(t, err)
+	assert.Equal(t, persistence.ACL{"bob": persistence.PermissionWrite}, n.ACL)
+	assert.Equal(t, persistence.ACL{"alice": persistence.PermissionRead, "bob": persistence.PermissionWrite}, n.EffectiveACL)
+	_, err = mtx.SetNodeACL("/a", "alice", persistence.PermissionAdmin)
+	assert.Nil(t, err)
+	_, err = mtx.SetNodeACL("/a", "a'b", persistence.PermissionRead)
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	_, err = mtx.SetNodeACL("/a", "alice", "owner")
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+	_, err = mtx.SetNodeACL("/c", "alice", persistence.PermissionRead)
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+
+	names := func(a persistence.Access) []string {
+		ns, err := mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: "path like '/%'", Access: &a, Limit: 10})
+		assert.Nil(t, err)
+		var res []string
+		for _, n := range ns {
+			res = append(res, persistence.ConcatPath(n.Path, n.Name))
+		}
+		return res
+	}
+	assert.Equal(t, []string{"/a", "/a/doc"}, names(persistence.Access{Principals: []string{"alice"}, Permission: persistence.PermissionAdmin}))
+	assert.Equal(t, []string{"/a/doc"}, names(persistence.Access{Principals: []string{"x", "bob"}, Permission: persistence.PermissionWrite}))
+	assert.Nil(t, names(persistence.Access{Principals: []string{"bob"}, Permission: persistence.PermissionAdmin}))
+	assert.Nil(t, names(persistence.Access{Principals: []string{"x'"}, Permission: persistence.PermissionRead}))
+
+	res, err := mtx.Search(persistence.SearchQuery{TextQuery: "hello", Access: &persistence.Access{Principals: []string{"bob"}, Permission: persistence.PermissionRead}, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.Total)
+	res, err = mtx.Search(persistence.SearchQuery{TextQuery: "hello", Access: &persistence.Access{Principals: []string{"eve"}, Permission: persistence.PermissionRead}, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), res.Total)
+
+	_, err = mtx.PatchNodes(persistence.PatchNodesQuery{FilterConditions: "path like '/%'", Patch: persistence.TagsPatch{Set: persistence.Tags{"k": "v"}},
+		Access: &persistence.Access{Principals: []string{"eve"}, Permission: persistence.PermissionWrite}})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+
+	// the copy inherits the destination ACL, but not the ACL of the original
+	cr, err := mtx.CopyNodes(persistence.CopyNodesQuery{From: "/a/doc", To: "/a/doc2"})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(cr.Node.ACL))
+	assert.Equal(t, persistence.ACL{"alice": persistence.PermissionAdmin}, cr.Node.EffectiveACL)
+
+	// the folder children must be accessible to delete it
+	bob := &persistence.Access{Principals: []string{"bob"}, Permission: persistence.PermissionWrite}
+	_, err = mtx.SetNodeACL("/a", "bob", persistence.PermissionWrite)
+	assert.Nil(t, err)
+	_, err = mtx.SetNodeACL("/a/doc", "bob", persistence.PermissionRead)
+	assert.Nil(t, err)
+	err = mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Force: true, Access: bob})
+	assert.True(t, errors.Is(err, errors.ErrNotAuthorized))
+	_, err = mtx.SetNodeACL("/a/doc", "bob", "")
+	assert.Nil(t, err)
+	err = mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/b'", Access: bob})
+	assert.True(t, errors.Is(err, errors.ErrNotExist))
+	assert.Nil(t, mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Force: true, Access: bob}))
+}
+
+func TestSearch(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a", Tags: persistence.Tags{"k": "1"}},
+		persistence.Node{Path: "/", Name: "b", Tags: persistence.Tags{"k": "2"}})
+	assert.Nil(t, err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[0].ID, Format: "txt", Segment: "Hello world"},
+		persistence.IndexRecord{ID: "2", NodeID: nodes[0].ID, Format: "txt", Segment: "hello hello world"},
+		persistence.IndexRecord{ID: "1", NodeID: nodes[1].ID, Format: "txt", Segment: "hello there, wonderful world"})
+	assert.Nil(t, err)
+
+	_, err = mtx.Search(persistence.SearchQuery{Limit: 10})
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+
+	res, err := mtx.Search(persistence.SearchQuery{TextQuery: "hello world", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), res.Total)
+	assert.Equal(t, "/a", res.Items[0].Path)
+	assert.Equal(t, "1", res.Items[0].ID)
+	assert.Equal(t, []string{"Hello", "world"}, res.Items[0].MatchedKeywordsList)
+	assert.Equal(t, "/b", res.Items[1].Path)
+
+	res, err = mtx.Search(persistence.SearchQuery{TextQuery: "hello world", GroupByPathOff: true, Offset: 1, Limit: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), res.Total)
+	assert.Equal(t, 1, len(res.Items))
+
+	res, err = mtx.Search(persistence.SearchQuery{TextQuery: "hello -there", FilterConditions: "tag('k') in ['1', '2']", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.Total)
+	assert.Equal(t, "/a", res.Items[0].Path)
+
+	res, err = mtx.Search(persistence.SearchQuery{TextQuery: "world", FilterConditions: "tag('k') = 2 and format like 't%'", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.Total)
+	assert.Equal(t, "/b", res.Items[0].Path)
+
+	res, err = mtx.Search(persistence.SearchQuery{TextQuery: "world"})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), res.Total)
+	assert.Nil(t, res.Items)
+}
+
+func TestSearchModes(t *testing.T) {
+	mtx := NewDb().NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"}, persistence.Node{Path: "/", Name: "b"})
+	assert.Nil(t, err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[0].ID, Format: "txt", Segment: "red apples", Embedding: persistence.Embedding{1, 0}},
+		persistence.IndexRecord{ID: "2", NodeID: nodes[0].ID, Format: "txt", Segment: "green pears", Embedding: persistence.Embedding{0, 1}},
+		persistence.IndexRecord{ID: "1", NodeID: nodes[1].ID, Format: "txt", Segment: "apple pie"},
+		persistence.IndexRecord{ID: "2", NodeID: nodes[1].ID, Format: "txt", Segment: "pie", Embedding: persistence.Embedding{1, 0, 0}})
+	assert.Nil(t, err)
+
+	// the records without embeddings, or with the different dimensions, are not found
+	res, err := mtx.Search(persistence.SearchQuery{TextQuery: "[0.1, 1]", Mode: persistence.SearchModeSemantic, GroupByPathOff: true, Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), res.Total)
+	assert.Equal(t, "2", res.Items[0].ID)
+	assert.Equal(t, "/a", res.Items[0].Path)
+	assert.NotNil(t, res.Items[0].SemanticScore)
+
+	q := persistence.SearchQuery{TextQuery: "red", Embedding: persistence.Embedding{0.1, 1}, Mode: persistence.SearchModeHybrid, GroupByPathOff: true, Limit: 10}
+	res, err = mtx.Search(q)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), res.Total)
+	assert.Equal(t, 2, len(res.Items))
+	assert.Equal(t, "/a", res.Items[0].Path)
+	assert.Equal(t, "1", res.Items[0].ID)
+	assert.NotNil(t, res.Items[0].LexicalScore)
+	assert.NotNil(t, res.Items[0].SemanticScore)
+	assert.Equal(t, "2", res.Items[1].ID)
+	assert.Nil(t, res.Items[1].LexicalScore)
+	assert.NotNil(t, res.Items[1].SemanticScore)
+
+	q.Mode = "abc"
+	_, err = mtx.Search(q)
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+
+	q.Mode = persistence.SearchModeLexical
+	q.Engine = SearchEngine
+	res, err = mtx.Search(q)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(res.Items))
+	q.Engine = "pgfts"
+	_, err = mtx.Search(q)
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+}
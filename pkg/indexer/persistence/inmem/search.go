@@ -0,0 +1,182 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inmem
+
+import (
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// textQuery is the parsed search text. The syntax is the simplified
+// version of the websearch syntax: all the terms must be present in the segment,
+// the terms prefixed by '-' must not be there.
+type textQuery struct {
+	terms    []string
+	excluded []string
+}
+
+func parseTextQuery(s string) textQuery {
+	var tq textQuery
+	for _, w := range strings.Fields(s) {
+		exclude := strings.HasPrefix(w, "-")
+		for _, t := range tokenize(w) {
+			if exclude {
+				tq.excluded = append(tq.excluded, strings.ToLower(t))
+			} else {
+				tq.terms = append(tq.terms, strings.ToLower(t))
+			}
+		}
+	}
+	return tq
+}
+
+// tokenize splits the s to the words consisting of letters and digits
+func tokenize(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// rank returns the score of the segment for the query and the list of the matched
+// words (as they are in the segment). The score is 0, if the segment doesn't match.
+func (tq textQuery) rank(segment string) (float32, []string) {
+	if len(tq.terms) == 0 {
+		return 0, nil
+	}
+	words := tokenize(segment)
+	if len(words) == 0 {
+		return 0, nil
+	}
+	freq := make(map[string]int, len(words))
+	var matched []string
+	for _, w := range words {
+		lw := strings.ToLower(w)
+		freq[lw]++
+		for _, t := range tq.terms {
+			if t == lw && freq[lw] == 1 {
+				matched = append(matched, w)
+				break
+			}
+		}
+	}
+	for _, t := range tq.excluded {
+		if freq[t] > 0 {
+			return 0, nil
+		}
+	}
+	var score float32
+	for _, t := range tq.terms {
+		if freq[t] == 0 {
+			return 0, nil
+		}
+		score += float32(freq[t]) / float32(len(words))
+	}
+	return score / float32(len(tq.terms)), matched
+}
+
+// rankFn returns the score of the record and the matched keywords, ok is false if the record doesn't match
+type rankFn func(r persistence.IndexRecord) (score float32, matched []string, ok bool)
+
+// lexicalRank returns the rankFn of the full-text search, the score is multiplied by the record rank multiplier
+func lexicalRank(q persistence.SearchQuery) rankFn {
+	tq := parseTextQuery(q.TextQuery)
+	return func(r persistence.IndexRecord) (float32, []string, bool) {
+		score, matched := tq.rank(r.Segment)
+		return score * float32(r.RankMult), matched, score > 0
+	}
+}
+
+// semanticRank returns the rankFn of the semantic search, the score is the cosine similarity
+// of the record and the query embeddings. The records without embeddings don't match.
+func semanticRank(emb persistence.Embedding) rankFn {
+	qn := norm(emb)
+	return func(r persistence.IndexRecord) (float32, []string, bool) {
+		if len(r.Embedding) != len(emb) || qn == 0 {
+			return 0, nil, false
+		}
+		rn := norm(r.Embedding)
+		if rn == 0 {
+			return 0, nil, false
+		}
+		var dot float64
+		for i := range emb {
+			dot += float64(emb[i]) * float64(r.Embedding[i])
+		}
+		return float32(dot / (qn * rn)), nil, true
+	}
+}
+
+func norm(e persistence.Embedding) float64 {
+	var res float64
+	for _, v := range e {
+		res += float64(v) * float64(v)
+	}
+	return math.Sqrt(res)
+}
+
+// search runs the query against the state. The results are grouped by the node (path), so
+// the best matching record represents the node in the result, unless the GroupByPathOff is set
+func search(st *state, f filter, q persistence.SearchQuery, rank rankFn) persistence.SearchQueryResult {
+	var items []persistence.SearchQueryResultItem
+	for nID, recs := range st.records {
+		n := st.nodes[nID]
+		if n.DeletedAt != nil || !accessible(q.Access, n) {
+			continue
+		}
+		var best *persistence.SearchQueryResultItem
+		for _, r := range recs {
+			if !f.match(fcObject{node: n, rec: &r}) {
+				continue
+			}
+			score, matched, ok := rank(r)
+			if !ok {
+				continue
+			}
+			it := persistence.SearchQueryResultItem{
+				IndexRecord:         r,
+				Path:                n.Name,
+				MatchedKeywordsList: matched,
+				Score:               score,
+			}
+			if q.GroupByPathOff {
+				items = append(items, it)
+				continue
+			}
+			if best == nil || best.Score < it.Score || (best.Score == it.Score && it.ID < best.ID) {
+				best = &it
+			}
+		}
+		if best != nil {
+			items = append(items, *best)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		if items[i].Path != items[j].Path {
+			return items[i].Path < items[j].Path
+		}
+		return items[i].ID < items[j].ID
+	})
+	total := int64(len(items))
+	if q.Limit <= 0 {
+		return persistence.SearchQueryResult{Total: total}
+	}
+	return persistence.SearchQueryResult{Items: page(items, int64(q.Offset), int64(q.Limit)), Total: total}
+}
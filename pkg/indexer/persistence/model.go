@@ -18,20 +18,61 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"strconv"
 	"strings"
 	"time"
 )
 
 type (
 	Format struct {
-		ID        string    `db:"id"`
-		Basis     []byte    `db:"basis"`
-		CreatedAt time.Time `db:"created_at"`
-		UpdatedAt time.Time `db:"updated_at"`
+		ID    string `db:"id"`
+		Basis []byte `db:"basis"`
+		// KeepHistory flag makes the replaced and deleted index records of the format
+		// to be kept as the IndexRecordRevision(s)
+		KeepHistory bool      `db:"keep_history"`
+		CreatedAt   time.Time `db:"created_at"`
+		UpdatedAt   time.Time `db:"updated_at"`
 	}
 
 	Tags map[string]string
 
+	// Attrs are the typed attributes of the node, every attribute must be declared by an AttrSchema.
+	// The values are float64 for the numbers, bool, string, []string for the strings lists, and the
+	// timestamps are strings in the ql.DateLayout, so they are ordered the same way as the strings.
+	Attrs map[string]any
+
+	// AttrType is the type of the node attribute
+	AttrType string
+
+	// Permission is the access level of the principal to the node, every permission includes the
+	// lower ones: the admin permission includes the write one, which includes the read one
+	Permission string
+
+	// ACL contains the permissions of the principals (users or groups) by their names
+	ACL map[string]Permission
+
+	// Access describes the access requested for the nodes, the node is accessible if its effective ACL
+	// grants the Permission (or a higher one) to any of the Principals
+	Access struct {
+		Principals []string
+		Permission Permission
+	}
+
+	// AttrSchema declares the typed attribute Name for the nodes under the Prefix. The attribute has
+	// the same type for all the prefixes it is declared for.
+	AttrSchema struct {
+		// Prefix is the path the node fqnps must start with, it always ends by "/"
+		Prefix    string    `db:"prefix"`
+		Name      string    `db:"name"`
+		Type      AttrType  `db:"type"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+
+	// Embedding is the vector representation of a text used by the semantic search. It
+	// is stored in the pgvector text format, e.g. "[0.1,0.2,0.3]".
+	Embedding []float32
+
 	// Node describes an object. The node has Path and Name, and the pair <Path, Name> must
 	// be unique within the tree. Name cannot be empty.
 	//
@@ -40,11 +81,29 @@ type (
 		ID   int64  `db:"id"`
 		Path string `db:"path"`
 		// Name is either name of the folder or a document
-		Name      string    `db:"name"`
-		Tags      Tags      `db:"tags"`
-		Flags     int32     `db:"flags"`
-		CreatedAt time.Time `db:"created_at"`
-		UpdatedAt time.Time `db:"updated_at"`
+		Name string `db:"name"`
+		Tags Tags   `db:"tags"`
+		// EffectiveTags are the node tags together with the tags inherited from its ancestors, the
+		// node own tags override the inherited ones, and the tags of the nearer ancestors override
+		// the farther ones. They are maintained by the storage, the value provided is ignored.
+		EffectiveTags Tags `db:"effective_tags"`
+		// ACL contains the permissions granted for the node and its descendants, it is changed by the
+		// SetNodeACL only, the value provided to the UpdateNode is ignored
+		ACL ACL `db:"acl"`
+		// EffectiveACL is the node ACL together with the ACLs inherited from its ancestors, the same way
+		// as for the EffectiveTags, the nearer permissions of the principal override the farther ones.
+		// It is maintained by the storage, the value provided is ignored.
+		EffectiveACL ACL       `db:"effective_acl"`
+		Attrs        Attrs     `db:"attrs"`
+		Flags        int32     `db:"flags"`
+		CreatedAt    time.Time `db:"created_at"`
+		UpdatedAt    time.Time `db:"updated_at"`
+		// DeletedAt is set when the node is moved to the trash, the trashed nodes
+		// are hidden until they are restored or purged
+		DeletedAt *time.Time `db:"deleted_at"`
+		// Version is incremented on every change of the node or its index records,
+		// it starts from 1 for the new nodes
+		Version int64 `db:"version"`
 	}
 
 	IndexRecord struct {
@@ -56,9 +115,27 @@ type (
 		RankMult  float64   `db:"rank_multiplier"`
 		CreatedAt time.Time `db:"created_at"`
 		UpdatedAt time.Time `db:"updated_at"`
+		// Version is incremented on every update of the record, it starts from 1 for the new records
+		Version int64 `db:"version"`
+		// Meta is the optional key-value metadata of the record, it can be filtered by the rmeta() function
+		Meta Tags `db:"meta"`
 
 		// search module specific fields
-		SegmentTsVector string `db:"segment_tsvector"`
+		SegmentTsVector string    `db:"segment_tsvector"`
+		Embedding       Embedding `db:"embedding"`
+	}
+
+	// IndexRecordDoc is the index record together with the node attributes. It is used
+	// by the search engines, which keep their own index out of the database.
+	IndexRecordDoc struct {
+		IndexRecord
+		// NodePath is the path of the record node, it always ends by "/"
+		NodePath string `db:"node_path"`
+		// NodeName is the fqnp of the record node
+		NodeName string `db:"node_name"`
+		Tags     Tags   `db:"tags"`
+		// EffectiveACL is the effective ACL of the record node, the search engines filter the records by it
+		EffectiveACL ACL `db:"effective_acl"`
 	}
 
 	IndexRecordQuery struct {
@@ -70,22 +147,82 @@ type (
 		CreatedBefore time.Time
 		FromID        string
 		Limit         int
+		// AsOf, if not zero, makes the records to be selected as they were at the time. The
+		// replaced and deleted records are available only for the formats keeping the history.
+		AsOf time.Time
+	}
+
+	// IndexRecordRevision is the replaced or deleted version of the index record. The
+	// version was valid from its UpdatedAt time till the ReplacedAt time.
+	IndexRecordRevision struct {
+		IndexRecord
+		// Revision is the unique sequence number of the revision
+		Revision   int64     `db:"revision"`
+		ReplacedAt time.Time `db:"replaced_at"`
+		// Deleted is true if the record was deleted at the ReplacedAt time
+		Deleted bool `db:"deleted"`
+	}
+
+	// IndexRecordRevisionsQuery allows to select the revisions of the node records, the
+	// revisions are ordered from the newest to the oldest ones
+	IndexRecordRevisionsQuery struct {
+		NodeID int64
+		// RecordID is the record revisions filter, if empty all the node records revisions are selected
+		RecordID string
+		Offset   int64
+		Limit    int64
+	}
+
+	// IndexRecordsDiff describes the difference between two versions of the node records
+	IndexRecordsDiff struct {
+		Added   []IndexRecord
+		Removed []IndexRecord
+		Changed []IndexRecordChange
+	}
+
+	// IndexRecordChange contains the two versions of the changed index record
+	IndexRecordChange struct {
+		From IndexRecord
+		To   IndexRecord
 	}
 
 	SearchQuery struct {
-		TextQuery        string
+		TextQuery string
+		// Embedding is the TextQuery embedding, it is used by the semantic search only
+		Embedding        Embedding
 		FilterConditions string
 		GroupByPathOff   bool // GroupByPathOff turns off results grouping by path.
 		Offset           int
 		Limit            int
+		// Mode is the search mode, the default mode of the search engine is used if it is empty
+		Mode SearchMode
+		// Fusion is the results merging method for the SearchModeHybrid, FusionRRF is used if it is empty
+		Fusion FusionMethod
+		// SemanticWeight is the semantic score weight [0, 1] for the FusionWeighted,
+		// DefaultSemanticWeight is used if it is 0
+		SemanticWeight float64
+		// Engine is the name of the search engine to be used, the default one is used if it is empty
+		Engine string
+		// Access, if not nil, restricts the search to the records of the nodes accessible
+		Access *Access
 	}
 
+	// SearchMode defines how the text query is matched against the index records
+	SearchMode string
+
+	// FusionMethod defines how the lexical and the semantic search results are merged
+	FusionMethod string
+
 	SearchQueryResultItem struct {
 		IndexRecord
 		Path                string   `db:"path"`
 		MatchedKeywordsList []string // mapped manually after filling the MatchedKeywords
 		MatchedKeywords     string   `db:"matched_keywords"`
 		Score               float32  `db:"score"`
+		// LexicalScore and SemanticScore are the component scores of the record,
+		// they are nil if the record is not found by the corresponding search
+		LexicalScore  *float32 `db:"-"`
+		SemanticScore *float32 `db:"-"`
 	}
 
 	SearchQueryResult struct {
@@ -93,6 +230,29 @@ type (
 		Total int64
 	}
 
+	// TagsPatch describes the changes of the node tags. All the tags are removed first if Clear
+	// is true, then the Remove keys are removed, and the Set tags are added or replaced.
+	TagsPatch struct {
+		Set    Tags
+		Remove []string
+		Clear  bool
+	}
+
+	// PatchNodesQuery provides parameters for patching the tags of multiple nodes
+	PatchNodesQuery struct {
+		// ID selects the node by its ID, if it is not 0
+		ID int64
+		// FilterConditions contains the node selection filter, it is used if the ID is 0
+		FilterConditions string
+		// Patch is the tags patch applied to the selected nodes
+		Patch TagsPatch
+		// Attrs, if not nil, replaces the attributes of the selected nodes by the same update as the
+		// tags patch, so the node version is incremented once
+		Attrs Attrs
+		// Access, if not nil, restricts the nodes selected to the accessible ones
+		Access *Access
+	}
+
 	// DeleteNodesQuery provides parameters for deleting multiple nodes
 	DeleteNodesQuery struct {
 		// FilterConditions contains the node selection filter
@@ -101,6 +261,35 @@ type (
 		// the selected node is a folder, and it has children, that don't match the filter criteria,
 		// they also will be deleted if the force is true.
 		Force bool
+		// Trash flag makes the nodes to be moved to the trash instead of deleting them,
+		// the trashed nodes may be restored until they are purged
+		Trash bool
+		// Offset and Limit define the page of the nodes returned by the delete preview
+		Offset int64
+		Limit  int64
+		// Access, if not nil, restricts the nodes matched to the accessible ones. ErrNotAuthorized is
+		// returned if any of the nodes to be deleted (e.g. the children of the folders) is not accessible.
+		Access *Access
+	}
+
+	// DeleteNodesPreview describes what the DeleteNodesQuery would delete
+	DeleteNodesPreview struct {
+		// Nodes contains the requested page of the nodes to be deleted ordered by their fqnps
+		Nodes []Node
+		// Total is the number of the nodes to be deleted
+		Total int64
+		// Records is the number of the index records to be deleted
+		Records int64
+		// ForceRequired is true if the matched folders have children, which do not match
+		// the filter, so the nodes may be deleted with the Force flag only. The Nodes, Total
+		// and Records always include such children.
+		ForceRequired bool
+	}
+
+	// RestoreNodesQuery provides parameters for restoring the trashed nodes
+	RestoreNodesQuery struct {
+		// FilterConditions contains the trashed node selection filter
+		FilterConditions string
 	}
 
 	// ListNodesQuery allows to select nodes by the condition provided
@@ -109,6 +298,29 @@ type (
 		FilterConditions string
 		Offset           int64
 		Limit            int64
+		// Access, if not nil, restricts the nodes selected to the accessible ones, it is
+		// ignored by the ListTrash
+		Access *Access
+	}
+
+	// CopyNodesQuery describes the subtree to be copied
+	CopyNodesQuery struct {
+		// From is the fqnp of the root node of the subtree
+		From string
+		// To is the fqnp of the root node copy
+		To string
+		// Tags, if not nil, replaces the tags of all the nodes copied
+		Tags Tags
+	}
+
+	// CopyNodesResult contains the result of the subtree copy
+	CopyNodesResult struct {
+		// Node is the copy of the subtree root node
+		Node Node
+		// Nodes is the number of the nodes copied
+		Nodes int64
+		// Records is the number of the index records copied
+		Records int64
 	}
 
 	QueryResult[T any, N any] struct {
@@ -116,6 +328,161 @@ type (
 		NextID N
 		Total  int64
 	}
+
+	// Change describes a node or an index record change. The changes are written to the
+	// changelog in the same transaction as the changed objects, the changelog is ordered by the
+	// change IDs, so the ID may be used as the cursor for following the changes.
+	Change struct {
+		ID     int64        `db:"id"`
+		Object ChangeObject `db:"object"`
+		Op     ChangeOp     `db:"op"`
+		NodeID int64        `db:"node_id"`
+		// Path, Name, Tags, EffectiveTags, Attrs and Flags are the node attributes at the time of
+		// the change. The Name contains the fqnp, the same way as it is stored for the node.
+		Path          string `db:"path"`
+		Name          string `db:"name"`
+		Tags          Tags   `db:"tags"`
+		EffectiveTags Tags   `db:"effective_tags"`
+		Attrs         Attrs  `db:"attrs"`
+		Flags         int32  `db:"flags"`
+		// RecordID, Format and Meta are set for the index record changes only
+		RecordID  string    `db:"record_id"`
+		Format    string    `db:"format"`
+		Meta      Tags      `db:"meta"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+
+	// ChangeObject is the type of the changed object
+	ChangeObject string
+
+	// ChangeOp is the change operation
+	ChangeOp string
+
+	// ChangesQuery allows to select the changes from the changelog
+	ChangesQuery struct {
+		// FromID is the cursor, the changes with the IDs greater than FromID are selected
+		FromID int64
+		// FilterConditions contains the filter for the changed nodes, it may be empty
+		FilterConditions string
+		Limit            int
+	}
+
+	// Webhook describes the subscription for the index events, the events are POSTed to the URL
+	// as the signed JSON payloads
+	Webhook struct {
+		ID  string `db:"id"`
+		URL string `db:"url"`
+		// Secret is used to sign the payloads, the payloads are not signed if it is empty
+		Secret string `db:"secret"`
+		// Path is the fqnp of the node, which events and the events of its subtree are sent. The
+		// events of all the nodes are sent if it is "/".
+		Path string `db:"path"`
+		// Events contains the types of the events sent, all the events are sent if it is empty
+		Events Strings `db:"events"`
+		// Cursor is the ID of the last change, which is delivered or added to the dead letters
+		Cursor int64 `db:"cursor"`
+		// Attempts is the number of the failed delivery attempts of the event next to the Cursor
+		Attempts int `db:"attempts"`
+		// NextAttemptAt is the time, when the failed delivery is retried
+		NextAttemptAt time.Time `db:"next_attempt_at"`
+		// LockedBy is the instance, which delivers the events to the webhook, the webhook is
+		// locked by it till the LockedUntil
+		LockedBy    string    `db:"locked_by"`
+		LockedUntil time.Time `db:"locked_until"`
+		CreatedAt   time.Time `db:"created_at"`
+		UpdatedAt   time.Time `db:"updated_at"`
+	}
+
+	// DeadLetter describes the webhook event, which could not be delivered
+	DeadLetter struct {
+		ID        int64  `db:"id"`
+		WebhookID string `db:"webhook_id"`
+		// ChangeID is the ID of the change the event is sent for
+		ChangeID int64  `db:"change_id"`
+		Event    string `db:"event"`
+		// Payload is the JSON payload of the event
+		Payload []byte `db:"payload"`
+		// Error is the last delivery error
+		Error     string    `db:"error"`
+		Attempts  int       `db:"attempts"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+
+	// DeadLettersQuery allows to select the dead letters of the webhook, the newest ones go first
+	DeadLettersQuery struct {
+		WebhookID string
+		Offset    int64
+		Limit     int64
+	}
+
+	// IdempotencyKey describes the request made with the idempotency key provided by the client
+	IdempotencyKey struct {
+		Key string `db:"key"`
+		// Fingerprint is the hash of the request, the key cannot be reused for another request
+		Fingerprint string `db:"fingerprint"`
+		// Done is true if the request is completed, the Response contains its result then
+		Done     bool   `db:"done"`
+		Response []byte `db:"response"`
+		// LockedUntil is the lease of the request in progress, the request may be taken over by
+		// its retry after the lease is expired (e.g. the process executing it has crashed)
+		LockedUntil time.Time `db:"locked_until"`
+		CreatedAt   time.Time `db:"created_at"`
+	}
+
+	// Job describes the asynchronous parsing of the document, which records are written to the node
+	Job struct {
+		ID     int64 `db:"id"`
+		NodeID int64 `db:"node_id"`
+		// Path is the fqnp of the node
+		Path   string    `db:"path"`
+		Parser string    `db:"parser"`
+		Status JobStatus `db:"status"`
+		// Records is the number of the records written by the parser so far
+		Records int64  `db:"records"`
+		Error   string `db:"error"`
+		// LockedBy is the instance, which runs the job, the job is locked by it till the LockedUntil
+		LockedBy    string    `db:"locked_by"`
+		LockedUntil time.Time `db:"locked_until"`
+		CreatedAt   time.Time `db:"created_at"`
+		UpdatedAt   time.Time `db:"updated_at"`
+	}
+
+	// JobStatus is the status of the job
+	JobStatus string
+
+	// JobsQuery allows to select the jobs, the newest ones go first
+	JobsQuery struct {
+		// Status selects the jobs with the status provided, all the jobs are selected if it is empty
+		Status JobStatus
+		// LockedBefore selects the jobs, which locks expire before the time, if it is not zero
+		LockedBefore time.Time
+		Offset       int64
+		Limit        int64
+	}
+
+	// Strings is the list of strings stored as a JSON array
+	Strings []string
+
+	// EngineSwitch describes the online switch of the default search engine
+	EngineSwitch struct {
+		ID    int64             `db:"id"`
+		From  string            `db:"from_engine"`
+		To    string            `db:"to_engine"`
+		State EngineSwitchState `db:"state"`
+		// Phase is the current step of the switch, e.g. "index"
+		Phase string `db:"phase"`
+		// Progress is the completed part [0, 1] of the current Phase
+		Progress float64 `db:"progress"`
+		Error    string  `db:"error"`
+		// Owner is the instance running the switch, it updates the UpdatedAt of the running
+		// switch periodically, so the switch abandoned by a stopped instance can be detected
+		Owner     string    `db:"owner"`
+		CreatedAt time.Time `db:"created_at"`
+		UpdatedAt time.Time `db:"updated_at"`
+	}
+
+	// EngineSwitchState is the state of the EngineSwitch
+	EngineSwitchState string
 )
 
 const (
@@ -123,16 +490,79 @@ const (
 	NodeFlagDocument = 1 // if it is set, it is a document. If not set, it is a folder
 )
 
+const (
+	ChangeObjectNode   ChangeObject = "node"
+	ChangeObjectRecord ChangeObject = "record"
+
+	ChangeOpCreate ChangeOp = "create"
+	ChangeOpUpdate ChangeOp = "update"
+	ChangeOpDelete ChangeOp = "delete"
+)
+
+const (
+	EngineSwitchRunning  EngineSwitchState = "running"
+	EngineSwitchDone     EngineSwitchState = "done"
+	EngineSwitchFailed   EngineSwitchState = "failed"
+	EngineSwitchCanceled EngineSwitchState = "canceled"
+)
+
+const (
+	AttrString    AttrType = "string"
+	AttrNumber    AttrType = "number"
+	AttrBool      AttrType = "bool"
+	AttrTimestamp AttrType = "timestamp"
+	AttrStrings   AttrType = "strings"
+)
+
+const (
+	PermissionRead  Permission = "read"
+	PermissionWrite Permission = "write"
+	PermissionAdmin Permission = "admin"
+)
+
+const (
+	JobPending  JobStatus = "pending"
+	JobRunning  JobStatus = "running"
+	JobDone     JobStatus = "done"
+	JobFailed   JobStatus = "failed"
+	JobCanceled JobStatus = "canceled"
+)
+
+// Finished returns true if the job with the status is completed
+func (js JobStatus) Finished() bool {
+	return js == JobDone || js == JobFailed || js == JobCanceled
+}
+
 func (t Tags) Value() (value driver.Value, err error) {
 	return json.Marshal(t)
 }
 
 func (t *Tags) Scan(value any) error {
-	buf, ok := value.([]byte)
-	if !ok {
-		return fmt.Errorf("not a []byte value in scan")
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, &t)
+	case string:
+		// some drivers (e.g. SQLite) return the JSON text columns as strings
+		return json.Unmarshal([]byte(v), &t)
+	}
+	return fmt.Errorf("not a []byte value in scan")
+}
+
+func (s Strings) Value() (value driver.Value, err error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s)
+}
+
+func (s *Strings) Scan(value any) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, &s)
+	case string:
+		return json.Unmarshal([]byte(v), &s)
 	}
-	return json.Unmarshal(buf, &t)
+	return fmt.Errorf("not a []byte value in scan")
 }
 
 func (t Tags) JSON() string {
@@ -149,3 +579,98 @@ func (t Tags) JSON() string {
 	sb.WriteString("}")
 	return sb.String()
 }
+
+// IsEmpty returns true if the patch does not change the tags
+func (tp TagsPatch) IsEmpty() bool {
+	return !tp.Clear && len(tp.Remove) == 0 && len(tp.Set) == 0
+}
+
+// Apply returns the new tags, which are the tags t patched by the tp
+func (tp TagsPatch) Apply(t Tags) Tags {
+	res := Tags{}
+	if !tp.Clear {
+		for k, v := range t {
+			res[k] = v
+		}
+	}
+	for _, k := range tp.Remove {
+		delete(res, k)
+	}
+	for k, v := range tp.Set {
+		res[k] = v
+	}
+	return res
+}
+
+// Check returns ErrInvalid if the patch is empty or no node selection is specified
+func (q PatchNodesQuery) Check() error {
+	if q.Patch.IsEmpty() {
+		return fmt.Errorf("the tags patch must not be empty: %w", errors.ErrInvalid)
+	}
+	if q.ID == 0 && strings.TrimSpace(q.FilterConditions) == "" {
+		return fmt.Errorf("the node ID or the filter conditions must be specified: %w", errors.ErrInvalid)
+	}
+	return nil
+}
+
+// Value returns the embedding in the pgvector text format, the empty embedding is NULL
+func (e Embedding) Value() (driver.Value, error) {
+	if len(e) == 0 {
+		return nil, nil
+	}
+	return e.String(), nil
+}
+
+// Scan reads the embedding from the pgvector text format
+func (e *Embedding) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		return e.Scan(string(v))
+	case string:
+		res, err := ParseEmbedding(v)
+		if err != nil {
+			return err
+		}
+		*e = res
+		return nil
+	}
+	return fmt.Errorf("not a string value in scan")
+}
+
+func (e Embedding) String() string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	for i, f := range e {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.FormatFloat(float64(f), 'f', -1, 32))
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
+
+// ParseEmbedding parses the embedding in the pgvector text format, e.g. "[0.1,0.2,0.3]"
+func ParseEmbedding(s string) (Embedding, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
+		return nil, fmt.Errorf("the embedding %q must be in the [0.1,0.2,...] form: %w", s, errors.ErrInvalid)
+	}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+	if len(s) == 0 {
+		return Embedding{}, nil
+	}
+	parts := strings.Split(s, ",")
+	res := make(Embedding, len(parts))
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 32)
+		if err != nil {
+			return nil, fmt.Errorf("the embedding element %q is not a number: %w", p, errors.ErrInvalid)
+		}
+		res[i] = float32(f)
+	}
+	return res, nil
+}
@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEmbedding(t *testing.T) {
+	v, err := Embedding{}.Value()
+	assert.Nil(t, err)
+	assert.Nil(t, v)
+
+	v, err = Embedding{0.5, -1, 2.25}.Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "[0.5,-1,2.25]", v)
+
+	var e Embedding
+	assert.Nil(t, e.Scan([]byte("[0.5,-1,2.25]")))
+	assert.Equal(t, Embedding{0.5, -1, 2.25}, e)
+	assert.Nil(t, e.Scan(nil))
+	assert.Nil(t, e)
+
+	e, err = ParseEmbedding(" [ 1, 2 ] ")
+	assert.Nil(t, err)
+	assert.Equal(t, Embedding{1, 2}, e)
+	e, err = ParseEmbedding("[]")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(e))
+
+	for _, s := range []string{"", "1,2", "[1,2", "[1,a]", "hello world"} {
+		_, err = ParseEmbedding(s)
+		assert.ErrorIs(t, err, errors.ErrInvalid, s)
+	}
+}
+
+func TestTagsPatch(t *testing.T) {
+	tags := Tags{"a": "1", "b": "2"}
+	assert.True(t, TagsPatch{}.IsEmpty())
+	assert.Equal(t, Tags{"a": "1", "b": "2"}, TagsPatch{}.Apply(tags))
+	assert.Equal(t, Tags{"b": "3", "c": "4"}, TagsPatch{Set: Tags{"b": "3", "c": "4"}, Remove: []string{"a"}}.Apply(tags))
+	assert.Equal(t, Tags{"a": "5"}, TagsPatch{Set: Tags{"a": "5"}, Remove: []string{"a"}, Clear: true}.Apply(tags))
+	assert.Equal(t, Tags{}, TagsPatch{Clear: true}.Apply(nil))
+	assert.Equal(t, Tags{"a": "1", "b": "2"}, tags)
+
+	assert.Nil(t, PatchNodesQuery{ID: 1, Patch: TagsPatch{Clear: true}}.Check())
+	assert.Nil(t, PatchNodesQuery{FilterConditions: "path = '/a'", Patch: TagsPatch{Remove: []string{"a"}}}.Check())
+	assert.True(t, errors.Is(PatchNodesQuery{ID: 1}.Check(), errors.ErrInvalid))
+	assert.True(t, errors.Is(PatchNodesQuery{FilterConditions: " ", Patch: TagsPatch{Clear: true}}.Check(), errors.ErrInvalid))
+}
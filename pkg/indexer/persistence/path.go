@@ -95,3 +95,28 @@ func ToNodePathName(path string) (string, string) { // path, name
 	}
 	return path, strings.TrimSpace(parts[len(parts)-1])
 }
+
+// InSubtree returns whether the fqnp belongs to the subtree of the node with the root fqnp,
+// the root node itself is in its subtree.
+func InSubtree(root, fqnp string) bool {
+	root, fqnp = ConcatPath(root, ""), ConcatPath(fqnp, "")
+	return root == "/" || fqnp == root || strings.HasPrefix(fqnp, root+"/")
+}
+
+// SubtreeConditions returns the filter conditions selecting the node with the root fqnp and its
+// subtree, the conditions are empty for the root "/"
+func SubtreeConditions(root string) (string, error) {
+	root = ConcatPath(root, "")
+	if root == "/" {
+		return "", nil
+	}
+	// the QL strings don't support escaping, so the quote not used by the fqnp is chosen
+	q := "'"
+	if strings.Contains(root, q) {
+		q = "\""
+	}
+	if strings.Contains(root, q) {
+		return "", fmt.Errorf("the path=%q cannot contain both single and double quotes: %w", root, errors.ErrInvalid)
+	}
+	return fmt.Sprintf("node = %s%s%s or prefix(node, %s%s/%s)", q, root, q, q, root, q), nil
+}
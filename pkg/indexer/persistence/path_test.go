@@ -1,6 +1,7 @@
 package persistence
 
 import (
+	"github.com/acquirecloud/golibs/errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -34,3 +35,25 @@ func TestConcatPath(t *testing.T) {
 	assert.Equal(t, "/aaa", ConcatPath("", "aaa"))
 	assert.Equal(t, "/aaa", ConcatPath("", "//aaa"))
 }
+
+func TestInSubtree(t *testing.T) {
+	assert.True(t, InSubtree("/", "/aaa"))
+	assert.True(t, InSubtree("/aaa", "/aaa"))
+	assert.True(t, InSubtree("/aaa/", "aaa/bbb/ccc"))
+	assert.False(t, InSubtree("/aaa", "/aaab"))
+	assert.False(t, InSubtree("/aaa/bbb", "/aaa"))
+}
+
+func TestSubtreeConditions(t *testing.T) {
+	fc, err := SubtreeConditions("")
+	assert.Nil(t, err)
+	assert.Equal(t, "", fc)
+	fc, err = SubtreeConditions("aaa/")
+	assert.Nil(t, err)
+	assert.Equal(t, "node = '/aaa' or prefix(node, '/aaa/')", fc)
+	fc, err = SubtreeConditions("/a'b")
+	assert.Nil(t, err)
+	assert.Equal(t, "node = \"/a'b\" or prefix(node, \"/a'b/\")", fc)
+	_, err = SubtreeConditions("/a'b\"c")
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+}
@@ -15,6 +15,7 @@ package persistence
 
 import (
 	"context"
+	"time"
 )
 
 type (
@@ -56,23 +57,138 @@ type (
 
 		// GetNode returns the node by its fqnp
 		GetNode(fqnp string) (Node, error)
-		// UpdateNode updates node data
+		// LockNode returns the node by its fqnp the same way as GetNode does, but the node is locked
+		// till the end of the transaction, so it cannot be changed by other transactions meanwhile
+		LockNode(fqnp string) (Node, error)
+		// UpdateNode updates node data and increments the node version. The tags are replaced if they
+		// are not empty, the attributes are replaced if they are not nil.
 		UpdateNode(node Node) error
+		// PatchNodes applies the tags patch to the nodes selected by the query and increments
+		// their versions. It returns the number of the nodes patched, or ErrNotExist if no
+		// nodes are selected. ErrInvalid is returned if the patch is empty.
+		PatchNodes(query PatchNodesQuery) (int64, error)
+		// SetNodeACL sets the permission of the principal for the node with the fqnp, the permission
+		// is removed if it is empty. The effective ACLs of the node and its descendants are updated, the
+		// node version is not changed. The function returns the node updated or ErrNotExist if the node
+		// is not found.
+		SetNodeACL(fqnp, principal string, perm Permission) (Node, error)
+		// MoveNode moves the node with the fqnp from to the fqnp to, the paths of all the node
+		// descendants are changed accordingly and the versions of the nodes moved are incremented.
+		// The parent folder of the to fqnp must exist.
+		// The function returns ErrNotExist if the node or the parent folder is not found, ErrExist if
+		// a node with the fqnp to already exists, and ErrInvalid if the parent is a document or it is
+		// in the subtree of the node moved.
+		MoveNode(from, to string) (Node, error)
+		// CopyNodes copies the node with all its descendants and their index records. The same
+		// as for MoveNode, the parent folder of the copy must exist. The function returns ErrNotExist
+		// if the node or the parent folder is not found, ErrExist if a node with the copy fqnp already
+		// exists, and ErrInvalid if the parent is a document or it is in the subtree of the node copied.
+		// The copies ACLs are empty, so the copies inherit the ACL of the destination folder.
+		CopyNodes(query CopyNodesQuery) (CopyNodesResult, error)
 
 		// DeleteNodes deletes the Nodes that matches to the DeleteNodesQuery and all the records associated with the nodes.
 		// force allows to delete folder nodes with children. If the node is a folder, and there are children,
-		// but the force flag is false, the function will return ErrConflict error
+		// but the force flag is false, the function will return ErrConflict error. If the trash flag is set,
+		// the nodes are moved to the trash instead, the trashed nodes are not returned by the other functions.
 		//
 		// NOTE: The operation is not atomic until an external transaction is not started,
 		// the caller MUST start the transaction before using this method.
 		DeleteNodes(DeleteNodesQuery) error
+		// PreviewDeleteNodes returns the nodes, which would be deleted (or trashed) by the DeleteNodes
+		// called with the same query, without deleting them. The Force flag of the query is ignored.
+		PreviewDeleteNodes(query DeleteNodesQuery) (DeleteNodesPreview, error)
+		// ListTrash returns the trashed nodes according to the query filter request
+		ListTrash(query ListNodesQuery) ([]Node, error)
+		// RestoreNodes restores the trashed nodes that match the query together with their trashed
+		// descendants and ancestors. It returns the number of the nodes restored or ErrNotExist if
+		// no trashed node matches the query.
+		RestoreNodes(query RestoreNodesQuery) (int64, error)
+		// PurgeTrash deletes the nodes trashed before the time provided and all the records
+		// associated with the nodes. It returns the number of the nodes deleted.
+		PurgeTrash(before time.Time) (int64, error)
 
-		// UpsertIndexRecords creates or updates index record entries. It returns the new records created
+		// UpsertIndexRecords creates or updates index record entries. It returns the new records created.
+		// The versions of the updated records and of their nodes are incremented.
 		UpsertIndexRecords(records ...IndexRecord) (int64, error)
-		// DeleteIndexRecords deletes index record entries
+		// DeleteIndexRecords deletes index record entries, the versions of their nodes are incremented
 		DeleteIndexRecords(records ...IndexRecord) (int64, error)
 		// QueryIndexRecords lists query matching index record entries
 		QueryIndexRecords(query IndexRecordQuery) (QueryResult[IndexRecord, string], error)
+		// ListIndexRecordRevisions lists the replaced and deleted versions of the node index records
+		ListIndexRecordRevisions(query IndexRecordRevisionsQuery) ([]IndexRecordRevision, error)
+
+		// ListChanges lists the changelog entries ordered by their IDs. The result NextID is the
+		// cursor to continue from, it is advanced even if no changes match the filter conditions.
+		ListChanges(query ChangesQuery) (QueryResult[Change, int64], error)
+		// LastChangeID returns the ID of the last changelog entry, or 0 if the changelog is empty
+		LastChangeID() (int64, error)
+		// PurgeChanges deletes the changelog entries created before the time provided
+		PurgeChanges(before time.Time) (int64, error)
+
+		// CreateWebhook creates the new webhook subscription
+		CreateWebhook(webhook Webhook) (Webhook, error)
+		// GetWebhook returns the webhook by its ID
+		GetWebhook(ID string) (Webhook, error)
+		// DeleteWebhook deletes the webhook together with its dead letters
+		DeleteWebhook(ID string) error
+		// ListWebhooks returns all the webhooks ordered by their IDs
+		ListWebhooks() ([]Webhook, error)
+		// LockWebhook locks the webhook by the owner till the lockedUntil, if the webhook is locked by the
+		// owner already, or its lock is not after the expired time. It returns ErrNotExist if there is no
+		// such webhook, or it is locked by another owner.
+		LockWebhook(ID, owner string, expired, lockedUntil time.Time) (Webhook, error)
+		// UpdateWebhookCursor sets the ID of the last change processed by the webhook and resets its failed
+		// delivery attempts. It returns ErrNotExist if the webhook is not locked by the owner.
+		UpdateWebhookCursor(ID, owner string, cursor int64) error
+		// UpdateWebhookAttempts sets the number of the failed delivery attempts of the webhook and the time of
+		// the next one. It returns ErrNotExist if the webhook is not locked by the owner.
+		UpdateWebhookAttempts(ID, owner string, attempts int, nextAttemptAt time.Time) error
+		// CreateDeadLetter adds the webhook event, which could not be delivered, to the dead letters
+		CreateDeadLetter(dl DeadLetter) (DeadLetter, error)
+		// ListDeadLetters returns the dead letters of the webhook
+		ListDeadLetters(query DeadLettersQuery) ([]DeadLetter, error)
+
+		// CreateIdempotencyKey stores the new idempotency key, it returns ErrExist if the key already exists
+		CreateIdempotencyKey(ik IdempotencyKey) error
+		// GetIdempotencyKey returns the idempotency key by its value
+		GetIdempotencyKey(key string) (IdempotencyKey, error)
+		// LockIdempotencyKey sets the LockedUntil of the request in progress with the key to the lockedUntil,
+		// if its current LockedUntil is not after the expired time. It returns ErrNotExist if there is no
+		// such request in progress, or its lease is not expired.
+		LockIdempotencyKey(key string, expired, lockedUntil time.Time) error
+		// CompleteIdempotencyKey marks the idempotency key request done and stores its response
+		CompleteIdempotencyKey(key string, response []byte) error
+		// DeleteIdempotencyKey deletes the idempotency key
+		DeleteIdempotencyKey(key string) error
+		// PurgeIdempotencyKeys deletes the idempotency keys created before the time provided
+		PurgeIdempotencyKeys(before time.Time) (int64, error)
+
+		// CreateAttrSchema declares the typed node attribute for the prefix, the index of the attribute values is
+		// created with its first declaration. The function returns ErrExist if the attribute is already declared
+		// for the prefix, or ErrConflict if it is declared with another type for another prefix.
+		CreateAttrSchema(as AttrSchema) (AttrSchema, error)
+		// DeleteAttrSchema deletes the attribute declaration for the prefix, the attribute values of the nodes are
+		// kept. The attribute index is dropped with its last declaration.
+		DeleteAttrSchema(prefix, name string) error
+		// ListAttrSchemas returns all the attributes declarations ordered by their prefixes and names
+		ListAttrSchemas() ([]AttrSchema, error)
+
+		// CreateJob creates the new job, the job ID is assigned on the creation
+		CreateJob(job Job) (Job, error)
+		// GetJob returns the job by its ID
+		GetJob(ID int64) (Job, error)
+		// UpdateJob updates the job status, the number of the records written and the error
+		UpdateJob(job Job) error
+		// ListJobs returns the jobs according to the query
+		ListJobs(query JobsQuery) ([]Job, error)
+		// LockJob locks the pending or running job by the owner till the lockedUntil, if the job is locked
+		// by the owner already, or its lock is not after the expired time. It returns ErrNotExist if there
+		// is no such job, or it is locked by another owner.
+		LockJob(ID int64, owner string, expired, lockedUntil time.Time) (Job, error)
+		// StartJob sets the running status of the pending job locked by the owner and resets the number
+		// of its records written. It returns ErrNotExist if there is no such job, it is not pending anymore
+		// (e.g. it is canceled), or it is locked by another owner.
+		StartJob(ID int64, owner string) (Job, error)
 
 		// Search performs search across existing index records
 		// the query string should be formed in accordance with the query
@@ -90,4 +206,16 @@ type (
 		// NewTx creates Tx object
 		NewTx(ctx context.Context) Tx
 	}
+
+	// EngineSwitcher is implemented by the Db, which allows to change the default search engine
+	// without downtime: the new engine index is built in the background, while the current
+	// engine is serving the searches, and the engines are flipped when the index is ready.
+	EngineSwitcher interface {
+		// SwitchEngine starts the switch to the engine, it returns ErrConflict if another switch is running
+		SwitchEngine(ctx context.Context, engine string) (EngineSwitch, error)
+		// GetEngineSwitch returns the last switch, it returns ErrNotExist if there were no switches
+		GetEngineSwitch(ctx context.Context) (EngineSwitch, error)
+		// CancelEngineSwitch cancels the running switch, it returns ErrNotExist if no switch is running
+		CancelEngineSwitch(ctx context.Context) (EngineSwitch, error)
+	}
 )
@@ -0,0 +1,421 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bleve
+
+import (
+	"context"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/analysis/lang/en"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"github.com/jmoiron/sqlx"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/ql"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Index is the Bleve search index of the index records. The index keeps the segments
+	// and the node attributes needed for the filter conditions, but the records themselves
+	// are read from Postgres.
+	Index struct {
+		idx bleve.Index
+	}
+
+	// hit is a search hit, the score is already multiplied by the record rank multiplier
+	hit struct {
+		docID  string
+		nodeID int64
+		id     string
+		node   string
+		score  float64
+	}
+)
+
+const (
+	fieldSegment = "segment"
+	fieldNodeID  = "node_id"
+	fieldID      = "rid"
+	fieldPath    = "path"
+	fieldNode    = "node"
+	fieldFormat  = "format"
+	fieldRank    = "rank"
+	fieldTags    = "tags"
+	fieldACL     = "acl"
+
+	// hitsBatchSize is the number of hits read from the index per one request
+	hitsBatchSize = 1000
+	// groupSlack is the number of the hits read per one result, when the hits are grouped by node
+	groupSlack = 4
+)
+
+// FcTranslator is used for the filter conditions of the nodes selection (ListNodes etc.), which
+// are still done by Postgres
+var FcTranslator = ql.NewTranslator(ql.PqFilterConditionsDialect)
+
+// Open opens the index in the dir, or creates the new one, if the dir doesn't exist
+func Open(dir string) (*Index, error) {
+	idx, err := bleve.Open(dir)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		idx, err = bleve.New(dir, newMapping())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Index{idx: idx}, nil
+}
+
+func newMapping() *mapping.IndexMappingImpl {
+	segment := bleve.NewTextFieldMapping()
+	segment.Analyzer = en.AnalyzerName
+	segment.Store = false
+	segment.IncludeTermVectors = true
+
+	kw := bleve.NewKeywordFieldMapping()
+	rank := bleve.NewNumericFieldMapping()
+	rank.Index = false
+
+	dm := bleve.NewDocumentMapping()
+	dm.AddFieldMappingsAt(fieldSegment, segment)
+	dm.AddFieldMappingsAt(fieldNodeID, kw)
+	dm.AddFieldMappingsAt(fieldID, kw)
+	dm.AddFieldMappingsAt(fieldPath, kw)
+	dm.AddFieldMappingsAt(fieldNode, kw)
+	dm.AddFieldMappingsAt(fieldFormat, kw)
+	dm.AddFieldMappingsAt(fieldRank, rank)
+	dm.AddFieldMappingsAt(fieldACL, kw)
+	// the tags are mapped dynamically by the default (keyword) analyzer
+	dm.AddSubDocumentMapping(fieldTags, bleve.NewDocumentMapping())
+
+	im := bleve.NewIndexMapping()
+	im.DefaultAnalyzer = keyword.Name
+	im.DefaultMapping = dm
+	return im
+}
+
+// Upsert is a part of postgres.ExtIndex
+func (i *Index) Upsert(ctx context.Context, docs ...persistence.IndexRecordDoc) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	b := i.idx.NewBatch()
+	for _, d := range docs {
+		tags := make(map[string]any, len(d.Tags))
+		for k, v := range d.Tags {
+			tags[k] = v
+		}
+		if err := b.Index(docID(d.NodeID, d.ID), map[string]any{
+			fieldSegment: d.Segment,
+			fieldNodeID:  strconv.FormatInt(d.NodeID, 10),
+			fieldID:      d.ID,
+			fieldPath:    d.NodePath,
+			fieldNode:    d.NodeName,
+			fieldFormat:  d.Format,
+			fieldRank:    d.RankMult,
+			fieldTags:    tags,
+			fieldACL:     d.EffectiveACL.Grants(),
+		}); err != nil {
+			return err
+		}
+	}
+	return i.idx.Batch(b)
+}
+
+// Delete is a part of postgres.ExtIndex
+func (i *Index) Delete(ctx context.Context, recs ...persistence.IndexRecord) error {
+	if len(recs) == 0 {
+		return nil
+	}
+	b := i.idx.NewBatch()
+	for _, r := range recs {
+		b.Delete(docID(r.NodeID, r.ID))
+	}
+	return i.idx.Batch(b)
+}
+
+// DeleteNodes is a part of postgres.ExtIndex
+func (i *Index) DeleteNodes(ctx context.Context, nodeIDs ...int64) error {
+	for _, nID := range nodeIDs {
+		q := termQuery(fieldNodeID, strconv.FormatInt(nID, 10))
+		for {
+			res, err := i.idx.SearchInContext(ctx, bleve.NewSearchRequestOptions(q, hitsBatchSize, 0, false))
+			if err != nil {
+				return err
+			}
+			if len(res.Hits) == 0 {
+				break
+			}
+			b := i.idx.NewBatch()
+			for _, h := range res.Hits {
+				b.Delete(h.ID)
+			}
+			if err = i.idx.Batch(b); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Count is a part of postgres.ExtIndex
+func (i *Index) Count(ctx context.Context) (int64, error) {
+	cnt, err := i.idx.DocCount()
+	return int64(cnt), err
+}
+
+// Close is a part of postgres.ExtIndex
+func (i *Index) Close() error {
+	return i.idx.Close()
+}
+
+// Search is the SearchFn for the Bleve index. The text query is matched against the record
+// segments, all the terms must be in the segment. The filter conditions are applied to
+// the records in the index, and the found records are read from the database by qx. The records
+// are filtered by the grants of their nodes effective ACLs, if the query Access is set.
+func (i *Index) Search(ctx context.Context, qx sqlx.QueryerContext, q persistence.SearchQuery) (persistence.SearchQueryResult, error) {
+	tq := bleve.NewMatchQuery(q.TextQuery)
+	tq.SetField(fieldSegment)
+	tq.SetOperator(query.MatchQueryOperatorAnd)
+	fq, err := filterQuery(q.FilterConditions)
+	if err != nil {
+		return persistence.SearchQueryResult{}, err
+	}
+	qs := []query.Query{tq}
+	if fq != nil {
+		qs = append(qs, fq)
+	}
+	if q.Access != nil {
+		qs = append(qs, accessQuery(*q.Access))
+	}
+	sq := qs[0]
+	if len(qs) > 1 {
+		sq = bleve.NewConjunctionQuery(qs...)
+	}
+
+	// the top hits are read for the requested page only, the rank multipliers reorder the read hits
+	n := 0
+	if q.Limit > 0 {
+		n = max(q.Offset, 0) + q.Limit
+	}
+	hits, total, all, err := i.hits(ctx, sq, n, !q.GroupByPathOff)
+	if err != nil {
+		return persistence.SearchQueryResult{}, err
+	}
+	if !q.GroupByPathOff {
+		hits = groupByNode(hits)
+		// the number of the found nodes is known, if all the hits are read, otherwise the
+		// number of the found records is returned as the upper bound of it
+		if all {
+			total = int64(len(hits))
+		}
+	}
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].score != hits[b].score {
+			return hits[a].score > hits[b].score
+		}
+		if !q.GroupByPathOff && hits[a].node != hits[b].node {
+			return hits[a].node < hits[b].node
+		}
+		return hits[a].id < hits[b].id
+	})
+
+	if q.Limit <= 0 || q.Offset >= len(hits) {
+		return persistence.SearchQueryResult{Total: total}, nil
+	}
+	hits = hits[max(q.Offset, 0):min(max(q.Offset, 0)+q.Limit, len(hits))]
+
+	items, err := readItems(ctx, qx, hits)
+	if err != nil {
+		return persistence.SearchQueryResult{}, err
+	}
+	if err = i.fillMatchedKeywords(ctx, tq, items); err != nil {
+		return persistence.SearchQueryResult{}, err
+	}
+	return persistence.SearchQueryResult{Items: items, Total: total}, nil
+}
+
+// hits returns the top hits for the query q, which are enough for n results, and the total number
+// of the records matched. If group is true, the hits are read until n different nodes are found.
+// The returned all is true, if all the matched hits are read.
+func (i *Index) hits(ctx context.Context, q query.Query, n int, group bool) (res []hit, total int64, all bool, err error) {
+	size := n
+	if group {
+		size *= groupSlack
+	}
+	size = min(size, hitsBatchSize)
+	nodes := make(map[int64]bool)
+	for {
+		// the search after the score sort values is not supported by Bleve, so the hits are read by the offset
+		req := bleve.NewSearchRequestOptions(q, size, len(res), false)
+		req.Fields = []string{fieldNodeID, fieldID, fieldNode, fieldRank}
+		req.SortBy([]string{"-_score", "_id"})
+		sr, err := i.idx.SearchInContext(ctx, req)
+		if err != nil {
+			return nil, 0, false, fmt.Errorf("bleve search failed: %w", err)
+		}
+		total = int64(sr.Total)
+		for _, h := range sr.Hits {
+			nodeID, _ := strconv.ParseInt(fmt.Sprint(h.Fields[fieldNodeID]), 10, 64)
+			rank, _ := h.Fields[fieldRank].(float64)
+			if rank <= 0 {
+				rank = 1.0
+			}
+			res = append(res, hit{
+				docID:  h.ID,
+				nodeID: nodeID,
+				id:     fmt.Sprint(h.Fields[fieldID]),
+				node:   fmt.Sprint(h.Fields[fieldNode]),
+				score:  h.Score * rank,
+			})
+			nodes[nodeID] = true
+		}
+		if len(res) >= int(total) {
+			return res, total, true, nil
+		}
+		if len(sr.Hits) < size || (!group && len(res) >= n) || (group && len(nodes) >= n) {
+			return res, total, false, nil
+		}
+	}
+}
+
+// groupByNode returns the best hit for every node
+func groupByNode(hits []hit) []hit {
+	best := make(map[int64]int)
+	var res []hit
+	for _, h := range hits {
+		idx, ok := best[h.nodeID]
+		if !ok {
+			best[h.nodeID] = len(res)
+			res = append(res, h)
+			continue
+		}
+		if b := res[idx]; b.score < h.score || (b.score == h.score && h.id < b.id) {
+			res[idx] = h
+		}
+	}
+	return res
+}
+
+// readItems reads the records for the hits from the database, the result items are in the hits order
+func readItems(ctx context.Context, qx sqlx.QueryerContext, hits []hit) ([]persistence.SearchQueryResultItem, error) {
+	var sb strings.Builder
+	var args []any
+	for _, h := range hits {
+		if sb.Len() > 0 {
+			sb.WriteString(" or ")
+		}
+		sb.WriteString("(ir.node_id = ? and ir.id = ?)")
+		args = append(args, h.nodeID, h.id)
+	}
+	rows, err := qx.QueryxContext(ctx, sqlx.Rebind(sqlx.DOLLAR,
+		"select ir.*, n.name as path from index_record as ir inner join node as n on n.id = ir.node_id where "+sb.String()), args...)
+	if err != nil {
+		return nil, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	recs, err := persistence.ScanRowsQueryResult[persistence.SearchQueryResultItem](rows)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]persistence.SearchQueryResultItem, len(recs))
+	for _, r := range recs {
+		byID[docID(r.NodeID, r.ID)] = r
+	}
+	res := make([]persistence.SearchQueryResultItem, 0, len(hits))
+	for _, h := range hits {
+		// the record may be deleted in between, skip it then
+		if r, ok := byID[h.docID]; ok {
+			r.Score = float32(h.score)
+			res = append(res, r)
+		}
+	}
+	return res, nil
+}
+
+// fillMatchedKeywords finds the segment words matched by the text query tq
+func (i *Index) fillMatchedKeywords(ctx context.Context, tq query.Query, items []persistence.SearchQueryResultItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]string, len(items))
+	for idx, it := range items {
+		ids[idx] = docID(it.NodeID, it.ID)
+	}
+	req := bleve.NewSearchRequestOptions(bleve.NewConjunctionQuery(bleve.NewDocIDQuery(ids), tq), len(ids), 0, false)
+	req.IncludeLocations = true
+	sr, err := i.idx.SearchInContext(ctx, req)
+	if err != nil {
+		return fmt.Errorf("bleve search failed: %w", err)
+	}
+	locs := make(map[string]search.TermLocationMap, len(sr.Hits))
+	for _, h := range sr.Hits {
+		locs[h.ID] = h.Locations[fieldSegment]
+	}
+	for idx, it := range items {
+		items[idx].MatchedKeywordsList = matchedKeywords(it.Segment, locs[ids[idx]])
+	}
+	return nil
+}
+
+// matchedKeywords returns the unique words of the segment found in the locations, in the order they are in the segment
+func matchedKeywords(segment string, tlm search.TermLocationMap) []string {
+	var all []*search.Location
+	for _, ls := range tlm {
+		all = append(all, ls...)
+	}
+	sort.Slice(all, func(a, b int) bool { return all[a].Start < all[b].Start })
+	var res []string
+	seen := make(map[string]bool)
+	for _, l := range all {
+		if l.End > uint64(len(segment)) || l.Start >= l.End {
+			continue
+		}
+		w := segment[l.Start:l.End]
+		if !seen[w] {
+			seen[w] = true
+			res = append(res, w)
+		}
+	}
+	return res
+}
+
+// accessQuery returns the query matching the records of the nodes accessible with the access a
+func accessQuery(a persistence.Access) query.Query {
+	grants := a.Grants()
+	if len(grants) == 0 {
+		return bleve.NewMatchNoneQuery()
+	}
+	qs := make([]query.Query, 0, len(grants))
+	for _, g := range grants {
+		qs = append(qs, termQuery(fieldACL, g))
+	}
+	return bleve.NewDisjunctionQuery(qs...)
+}
+
+func docID(nodeID int64, id string) string {
+	return fmt.Sprintf("%d/%s", nodeID, id)
+}
+
+func tagField(name string) string {
+	return fieldTags + "." + name
+}
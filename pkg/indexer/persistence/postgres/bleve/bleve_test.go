@@ -0,0 +1,209 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bleve
+
+import (
+	"context"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/blevesearch/bleve/v2"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func newTestIndex(t *testing.T) *Index {
+	idx, err := Open(filepath.Join(t.TempDir(), "test.bleve"))
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		_ = idx.Close()
+	})
+	ctx := context.Background()
+	assert.Nil(t, idx.Upsert(ctx,
+		testDoc(1, "1", "/a/", "/a/doc1", "red apples and green pears", persistence.Tags{"lang": "en"}),
+		testDoc(1, "2", "/a/", "/a/doc1", "yellow bananas", persistence.Tags{"lang": "en"}),
+		testDoc(2, "1", "/b/", "/b/doc2", "an apple a day", persistence.Tags{"lang": "de"}),
+		testDoc(3, "1", "/b/", "/b/doc3", "apple pie with apples", nil)))
+	return idx
+}
+
+func testDoc(nodeID int64, id, path, name, segment string, tags persistence.Tags) persistence.IndexRecordDoc {
+	return persistence.IndexRecordDoc{
+		IndexRecord: persistence.IndexRecord{ID: id, NodeID: nodeID, Segment: segment, Format: "txt", RankMult: 1.0},
+		NodePath:    path,
+		NodeName:    name,
+		Tags:        tags,
+	}
+}
+
+func TestIndex_Count(t *testing.T) {
+	idx := newTestIndex(t)
+	ctx := context.Background()
+
+	cnt, err := idx.Count(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), cnt)
+
+	// upsert replaces the existing record
+	assert.Nil(t, idx.Upsert(ctx, testDoc(1, "2", "/a/", "/a/doc1", "ripe bananas", nil)))
+	cnt, err = idx.Count(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), cnt)
+
+	assert.Nil(t, idx.Delete(ctx, persistence.IndexRecord{ID: "2", NodeID: 1}))
+	cnt, err = idx.Count(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), cnt)
+
+	assert.Nil(t, idx.DeleteNodes(ctx, 2, 3))
+	cnt, err = idx.Count(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), cnt)
+}
+
+func TestIndex_SearchTotal(t *testing.T) {
+	idx := newTestIndex(t)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		q     persistence.SearchQuery
+		total int64
+	}{
+		{persistence.SearchQuery{TextQuery: "apple"}, 3},
+		{persistence.SearchQuery{TextQuery: "apples"}, 3},
+		{persistence.SearchQuery{TextQuery: "apple pie"}, 1},
+		{persistence.SearchQuery{TextQuery: "bananas"}, 1},
+		{persistence.SearchQuery{TextQuery: "oranges"}, 0},
+		{persistence.SearchQuery{TextQuery: "apple", FilterConditions: `tag("lang") = "en"`}, 1},
+		{persistence.SearchQuery{TextQuery: "apple", FilterConditions: `tag("lang") != "en"`}, 2},
+		{persistence.SearchQuery{TextQuery: "apple", FilterConditions: `tag("lang") in ["en", "de"]`}, 2},
+		{persistence.SearchQuery{TextQuery: "apple", FilterConditions: `prefix(path, "/b")`}, 2},
+		{persistence.SearchQuery{TextQuery: "apple", FilterConditions: `format like "t_t"`}, 3},
+		{persistence.SearchQuery{TextQuery: "apple", FilterConditions: `not (path = "/a/" or tag("lang") = "de")`}, 1},
+		{persistence.SearchQuery{TextQuery: "apple", FilterConditions: `format = "pdf"`}, 0},
+		{persistence.SearchQuery{TextQuery: "a", FilterConditions: `path = "/a/"`, GroupByPathOff: true}, 0},
+		{persistence.SearchQuery{TextQuery: "bananas pears", GroupByPathOff: true}, 0},
+	} {
+		res, err := idx.Search(ctx, nil, tc.q)
+		assert.Nil(t, err, tc.q)
+		assert.Equal(t, tc.total, res.Total, tc.q)
+		assert.Equal(t, 0, len(res.Items), tc.q)
+	}
+
+	_, err := idx.Search(ctx, nil, persistence.SearchQuery{TextQuery: "apple", FilterConditions: `tag("lang") = `})
+	assert.NotNil(t, err)
+	_, err = idx.Search(ctx, nil, persistence.SearchQuery{TextQuery: "apple", FilterConditions: `path = node`})
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	_, err = idx.Search(ctx, nil, persistence.SearchQuery{TextQuery: "apple", FilterConditions: `attr("year") > 2000`})
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+}
+
+func TestIndex_SearchAccess(t *testing.T) {
+	idx := newTestIndex(t)
+	ctx := context.Background()
+	doc := testDoc(2, "1", "/b/", "/b/doc2", "an apple a day", persistence.Tags{"lang": "de"})
+	doc.EffectiveACL = persistence.ACL{"alice": persistence.PermissionRead, "eng": persistence.PermissionWrite}
+	assert.Nil(t, idx.Upsert(ctx, doc))
+
+	for _, tc := range []struct {
+		a     persistence.Access
+		total int64
+	}{
+		{persistence.Access{Principals: []string{"alice"}, Permission: persistence.PermissionRead}, 1},
+		{persistence.Access{Principals: []string{"alice"}, Permission: persistence.PermissionWrite}, 0},
+		{persistence.Access{Principals: []string{"bob", "eng"}, Permission: persistence.PermissionRead}, 1},
+		{persistence.Access{Principals: []string{"bob"}, Permission: persistence.PermissionRead}, 0},
+		{persistence.Access{Permission: persistence.PermissionRead}, 0},
+	} {
+		res, err := idx.Search(ctx, nil, persistence.SearchQuery{TextQuery: "apple", Access: &tc.a})
+		assert.Nil(t, err, tc.a)
+		assert.Equal(t, tc.total, res.Total, tc.a)
+	}
+
+	res, err := idx.Search(ctx, nil, persistence.SearchQuery{TextQuery: "apple", FilterConditions: `tag("lang") = "de"`,
+		Access: &persistence.Access{Principals: []string{"alice"}, Permission: persistence.PermissionRead}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.Total)
+}
+
+func TestIndex_Hits(t *testing.T) {
+	idx := newTestIndex(t)
+	tq := bleve.NewMatchQuery("apple")
+	tq.SetField(fieldSegment)
+
+	hits, total, all, err := idx.hits(context.Background(), tq, 10, false)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(hits))
+	assert.Equal(t, int64(3), total)
+	assert.True(t, all)
+	for _, h := range hits {
+		assert.Equal(t, docID(h.nodeID, h.id), h.docID)
+		assert.True(t, h.score > 0)
+	}
+
+	// the top hits are read only
+	top, total, all, err := idx.hits(context.Background(), tq, 1, false)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(top))
+	assert.Equal(t, int64(3), total)
+	assert.False(t, all)
+	assert.Equal(t, hits[0].docID, top[0].docID)
+	_, total, all, err = idx.hits(context.Background(), tq, 0, false)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.False(t, all)
+
+	// the hits are read until the requested number of the nodes is found
+	for j := 0; j < 14; j++ {
+		assert.Nil(t, idx.Upsert(context.Background(), testDoc(4, strconv.Itoa(j), "/c/", "/c/doc4", "apple apple apple", nil)))
+	}
+	top, total, all, err = idx.hits(context.Background(), tq, 2, true)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(17), total)
+	assert.False(t, all)
+	assert.Equal(t, 16, len(top))
+	assert.Equal(t, 3, len(groupByNode(top)))
+	assert.Nil(t, idx.DeleteNodes(context.Background(), 4))
+	hits, _, _, err = idx.hits(context.Background(), tq, 10, false)
+	assert.Nil(t, err)
+
+	hits = groupByNode(append(hits, hit{docID: "3/2", nodeID: 3, id: "2", score: hits[0].score + 1}))
+	assert.Equal(t, 3, len(hits))
+	for _, h := range hits {
+		if h.nodeID == 3 {
+			assert.Equal(t, "2", h.id)
+		}
+	}
+}
+
+func TestIndex_FillMatchedKeywords(t *testing.T) {
+	idx := newTestIndex(t)
+	tq := bleve.NewMatchQuery("apple")
+	tq.SetField(fieldSegment)
+
+	items := []persistence.SearchQueryResultItem{
+		{IndexRecord: persistence.IndexRecord{ID: "1", NodeID: 3, Segment: "apple pie with apples"}},
+		{IndexRecord: persistence.IndexRecord{ID: "2", NodeID: 1, Segment: "yellow bananas"}},
+	}
+	assert.Nil(t, idx.fillMatchedKeywords(context.Background(), tq, items))
+	assert.Equal(t, []string{"apple", "apples"}, items[0].MatchedKeywordsList)
+	assert.Nil(t, items[1].MatchedKeywordsList)
+}
+
+func TestLikeToWildcard(t *testing.T) {
+	assert.Equal(t, "*doc?.txt", likeToWildcard("%doc_.txt"))
+	assert.Equal(t, "doc", likeToWildcard("doc"))
+}
@@ -0,0 +1,215 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bleve
+
+import (
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"github.com/simila-io/simila/pkg/ql"
+	"strconv"
+	"strings"
+)
+
+// filterQuery turns the filter conditions to the Bleve query. It returns nil if the filter
+// conditions are empty. The filter conditions are checked by FcTranslator first, so the
+// same conditions are valid for the search and for the nodes selection (ListNodes etc.)
+func filterQuery(fc string) (query.Query, error) {
+	e, err := ql.Parse(fc)
+	if err != nil || e == nil {
+		return nil, err
+	}
+	var sb strings.Builder
+	if err = FcTranslator.Expression2Sql(&sb, e); err != nil {
+		return nil, fmt.Errorf("failed to translate expression=%q: %w", fc, err)
+	}
+	q, err := expressionQuery(e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to translate expression=%q: %w", fc, err)
+	}
+	return q, nil
+}
+
+func expressionQuery(e *ql.Expression) (query.Query, error) {
+	var qs []query.Query
+	for _, oc := range e.Or {
+		q, err := orConditionQuery(oc)
+		if err != nil {
+			return nil, err
+		}
+		qs = append(qs, q)
+	}
+	if len(qs) == 1 {
+		return qs[0], nil
+	}
+	return bleve.NewDisjunctionQuery(qs...), nil
+}
+
+func orConditionQuery(oc *ql.OrCondition) (query.Query, error) {
+	var qs []query.Query
+	for _, xc := range oc.And {
+		q, err := xConditionQuery(xc)
+		if err != nil {
+			return nil, err
+		}
+		qs = append(qs, q)
+	}
+	if len(qs) == 1 {
+		return qs[0], nil
+	}
+	return bleve.NewConjunctionQuery(qs...), nil
+}
+
+func xConditionQuery(xc *ql.XCondition) (query.Query, error) {
+	var q query.Query
+	var err error
+	if xc.Expr != nil {
+		q, err = expressionQuery(xc.Expr)
+	} else {
+		q, err = conditionQuery(xc.Cond)
+	}
+	if err != nil || !xc.Not {
+		return q, err
+	}
+	return not(q), nil
+}
+
+func conditionQuery(c *ql.Condition) (query.Query, error) {
+	if c.Op == "" {
+		// prefix() is the only function, which doesn't need the operation
+		f := c.FirstParam.Function
+		if f == nil || f.Name != "prefix" {
+			return nil, fmt.Errorf("%s is not supported without the operation: %w", c.FirstParam.Name(false), errors.ErrInvalid)
+		}
+		field, err := fieldName(f.Params[0])
+		if err != nil {
+			return nil, err
+		}
+		v, err := constValue(f.Params[1])
+		if err != nil {
+			return nil, err
+		}
+		pq := bleve.NewPrefixQuery(v)
+		pq.SetField(field)
+		return pq, nil
+	}
+
+	field, err := fieldName(&c.FirstParam)
+	if err != nil {
+		return nil, err
+	}
+	op := strings.ToUpper(c.Op)
+	if op == "IN" {
+		var qs []query.Query
+		for _, cv := range c.SecondParam.Array {
+			qs = append(qs, termQuery(field, constString(cv)))
+		}
+		if len(qs) == 0 {
+			return bleve.NewMatchNoneQuery(), nil
+		}
+		return bleve.NewDisjunctionQuery(qs...), nil
+	}
+
+	v, err := constValue(c.SecondParam)
+	if err != nil {
+		return nil, err
+	}
+	t, f := true, false
+	switch op {
+	case "=":
+		return termQuery(field, v), nil
+	case "!=":
+		return not(termQuery(field, v)), nil
+	case "<":
+		return rangeQuery(field, "", v, nil, &f), nil
+	case "<=":
+		return rangeQuery(field, "", v, nil, &t), nil
+	case ">":
+		return rangeQuery(field, v, "", &f, nil), nil
+	case ">=":
+		return rangeQuery(field, v, "", &t, nil), nil
+	case "LIKE":
+		wq := bleve.NewWildcardQuery(likeToWildcard(v))
+		wq.SetField(field)
+		return wq, nil
+	}
+	return nil, fmt.Errorf("unknown operation %s: %w", c.Op, errors.ErrInvalid)
+}
+
+// fieldName returns the index field name for the parameter p
+func fieldName(p *ql.Param) (string, error) {
+	if p.Function != nil && p.Function.Name == "tag" {
+		return tagField(p.Function.Params[0].Const.String), nil
+	}
+	switch p.Identifier {
+	case "path":
+		return fieldPath, nil
+	case "node":
+		return fieldNode, nil
+	case "format":
+		return fieldFormat, nil
+	}
+	return "", fmt.Errorf("%s cannot be used as a field name in the bleve search: %w", p.Name(false), errors.ErrInvalid)
+}
+
+func constValue(p *ql.Param) (string, error) {
+	if p.Const == nil {
+		return "", fmt.Errorf("%s must be a constant in the bleve search: %w", p.Name(false), errors.ErrInvalid)
+	}
+	return constString(p.Const), nil
+}
+
+func constString(c *ql.Const) string {
+	if c.IsString() {
+		return c.String
+	}
+	return strconv.FormatFloat(float64(c.Number), 'f', -1, 32)
+}
+
+func termQuery(field, v string) query.Query {
+	tq := bleve.NewTermQuery(v)
+	tq.SetField(field)
+	return tq
+}
+
+func rangeQuery(field, min, max string, minIncl, maxIncl *bool) query.Query {
+	rq := bleve.NewTermRangeInclusiveQuery(min, max, minIncl, maxIncl)
+	rq.SetField(field)
+	return rq
+}
+
+func not(q query.Query) query.Query {
+	bq := bleve.NewBooleanQuery()
+	bq.AddMust(bleve.NewMatchAllQuery())
+	bq.AddMustNot(q)
+	return bq
+}
+
+// likeToWildcard turns the LIKE pattern to the bleve wildcard one
+func likeToWildcard(pattern string) string {
+	var sb strings.Builder
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			sb.WriteRune('*')
+		case '_':
+			sb.WriteRune('?')
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,468 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/ql"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// Config contains the Elasticsearch (or OpenSearch) connection settings
+	Config struct {
+		// URL is the cluster address, e.g. "http://localhost:9200"
+		URL string
+		// Index is the name of the index where the records are stored
+		Index    string
+		Username string
+		Password string
+	}
+
+	// Index is the Elasticsearch index of the index records. The index keeps the segments
+	// and the node attributes needed for the filter conditions, but the records themselves
+	// are read from Postgres.
+	Index struct {
+		cfg Config
+		cl  *http.Client
+	}
+
+	searchResponse struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+			Hits []searchHit `json:"hits"`
+		} `json:"hits"`
+		Aggregations struct {
+			Nodes struct {
+				Value int64 `json:"value"`
+			} `json:"nodes"`
+		} `json:"aggregations"`
+	}
+
+	searchHit struct {
+		ID        string              `json:"_id"`
+		Score     float64             `json:"_score"`
+		Source    hitSource           `json:"_source"`
+		Highlight map[string][]string `json:"highlight"`
+	}
+
+	hitSource struct {
+		NodeID string `json:"node_id"`
+		ID     string `json:"rid"`
+	}
+
+	bulkResponse struct {
+		Errors bool             `json:"errors"`
+		Items  []map[string]any `json:"items"`
+	}
+)
+
+const (
+	fieldSegment = "segment"
+	fieldNodeID  = "node_id"
+	fieldID      = "rid"
+	fieldPath    = "path"
+	fieldNode    = "node"
+	fieldFormat  = "format"
+	fieldRank    = "rank"
+	fieldTags    = "tags"
+	fieldACL     = "acl"
+
+	// hitsBatchSize is the number of hits read from the index per one request
+	hitsBatchSize = 1000
+
+	// the highlight tags are control characters, so they cannot be confused with the segment text
+	hlPreTag  = "\x02"
+	hlPostTag = "\x03"
+
+	reqTimeout = 30 * time.Second
+)
+
+// FcTranslator is used for the filter conditions of the nodes selection (ListNodes etc.), which
+// are still done by Postgres
+var FcTranslator = ql.NewTranslator(ql.PqFilterConditionsDialect)
+
+// Open returns the Index for the configuration provided. The index is created in the
+// cluster, if it doesn't exist, or the fields added later are mapped in the existing one.
+func Open(ctx context.Context, cfg Config) (*Index, error) {
+	if cfg.URL == "" || cfg.Index == "" {
+		return nil, fmt.Errorf("the elasticsearch URL and index must be specified: %w", errors.ErrInvalid)
+	}
+	cfg.URL = strings.TrimSuffix(cfg.URL, "/")
+	i := &Index{cfg: cfg, cl: &http.Client{Timeout: reqTimeout}}
+	status, _, err := i.do(ctx, http.MethodHead, "", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		if err = i.call(ctx, http.MethodPut, "", "application/json", newMapping(), nil); err != nil {
+			return nil, fmt.Errorf("could not create the index %s: %w", cfg.Index, err)
+		}
+		return i, nil
+	}
+	if err = i.call(ctx, http.MethodPut, "/_mapping", "application/json", obj{"properties": obj{fieldACL: obj{"type": "keyword"}}}, nil); err != nil {
+		return nil, fmt.Errorf("could not update the index %s mapping: %w", cfg.Index, err)
+	}
+	return i, nil
+}
+
+func newMapping() obj {
+	kw := obj{"type": "keyword"}
+	return obj{
+		"mappings": obj{
+			// the tags are mapped as keywords
+			"dynamic_templates": []any{
+				obj{"tags": obj{"path_match": fieldTags + ".*", "mapping": kw}},
+			},
+			"properties": obj{
+				fieldSegment: obj{"type": "text", "analyzer": "english"},
+				fieldNodeID:  kw,
+				fieldID:      kw,
+				fieldPath:    kw,
+				fieldNode:    kw,
+				fieldFormat:  kw,
+				fieldRank:    obj{"type": "double", "index": false},
+				fieldTags:    obj{"type": "object"},
+				fieldACL:     kw,
+			},
+		},
+	}
+}
+
+// Upsert is a part of postgres.ExtIndex
+func (i *Index) Upsert(ctx context.Context, docs ...persistence.IndexRecordDoc) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, d := range docs {
+		tags := obj{}
+		for k, v := range d.Tags {
+			tags[k] = v
+		}
+		_ = enc.Encode(obj{"index": obj{"_id": docID(d.NodeID, d.ID)}})
+		if err := enc.Encode(obj{
+			fieldSegment: d.Segment,
+			fieldNodeID:  strconv.FormatInt(d.NodeID, 10),
+			fieldID:      d.ID,
+			fieldPath:    d.NodePath,
+			fieldNode:    d.NodeName,
+			fieldFormat:  d.Format,
+			fieldRank:    d.RankMult,
+			fieldTags:    tags,
+			fieldACL:     d.EffectiveACL.Grants(),
+		}); err != nil {
+			return err
+		}
+	}
+	return i.bulk(ctx, &buf)
+}
+
+// Delete is a part of postgres.ExtIndex
+func (i *Index) Delete(ctx context.Context, recs ...persistence.IndexRecord) error {
+	ids := make([]string, 0, len(recs))
+	for _, r := range recs {
+		ids = append(ids, docID(r.NodeID, r.ID))
+	}
+	return i.deleteDocs(ctx, ids)
+}
+
+// DeleteNodes is a part of postgres.ExtIndex
+func (i *Index) DeleteNodes(ctx context.Context, nodeIDs ...int64) error {
+	if len(nodeIDs) == 0 {
+		return nil
+	}
+	ids := make([]any, 0, len(nodeIDs))
+	for _, nID := range nodeIDs {
+		ids = append(ids, strconv.FormatInt(nID, 10))
+	}
+	q := obj{"query": obj{"terms": obj{fieldNodeID: ids}}, "size": hitsBatchSize, "_source": false}
+	for {
+		var sr searchResponse
+		if err := i.call(ctx, http.MethodPost, "/_search", "application/json", q, &sr); err != nil {
+			return err
+		}
+		if len(sr.Hits.Hits) == 0 {
+			return nil
+		}
+		docIDs := make([]string, 0, len(sr.Hits.Hits))
+		for _, h := range sr.Hits.Hits {
+			docIDs = append(docIDs, h.ID)
+		}
+		if err := i.deleteDocs(ctx, docIDs); err != nil {
+			return err
+		}
+	}
+}
+
+// Count is a part of postgres.ExtIndex
+func (i *Index) Count(ctx context.Context) (int64, error) {
+	var sr searchResponse
+	err := i.call(ctx, http.MethodPost, "/_search", "application/json", obj{"size": 0, "track_total_hits": true}, &sr)
+	return sr.Hits.Total.Value, err
+}
+
+// Close is a part of postgres.ExtIndex
+func (i *Index) Close() error {
+	i.cl.CloseIdleConnections()
+	return nil
+}
+
+// Search is the SearchFn for the Elasticsearch index. The text query is matched against the
+// record segments, all the terms must be in the segment. The filter conditions are applied to
+// the records in the index, and the found records are read from the database by qx. The records
+// are filtered by the grants of their nodes effective ACLs, if the query Access is set.
+func (i *Index) Search(ctx context.Context, qx sqlx.QueryerContext, q persistence.SearchQuery) (persistence.SearchQueryResult, error) {
+	req, err := searchRequest(q)
+	if err != nil {
+		return persistence.SearchQueryResult{}, err
+	}
+	var sr searchResponse
+	if err = i.call(ctx, http.MethodPost, "/_search", "application/json", req, &sr); err != nil {
+		return persistence.SearchQueryResult{}, err
+	}
+	total := sr.Hits.Total.Value
+	if !q.GroupByPathOff {
+		total = sr.Aggregations.Nodes.Value
+	}
+	if len(sr.Hits.Hits) == 0 {
+		return persistence.SearchQueryResult{Total: total}, nil
+	}
+	items, err := readItems(ctx, qx, sr.Hits.Hits)
+	if err != nil {
+		return persistence.SearchQueryResult{}, err
+	}
+	return persistence.SearchQueryResult{Items: items, Total: total}, nil
+}
+
+// searchRequest returns the _search request body for the query q
+func searchRequest(q persistence.SearchQuery) (obj, error) {
+	boolQ := obj{"must": []any{obj{"match": obj{fieldSegment: obj{"query": q.TextQuery, "operator": "and"}}}}}
+	fq, err := filterQuery(q.FilterConditions)
+	if err != nil {
+		return nil, err
+	}
+	var filter []any
+	if fq != nil {
+		filter = append(filter, fq)
+	}
+	if q.Access != nil {
+		// the empty terms list, if there are no grants, matches nothing
+		filter = append(filter, obj{"terms": obj{fieldACL: append([]string{}, q.Access.Grants()...)}})
+	}
+	if len(filter) > 0 {
+		boolQ["filter"] = filter
+	}
+	req := obj{
+		"query": obj{"function_score": obj{
+			"query":              obj{"bool": boolQ},
+			"field_value_factor": obj{"field": fieldRank, "missing": 1},
+			"boost_mode":         "multiply",
+		}},
+		"from":             max(q.Offset, 0),
+		"size":             max(q.Limit, 0),
+		"sort":             []any{obj{"_score": "desc"}, obj{fieldNode: "asc"}, obj{fieldID: "asc"}},
+		"track_scores":     true,
+		"track_total_hits": true,
+		"_source":          []string{fieldNodeID, fieldID},
+		"highlight": obj{
+			"pre_tags":  []string{hlPreTag},
+			"post_tags": []string{hlPostTag},
+			"fields":    obj{fieldSegment: obj{"number_of_fragments": 0}},
+		},
+	}
+	if !q.GroupByPathOff {
+		// the best record of every node, the total is the number of the nodes found
+		req["collapse"] = obj{"field": fieldNodeID}
+		req["aggs"] = obj{"nodes": obj{"cardinality": obj{"field": fieldNodeID, "precision_threshold": 40000}}}
+	}
+	return req, nil
+}
+
+// readItems reads the records for the hits from the database, the result items are in the hits order
+func readItems(ctx context.Context, qx sqlx.QueryerContext, hits []searchHit) ([]persistence.SearchQueryResultItem, error) {
+	var sb strings.Builder
+	var args []any
+	for _, h := range hits {
+		if sb.Len() > 0 {
+			sb.WriteString(" or ")
+		}
+		sb.WriteString("(ir.node_id = ? and ir.id = ?)")
+		args = append(args, h.Source.NodeID, h.Source.ID)
+	}
+	rows, err := qx.QueryxContext(ctx, sqlx.Rebind(sqlx.DOLLAR,
+		"select ir.*, n.name as path from index_record as ir inner join node as n on n.id = ir.node_id where "+sb.String()), args...)
+	if err != nil {
+		return nil, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	recs, err := persistence.ScanRowsQueryResult[persistence.SearchQueryResultItem](rows)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]persistence.SearchQueryResultItem, len(recs))
+	for _, r := range recs {
+		byID[docID(r.NodeID, r.ID)] = r
+	}
+	res := make([]persistence.SearchQueryResultItem, 0, len(hits))
+	for _, h := range hits {
+		// the record may be deleted in between, skip it then
+		if r, ok := byID[h.ID]; ok {
+			r.Score = float32(h.Score)
+			r.MatchedKeywordsList = matchedKeywords(h.Highlight[fieldSegment])
+			res = append(res, r)
+		}
+	}
+	return res, nil
+}
+
+// matchedKeywords returns the unique highlighted words in the order they are in the fragments
+func matchedKeywords(fragments []string) []string {
+	var res []string
+	seen := make(map[string]bool)
+	for _, f := range fragments {
+		for {
+			start := strings.Index(f, hlPreTag)
+			if start < 0 {
+				break
+			}
+			f = f[start+len(hlPreTag):]
+			end := strings.Index(f, hlPostTag)
+			if end < 0 {
+				break
+			}
+			if w := f[:end]; !seen[w] {
+				seen[w] = true
+				res = append(res, w)
+			}
+			f = f[end+len(hlPostTag):]
+		}
+	}
+	return res
+}
+
+func (i *Index) deleteDocs(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, id := range ids {
+		_ = enc.Encode(obj{"delete": obj{"_id": id}})
+	}
+	return i.bulk(ctx, &buf)
+}
+
+// bulk sends the _bulk request, the changes are visible for the search when the call is over
+func (i *Index) bulk(ctx context.Context, body *bytes.Buffer) error {
+	var br bulkResponse
+	if err := i.call(ctx, http.MethodPost, "/_bulk?refresh=wait_for", "application/x-ndjson", body, &br); err != nil {
+		return err
+	}
+	if !br.Errors {
+		return nil
+	}
+	for _, it := range br.Items {
+		for op, r := range it {
+			res, _ := r.(map[string]any)
+			if e, ok := res["error"]; ok {
+				// the record may be deleted already
+				if op == "delete" && res["status"] == float64(http.StatusNotFound) {
+					continue
+				}
+				return fmt.Errorf("elasticsearch bulk %s of %v failed: %v", op, res["_id"], e)
+			}
+		}
+	}
+	return nil
+}
+
+// call sends the request to the index endpoint. The body is either io.Reader, or an object
+// to be sent as JSON. The response is decoded to the res, if it is not nil.
+func (i *Index) call(ctx context.Context, method, endpoint, contentType string, body, res any) error {
+	var r io.Reader
+	switch b := body.(type) {
+	case nil:
+	case io.Reader:
+		r = b
+	default:
+		buf, err := json.Marshal(b)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(buf)
+	}
+	status, data, err := i.do(ctx, method, endpoint, contentType, r)
+	if err != nil {
+		return err
+	}
+	if status/100 != 2 {
+		err = fmt.Errorf("elasticsearch %s %s failed: status=%d, response=%s", method, endpoint, status, data)
+		if status == http.StatusBadRequest {
+			err = fmt.Errorf("%s: %w", err.Error(), errors.ErrInvalid)
+		}
+		return err
+	}
+	if res == nil {
+		return nil
+	}
+	return json.Unmarshal(data, res)
+}
+
+// do sends the request and returns the response status and body
+func (i *Index) do(ctx context.Context, method, endpoint, contentType string, body io.Reader) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, i.cfg.URL+"/"+i.cfg.Index+endpoint, body)
+	if err != nil {
+		return 0, nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if i.cfg.Username != "" {
+		req.SetBasicAuth(i.cfg.Username, i.cfg.Password)
+	}
+	resp, err := i.cl.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("elasticsearch %s %s failed: %w", method, endpoint, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	data, err := io.ReadAll(resp.Body)
+	return resp.StatusCode, data, err
+}
+
+func docID(nodeID int64, id string) string {
+	return fmt.Sprintf("%d/%s", nodeID, id)
+}
+
+func tagField(name string) string {
+	return fieldTags + "." + name
+}
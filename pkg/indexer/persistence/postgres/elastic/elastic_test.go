@@ -0,0 +1,327 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elastic
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type (
+	// testCluster is the Elasticsearch stand-in, it supports the index creation, _mapping, _bulk
+	// and _search requests. The search supports the match query on the segment, the terms query
+	// on the node_id and the terms filter on the acl only, the other filters are ignored.
+	testCluster struct {
+		lock     sync.Mutex
+		index    string
+		created  bool
+		mappings []obj
+		docs     map[string]obj
+		searches []obj
+	}
+)
+
+func newTestCluster(t *testing.T) (*testCluster, *httptest.Server) {
+	tc := &testCluster{index: "simila", docs: map[string]obj{}}
+	srv := httptest.NewServer(tc)
+	t.Cleanup(srv.Close)
+	return tc, srv
+}
+
+func (tc *testCluster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tc.lock.Lock()
+	defer tc.lock.Unlock()
+	switch {
+	case r.URL.Path == "/"+tc.index && r.Method == http.MethodHead:
+		if !tc.created {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case r.URL.Path == "/"+tc.index && r.Method == http.MethodPut:
+		tc.created = true
+		writeJSON(w, obj{"acknowledged": true})
+	case r.URL.Path == "/"+tc.index+"/_mapping" && r.Method == http.MethodPut:
+		var m obj
+		_ = json.NewDecoder(r.Body).Decode(&m)
+		tc.mappings = append(tc.mappings, m)
+		writeJSON(w, obj{"acknowledged": true})
+	case r.URL.Path == "/"+tc.index+"/_bulk":
+		tc.bulk(w, r)
+	case r.URL.Path == "/"+tc.index+"/_search":
+		tc.search(w, r)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (tc *testCluster) bulk(w http.ResponseWriter, r *http.Request) {
+	var items []any
+	sc := bufio.NewScanner(r.Body)
+	sc.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for sc.Scan() {
+		var action map[string]struct {
+			ID string `json:"_id"`
+		}
+		_ = json.Unmarshal(sc.Bytes(), &action)
+		if a, ok := action["index"]; ok {
+			sc.Scan()
+			var doc obj
+			_ = json.Unmarshal(sc.Bytes(), &doc)
+			tc.docs[a.ID] = doc
+			items = append(items, obj{"index": obj{"_id": a.ID, "status": 200}})
+		}
+		if a, ok := action["delete"]; ok {
+			delete(tc.docs, a.ID)
+			items = append(items, obj{"delete": obj{"_id": a.ID, "status": 200}})
+		}
+	}
+	writeJSON(w, obj{"errors": false, "items": items})
+}
+
+func (tc *testCluster) search(w http.ResponseWriter, r *http.Request) {
+	var req obj
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	tc.searches = append(tc.searches, req)
+
+	var words []string
+	var nodeIDs, grants map[string]bool
+	if q, ok := req["query"].(obj); ok {
+		if fs, ok := q["function_score"].(obj); ok {
+			boolQ := fs["query"].(obj)["bool"].(obj)
+			text := boolQ["must"].([]any)[0].(obj)["match"].(obj)[fieldSegment].(obj)["query"].(string)
+			words = strings.Fields(strings.ToLower(text))
+			filter, _ := boolQ["filter"].([]any)
+			for _, f := range filter {
+				if terms, ok := f.(obj)["terms"].(obj); ok && terms[fieldACL] != nil {
+					grants = map[string]bool{}
+					for _, g := range terms[fieldACL].([]any) {
+						grants[g.(string)] = true
+					}
+				}
+			}
+		}
+		if terms, ok := q["terms"].(obj); ok {
+			nodeIDs = map[string]bool{}
+			for _, id := range terms[fieldNodeID].([]any) {
+				nodeIDs[id.(string)] = true
+			}
+		}
+	}
+
+	var ids []string
+	for id, doc := range tc.docs {
+		if nodeIDs != nil && !nodeIDs[doc[fieldNodeID].(string)] {
+			continue
+		}
+		if !containsAll(doc[fieldSegment].(string), words) {
+			continue
+		}
+		if grants != nil && !anyGranted(doc[fieldACL], grants) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	total := len(ids)
+	if size, ok := req["size"].(float64); ok && int(size) < len(ids) {
+		ids = ids[:int(size)]
+	}
+	hits := make([]any, 0, len(ids))
+	for _, id := range ids {
+		doc := tc.docs[id]
+		hits = append(hits, obj{
+			"_id":       id,
+			"_score":    1.0,
+			"_source":   obj{fieldNodeID: doc[fieldNodeID], fieldID: doc[fieldID]},
+			"highlight": obj{fieldSegment: []string{highlight(doc[fieldSegment].(string), words)}},
+		})
+	}
+	writeJSON(w, obj{
+		"hits":         obj{"total": obj{"value": total}, "hits": hits},
+		"aggregations": obj{"nodes": obj{"value": total}},
+	})
+}
+
+func containsAll(segment string, words []string) bool {
+	fields := strings.Fields(strings.ToLower(segment))
+	for _, w := range words {
+		found := false
+		for _, f := range fields {
+			found = found || f == w
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func anyGranted(acl any, grants map[string]bool) bool {
+	docGrants, _ := acl.([]any)
+	for _, g := range docGrants {
+		if grants[g.(string)] {
+			return true
+		}
+	}
+	return false
+}
+
+func highlight(segment string, words []string) string {
+	fields := strings.Fields(segment)
+	for i, f := range fields {
+		for _, w := range words {
+			if strings.ToLower(f) == w {
+				fields[i] = hlPreTag + f + hlPostTag
+			}
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestIndex(t *testing.T) (*testCluster, *Index) {
+	tc, srv := newTestCluster(t)
+	idx, err := Open(context.Background(), Config{URL: srv.URL, Index: tc.index})
+	assert.Nil(t, err)
+	assert.True(t, tc.created)
+	assert.Nil(t, idx.Upsert(context.Background(),
+		testDoc(1, "1", "/a/", "/a/doc1", "red apple and green pear", persistence.Tags{"lang": "en"}),
+		testDoc(1, "2", "/a/", "/a/doc1", "yellow banana", persistence.Tags{"lang": "en"}),
+		testDoc(2, "1", "/b/", "/b/doc2", "an apple a day", persistence.Tags{"lang": "de"})))
+	return tc, idx
+}
+
+func testDoc(nodeID int64, id, path, name, segment string, tags persistence.Tags) persistence.IndexRecordDoc {
+	return persistence.IndexRecordDoc{
+		IndexRecord: persistence.IndexRecord{ID: id, NodeID: nodeID, Segment: segment, Format: "txt", RankMult: 1.0},
+		NodePath:    path,
+		NodeName:    name,
+		Tags:        tags,
+	}
+}
+
+func TestOpen(t *testing.T) {
+	_, err := Open(context.Background(), Config{URL: "http://localhost:9200"})
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+
+	tc, srv := newTestCluster(t)
+	tc.created = true
+	_, err = Open(context.Background(), Config{URL: srv.URL + "/", Index: tc.index})
+	assert.Nil(t, err)
+	assert.Equal(t, []obj{{"properties": obj{fieldACL: obj{"type": "keyword"}}}}, tc.mappings)
+}
+
+func TestIndex_Upsert(t *testing.T) {
+	tc, idx := newTestIndex(t)
+	assert.Equal(t, 3, len(tc.docs))
+	doc := tc.docs["1/1"]
+	assert.Equal(t, "red apple and green pear", doc[fieldSegment])
+	assert.Equal(t, "1", doc[fieldNodeID])
+	assert.Equal(t, "/a/", doc[fieldPath])
+	assert.Equal(t, "/a/doc1", doc[fieldNode])
+	assert.Equal(t, obj{"lang": "en"}, doc[fieldTags])
+
+	cnt, err := idx.Count(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), cnt)
+}
+
+func TestIndex_Delete(t *testing.T) {
+	tc, idx := newTestIndex(t)
+	ctx := context.Background()
+
+	assert.Nil(t, idx.Delete(ctx, persistence.IndexRecord{ID: "2", NodeID: 1}))
+	assert.Equal(t, 2, len(tc.docs))
+	assert.Nil(t, idx.DeleteNodes(ctx, 1))
+	assert.Equal(t, 1, len(tc.docs))
+	assert.NotNil(t, tc.docs["2/1"])
+
+	cnt, err := idx.Count(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), cnt)
+}
+
+func TestIndex_SearchTotal(t *testing.T) {
+	tc, idx := newTestIndex(t)
+	ctx := context.Background()
+
+	res, err := idx.Search(ctx, nil, persistence.SearchQuery{TextQuery: "apple", FilterConditions: `tag("lang") = "en"`})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), res.Total)
+	assert.Equal(t, 0, len(res.Items))
+
+	req := tc.searches[len(tc.searches)-1]
+	assert.Equal(t, obj{"field": fieldNodeID}, req["collapse"])
+	boolQ := req["query"].(obj)["function_score"].(obj)["query"].(obj)["bool"].(obj)
+	assert.Equal(t, []any{obj{"term": obj{"tags.lang": "en"}}}, boolQ["filter"])
+
+	res, err = idx.Search(ctx, nil, persistence.SearchQuery{TextQuery: "yellow banana", GroupByPathOff: true})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.Total)
+	assert.Nil(t, tc.searches[len(tc.searches)-1]["collapse"])
+
+	_, err = idx.Search(ctx, nil, persistence.SearchQuery{TextQuery: "apple", FilterConditions: `path = node`})
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+}
+
+func TestIndex_SearchAccess(t *testing.T) {
+	tc, idx := newTestIndex(t)
+	ctx := context.Background()
+	doc := testDoc(2, "1", "/b/", "/b/doc2", "an apple a day", persistence.Tags{"lang": "de"})
+	doc.EffectiveACL = persistence.ACL{"alice": persistence.PermissionRead, "eng": persistence.PermissionWrite}
+	assert.Nil(t, idx.Upsert(ctx, doc))
+	assert.Equal(t, []any{"read:alice", "read:eng", "write:eng"}, tc.docs["2/1"][fieldACL])
+
+	for _, c := range []struct {
+		a     persistence.Access
+		total int64
+	}{
+		{persistence.Access{Principals: []string{"alice"}, Permission: persistence.PermissionRead}, 1},
+		{persistence.Access{Principals: []string{"alice"}, Permission: persistence.PermissionWrite}, 0},
+		{persistence.Access{Principals: []string{"bob", "eng"}, Permission: persistence.PermissionRead}, 1},
+		{persistence.Access{Principals: []string{"bob"}, Permission: persistence.PermissionRead}, 0},
+		{persistence.Access{Permission: persistence.PermissionRead}, 0},
+	} {
+		res, err := idx.Search(ctx, nil, persistence.SearchQuery{TextQuery: "apple", Access: &c.a})
+		assert.Nil(t, err, c.a)
+		assert.Equal(t, c.total, res.Total, c.a)
+	}
+
+	_, err := idx.Search(ctx, nil, persistence.SearchQuery{TextQuery: "apple", FilterConditions: `tag("lang") = "de"`,
+		Access: &persistence.Access{Principals: []string{"alice", "bob"}, Permission: persistence.PermissionRead}})
+	assert.Nil(t, err)
+	boolQ := tc.searches[len(tc.searches)-1]["query"].(obj)["function_score"].(obj)["query"].(obj)["bool"].(obj)
+	assert.Equal(t, []any{obj{"term": obj{"tags.lang": "de"}}, obj{"terms": obj{fieldACL: []any{"read:alice", "read:bob"}}}}, boolQ["filter"])
+}
+
+func TestMatchedKeywords(t *testing.T) {
+	assert.Nil(t, matchedKeywords(nil))
+	assert.Equal(t, []string{"Apple", "pie", "apples"},
+		matchedKeywords([]string{hlPreTag + "Apple" + hlPostTag + " " + hlPreTag + "pie" + hlPostTag + " with " +
+			hlPreTag + "apples" + hlPostTag + " and " + hlPreTag + "pie" + hlPostTag}))
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elastic
+
+import (
+	"encoding/json"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFilterQuery(t *testing.T) {
+	for _, tc := range []struct {
+		fc  string
+		exp string
+	}{
+		{``, `null`},
+		{`format = "txt"`, `{"term":{"format":"txt"}}`},
+		{`tag("a") != "b"`, `{"bool":{"must_not":[{"term":{"tags.a":"b"}}]}}`},
+		{`tag("a") in ["b", "c"]`, `{"terms":{"tags.a":["b","c"]}}`},
+		{`tag("n") >= 10`, `{"range":{"tags.n":{"gte":"10"}}}`},
+		{`prefix(path, "/a/")`, `{"prefix":{"path":"/a/"}}`},
+		{`format like "%doc_"`, `{"wildcard":{"format":{"value":"*doc?"}}}`},
+		{`path = "/a/" and format = "txt"`, `{"bool":{"filter":[{"term":{"path":"/a/"}},{"term":{"format":"txt"}}]}}`},
+		{`path = "/a/" or not (format = "txt")`,
+			`{"bool":{"minimum_should_match":1,"should":[{"term":{"path":"/a/"}},{"bool":{"must_not":[{"term":{"format":"txt"}}]}}]}}`},
+	} {
+		q, err := filterQuery(tc.fc)
+		assert.Nil(t, err, tc.fc)
+		buf, err := json.Marshal(q)
+		assert.Nil(t, err)
+		assert.JSONEq(t, tc.exp, string(buf), tc.fc)
+	}
+
+	for _, fc := range []string{`path = `, `path = format`, `tag("a") = path`} {
+		_, err := filterQuery(fc)
+		assert.NotNil(t, err, fc)
+	}
+
+	// the node attributes are not indexed
+	for _, fc := range []string{`attr("year") > 2000`, `attr("draft") = true`, `not (attr("lang") = "en")`} {
+		_, err := filterQuery(fc)
+		assert.ErrorIs(t, err, errors.ErrInvalid, fc)
+	}
+}
+
+func TestLikeToWildcard(t *testing.T) {
+	assert.Equal(t, "*doc?.txt", likeToWildcard("%doc_.txt"))
+	assert.Equal(t, `a\*b\?`, likeToWildcard("a*b?"))
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"strings"
+)
+
+type (
+	// ExtIndex is the search index kept out of Postgres (e.g. Bleve). The nodes and the index
+	// records are stored in Postgres, and the changes of the index records are propagated to the
+	// ExtIndex when the transaction is committed.
+	ExtIndex interface {
+		// Upsert adds or replaces the records in the index
+		Upsert(ctx context.Context, docs ...persistence.IndexRecordDoc) error
+		// Delete removes the records from the index, only the NodeID and ID of the records are used
+		Delete(ctx context.Context, recs ...persistence.IndexRecord) error
+		// DeleteNodes removes all the records of the nodes from the index
+		DeleteNodes(ctx context.Context, nodeIDs ...int64) error
+		// Count returns the number of the records in the index
+		Count(ctx context.Context) (int64, error)
+		// Search is the SearchFn for the index, the qx is used to read the records found
+		Search(ctx context.Context, qx sqlx.QueryerContext, q persistence.SearchQuery) (persistence.SearchQueryResult, error)
+		// Close closes the index
+		Close() error
+	}
+
+	// extFn is the ExtIndex change, which is applied on commit
+	extFn func(ctx context.Context, idx ExtIndex) error
+)
+
+const (
+	// selectRecordDocs is the query to read persistence.IndexRecordDoc objects, the trashed
+	// nodes records are skipped, so they are never added to the ExtIndex
+	selectRecordDocs = "select ir.*, n.path as node_path, n.name as node_name, n.tags, n.effective_acl " +
+		"from index_record as ir inner join node as n on n.id = ir.node_id where n.deleted_at is null and "
+
+	extSyncBatchSize = 1000
+)
+
+// extApply applies the change fn to the ExtIndex. If the transaction is active, the change is
+// postponed until the transaction is committed.
+func (t *tx) extApply(fn extFn) error {
+	if t.extIdx == nil {
+		return nil
+	}
+	if t.tx != nil {
+		t.extPending = append(t.extPending, fn)
+		return nil
+	}
+	return fn(t.ctx, t.extIdx)
+}
+
+// extCommit applies the changes postponed by the transaction
+func (t *tx) extCommit() error {
+	pending := t.extPending
+	t.extPending = nil
+	for _, fn := range pending {
+		if err := fn(t.ctx, t.extIdx); err != nil {
+			return fmt.Errorf("the transaction is committed, but the search index update failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// extUpsertRecords reads the records with the node attributes and sends them to the ExtIndex
+func (m *modelTx) extUpsertRecords(recs []persistence.IndexRecord) error {
+	if m.extIdx == nil || len(recs) == 0 {
+		return nil
+	}
+	var sb strings.Builder
+	var args []any
+	for _, r := range recs {
+		if sb.Len() > 0 {
+			sb.WriteString(" or ")
+		}
+		sb.WriteString("(ir.node_id = ? and ir.id = ?)")
+		args = append(args, r.NodeID, r.ID)
+	}
+	docs, err := readRecordDocs(m.ctx, m.executor(), sqlx.Rebind(sqlx.DOLLAR, selectRecordDocs+"("+sb.String()+")"), args...)
+	if err != nil {
+		return err
+	}
+	return m.extApply(func(ctx context.Context, idx ExtIndex) error {
+		return idx.Upsert(ctx, docs...)
+	})
+}
+
+// extUpsertNode re-indexes all the records of the nodes, it is needed when the nodes attributes are changed
+func (m *modelTx) extUpsertNode(nodeIDs ...int64) error {
+	if m.extIdx == nil || len(nodeIDs) == 0 {
+		return nil
+	}
+	docs, err := readRecordDocs(m.ctx, m.executor(), selectRecordDocs+"ir.node_id = any($1)", pq.Array(nodeIDs))
+	if err != nil {
+		return err
+	}
+	return m.extApply(func(ctx context.Context, idx ExtIndex) error {
+		return idx.Upsert(ctx, docs...)
+	})
+}
+
+// extSync fills the empty ExtIndex by the records stored in the database. It
+// allows to switch to the search module, which uses ExtIndex, for the existing data.
+func extSync(ctx context.Context, db *sqlx.DB, idx ExtIndex) error {
+	cnt, err := idx.Count(ctx)
+	if err != nil || cnt > 0 {
+		return err
+	}
+	lastNodeID, lastID := int64(0), ""
+	for {
+		docs, err := readRecordDocs(ctx, db, selectRecordDocs+
+			"(ir.node_id, ir.id) > ($1, $2) order by ir.node_id, ir.id limit $3", lastNodeID, lastID, extSyncBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(docs) == 0 {
+			return nil
+		}
+		if err = idx.Upsert(ctx, docs...); err != nil {
+			return err
+		}
+		lastNodeID, lastID = docs[len(docs)-1].NodeID, docs[len(docs)-1].ID
+	}
+}
+
+func readRecordDocs(ctx context.Context, qx sqlx.QueryerContext, query string, args ...any) ([]persistence.IndexRecordDoc, error) {
+	rows, err := qx.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return persistence.ScanRows[persistence.IndexRecordDoc](rows)
+}
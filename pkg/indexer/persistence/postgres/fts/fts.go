@@ -39,7 +39,43 @@ create index if not exists "idx_index_record_segment_tsvector" on "index_record"
 	createSegmentTsVectorDown = ` 
 drop index if exists "idx_index_record_segment_tsvector";
 
+drop trigger if exists "trg_index_record_segment_tsvector" on "index_record";
+
+drop function if exists "index_record_segment_tsvector";
+
 alter table "index_record" drop column if exists "segment_tsvector";
+`
+	// the generated column can't be added without the table rewrite, so it is
+	// a regular column filled by the trigger and the BackfillBatch for the online migrations
+	createSegmentTsVectorOnlineUp = `
+alter table "index_record" add column if not exists "segment_tsvector" tsvector;
+
+create or replace function "index_record_segment_tsvector"() returns trigger as $$
+begin
+    new."segment_tsvector" := to_tsvector('public.simila', new."segment");
+    return new;
+end
+$$ language plpgsql;
+
+create or replace trigger "trg_index_record_segment_tsvector" before insert or update of "segment" on "index_record"
+    for each row execute function "index_record_segment_tsvector"();
+`
+	createSegmentTsVectorIndexConcurrentlyUp = `
+create index concurrently if not exists "idx_index_record_segment_tsvector" on "index_record" using gin ("segment_tsvector");
+`
+
+	// BackfillBatch fills the "segment_tsvector" column of the next batch of the records after the
+	// ($1 node_id, $2 id) key, the batch size is $3. It returns the key of the last record of the
+	// batch, or no rows if there are no records after the key.
+	BackfillBatch = `
+with b as (
+	select "node_id", "id" from "index_record" where ("node_id", "id") > ($1, $2) order by "node_id", "id" limit $3
+), u as (
+	update "index_record" as ir set "segment_tsvector" = to_tsvector('public.simila', ir."segment")
+	from b where ir."node_id" = b."node_id" and ir."id" = b."id"
+	returning 1
+)
+select b."node_id", b."id" from b where (select count(*) from u) >= 0 order by b."node_id" desc, b."id" desc limit 1
 `
 )
 
@@ -85,18 +121,45 @@ func Migrations(rollback bool) []*migrate.Migration {
 	}
 }
 
+// OnlineMigrations returns the same migrations as Migrations, but the "segment_tsvector" column
+// is filled by the trigger, and its index is not created by them. After the migrations are applied,
+// the existing records must be filled by the BackfillBatch, and then the OnlineIndexes must be
+// created. It allows to turn the module on while the "index_record" table is in use.
+func OnlineMigrations() []*migrate.Migration {
+	return []*migrate.Migration{
+		createTsConfig("3000", false),
+		{
+			Id:   "3001",
+			Up:   []string{createSegmentTsVectorOnlineUp},
+			Down: []string{createSegmentTsVectorDown},
+		},
+	}
+}
+
+// OnlineIndexes returns the module indexes (the index name to the create statement),
+// which are created concurrently, after the OnlineMigrations are applied.
+func OnlineIndexes() map[string]string {
+	return map[string]string{"idx_index_record_segment_tsvector": createSegmentTsVectorIndexConcurrentlyUp}
+}
+
 // Search is an implementation of the postgres.SearchFn
 // function based on the postgres built-in full-text search.
 // SearchQuery.TextQuery must be formed in accordance with the `websearch_to_tsquery()` query syntax,
 // see https://www.postgresql.org/docs/current/textsearch-controls.html#TEXTSEARCH-PARSING-QUERIES.
 func Search(ctx context.Context, qx sqlx.QueryerContext, q persistence.SearchQuery) (persistence.SearchQueryResult, error) {
-	var sb strings.Builder
-	sb.Grow(2 * len(q.FilterConditions))
-	if err := FcTranslator.Translate(&sb, q.FilterConditions); err != nil {
+	var fc strings.Builder
+	fc.Grow(2 * len(q.FilterConditions))
+	if err := FcTranslator.Translate(&fc, q.FilterConditions); err != nil {
 		return persistence.SearchQueryResult{}, persistence.MapError(err)
 	}
-	if sb.Len() > 0 {
-		sb.WriteString(" and ")
+	// the trashed nodes records are never returned
+	var sb strings.Builder
+	sb.WriteString("n.deleted_at is null and ")
+	if q.Access != nil {
+		sb.WriteString(persistence.PqAccessCondition(*q.Access) + " and ")
+	}
+	if fc.Len() > 0 {
+		sb.WriteString("(" + fc.String() + ") and ")
 	}
 
 	var params []any
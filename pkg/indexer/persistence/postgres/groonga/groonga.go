@@ -16,6 +16,9 @@ create extension if not exists pgroonga;
 `
 	createSegmentIndexUp = `
 create index if not exists "idx_index_record_segment_groonga" on "index_record" using pgroonga ("segment") with (tokenizer='TokenNgram("unify_alphabet", false, "unify_symbol", false, "unify_digit", false)');
+`
+	createSegmentIndexConcurrentlyUp = `
+create index concurrently if not exists "idx_index_record_segment_groonga" on "index_record" using pgroonga ("segment") with (tokenizer='TokenNgram("unify_alphabet", false, "unify_symbol", false, "unify_digit", false)');
 `
 	createSegmentIndexDown = `
 drop index if exists "idx_index_record_segment_groonga";
@@ -57,18 +60,40 @@ func Migrations(rollback bool) []*migrate.Migration {
 	}
 }
 
+// OnlineMigrations returns the same migrations as Migrations, but the segment index
+// is not created by them, it must be created by OnlineIndexes after the migrations
+// are applied. It allows to turn the module on while the "index_record" table is in use.
+func OnlineMigrations() []*migrate.Migration {
+	return []*migrate.Migration{
+		createExtension("1000", false),
+		createSegmentIndex("1001", true),
+	}
+}
+
+// OnlineIndexes returns the module indexes (the index name to the create statement),
+// which are created concurrently, after the OnlineMigrations are applied.
+func OnlineIndexes() map[string]string {
+	return map[string]string{"idx_index_record_segment_groonga": createSegmentIndexConcurrentlyUp}
+}
+
 // Search is an implementation of the postgres.SearchFn
 // function based on the "pgroonga" postgres extension.
 // SearchQuery.TextQuery must be formed in accordance with the "pgroonga" query syntax,
 // see https://pgroonga.github.io/reference/operators/query-v2.html.
 func Search(ctx context.Context, qx sqlx.QueryerContext, q persistence.SearchQuery) (persistence.SearchQueryResult, error) {
-	var sb strings.Builder
-	sb.Grow(2 * len(q.FilterConditions))
-	if err := FcTranslator.Translate(&sb, q.FilterConditions); err != nil {
+	var fc strings.Builder
+	fc.Grow(2 * len(q.FilterConditions))
+	if err := FcTranslator.Translate(&fc, q.FilterConditions); err != nil {
 		return persistence.SearchQueryResult{}, persistence.MapError(err)
 	}
-	if sb.Len() > 0 {
-		sb.WriteString(" and ")
+	// the trashed nodes records are never returned
+	var sb strings.Builder
+	sb.WriteString("n.deleted_at is null and ")
+	if q.Access != nil {
+		sb.WriteString(persistence.PqAccessCondition(*q.Access) + " and ")
+	}
+	if fc.Len() > 0 {
+		sb.WriteString("(" + fc.String() + ") and ")
 	}
 
 	var params []any
@@ -20,7 +20,9 @@ import (
 	migrate "github.com/rubenv/sql-migrate"
 	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/fts"
 	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/groonga"
+	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/pgvector"
 	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/trigram"
+	"slices"
 )
 
 const (
@@ -80,6 +82,277 @@ insert into format (id) values('txt') on conflict do nothing;
 `
 	addTxtFormatDown = `
 delete from format where id='txt';
+`
+
+	createEngineSwitchUp = `
+create table if not exists "engine_switch"
+(
+    "id"          bigserial                not null,
+    "from_engine" varchar(255)             not null,
+    "to_engine"   varchar(255)             not null,
+    "state"       varchar(32)              not null,
+    "phase"       varchar(32)              not null default '',
+    "progress"    double precision         not null default 0,
+    "error"       text                     not null default '',
+    "created_at"  timestamp with time zone not null default (now() at time zone 'utc'),
+    "updated_at"  timestamp with time zone not null default (now() at time zone 'utc'),
+    primary key ("id")
+);
+`
+	createEngineSwitchDown = `
+drop table if exists "engine_switch";
+`
+
+	addNodeDeletedAtUp = `
+alter table "node" add column if not exists "deleted_at" timestamp with time zone;
+create index if not exists "idx_node_deleted_at" on "node" ("deleted_at");
+`
+	addNodeDeletedAtDown = `
+drop index if exists "idx_node_deleted_at";
+alter table "node" drop column if exists "deleted_at";
+`
+
+	addRecordHistoryUp = `
+alter table "format" add column if not exists "keep_history" boolean not null default false;
+
+create table if not exists "index_record_history"
+(
+    "revision"        bigserial                not null,
+    "id"              varchar(255)             not null,
+    "node_id"         bigint                   not null references "node" ("id") on delete cascade,
+    "segment"         text                     not null,
+    "vector"          bytea,
+    "format"          varchar(255)             not null references "format" ("id") on delete restrict,
+    "rank_multiplier" numeric                  not null default 1.0,
+    "created_at"      timestamp with time zone not null,
+    "updated_at"      timestamp with time zone not null,
+    "replaced_at"     timestamp with time zone not null,
+    "deleted"         boolean                  not null default false,
+    primary key ("revision")
+);
+
+create index if not exists "idx_index_record_history_node_id_id" on "index_record_history" ("node_id", "id");
+create index if not exists "idx_index_record_history_replaced_at" on "index_record_history" ("replaced_at");
+`
+	addRecordHistoryDown = `
+drop table if exists "index_record_history";
+alter table "format" drop column if exists "keep_history";
+`
+
+	createChangelogUp = `
+create table if not exists "changelog"
+(
+    "id"         bigserial                not null,
+    "object"     varchar(32)              not null,
+    "op"         varchar(32)              not null,
+    "node_id"    bigint                   not null,
+    "path"       varchar(1024)            not null,
+    "name"       varchar(1024)            not null,
+    "tags"       jsonb                    not null default '{}'::jsonb,
+    "flags"      integer                  not null default 0,
+    "record_id"  varchar(255)             not null default '',
+    "format"     varchar(255)             not null default '',
+    "created_at" timestamp with time zone not null default (now() at time zone 'utc'),
+    primary key ("id")
+);
+
+create index if not exists "idx_changelog_created_at" on "changelog" ("created_at");
+`
+	createChangelogDown = `
+drop table if exists "changelog";
+`
+
+	createWebhooksUp = `
+create table if not exists "webhook"
+(
+    "id"         varchar(255)             not null,
+    "url"        varchar(2048)            not null,
+    "secret"     varchar(255)             not null default '',
+    "path"       varchar(1024)            not null default '/',
+    "events"     jsonb                    not null default '[]'::jsonb,
+    "cursor"     bigint                   not null default 0,
+    "created_at" timestamp with time zone not null default (now() at time zone 'utc'),
+    "updated_at" timestamp with time zone not null default (now() at time zone 'utc'),
+    primary key ("id")
+);
+
+create table if not exists "webhook_dead_letter"
+(
+    "id"         bigserial                not null,
+    "webhook_id" varchar(255)             not null references "webhook" ("id") on delete cascade,
+    "change_id"  bigint                   not null,
+    "event"      varchar(64)              not null,
+    "payload"    jsonb                    not null,
+    "error"      text                     not null default '',
+    "attempts"   integer                  not null default 0,
+    "created_at" timestamp with time zone not null default (now() at time zone 'utc'),
+    primary key ("id")
+);
+
+create index if not exists "idx_webhook_dead_letter_webhook_id" on "webhook_dead_letter" ("webhook_id");
+`
+	createWebhooksDown = `
+drop table if exists "webhook_dead_letter";
+drop table if exists "webhook";
+`
+
+	addVersionsUp = `
+alter table "node" add column if not exists "version" bigint not null default 1;
+alter table "index_record" add column if not exists "version" bigint not null default 1;
+alter table "index_record_history" add column if not exists "version" bigint not null default 1;
+`
+	addVersionsDown = `
+alter table "index_record_history" drop column if exists "version";
+alter table "index_record" drop column if exists "version";
+alter table "node" drop column if exists "version";
+`
+
+	createIdempotencyKeysUp = `
+create table if not exists "idempotency_key"
+(
+    "key"         varchar(255)             not null,
+    "fingerprint" varchar(255)             not null,
+    "done"        boolean                  not null default false,
+    "response"    bytea,
+    "created_at"  timestamp with time zone not null default (now() at time zone 'utc'),
+    primary key ("key")
+);
+
+create index if not exists "idx_idempotency_key_created_at" on "idempotency_key" ("created_at");
+`
+	createIdempotencyKeysDown = `
+drop table if exists "idempotency_key";
+`
+
+	createJobsUp = `
+create table if not exists "job"
+(
+    "id"         bigserial                not null,
+    "node_id"    bigint                   not null,
+    "path"       varchar(1024)            not null,
+    "parser"     varchar(255)             not null,
+    "status"     varchar(32)              not null,
+    "records"    bigint                   not null default 0,
+    "error"      text                     not null default '',
+    "created_at" timestamp with time zone not null default (now() at time zone 'utc'),
+    "updated_at" timestamp with time zone not null default (now() at time zone 'utc'),
+    primary key ("id")
+);
+
+create index if not exists "idx_job_status" on "job" ("status");
+`
+	createJobsDown = `
+drop table if exists "job";
+`
+
+	// the attributes indexes are created by CreateAttrSchema, they are dropped with the attrs column
+	addNodeAttrsUp = `
+alter table "node" add column if not exists "attrs" jsonb not null default '{}'::jsonb;
+alter table "changelog" add column if not exists "attrs" jsonb not null default '{}'::jsonb;
+
+create table if not exists "attr_schema"
+(
+    "prefix"     varchar(1024)            not null,
+    "name"       varchar(255)             not null,
+    "type"       varchar(32)              not null,
+    "created_at" timestamp with time zone not null default (now() at time zone 'utc'),
+    primary key ("prefix", "name")
+);
+`
+	addNodeAttrsDown = `
+drop table if exists "attr_schema";
+alter table "changelog" drop column if exists "attrs";
+alter table "node" drop column if exists "attrs";
+`
+
+	addRecordMetaUp = `
+alter table "index_record" add column if not exists "meta" jsonb not null default '{}'::jsonb;
+alter table "index_record_history" add column if not exists "meta" jsonb not null default '{}'::jsonb;
+alter table "changelog" add column if not exists "meta" jsonb not null default '{}'::jsonb;
+`
+	addRecordMetaDown = `
+alter table "changelog" drop column if exists "meta";
+alter table "index_record_history" drop column if exists "meta";
+alter table "index_record" drop column if exists "meta";
+`
+
+	addEffectiveTagsUp = `
+alter table "node" add column if not exists "effective_tags" jsonb not null default '{}'::jsonb;
+alter table "changelog" add column if not exists "effective_tags" jsonb not null default '{}'::jsonb;
+create index if not exists "idx_node_effective_tags" on "node" using gin ("effective_tags");
+` + effectiveTagsUpdate + ";"
+	addEffectiveTagsDown = `
+drop index if exists "idx_node_effective_tags";
+alter table "changelog" drop column if exists "effective_tags";
+alter table "node" drop column if exists "effective_tags";
+`
+
+	addNodeACLUp = `
+alter table "node" add column if not exists "acl" jsonb not null default '{}'::jsonb;
+alter table "node" add column if not exists "effective_acl" jsonb not null default '{}'::jsonb;
+create index if not exists "idx_node_effective_acl" on "node" using gin ("effective_acl");
+`
+	addNodeACLDown = `
+drop index if exists "idx_node_effective_acl";
+alter table "node" drop column if exists "effective_acl";
+alter table "node" drop column if exists "acl";
+`
+
+	addChangelogSeqUp = `
+alter table "changelog" add column if not exists "txid" xid8 not null default pg_current_xact_id();
+alter table "changelog" add column if not exists "seq" bigint;
+update "changelog" set "seq" = "id";
+create unique index if not exists "idx_changelog_seq" on "changelog" ("seq");
+create index if not exists "idx_changelog_txid_id" on "changelog" ("txid", "id") where "seq" is null;
+`
+	addChangelogSeqDown = `
+drop index if exists "idx_changelog_txid_id";
+drop index if exists "idx_changelog_seq";
+alter table "changelog" drop column if exists "seq";
+alter table "changelog" drop column if exists "txid";
+`
+
+	addNodePathCIndexUp = `
+create index if not exists "idx_node_path_c" on "node" ("path" collate "C");
+`
+	addNodePathCIndexDown = `
+drop index if exists "idx_node_path_c";
+`
+
+	addIdempotencyKeyLeaseUp = `
+alter table "idempotency_key" add column if not exists "locked_until" timestamp with time zone not null default '1970-01-01 00:00:00+00';
+`
+	addIdempotencyKeyLeaseDown = `
+alter table "idempotency_key" drop column if exists "locked_until";
+`
+
+	addJobLockUp = `
+alter table "job" add column if not exists "locked_by" varchar(255) not null default '';
+alter table "job" add column if not exists "locked_until" timestamp with time zone not null default '1970-01-01 00:00:00+00';
+`
+	addJobLockDown = `
+alter table "job" drop column if exists "locked_until";
+alter table "job" drop column if exists "locked_by";
+`
+
+	addWebhookLockUp = `
+alter table "webhook" add column if not exists "attempts" integer not null default 0;
+alter table "webhook" add column if not exists "next_attempt_at" timestamp with time zone not null default '1970-01-01 00:00:00+00';
+alter table "webhook" add column if not exists "locked_by" varchar(255) not null default '';
+alter table "webhook" add column if not exists "locked_until" timestamp with time zone not null default '1970-01-01 00:00:00+00';
+`
+	addWebhookLockDown = `
+alter table "webhook" drop column if exists "locked_until";
+alter table "webhook" drop column if exists "locked_by";
+alter table "webhook" drop column if exists "next_attempt_at";
+alter table "webhook" drop column if exists "attempts";
+`
+
+	addEngineSwitchOwnerUp = `
+alter table "engine_switch" add column if not exists "owner" varchar(255) not null default '';
+`
+	addEngineSwitchOwnerDown = `
+alter table "engine_switch" drop column if exists "owner";
 `
 )
 
@@ -99,6 +372,150 @@ func addTxtFormat(id string) *migrate.Migration {
 	}
 }
 
+func createEngineSwitch(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{createEngineSwitchUp},
+		Down: []string{createEngineSwitchDown},
+	}
+}
+
+func addNodeDeletedAt(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addNodeDeletedAtUp},
+		Down: []string{addNodeDeletedAtDown},
+	}
+}
+
+func addRecordHistory(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addRecordHistoryUp},
+		Down: []string{addRecordHistoryDown},
+	}
+}
+
+func createChangelog(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{createChangelogUp},
+		Down: []string{createChangelogDown},
+	}
+}
+
+func createWebhooks(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{createWebhooksUp},
+		Down: []string{createWebhooksDown},
+	}
+}
+
+func addVersions(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addVersionsUp},
+		Down: []string{addVersionsDown},
+	}
+}
+
+func createIdempotencyKeys(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{createIdempotencyKeysUp},
+		Down: []string{createIdempotencyKeysDown},
+	}
+}
+
+func createJobs(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{createJobsUp},
+		Down: []string{createJobsDown},
+	}
+}
+
+func addNodeAttrs(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addNodeAttrsUp},
+		Down: []string{addNodeAttrsDown},
+	}
+}
+
+func addRecordMeta(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addRecordMetaUp},
+		Down: []string{addRecordMetaDown},
+	}
+}
+
+func addEffectiveTags(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addEffectiveTagsUp},
+		Down: []string{addEffectiveTagsDown},
+	}
+}
+
+func addNodeACL(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addNodeACLUp},
+		Down: []string{addNodeACLDown},
+	}
+}
+
+func addChangelogSeq(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addChangelogSeqUp},
+		Down: []string{addChangelogSeqDown},
+	}
+}
+
+func addNodePathCIndex(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addNodePathCIndexUp},
+		Down: []string{addNodePathCIndexDown},
+	}
+}
+
+func addIdempotencyKeyLease(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addIdempotencyKeyLeaseUp},
+		Down: []string{addIdempotencyKeyLeaseDown},
+	}
+}
+
+func addJobLock(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addJobLockUp},
+		Down: []string{addJobLockDown},
+	}
+}
+
+func addWebhookLock(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addWebhookLockUp},
+		Down: []string{addWebhookLockDown},
+	}
+}
+
+func addEngineSwitchOwner(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addEngineSwitchOwnerUp},
+		Down: []string{addEngineSwitchOwnerDown},
+	}
+}
+
 // migrations returns migrations to be reused for
 // all the specific search implementations, the range of
 // "common" migrations IDs [0-999]
@@ -106,6 +523,24 @@ func migrations() []*migrate.Migration {
 	return []*migrate.Migration{
 		initSchema("0"),
 		addTxtFormat("1"),
+		createEngineSwitch("2"),
+		addNodeDeletedAt("3"),
+		addRecordHistory("4"),
+		createChangelog("5"),
+		createWebhooks("6"),
+		addVersions("7"),
+		createIdempotencyKeys("8"),
+		createJobs("9"),
+		addNodeAttrs("10"),
+		addRecordMeta("11"),
+		addEffectiveTags("12"),
+		addNodeACL("13"),
+		addChangelogSeq("14"),
+		addNodePathCIndex("15"),
+		addIdempotencyKeyLease("16"),
+		addJobLock("17"),
+		addWebhookLock("18"),
+		addEngineSwitchOwner("19"),
 	}
 }
 
@@ -211,22 +646,80 @@ func migrateFtsDown(ctx context.Context, db *sql.DB) error {
 	return nil
 }
 
-// rollback NOT currSearch module migrations,
-// this will leave only "common" migrations and
-// will allow switching between search modules,
-// if we set migrate.SetIgnoreUnknown(true)
+// pgvector
 
-func rollbackOthers(ctx context.Context, db *sql.DB, currSearch SearchModuleName) error {
-	migrate.SetIgnoreUnknown(true)
+func migratePgvectorUp(ctx context.Context, db *sql.DB, cfg pgvector.Config) error {
 	var migrs []*migrate.Migration
-	if currSearch != SearchModuleFts {
-		migrs = append(migrs, fts.Migrations(true)...)
+	migrs = append(migrs, migrations()...)
+	migrs = append(migrs, pgvector.Migrations(cfg, false)...)
+	mms := migrate.MemoryMigrationSource{
+		Migrations: migrs,
 	}
-	if currSearch != SearchModuleGroonga {
-		migrs = append(migrs, groonga.Migrations(true)...)
+	if _, err := migrate.ExecContext(ctx, db, "postgres", mms, migrate.Up); err != nil {
+		return err
 	}
-	if currSearch != SearchModuleTrigram {
-		migrs = append(migrs, trigram.Migrations(true)...)
+	return nil
+}
+
+func migratePgvectorDown(ctx context.Context, db *sql.DB) error {
+	var migrs []*migrate.Migration
+	migrs = append(migrs, migrations()...)
+	migrs = append(migrs, pgvector.Migrations(pgvector.Config{}, true)...)
+	mms := migrate.MemoryMigrationSource{
+		Migrations: migrs,
+	}
+	if _, err := migrate.ExecContext(ctx, db, "postgres", mms, migrate.Down); err != nil {
+		return err
+	}
+	return nil
+}
+
+// moduleMigrations returns the migrations of the search module, which are applied on top
+// of the "common" migrations. The modules, which keep the index out of Postgres, have none.
+func moduleMigrations(m SearchModuleName, cfg pgvector.Config, rollback bool) []*migrate.Migration {
+	switch m {
+	case SearchModuleGroonga:
+		return groonga.Migrations(rollback)
+	case SearchModuleTrigram:
+		return trigram.Migrations(rollback)
+	case SearchModuleFts:
+		return fts.Migrations(rollback)
+	case SearchModulePgvector:
+		return pgvector.Migrations(cfg, rollback)
+	}
+	return nil
+}
+
+// migrateModulesUp applies the "common" migrations and the migrations of all the
+// search modules provided. The modules migrations IDs ranges don't overlap, so
+// the modules can be used side by side.
+func migrateModulesUp(ctx context.Context, db *sql.DB, cfg pgvector.Config, modules ...SearchModuleName) error {
+	var migrs []*migrate.Migration
+	migrs = append(migrs, migrations()...)
+	for _, m := range modules {
+		migrs = append(migrs, moduleMigrations(m, cfg, false)...)
+	}
+	mms := migrate.MemoryMigrationSource{
+		Migrations: migrs,
+	}
+	if _, err := migrate.ExecContext(ctx, db, "postgres", mms, migrate.Up); err != nil {
+		return err
+	}
+	return nil
+}
+
+// rollback NOT kept search modules migrations,
+// this will leave only "common" migrations and
+// the kept modules ones, and will allow switching
+// between search modules, if we set migrate.SetIgnoreUnknown(true)
+
+func rollbackOthers(ctx context.Context, db *sql.DB, keep ...SearchModuleName) error {
+	migrate.SetIgnoreUnknown(true)
+	var migrs []*migrate.Migration
+	for _, m := range []SearchModuleName{SearchModuleFts, SearchModuleGroonga, SearchModuleTrigram, SearchModulePgvector} {
+		if !slices.Contains(keep, m) {
+			migrs = append(migrs, moduleMigrations(m, pgvector.Config{}, true)...)
+		}
 	}
 	mms := migrate.MemoryMigrationSource{
 		Migrations: migrs,
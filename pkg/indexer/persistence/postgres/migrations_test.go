@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/pgvector"
 	"github.com/stretchr/testify/assert"
 	"time"
 )
@@ -23,7 +24,7 @@ func (ts *pgCommonTestSuite) TestMigrations() {
 	assert.NoError(ts.T(), migrateCommonUp(ctx, ts.db.db.DB))
 	count, err = persistence.Count(ctx, ts.db.db, "select count(*) from gorp_migrations")
 	assert.Nil(ts.T(), err)
-	assert.Equal(ts.T(), int64(2), count)
+	assert.Equal(ts.T(), int64(20), count)
 
 	// down
 	assert.NoError(ts.T(), migrateCommonDown(ctx, ts.db.db.DB))
@@ -48,7 +49,7 @@ func (ts *pgGroongaTestSuite) TestMigrations() {
 	assert.NoError(ts.T(), migrateGroongaUp(ctx, ts.db.db.DB))
 	count, err = persistence.Count(ctx, ts.db.db, "select count(*) from gorp_migrations")
 	assert.Nil(ts.T(), err)
-	assert.Equal(ts.T(), int64(4), count)
+	assert.Equal(ts.T(), int64(22), count)
 
 	// down
 	assert.NoError(ts.T(), migrateGroongaDown(ctx, ts.db.db.DB))
@@ -73,7 +74,7 @@ func (ts *pgTrigramTestSuite) TestMigrations() {
 	assert.NoError(ts.T(), migrateTrigramUp(ctx, ts.db.db.DB))
 	count, err = persistence.Count(ctx, ts.db.db, "select count(*) from gorp_migrations")
 	assert.Nil(ts.T(), err)
-	assert.Equal(ts.T(), int64(4), count)
+	assert.Equal(ts.T(), int64(22), count)
 
 	// down
 	assert.NoError(ts.T(), migrateTrigramDown(ctx, ts.db.db.DB))
@@ -98,7 +99,7 @@ func (ts *pgFtsTestSuite) TestMigrations() {
 	assert.NoError(ts.T(), migrateFtsUp(ctx, ts.db.db.DB))
 	count, err = persistence.Count(ctx, ts.db.db, "select count(*) from gorp_migrations")
 	assert.Nil(ts.T(), err)
-	assert.Equal(ts.T(), int64(4), count)
+	assert.Equal(ts.T(), int64(22), count)
 
 	// down
 	assert.NoError(ts.T(), migrateFtsDown(ctx, ts.db.db.DB))
@@ -106,3 +107,28 @@ func (ts *pgFtsTestSuite) TestMigrations() {
 	assert.Nil(ts.T(), err)
 	assert.Equal(ts.T(), int64(0), count)
 }
+
+// pgvector
+
+func (ts *pgPgvectorTestSuite) TestMigrations() {
+	ctx, cancelFn := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelFn()
+
+	// down
+	assert.NoError(ts.T(), migratePgvectorDown(ctx, ts.db.db.DB))
+	count, err := persistence.Count(ctx, ts.db.db, "select count(*) from gorp_migrations")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(0), count)
+
+	// up
+	assert.NoError(ts.T(), migratePgvectorUp(ctx, ts.db.db.DB, pgvector.DefaultConfig()))
+	count, err = persistence.Count(ctx, ts.db.db, "select count(*) from gorp_migrations")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(23), count)
+
+	// down
+	assert.NoError(ts.T(), migratePgvectorDown(ctx, ts.db.db.DB))
+	count, err = persistence.Count(ctx, ts.db.db, "select count(*) from gorp_migrations")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(0), count)
+}
@@ -0,0 +1,254 @@
+package pgvector
+
+import (
+	"context"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/jmoiron/sqlx"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/ql"
+	"strings"
+)
+
+type (
+	// Config contains the pgvector module settings. The settings are applied when the
+	// module migrations are run the first time, so to change them the module must be
+	// turned off (another search module is selected) and turned on again.
+	Config struct {
+		// Dims is the number of the embedding dimensions
+		Dims int
+		// IndexType is the vector index type, either IndexHnsw or IndexIvfFlat
+		IndexType string
+	}
+)
+
+const (
+	IndexHnsw    = "hnsw"
+	IndexIvfFlat = "ivfflat"
+
+	// DefaultDims is the number of the embedding dimensions used if it is not specified
+	DefaultDims = 1536
+
+	createExtensionUp = `
+create extension if not exists vector;
+`
+	createEmbeddingUp = `
+alter table "index_record" add column if not exists "embedding" vector(%d);
+`
+	createEmbeddingDown = `
+alter table "index_record" drop column if exists "embedding";
+`
+	createHnswIndexUp = `
+create index if not exists "idx_index_record_embedding" on "index_record" using hnsw ("embedding" vector_cosine_ops);
+`
+	createIvfFlatIndexUp = `
+create index if not exists "idx_index_record_embedding" on "index_record" using ivfflat ("embedding" vector_cosine_ops) with (lists = 100);
+`
+	createHnswIndexConcurrentlyUp = `
+create index concurrently if not exists "idx_index_record_embedding" on "index_record" using hnsw ("embedding" vector_cosine_ops);
+`
+	createIvfFlatIndexConcurrentlyUp = `
+create index concurrently if not exists "idx_index_record_embedding" on "index_record" using ivfflat ("embedding" vector_cosine_ops) with (lists = 100);
+`
+	createEmbeddingIndexDown = `
+drop index if exists "idx_index_record_embedding";
+`
+
+	// BackfillSelect selects the next batch of the records without the embeddings after the
+	// ($1 node_id, $2 id) key, the batch size is $3. The records are to be embedded and
+	// written back by the BackfillUpdate.
+	BackfillSelect = `
+select "node_id", "id", "segment" from "index_record"
+where "embedding" is null and ("node_id", "id") > ($1, $2) order by "node_id", "id" limit $3
+`
+	// BackfillUpdate sets the embeddings $3 of the records by their $1 node_id and $2 id arrays,
+	// the embeddings stored since the records were selected are kept.
+	BackfillUpdate = `
+update "index_record" as ir set "embedding" = b."embedding"::vector
+from unnest($1::bigint[], $2::text[], $3::text[]) as b("node_id", "id", "embedding")
+where ir."node_id" = b."node_id" and ir."id" = b."id" and ir."embedding" is null
+`
+)
+
+// FcTranslator is the filter conditions translator from simila QL to the Postgres dialect
+var FcTranslator = ql.NewTranslator(ql.PqFilterConditionsDialect)
+
+// DefaultConfig returns the default module settings
+func DefaultConfig() Config {
+	return Config{Dims: DefaultDims, IndexType: IndexHnsw}
+}
+
+func createExtension(id string, rollback bool) *migrate.Migration {
+	m := &migrate.Migration{
+		Id: id,
+	}
+	if !rollback {
+		m.Up = []string{createExtensionUp}
+		m.DisableTransactionUp = true
+	}
+	return m
+}
+
+func createEmbedding(id string, cfg Config, rollback bool) *migrate.Migration {
+	m := &migrate.Migration{
+		Id:   id,
+		Down: []string{createEmbeddingDown},
+	}
+	if !rollback {
+		m.Up = []string{fmt.Sprintf(createEmbeddingUp, cfg.Dims)}
+	}
+	return m
+}
+
+func createEmbeddingIndex(id string, cfg Config, rollback bool) *migrate.Migration {
+	m := &migrate.Migration{
+		Id:   id,
+		Down: []string{createEmbeddingIndexDown},
+	}
+	if !rollback {
+		m.Up = []string{createHnswIndexUp}
+		if cfg.IndexType == IndexIvfFlat {
+			m.Up = []string{createIvfFlatIndexUp}
+		}
+	}
+	return m
+}
+
+// Check returns an error if the config settings are not valid
+func (c Config) Check() error {
+	if c.Dims <= 0 {
+		return fmt.Errorf("the embedding dimensions must be positive, but %d: %w", c.Dims, errors.ErrInvalid)
+	}
+	if c.IndexType != IndexHnsw && c.IndexType != IndexIvfFlat {
+		return fmt.Errorf("the vector index type must be %q or %q, but %q: %w", IndexHnsw, IndexIvfFlat, c.IndexType, errors.ErrInvalid)
+	}
+	return nil
+}
+
+// Migrations returns migrations to be applied on top of
+// the "common" migrations for the pgvector semantic search
+// module to work, the module migration IDs range is [4000-4999]
+func Migrations(cfg Config, rollback bool) []*migrate.Migration {
+	return []*migrate.Migration{
+		createExtension("4000", rollback),
+		createEmbedding("4001", cfg, rollback),
+		createEmbeddingIndex("4002", cfg, rollback),
+	}
+}
+
+// OnlineMigrations returns the same migrations as Migrations, but the embedding index
+// is not created by them, it must be created by OnlineIndexes after the migrations
+// are applied. It allows to turn the module on while the "index_record" table is in use,
+// the "embedding" column is added without the table rewrite.
+func OnlineMigrations(cfg Config) []*migrate.Migration {
+	return []*migrate.Migration{
+		createExtension("4000", false),
+		createEmbedding("4001", cfg, false),
+		createEmbeddingIndex("4002", cfg, true),
+	}
+}
+
+// OnlineIndexes returns the module indexes (the index name to the create statement),
+// which are created concurrently, after the OnlineMigrations are applied.
+func OnlineIndexes(cfg Config) map[string]string {
+	if cfg.IndexType == IndexIvfFlat {
+		return map[string]string{"idx_index_record_embedding": createIvfFlatIndexConcurrentlyUp}
+	}
+	return map[string]string{"idx_index_record_embedding": createHnswIndexConcurrentlyUp}
+}
+
+// Search is an implementation of the postgres.SearchFn function based on
+// the pgvector extension. The records are ranked by the cosine distance between the
+// record embedding and the SearchQuery.Embedding, the score is 1 - distance. If the
+// SearchQuery.Embedding is empty, the SearchQuery.TextQuery must contain the embedding
+// in the [0.1,0.2,...] form. The records without embeddings are never found.
+func Search(ctx context.Context, qx sqlx.QueryerContext, q persistence.SearchQuery) (persistence.SearchQueryResult, error) {
+	emb := q.Embedding
+	if len(emb) == 0 {
+		var err error
+		if emb, err = persistence.ParseEmbedding(q.TextQuery); err != nil {
+			return persistence.SearchQueryResult{}, fmt.Errorf("the query embedding is not provided: %w", err)
+		}
+	}
+
+	var fc strings.Builder
+	fc.Grow(2 * len(q.FilterConditions))
+	if err := FcTranslator.Translate(&fc, q.FilterConditions); err != nil {
+		return persistence.SearchQueryResult{}, persistence.MapError(err)
+	}
+	// the trashed nodes records are never returned
+	var sb strings.Builder
+	sb.WriteString("n.deleted_at is null and ")
+	if q.Access != nil {
+		sb.WriteString(persistence.PqAccessCondition(*q.Access) + " and ")
+	}
+	if fc.Len() > 0 {
+		sb.WriteString("(" + fc.String() + ") and ")
+	}
+	sb.WriteString(" ir.embedding is not null ")
+
+	params := []any{emb}
+	where := sb.String()
+
+	var count string
+	var query string
+
+	if q.GroupByPathOff {
+		count = fmt.Sprintf(`select count(*)
+			from index_record as ir
+			inner join node as n on n.id = ir.node_id
+			where %s`, where)
+
+		query = fmt.Sprintf(`select ir.*,
+			n.name as path,
+			(1 - (ir.embedding <=> $1::vector)) as score
+			from index_record as ir
+			inner join node as n on n.id = ir.node_id
+			where %s
+			order by ir.embedding <=> $1::vector, ir.id
+			offset $2 limit $3`, where)
+	} else {
+		count = fmt.Sprintf(`select count(distinct ir.node_id)
+			from index_record as ir
+			inner join node as n on n.id = ir.node_id
+			where %s`, where)
+
+		query = fmt.Sprintf(`select r.* from (
+				select distinct on (ir.node_id) ir.*,
+				n.name as path,
+				(1 - (ir.embedding <=> $1::vector)) as score
+				from index_record as ir
+				inner join node as n on n.id = ir.node_id
+				where %s
+				order by ir.node_id, ir.embedding <=> $1::vector, ir.id
+			) as r
+			order by r.score desc, r.path, r.id
+			offset $2 limit $3`, where)
+	}
+
+	// count
+	total, err := persistence.Count(ctx, qx, count)
+	if err != nil {
+		return persistence.SearchQueryResult{}, persistence.MapError(err)
+	}
+
+	// query
+	if q.Limit <= 0 {
+		return persistence.SearchQueryResult{Total: total}, nil
+	}
+	params = append(params, q.Offset, q.Limit)
+	rows, err := qx.QueryxContext(ctx, query, params...)
+	if err != nil {
+		return persistence.SearchQueryResult{}, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	// results
+	res, err := persistence.ScanRowsQueryResult[persistence.SearchQueryResultItem](rows)
+	if err != nil {
+		return persistence.SearchQueryResult{}, persistence.MapError(err)
+	}
+	return persistence.SearchQueryResult{Items: res, Total: total}, nil
+}
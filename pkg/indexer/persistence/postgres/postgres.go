@@ -5,80 +5,238 @@ import (
 	"fmt"
 	"github.com/acquirecloud/golibs/errors"
 	"github.com/jmoiron/sqlx"
+	"github.com/simila-io/simila/pkg/embedding"
+	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/bleve"
+	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/elastic"
 	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/fts"
 	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/groonga"
+	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/pgvector"
 	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/trigram"
+	"github.com/simila-io/simila/pkg/ql"
+	"slices"
+	"time"
 )
 
 const (
-	SearchModuleNone    = ""
-	SearchModuleGroonga = "pgroonga"
-	SearchModuleTrigram = "pgtrigram"
-	SearchModuleFts     = "pgfts"
+	SearchModuleNone     = ""
+	SearchModuleGroonga  = "pgroonga"
+	SearchModuleTrigram  = "pgtrigram"
+	SearchModuleFts      = "pgfts"
+	SearchModuleBleve    = "bleve"
+	SearchModuleElastic  = "elastic"
+	SearchModulePgvector = "pgvector"
 )
 
-type SearchModuleName string
+type (
+	SearchModuleName string
+
+	// Option allows to specify the optional settings for GetDb
+	Option func(o *options)
+
+	options struct {
+		bleveIndexDir string
+		elasticCfg    elastic.Config
+		pgvectorCfg   pgvector.Config
+		semantic      bool
+		modules       []SearchModuleName
+		embedder      embedding.Provider
+		// switchPoll is the interval of checking the search engine switches made by the other instances
+		switchPoll time.Duration
+		// switchTimeout is how long the running switch may be not updated by its owner, before it is
+		// considered abandoned and failed
+		switchTimeout time.Duration
+	}
+)
+
+// WithBleveIndexDir sets the directory where the Bleve search module keeps its index
+func WithBleveIndexDir(dir string) Option {
+	return func(o *options) {
+		o.bleveIndexDir = dir
+	}
+}
+
+// WithElasticConfig sets the Elasticsearch (or OpenSearch) settings for the Elasticsearch search module
+func WithElasticConfig(cfg elastic.Config) Option {
+	return func(o *options) {
+		o.elasticCfg = cfg
+	}
+}
+
+// WithPgvectorConfig sets the pgvector search module settings
+func WithPgvectorConfig(cfg pgvector.Config) Option {
+	return func(o *options) {
+		o.pgvectorCfg = cfg
+	}
+}
+
+// WithEmbedder sets the embedding provider, which fills the embeddings of the existing records,
+// when the default search module is switched to pgvector online
+func WithEmbedder(p embedding.Provider) Option {
+	return func(o *options) {
+		o.embedder = p
+	}
+}
+
+// WithSemanticSearch turns on the pgvector semantic search alongside the search module,
+// so the semantic and the hybrid search modes are available. The pgvector module
+// settings are specified by WithPgvectorConfig.
+func WithSemanticSearch() Option {
+	return func(o *options) {
+		o.semantic = true
+	}
+}
+
+// WithSearchModules turns on the search modules alongside the default one passed to GetDb,
+// so the search engine can be chosen per query (see persistence.SearchQuery.Engine). Only
+// one of the modules, which keep the index out of Postgres (bleve, elastic), can be used.
+func WithSearchModules(modules ...SearchModuleName) Option {
+	return func(o *options) {
+		o.modules = append(o.modules, modules...)
+	}
+}
 
 // MustGetDb does the same as GetDb but panics in case of an error
-func MustGetDb(ctx context.Context, dsName string, search SearchModuleName) *Db {
-	db, err := GetDb(ctx, dsName, search)
+func MustGetDb(ctx context.Context, dsName string, search SearchModuleName, opts ...Option) *Db {
+	db, err := GetDb(ctx, dsName, search, opts...)
 	if err != nil {
 		panic(err)
 	}
 	return db
 }
 
-// GetDb returns the Db object built for the given configuration
-func GetDb(ctx context.Context, dsName string, search SearchModuleName) (*Db, error) {
+// GetDb returns the Db object built for the given configuration. The search module is
+// used by default, the other modules turned on by the options are available by their names.
+// The indexes of the modules, which are not turned on, are dropped.
+func GetDb(ctx context.Context, dsName string, search SearchModuleName, opts ...Option) (*Db, error) {
+	o := options{pgvectorCfg: pgvector.DefaultConfig(), switchPoll: defaultSwitchPoll, switchTimeout: defaultSwitchTimeout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	// the settings are checked before connecting to the database
+	if _, err := o.searchModules(search); err != nil {
+		return nil, err
+	}
 	db, err := sqlx.ConnectContext(ctx, "postgres", dsName)
 	if err != nil {
 		return nil, fmt.Errorf("could not connect to the database: %w", err)
 	}
-	if err = rollbackOthers(ctx, db.DB, search); err != nil {
-		return nil, fmt.Errorf("rollback failed: %w", err)
+	configured := search
+	if search, err = switchedModule(ctx, db, search, o.switchTimeout); err != nil {
+		return nil, fmt.Errorf("could not read the search engine switches: %w", err)
 	}
-	switch search {
-	case SearchModuleNone:
-		return getDefaultDb(ctx, db)
-	case SearchModuleGroonga:
-		return getGroongaDb(ctx, db)
-	case SearchModuleTrigram:
-		return getTrigramDb(ctx, db)
-	case SearchModuleFts:
-		return getFtsDb(ctx, db)
+	modules, err := o.searchModules(search)
+	if err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("unsupported postgres search module=%s: %w", search, errors.ErrInvalid)
-}
-
-func getDefaultDb(ctx context.Context, db *sqlx.DB) (*Db, error) {
-	if err := migrateCommonUp(ctx, db.DB); err != nil {
-		return nil, fmt.Errorf("migration failed: %w", err)
+	if err = rollbackOthers(ctx, db.DB, modules...); err != nil {
+		return nil, fmt.Errorf("rollback failed: %w", err)
 	}
-	return newDb(db, dbExt{}), nil
-}
-
-func getGroongaDb(ctx context.Context, db *sqlx.DB) (*Db, error) {
-	if err := migrateGroongaUp(ctx, db.DB); err != nil {
+	if err = migrateModulesUp(ctx, db.DB, o.pgvectorCfg, modules...); err != nil {
 		return nil, fmt.Errorf("migration failed: %w", err)
 	}
-	return newDb(db, dbExt{tr: groonga.FcTranslator, searchFn: groonga.Search}), nil
+	dbe := dbExt{search: search, engines: map[SearchModuleName]SearchFn{}}
+	for _, m := range modules {
+		tr, err := openModule(ctx, db, o, m, &dbe)
+		if err != nil {
+			if dbe.extIdx != nil {
+				_ = dbe.extIdx.Close()
+			}
+			return nil, err
+		}
+		// the filter conditions translator of the default module is used
+		if m == search {
+			dbe.tr = tr
+		}
+	}
+	dbe.searchFn = dbe.engines[search]
+	return newDb(db, dbe, o, configured), nil
 }
 
-func getTrigramDb(ctx context.Context, db *sqlx.DB) (*Db, error) {
-	if err := migrateTrigramUp(ctx, db.DB); err != nil {
-		return nil, fmt.Errorf("migration failed: %w", err)
+// searchModules returns the search modules to be turned on, the default search module goes first
+func (o options) searchModules(search SearchModuleName) ([]SearchModuleName, error) {
+	var res []SearchModuleName
+	ext := 0
+	for _, m := range append([]SearchModuleName{search}, o.modules...) {
+		if slices.Contains(res, m) {
+			continue
+		}
+		switch m {
+		case SearchModuleNone:
+			if m != search {
+				return nil, fmt.Errorf("the search module name must be non-empty: %w", errors.ErrInvalid)
+			}
+		case SearchModuleGroonga, SearchModuleTrigram, SearchModuleFts, SearchModulePgvector:
+		case SearchModuleBleve, SearchModuleElastic:
+			ext++
+		default:
+			return nil, fmt.Errorf("unsupported postgres search module=%s: %w", m, errors.ErrInvalid)
+		}
+		res = append(res, m)
 	}
-	if err := setSessionParams(ctx, db, trigram.SessionParams()); err != nil {
-		return nil, fmt.Errorf("session params set failed: %w", err)
+	if ext > 1 {
+		return nil, fmt.Errorf("only one of %s and %s search modules can be used: %w", SearchModuleBleve, SearchModuleElastic, errors.ErrInvalid)
 	}
-	return newDb(db, dbExt{tr: trigram.FcTranslator, searchFn: trigram.Search}), nil
+	if o.semantic && !slices.Contains(res, SearchModulePgvector) {
+		res = append(res, SearchModulePgvector)
+	}
+	if slices.Contains(res, SearchModulePgvector) {
+		if err := o.pgvectorCfg.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
 }
 
-func getFtsDb(ctx context.Context, db *sqlx.DB) (*Db, error) {
-	if err := migrateFtsUp(ctx, db.DB); err != nil {
-		return nil, fmt.Errorf("migration failed: %w", err)
+// openModule makes the search module m ready to use and adds it to the dbe,
+// it returns the filter conditions translator of the module
+func openModule(ctx context.Context, db *sqlx.DB, o options, m SearchModuleName, dbe *dbExt) (ql.Translator, error) {
+	var tr ql.Translator
+	switch m {
+	case SearchModuleGroonga:
+		tr = groonga.FcTranslator
+		dbe.engines[m] = groonga.Search
+	case SearchModuleTrigram:
+		if err := setSessionParams(ctx, db, trigram.SessionParams()); err != nil {
+			return tr, fmt.Errorf("session params set failed: %w", err)
+		}
+		tr = trigram.FcTranslator
+		dbe.engines[m] = trigram.Search
+	case SearchModuleFts:
+		tr = fts.FcTranslator
+		dbe.engines[m] = fts.Search
+	case SearchModulePgvector:
+		tr = pgvector.FcTranslator
+		dbe.vectorFn = pgvector.Search
+		dbe.embeddings = true
+	case SearchModuleBleve:
+		if o.bleveIndexDir == "" {
+			return tr, fmt.Errorf("the bleve index directory must be specified: %w", errors.ErrInvalid)
+		}
+		idx, err := bleve.Open(o.bleveIndexDir)
+		if err != nil {
+			return tr, fmt.Errorf("could not open the bleve index: %w", err)
+		}
+		if err = extSync(ctx, db, idx); err != nil {
+			_ = idx.Close()
+			return tr, fmt.Errorf("bleve index sync failed: %w", err)
+		}
+		tr = bleve.FcTranslator
+		dbe.engines[m] = idx.Search
+		dbe.extIdx = idx
+	case SearchModuleElastic:
+		idx, err := elastic.Open(ctx, o.elasticCfg)
+		if err != nil {
+			return tr, fmt.Errorf("could not open the elasticsearch index: %w", err)
+		}
+		if err = extSync(ctx, db, idx); err != nil {
+			_ = idx.Close()
+			return tr, fmt.Errorf("elasticsearch index sync failed: %w", err)
+		}
+		tr = elastic.FcTranslator
+		dbe.engines[m] = idx.Search
+		dbe.extIdx = idx
 	}
-	return newDb(db, dbExt{tr: fts.FcTranslator, searchFn: fts.Search}), nil
+	return tr, nil
 }
 
 func setSessionParams(ctx context.Context, db *sqlx.DB, sessParams map[string]any) error {
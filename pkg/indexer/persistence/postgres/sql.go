@@ -21,24 +21,42 @@ import (
 	"github.com/acquirecloud/golibs/errors"
 	"github.com/acquirecloud/golibs/logging"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/simila-io/simila/pkg/indexer/persistence"
 	"github.com/simila-io/simila/pkg/ql"
 	"os"
 	"strings"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 )
 
 type (
 	// Db implements persistence.Db
 	Db struct {
 		logger logging.Logger
-		dbe    dbExt
-		db     *sqlx.DB
+		// dbe is replaced when the default search engine is switched online
+		dbe atomic.Pointer[dbExt]
+		db  *sqlx.DB
+		// o keeps the settings the Db was built with
+		o  options
+		sw switcher
 	}
 
 	dbExt struct {
+		// search is the default search module name
+		search SearchModuleName
+		// searchFn is the lexical search function, it can be nil
 		searchFn SearchFn
-		tr       ql.Translator
+		// vectorFn is the semantic search function, it can be nil
+		vectorFn SearchFn
+		// engines are the lexical search functions of the search modules turned on, by the module names
+		engines map[SearchModuleName]SearchFn
+		tr      ql.Translator
+		// extIdx is the search index kept out of Postgres, it can be nil
+		extIdx ExtIndex
+		// embeddings is true if the index records embeddings are stored (the "embedding" column exists)
+		embeddings bool
 	}
 
 	// SearchFn is used to provide different search implementations
@@ -59,6 +77,9 @@ type (
 		ctx context.Context // context for all the operations within the tx
 		db  *sqlx.DB        // never nil
 		tx  *sqlx.Tx        // keeps active transaction, if it exists. It can be nil, if not started.
+
+		extIdx     ExtIndex // the search index kept out of Postgres, it can be nil
+		extPending []extFn  // the ExtIndex changes to be applied on commit
 	}
 
 	// modelTx is a helper to persist persistence objects ModelTx
@@ -68,8 +89,15 @@ type (
 	}
 )
 
-func newDb(sdb *sqlx.DB, dbe dbExt) *Db {
-	return &Db{db: sdb, dbe: dbe, logger: logging.NewLogger("db.postgres")}
+func newDb(sdb *sqlx.DB, dbe dbExt, o options, configured SearchModuleName) *Db {
+	d := &Db{db: sdb, o: o, logger: logging.NewLogger("db.postgres")}
+	d.dbe.Store(&dbe)
+	d.sw.ctx, d.sw.cancel = context.WithCancel(context.Background())
+	d.sw.configured = configured
+	d.sw.owner = switchOwner()
+	d.sw.wg.Add(1)
+	go d.watchSwitches()
+	return d
 }
 
 // Init implements linker.Initializer interface
@@ -85,20 +113,33 @@ func (d *Db) Shutdown() {
 		d.logger.Errorf("not initialized, but shutting down")
 		return
 	}
+	// the running engine switch and the switches watch are canceled
+	d.sw.cancel()
+	d.sw.wg.Wait()
 	err := d.db.Close()
 	if err != nil {
 		d.logger.Warnf("could not close the DB connection: %v", err)
 	}
+	if dbe := d.dbe.Load(); dbe.extIdx != nil {
+		if err = dbe.extIdx.Close(); err != nil {
+			d.logger.Warnf("could not close the search index: %v", err)
+		}
+	}
 }
 
 // NewModelTx returns the new ModelTx object
 func (d *Db) NewModelTx(ctx context.Context) persistence.ModelTx {
-	return &modelTx{tx: d.NewTx(ctx).(*tx), dbe: d.dbe}
+	dbe := d.dbe.Load()
+	return &modelTx{tx: d.newTx(ctx, dbe), dbe: *dbe}
 }
 
 // NewTx returns the new Tx object
 func (d *Db) NewTx(ctx context.Context) persistence.Tx {
-	return &tx{ctx: ctx, db: d.db}
+	return d.newTx(ctx, d.dbe.Load())
+}
+
+func (d *Db) newTx(ctx context.Context, dbe *dbExt) *tx {
+	return &tx{ctx: ctx, db: d.db, extIdx: dbe.extIdx}
 }
 
 // ============================== tx ====================================
@@ -130,6 +171,7 @@ func (t *tx) Rollback() error {
 		err = t.tx.Rollback()
 		t.tx = nil
 	}
+	t.extPending = nil
 	return err
 }
 
@@ -139,7 +181,11 @@ func (t *tx) Commit() error {
 		err = t.tx.Commit()
 		t.tx = nil
 	}
-	return err
+	if err != nil {
+		t.extPending = nil
+		return err
+	}
+	return t.extCommit()
 }
 
 // ExecQuery executes a query with params within the transaction
@@ -178,8 +224,8 @@ func (m *modelTx) CreateFormat(format persistence.Format) (persistence.Format, e
 	}
 	format.CreatedAt = time.Now()
 	format.UpdatedAt = format.CreatedAt
-	_, err := m.executor().ExecContext(m.ctx, "insert into format (id, basis, created_at, updated_at) values ($1, $2, $3, $4)",
-		format.ID, format.Basis, format.CreatedAt, format.UpdatedAt)
+	_, err := m.executor().ExecContext(m.ctx, "insert into format (id, basis, keep_history, created_at, updated_at) values ($1, $2, $3, $4, $5)",
+		format.ID, format.Basis, format.KeepHistory, format.CreatedAt, format.UpdatedAt)
 	if err != nil {
 		return persistence.Format{}, persistence.MapError(err)
 	}
@@ -221,9 +267,10 @@ func (m *modelTx) CreateNodes(nodes ...persistence.Node) ([]persistence.Node, er
 
 	var sb strings.Builder
 	var params []any
+	names := make([]string, 0, len(nodes))
 
 	firstIdx := 1
-	sb.WriteString("insert into node (path, name, tags, flags, created_at, updated_at) values ")
+	sb.WriteString("insert into node (path, name, tags, attrs, acl, flags, created_at, updated_at) values ")
 	now := time.Now()
 
 	for i, n := range nodes {
@@ -239,27 +286,56 @@ func (m *modelTx) CreateNodes(nodes ...persistence.Node) ([]persistence.Node, er
 		if n.Tags == nil {
 			n.Tags = make(persistence.Tags)
 		}
+		if n.Attrs == nil {
+			n.Attrs = make(persistence.Attrs)
+		}
 		if i > 0 {
 			sb.WriteString(",")
 		}
 
-		sb.WriteString(fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)", firstIdx, firstIdx+1, firstIdx+2, firstIdx+3, firstIdx+4, firstIdx+5))
-		firstIdx += 6
+		sb.WriteString(fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)", firstIdx, firstIdx+1, firstIdx+2, firstIdx+3, firstIdx+4, firstIdx+5, firstIdx+6, firstIdx+7))
+		firstIdx += 8
 
+		names = append(names, persistence.ConcatPath(n.Path, n.Name))
 		params = append(params, n.Path)
-		params = append(params, persistence.ConcatPath(n.Path, n.Name))
+		params = append(params, names[i])
 		params = append(params, n.Tags)
+		params = append(params, n.Attrs)
+		params = append(params, n.ACL)
 		params = append(params, n.Flags)
 		params = append(params, now)
 		params = append(params, now)
 	}
 	sb.WriteString(" returning *")
+
+	// the trashed nodes keep their names until they are restored or purged
+	var trashed string
+	if err := persistence.MapError(m.executor().GetContext(m.ctx, &trashed, "select name from node where name = any($1) and deleted_at is not null limit 1", pq.Array(names))); err == nil {
+		return nil, fmt.Errorf("node with name=%s is in the trash, it must be restored or purged first: %w", trashed, errors.ErrExist)
+	} else if !errors.Is(err, errors.ErrNotExist) {
+		return nil, err
+	}
 	rows, err := m.executor().QueryxContext(m.ctx, sb.String(), params...)
 	if err != nil {
 		return nil, persistence.MapError(err)
 	}
 	defer rows.Close()
-	return persistence.ScanRows[persistence.Node](rows)
+	res, err := persistence.ScanRows[persistence.Node](rows)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, len(res))
+	for i, n := range res {
+		ids[i] = n.ID
+	}
+	inh, err := m.updateInherited(ids...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range res {
+		res[i].EffectiveTags, res[i].EffectiveACL = inh[res[i].ID].EffectiveTags, inh[res[i].ID].EffectiveACL
+	}
+	return res, m.logNodeChanges(persistence.ChangeOpCreate, "n.id = any($1)", pq.Array(ids))
 }
 
 func (m *modelTx) ListNodes(query persistence.ListNodesQuery) ([]persistence.Node, error) {
@@ -267,7 +343,8 @@ func (m *modelTx) ListNodes(query persistence.ListNodesQuery) ([]persistence.Nod
 	if err := m.dbe.tr.Translate(&sb, query.FilterConditions); err != nil {
 		return nil, err
 	}
-	rows, err := m.executor().QueryxContext(m.ctx, fmt.Sprintf("select * from node as n where %s order by n.name offset $1 limit $2", sb.String()), query.Offset, query.Limit)
+	rows, err := m.executor().QueryxContext(m.ctx, fmt.Sprintf("select * from node as n where n.deleted_at is null and (%s)%s order by n.name offset $1 limit $2",
+		sb.String(), accessCond(query.Access)), query.Offset, query.Limit)
 	if err != nil {
 		return nil, persistence.MapError(err)
 	}
@@ -297,7 +374,7 @@ func (m *modelTx) ListAllNodesByPath(path string) ([]persistence.Node, error) {
 	}
 
 	where := sqlx.Rebind(sqlx.DOLLAR, sb.String())
-	rows, err := m.executor().QueryxContext(m.ctx, fmt.Sprintf("select * from node where %s order by name", where), args...)
+	rows, err := m.executor().QueryxContext(m.ctx, fmt.Sprintf("select * from node where deleted_at is null and (%s) order by name", where), args...)
 	if err != nil {
 		return nil, persistence.MapError(err)
 
@@ -310,7 +387,16 @@ func (m *modelTx) ListAllNodesByPath(path string) ([]persistence.Node, error) {
 
 func (m *modelTx) GetNode(fqnp string) (persistence.Node, error) {
 	var node persistence.Node
-	if err := m.executor().GetContext(m.ctx, &node, "select * from node where name = $1", fqnp); err != nil {
+	if err := m.executor().GetContext(m.ctx, &node, "select * from node where name = $1 and deleted_at is null", fqnp); err != nil {
+		return persistence.Node{}, persistence.MapError(err)
+	}
+	cleanNameAfterRead(&node)
+	return node, nil
+}
+
+func (m *modelTx) LockNode(fqnp string) (persistence.Node, error) {
+	var node persistence.Node
+	if err := m.executor().GetContext(m.ctx, &node, "select * from node where name = $1 and deleted_at is null for update", fqnp); err != nil {
 		return persistence.Node{}, persistence.MapError(err)
 	}
 	cleanNameAfterRead(&node)
@@ -330,11 +416,18 @@ func (m *modelTx) UpdateNode(node persistence.Node) error {
 		sb.WriteString(" tags = ?")
 		args = append(args, node.Tags.JSON())
 	}
+	if node.Attrs != nil {
+		if len(args) > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(" attrs = ?")
+		args = append(args, node.Attrs.JSON())
+	}
 	if len(args) == 0 {
 		return nil
 	}
 
-	sb.WriteString(", updated_at = ? where id = ?")
+	sb.WriteString(", updated_at = ?, version = version + 1 where id = ? and deleted_at is null")
 	args = append(args, time.Now(), node.ID)
 
 	res, err := m.executor().ExecContext(m.ctx, sqlx.Rebind(sqlx.DOLLAR, sb.String()), args...)
@@ -345,46 +438,466 @@ func (m *modelTx) UpdateNode(node persistence.Node) error {
 	if rows == 0 {
 		return errors.ErrNotExist
 	}
-	return nil
+	if len(node.Tags) > 0 {
+		if _, err = m.updateInherited(node.ID); err != nil {
+			return err
+		}
+	}
+	if err = m.logNodeChanges(persistence.ChangeOpUpdate, "n.id = $1", node.ID); err != nil {
+		return err
+	}
+	return m.extUpsertNode(node.ID)
+}
+
+func (m *modelTx) PatchNodes(query persistence.PatchNodesQuery) (int64, error) {
+	if err := query.Check(); err != nil {
+		return 0, err
+	}
+	tags := "tags"
+	if query.Patch.Clear {
+		tags = "'{}'::jsonb"
+	}
+	args := []any{time.Now()}
+	if len(query.Patch.Remove) > 0 {
+		args = append(args, pq.Array(query.Patch.Remove))
+		tags = fmt.Sprintf("(%s - $%d::text[])", tags, len(args))
+	}
+	if len(query.Patch.Set) > 0 {
+		args = append(args, query.Patch.Set.JSON())
+		tags = fmt.Sprintf("(%s || $%d::jsonb)", tags, len(args))
+	}
+	if query.Attrs != nil {
+		args = append(args, query.Attrs.JSON())
+		tags += fmt.Sprintf(", attrs = $%d", len(args))
+	}
+	var where string
+	if query.ID != 0 {
+		args = append(args, query.ID)
+		where = fmt.Sprintf("id = $%d", len(args))
+	} else {
+		var sb strings.Builder
+		if err := m.dbe.tr.Translate(&sb, query.FilterConditions); err != nil {
+			return 0, err
+		}
+		where = "id in (select n.id from node as n left join index_record as ir on ir.node_id = n.id where n.deleted_at is null and (" + sb.String() + "))"
+	}
+	if query.Access != nil {
+		where = "id in (select n.id from node as n where " + persistence.PqAccessCondition(*query.Access) + ") and " + where
+	}
+
+	rows, err := m.executor().QueryxContext(m.ctx, "update node set tags = "+tags+", updated_at = $1, version = version + 1 "+
+		"where "+where+" and deleted_at is null returning id", args...)
+	if err != nil {
+		return 0, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, errors.ErrNotExist
+	}
+	if _, err = m.updateInherited(ids...); err != nil {
+		return 0, err
+	}
+	if err = m.logNodeChanges(persistence.ChangeOpUpdate, "n.id = any($1)", pq.Array(ids)); err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), m.extUpsertNode(ids...)
+}
+
+func (m *modelTx) SetNodeACL(fqnp, principal string, perm persistence.Permission) (persistence.Node, error) {
+	if err := persistence.CheckACLEntry(principal, perm); err != nil {
+		return persistence.Node{}, err
+	}
+	acl := "acl - $1::text"
+	args := []any{principal, fqnp}
+	if perm != "" {
+		acl = "acl || jsonb_build_object($1::text, $3::text)"
+		args = append(args, string(perm))
+	}
+	rows, err := m.executor().QueryxContext(m.ctx, "update node set acl = "+acl+" where name = $2 and deleted_at is null returning id", args...)
+	if err != nil {
+		return persistence.Node{}, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return persistence.Node{}, err
+	}
+	if len(ids) == 0 {
+		return persistence.Node{}, errors.ErrNotExist
+	}
+	inh, err := m.updateInherited(ids...)
+	if err != nil {
+		return persistence.Node{}, err
+	}
+	// the records of the node and its descendants are re-indexed with the new effective ACLs
+	updated := make([]int64, 0, len(inh))
+	for id := range inh {
+		updated = append(updated, id)
+	}
+	if err = m.extUpsertNode(updated...); err != nil {
+		return persistence.Node{}, err
+	}
+	return m.GetNode(fqnp)
+}
+
+func (m *modelTx) MoveNode(from, to string) (persistence.Node, error) {
+	from, to = persistence.ConcatPath(from, ""), persistence.ConcatPath(to, "")
+	node, err := m.GetNode(from)
+	if err != nil {
+		return persistence.Node{}, err
+	}
+	if from == to {
+		return node, nil
+	}
+	path, err := m.targetPath(from, to)
+	if err != nil {
+		return persistence.Node{}, err
+	}
+
+	// the node and its descendants fqnps are prefixed by the from fqnp, which is replaced by the to one
+	now := time.Now()
+	prefixLen := utf8.RuneCountInString(from)
+	rows, err := m.executor().QueryxContext(m.ctx, "update node set "+
+		"path = case when id = $1 then $2 else $3::text || substr(path, $4) end, "+
+		"name = $3::text || substr(name, $4), updated_at = $5, version = version + 1 "+
+		"where id = $1 or left(path, $4) = $6 returning id",
+		node.ID, path, to, prefixLen+1, now, from+"/")
+	if err != nil {
+		return persistence.Node{}, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return persistence.Node{}, err
+	}
+	inh, err := m.updateInherited(node.ID)
+	if err != nil {
+		return persistence.Node{}, err
+	}
+	if err = m.logNodeChanges(persistence.ChangeOpUpdate, "n.id = any($1)", pq.Array(ids)); err != nil {
+		return persistence.Node{}, err
+	}
+	if err = m.extUpsertNode(ids...); err != nil {
+		return persistence.Node{}, err
+	}
+	node.Path, node.Name = persistence.ToNodePathName(to)
+	node.EffectiveTags, node.EffectiveACL = inh[node.ID].EffectiveTags, inh[node.ID].EffectiveACL
+	node.UpdatedAt = now
+	node.Version++
+	return node, nil
+}
+
+func (m *modelTx) CopyNodes(query persistence.CopyNodesQuery) (persistence.CopyNodesResult, error) {
+	from, to := persistence.ConcatPath(query.From, ""), persistence.ConcatPath(query.To, "")
+	node, err := m.GetNode(from)
+	if err != nil {
+		return persistence.CopyNodesResult{}, err
+	}
+	path, err := m.targetPath(from, to)
+	if err != nil {
+		return persistence.CopyNodesResult{}, err
+	}
+	var tags any
+	if query.Tags != nil {
+		tags = query.Tags
+	}
+
+	// the copies fqnps are the originals ones with the from prefix replaced by the to one, the copies
+	// ACLs are empty, so they inherit the destination ACL instead of the grants of the originals
+	now := time.Now()
+	prefixLen := utf8.RuneCountInString(from)
+	rows, err := m.executor().QueryxContext(m.ctx, "insert into node (path, name, tags, attrs, flags, created_at, updated_at) "+
+		"select case when id = $1 then $2 else $3::text || substr(path, $4) end, $3::text || substr(name, $4), "+
+		"coalesce($5::jsonb, tags), attrs, flags, $6, $6 from node where (id = $1 or left(path, $4) = $7) and deleted_at is null returning *",
+		node.ID, path, to, prefixLen+1, tags, now, from+"/")
+	if err != nil {
+		return persistence.CopyNodesResult{}, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	nodes, err := scanNodes(rows)
+	if err != nil {
+		return persistence.CopyNodesResult{}, persistence.MapError(err)
+	}
+
+	cols := "id, node_id, segment, vector, format, rank_multiplier, meta, created_at, updated_at"
+	selCols := "ir.id, dn.id, ir.segment, ir.vector, ir.format, ir.rank_multiplier, ir.meta, $5, $5"
+	if m.dbe.embeddings {
+		cols, selCols = cols+", embedding", selCols+", ir.embedding"
+	}
+	res, err := m.executor().ExecContext(m.ctx, fmt.Sprintf("insert into index_record (%s) select %s "+
+		"from index_record as ir inner join node as sn on sn.id = ir.node_id "+
+		"inner join node as dn on dn.name = $1::text || substr(sn.name, $2) "+
+		"where (sn.id = $3 or left(sn.path, $2) = $4) and sn.deleted_at is null", cols, selCols),
+		to, prefixLen+1, node.ID, from+"/", now)
+	if err != nil {
+		return persistence.CopyNodesResult{}, persistence.MapError(err)
+	}
+	recs, _ := res.RowsAffected()
+
+	ids := make([]int64, len(nodes))
+	var root persistence.Node
+	for i, n := range nodes {
+		ids[i] = n.ID
+		if persistence.ConcatPath(n.Path, n.Name) == to {
+			root = n
+		}
+	}
+	inh, err := m.updateInherited(root.ID)
+	if err != nil {
+		return persistence.CopyNodesResult{}, err
+	}
+	root.EffectiveTags, root.EffectiveACL = inh[root.ID].EffectiveTags, inh[root.ID].EffectiveACL
+	if err = m.logNodeChanges(persistence.ChangeOpCreate, "n.id = any($1)", pq.Array(ids)); err != nil {
+		return persistence.CopyNodesResult{}, err
+	}
+	if err = m.logRecordChanges(changeOp(persistence.ChangeOpCreate), "ir.node_id = any($1)", pq.Array(ids)); err != nil {
+		return persistence.CopyNodesResult{}, err
+	}
+	if err = m.extUpsertNode(ids...); err != nil {
+		return persistence.CopyNodesResult{}, err
+	}
+	return persistence.CopyNodesResult{Node: root, Nodes: int64(len(nodes)), Records: recs}, nil
+}
+
+// targetPath checks whether the node with the from fqnp can be moved or copied to the fqnp to, and
+// returns the path part of the to fqnp. The parent folder of the to fqnp must exist.
+func (m *modelTx) targetPath(from, to string) (string, error) {
+	if to == "/" {
+		return "", fmt.Errorf("the node cannot be placed to the root: %w", errors.ErrInvalid)
+	}
+	if persistence.InSubtree(from, to) {
+		return "", fmt.Errorf("the node %q cannot be placed into its own subtree %q: %w", from, to, errors.ErrInvalid)
+	}
+	path, _ := persistence.ToNodePathName(to)
+	if path == "/" {
+		return path, nil
+	}
+	parent, err := m.GetNode(persistence.ConcatPath(path, ""))
+	if err != nil {
+		return "", fmt.Errorf("could not get the parent folder %q: %w", path, err)
+	}
+	if parent.Flags&persistence.NodeFlagDocument != 0 {
+		return "", fmt.Errorf("the node cannot be placed into the document %q: %w", path, errors.ErrInvalid)
+	}
+	return path, nil
 }
 
 func (m *modelTx) DeleteNodes(query persistence.DeleteNodesQuery) error {
-	var sb strings.Builder
-	if err := m.dbe.tr.Translate(&sb, query.FilterConditions); err != nil {
+	matched, err := m.deleteNodesMatched(query)
+	if err != nil {
+		return err
+	}
+	if err = m.checkDeleteAccess(query, matched); err != nil {
 		return err
 	}
-	filter := sb.String()
 	if !query.Force {
-		rows, err := m.db.Query(
-			"select n2.id "+
-				"from node as n2, (select n.* from node as n left join index_record as ir on ir.node_id = n.id where "+filter+") as n1 "+
-				"where n1.flags = $1 and n2.path like concat(n1.name, '%') and n2.id not in (select n.id from node as n left join index_record as ir on ir.node_id = n.id where "+filter+") limit 1",
-			persistence.NodeFlagFolder)
+		found, err := m.hasUnmatchedChildren(matched)
 		if err != nil {
-			return persistence.MapError(err)
+			return err
 		}
-		defer func() {
-			_ = rows.Close()
-		}()
-		if rows.Next() {
+		if found {
 			return fmt.Errorf("matched nodes have children that do not match the condition (force=%t): %w",
 				query.Force, errors.ErrConflict)
 		}
 	}
-	res, err := m.executor().ExecContext(m.ctx,
-		"delete from node as n2 "+
-			"using (select n.* from node as n left join index_record as ir on ir.node_id = n.id where "+filter+") as n1 "+
-			"where n2.id = n1.id or (n1.flags = $1 and n2.path like concat(n1.name, '%'))",
-		persistence.NodeFlagFolder)
+	// the records of the deleted nodes are deleted with them, so the node changes only are logged
+	if err = m.logNodeChanges(persistence.ChangeOpDelete, "n.id in ("+deleteTargets(matched, query.Trash)+")", persistence.NodeFlagFolder); err != nil {
+		return err
+	}
+	var rows *sqlx.Rows
+	if query.Trash {
+		rows, err = m.executor().QueryxContext(m.ctx,
+			"update node as n2 set deleted_at = $2 "+
+				"from ("+matched+") as n1 "+
+				"where n2.deleted_at is null and (n2.id = n1.id or (n1.flags = $1 and "+descendantsCond("n2", "n1")+")) returning n2.id",
+			persistence.NodeFlagFolder, time.Now())
+	} else {
+		rows, err = m.executor().QueryxContext(m.ctx,
+			"delete from node as n2 "+
+				"using ("+matched+") as n1 "+
+				"where n2.id = n1.id or (n1.flags = $1 and "+descendantsCond("n2", "n1")+") returning n2.id",
+			persistence.NodeFlagFolder)
+	}
 	if err != nil {
 		return persistence.MapError(err)
 	}
-	if cnt, _ := res.RowsAffected(); cnt == 0 {
+	defer func() {
+		_ = rows.Close()
+	}()
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
 		return errors.ErrNotExist
 	}
+	return m.extApply(func(ctx context.Context, idx ExtIndex) error {
+		return idx.DeleteNodes(ctx, ids...)
+	})
+}
+
+func (m *modelTx) PreviewDeleteNodes(query persistence.DeleteNodesQuery) (persistence.DeleteNodesPreview, error) {
+	matched, err := m.deleteNodesMatched(query)
+	if err != nil {
+		return persistence.DeleteNodesPreview{}, err
+	}
+	if err = m.checkDeleteAccess(query, matched); err != nil {
+		return persistence.DeleteNodesPreview{}, err
+	}
+	var res persistence.DeleteNodesPreview
+	if res.ForceRequired, err = m.hasUnmatchedChildren(matched); err != nil {
+		return persistence.DeleteNodesPreview{}, err
+	}
+
+	targets := deleteTargets(matched, query.Trash)
+	if res.Total, err = persistence.Count(m.ctx, m.executor(), "select count(*) from node where id in ("+targets+")", persistence.NodeFlagFolder); err != nil {
+		return persistence.DeleteNodesPreview{}, err
+	}
+	if res.Records, err = persistence.Count(m.ctx, m.executor(), "select count(*) from index_record where node_id in ("+targets+")", persistence.NodeFlagFolder); err != nil {
+		return persistence.DeleteNodesPreview{}, err
+	}
+	rows, err := m.executor().QueryxContext(m.ctx, "select * from node where id in ("+targets+") order by name offset $2 limit $3",
+		persistence.NodeFlagFolder, query.Offset, query.Limit)
+	if err != nil {
+		return persistence.DeleteNodesPreview{}, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	if res.Nodes, err = scanNodes(rows); err != nil {
+		return persistence.DeleteNodesPreview{}, persistence.MapError(err)
+	}
+	return res, nil
+}
+
+// deleteNodesMatched returns the query, which selects the nodes matching the DeleteNodesQuery filter. The
+// trashed nodes are not matched, they are deleted together with their live ancestors only.
+func (m *modelTx) deleteNodesMatched(query persistence.DeleteNodesQuery) (string, error) {
+	var sb strings.Builder
+	if err := m.dbe.tr.Translate(&sb, query.FilterConditions); err != nil {
+		return "", err
+	}
+	return "select n.* from node as n left join index_record as ir on ir.node_id = n.id where n.deleted_at is null and (" + sb.String() + ")" +
+		accessCond(query.Access), nil
+}
+
+// deleteTargets returns the query, which selects the IDs of the nodes to be deleted by the DeleteNodes
+// for the matched query. The $1 parameter of the query is the folder flag.
+func deleteTargets(matched string, trash bool) string {
+	targets := "select n2.id from node as n2, (" + matched + ") as n1 " +
+		"where (n2.id = n1.id or (n1.flags = $1 and " + descendantsCond("n2", "n1") + "))"
+	if trash {
+		targets += " and n2.deleted_at is null"
+	}
+	return targets
+}
+
+// checkDeleteAccess returns ErrNotAuthorized if some of the nodes to be deleted by the DeleteNodes
+// are not accessible for the query, the children of the matched folders are checked as well
+func (m *modelTx) checkDeleteAccess(query persistence.DeleteNodesQuery, matched string) error {
+	if query.Access == nil {
+		return nil
+	}
+	cnt, err := persistence.Count(m.ctx, m.executor(), "select count(*) from node as n where n.id in ("+deleteTargets(matched, query.Trash)+") and not "+
+		persistence.PqAccessCondition(*query.Access), persistence.NodeFlagFolder)
+	if err != nil {
+		return err
+	}
+	if cnt > 0 {
+		return fmt.Errorf("%d nodes to be deleted are not accessible: %w", cnt, errors.ErrNotAuthorized)
+	}
 	return nil
 }
 
+// hasUnmatchedChildren returns whether the folders selected by the matched query have
+// live children, which are not selected by the query
+func (m *modelTx) hasUnmatchedChildren(matched string) (bool, error) {
+	rows, err := m.executor().QueryxContext(m.ctx,
+		"select n2.id "+
+			"from node as n2, ("+matched+") as n1 "+
+			"where n1.flags = $1 and n2.deleted_at is null and "+descendantsCond("n2", "n1")+" and n2.id not in (select n.id from ("+matched+") as n) limit 1",
+		persistence.NodeFlagFolder)
+	if err != nil {
+		return false, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return rows.Next(), nil
+}
+
+func (m *modelTx) ListTrash(query persistence.ListNodesQuery) ([]persistence.Node, error) {
+	var sb strings.Builder
+	if err := m.dbe.tr.Translate(&sb, query.FilterConditions); err != nil {
+		return nil, err
+	}
+	where := "n.deleted_at is not null"
+	if sb.Len() > 0 {
+		where += " and (" + sb.String() + ")"
+	}
+	rows, err := m.executor().QueryxContext(m.ctx, fmt.Sprintf("select * from node as n where %s order by n.name offset $1 limit $2", where), query.Offset, query.Limit)
+	if err != nil {
+		return nil, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return scanNodes(rows)
+}
+
+func (m *modelTx) RestoreNodes(query persistence.RestoreNodesQuery) (int64, error) {
+	var sb strings.Builder
+	if err := m.dbe.tr.Translate(&sb, query.FilterConditions); err != nil {
+		return 0, err
+	}
+	if sb.Len() == 0 {
+		return 0, fmt.Errorf("the filter conditions must be specified: %w", errors.ErrInvalid)
+	}
+	// the trashed descendants of the matched folders and the trashed ancestors of the matched nodes are restored as well
+	rows, err := m.executor().QueryxContext(m.ctx,
+		"update node as n2 set deleted_at = null, updated_at = $2 "+
+			"from (select n.* from node as n left join index_record as ir on ir.node_id = n.id where n.deleted_at is not null and ("+sb.String()+")) as n1 "+
+			"where n2.deleted_at is not null and (n2.id = n1.id or (n1.flags = $1 and "+descendantsCond("n2", "n1")+") or "+descendantsCond("n1", "n2")+") returning n2.id",
+		persistence.NodeFlagFolder, time.Now())
+	if err != nil {
+		return 0, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, errors.ErrNotExist
+	}
+	if err = m.logNodeChanges(persistence.ChangeOpCreate, "n.id = any($1)", pq.Array(ids)); err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), m.extUpsertNode(ids...)
+}
+
+func (m *modelTx) PurgeTrash(before time.Time) (int64, error) {
+	res, err := m.executor().ExecContext(m.ctx, "delete from node where deleted_at < $1", before)
+	if err != nil {
+		return 0, persistence.MapError(err)
+	}
+	return res.RowsAffected()
+}
+
 func (m *modelTx) UpsertIndexRecords(records ...persistence.IndexRecord) (int64, error) {
 	if len(records) == 0 {
 		return 0, nil
@@ -393,8 +906,15 @@ func (m *modelTx) UpsertIndexRecords(records ...persistence.IndexRecord) (int64,
 	var sb strings.Builder
 	var params []any
 
+	// the embeddings are stored only if the search module supports them
+	cols, updCols, colsNum := "id, node_id, segment, vector, format, rank_multiplier, meta, created_at, updated_at",
+		"segment, vector, format, rank_multiplier, meta, updated_at", 9
+	if m.dbe.embeddings {
+		cols, updCols, colsNum = cols+", embedding", updCols+", embedding", colsNum+1
+	}
+
 	firstIdx := 1
-	sb.WriteString("insert into index_record (id, node_id, segment, vector, format, rank_multiplier, created_at, updated_at) values ")
+	sb.WriteString(fmt.Sprintf("insert into index_record (%s) values ", cols))
 	now := time.Now()
 	for i, r := range records {
 		if len(r.ID) == 0 {
@@ -412,12 +932,22 @@ func (m *modelTx) UpsertIndexRecords(records ...persistence.IndexRecord) (int64,
 		if len(r.Vector) == 0 {
 			r.Vector = []byte("{}")
 		}
+		if r.Meta == nil {
+			r.Meta = persistence.Tags{}
+		}
 		if i > 0 {
 			sb.WriteString(",")
 		}
 
-		sb.WriteString(fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)", firstIdx, firstIdx+1, firstIdx+2, firstIdx+3, firstIdx+4, firstIdx+5, firstIdx+6, firstIdx+7))
-		firstIdx += 8
+		sb.WriteString("(")
+		for j := 0; j < colsNum; j++ {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(fmt.Sprintf("$%d", firstIdx+j))
+		}
+		sb.WriteString(")")
+		firstIdx += colsNum
 
 		params = append(params, r.ID)
 		params = append(params, r.NodeID)
@@ -425,18 +955,31 @@ func (m *modelTx) UpsertIndexRecords(records ...persistence.IndexRecord) (int64,
 		params = append(params, r.Vector)
 		params = append(params, r.Format)
 		params = append(params, r.RankMult)
+		params = append(params, r.Meta)
 		params = append(params, now)
 		params = append(params, now)
+		if m.dbe.embeddings {
+			params = append(params, r.Embedding)
+		}
+	}
+	sb.WriteString(fmt.Sprintf(" on conflict (node_id,id) do update set (%s) = (excluded.%s), version = index_record.version + 1",
+		updCols, strings.ReplaceAll(updCols, ", ", ", excluded.")))
+	if err := m.keepRecordHistory(records, now, false); err != nil {
+		return 0, err
 	}
-	sb.WriteString(" on conflict (node_id,id) " +
-		"do update set (segment, vector, format, rank_multiplier, updated_at) = " +
-		"(excluded.segment, excluded.vector, excluded.format, excluded.rank_multiplier, excluded.updated_at)")
 	res, err := m.executor().ExecContext(m.ctx, sb.String(), params...)
 	if err != nil {
 		return 0, persistence.MapError(err)
 	}
 	cnt, _ := res.RowsAffected()
-	return cnt, nil
+	where, args := recordsCond(records)
+	if err = m.logRecordChanges(recordUpsertOp, where, args...); err != nil {
+		return 0, err
+	}
+	if err = m.touchNodes(records); err != nil {
+		return 0, err
+	}
+	return cnt, m.extUpsertRecords(records)
 }
 
 func (m *modelTx) DeleteIndexRecords(records ...persistence.IndexRecord) (int64, error) {
@@ -458,6 +1001,13 @@ func (m *modelTx) DeleteIndexRecords(records ...persistence.IndexRecord) (int64,
 		return 0, nil
 	}
 
+	if err := m.keepRecordHistory(records, time.Now(), true); err != nil {
+		return 0, err
+	}
+	logWhere, logArgs := recordsCond(records)
+	if err := m.logRecordChanges(changeOp(persistence.ChangeOpDelete), logWhere, logArgs...); err != nil {
+		return 0, err
+	}
 	where := sqlx.Rebind(sqlx.DOLLAR, sb.String())
 	res, err := m.executor().ExecContext(m.ctx, fmt.Sprintf("delete from index_record where %s", where), args...)
 	if err != nil {
@@ -467,7 +1017,12 @@ func (m *modelTx) DeleteIndexRecords(records ...persistence.IndexRecord) (int64,
 	if cnt == 0 {
 		return 0, errors.ErrNotExist
 	}
-	return cnt, nil
+	if err = m.touchNodes(records); err != nil {
+		return 0, err
+	}
+	return cnt, m.extApply(func(ctx context.Context, idx ExtIndex) error {
+		return idx.Delete(ctx, records...)
+	})
 }
 
 func (m *modelTx) QueryIndexRecords(query persistence.IndexRecordQuery) (persistence.QueryResult[persistence.IndexRecord, string], error) {
@@ -478,7 +1033,18 @@ func (m *modelTx) QueryIndexRecords(query persistence.IndexRecordQuery) (persist
 	sb := strings.Builder{}
 	sb.WriteString(" node_id = ? ")
 
+	// the records as of the time are the current records updated before the time,
+	// and the history revisions, which were valid at the time
+	src := "index_record"
 	args := make([]any, 0)
+	if !query.AsOf.IsZero() {
+		src = "(select id, node_id, segment, vector, format, rank_multiplier, meta, created_at, updated_at, version from index_record " +
+			"where node_id = ? and updated_at <= ? " +
+			"union all " +
+			"select id, node_id, segment, vector, format, rank_multiplier, meta, created_at, updated_at, version from index_record_history " +
+			"where node_id = ? and updated_at <= ? and replaced_at > ?) as ir"
+		args = append(args, query.NodeID, query.AsOf, query.NodeID, query.AsOf, query.AsOf)
+	}
 	args = append(args, query.NodeID)
 
 	if len(query.FromID) > 0 {
@@ -512,11 +1078,11 @@ func (m *modelTx) QueryIndexRecords(query persistence.IndexRecordQuery) (persist
 
 	var where string
 	if sb.Len() > 0 {
-		where = " where " + sqlx.Rebind(sqlx.DOLLAR, sb.String())
+		where = " where " + sb.String()
 	}
 
 	// count
-	total, err := persistence.Count(m.ctx, m.executor(), fmt.Sprintf("select count(*) from index_record %s ", where), args...)
+	total, err := persistence.Count(m.ctx, m.executor(), sqlx.Rebind(sqlx.DOLLAR, fmt.Sprintf("select count(*) from %s %s ", src, where)), args...)
 	if err != nil {
 		return persistence.QueryResult[persistence.IndexRecord, string]{}, persistence.MapError(err)
 	}
@@ -526,7 +1092,7 @@ func (m *modelTx) QueryIndexRecords(query persistence.IndexRecordQuery) (persist
 		return persistence.QueryResult[persistence.IndexRecord, string]{Total: total}, nil
 	}
 	args = append(args, query.Limit+1)
-	rows, err := m.executor().QueryxContext(m.ctx, fmt.Sprintf("select * from index_record %s order by id limit $%d", where, len(args)), args...)
+	rows, err := m.executor().QueryxContext(m.ctx, sqlx.Rebind(sqlx.DOLLAR, fmt.Sprintf("select * from %s %s order by id limit ?", src, where)), args...)
 	if err != nil {
 		return persistence.QueryResult[persistence.IndexRecord, string]{Total: total}, persistence.MapError(err)
 	}
@@ -546,20 +1112,581 @@ func (m *modelTx) QueryIndexRecords(query persistence.IndexRecordQuery) (persist
 	return persistence.QueryResult[persistence.IndexRecord, string]{Items: res, NextID: nextID, Total: total}, nil
 }
 
-func (m *modelTx) Search(query persistence.SearchQuery) (persistence.SearchQueryResult, error) {
-	if len(query.TextQuery) == 0 {
-		return persistence.SearchQueryResult{}, fmt.Errorf("text query must be non-empty: %w", errors.ErrInvalid)
+func (m *modelTx) ListIndexRecordRevisions(query persistence.IndexRecordRevisionsQuery) ([]persistence.IndexRecordRevision, error) {
+	if query.NodeID == 0 {
+		return nil, fmt.Errorf("node ID must be specified: %w", errors.ErrInvalid)
 	}
-	if m.dbe.searchFn != nil {
-		return m.dbe.searchFn(m.ctx, m.executor(), query)
+	where, args := "node_id = ?", []any{query.NodeID}
+	if len(query.RecordID) > 0 {
+		where += " and id = ?"
+		args = append(args, query.RecordID)
 	}
-	return persistence.SearchQueryResult{}, errors.ErrUnimplemented
-}
-
-func scanNodes(rows *sqlx.Rows) ([]persistence.Node, error) {
-	nodes, err := persistence.ScanRows[persistence.Node](rows)
+	args = append(args, query.Offset, query.Limit)
+	rows, err := m.executor().QueryxContext(m.ctx, sqlx.Rebind(sqlx.DOLLAR,
+		fmt.Sprintf("select * from index_record_history where %s order by revision desc offset ? limit ?", where)), args...)
 	if err != nil {
-		return nodes, err
+		return nil, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return persistence.ScanRows[persistence.IndexRecordRevision](rows)
+}
+
+// keepRecordHistory copies the current versions of the records of the formats keeping the history
+// to the index_record_history, it must be called before the records are replaced or deleted
+func (m *modelTx) keepRecordHistory(records []persistence.IndexRecord, replacedAt time.Time, deleted bool) error {
+	where, args := recordsCond(records)
+	args = append(args, replacedAt, deleted)
+	_, err := m.executor().ExecContext(m.ctx, fmt.Sprintf("insert into index_record_history "+
+		"(id, node_id, segment, vector, format, rank_multiplier, meta, created_at, updated_at, version, replaced_at, deleted) "+
+		"select ir.id, ir.node_id, ir.segment, ir.vector, ir.format, ir.rank_multiplier, ir.meta, ir.created_at, ir.updated_at, ir.version, $%d::timestamptz, $%d::boolean "+
+		"from index_record as ir inner join format as f on f.id = ir.format "+
+		"where f.keep_history and (%s)", len(args)-1, len(args), where), args...)
+	return persistence.MapError(err)
+}
+
+// touchNodes increments the versions of the records nodes
+func (m *modelTx) touchNodes(records []persistence.IndexRecord) error {
+	_, err := m.executor().ExecContext(m.ctx, "update node set version = version + 1 where id = any($1)", pq.Array(recordNodeIDs(records)))
+	return persistence.MapError(err)
+}
+
+// recordsCond returns the condition selecting the records by their IDs, the condition
+// parameters are numbered from $1
+func recordsCond(records []persistence.IndexRecord) (string, []any) {
+	var sb strings.Builder
+	var args []any
+	for _, r := range records {
+		if sb.Len() > 0 {
+			sb.WriteString(" or ")
+		}
+		sb.WriteString("(ir.node_id = ? and ir.id = ?)")
+		args = append(args, r.NodeID, r.ID)
+	}
+	return sqlx.Rebind(sqlx.DOLLAR, sb.String()), args
+}
+
+// changelogLockID is the advisory lock, which is held while the changes are numbered by sequenceChanges,
+// so the concurrent readers don't number the same changes
+const changelogLockID = 5_316_420
+
+// sequenceChangesStmt numbers the changes of the committed transactions, which are not numbered yet. The changes
+// of the transactions in progress are not visible to the statement, so they are numbered after their transactions
+// are committed, following all the changes numbered before, and a long-running transaction doesn't hold back the
+// numbering of the changes committed meanwhile. The readers following the changelog by the numbers never skip
+// the changes this way. The changes committed together are numbered in the order of their transaction IDs.
+const sequenceChangesStmt = "update changelog as c set seq = s.base + s.rn from " +
+	"(select id, (select coalesce(max(seq), 0) from changelog) as base, row_number() over (order by txid, id) as rn " +
+	"from changelog where seq is null) as s where c.id = s.id"
+
+// changeColumns are the changelog columns of the persistence.Change, the change ID is its sequence number
+const changeColumns = "n.seq as id, n.object, n.op, n.node_id, n.path, n.name, n.tags, n.effective_tags, n.attrs, n.flags, " +
+	"n.record_id, n.format, n.meta, n.created_at"
+
+// recordUpsertOp is the change operation of the upserted records, the record is created if its
+// creation and update times are the same
+const recordUpsertOp = "case when ir.created_at = ir.updated_at then 'create' else 'update' end"
+
+// changeOp returns the change operation SQL constant
+func changeOp(op persistence.ChangeOp) string {
+	return "'" + string(op) + "'"
+}
+
+// ancestorsCond selects the node n and its ancestors as the nodes a. The ancestors names are built from
+// the node path, so the ancestors are found by the names index.
+const ancestorsCond = "a.name = any(array(select '/' || array_to_string(p.names[1:i], '/') from " +
+	"(select string_to_array(trim(both '/' from n.path), '/') as names) as p, generate_series(1, cardinality(p.names)) as i) || n.name::text)"
+
+// effectiveTagsUpdate is the statement updating the nodes effective tags, the condition selecting the nodes
+// may be appended to it. For every tag key the value of the deepest node (the node itself or its ancestor) is taken.
+const effectiveTagsUpdate = "update node as n set effective_tags = (select coalesce(jsonb_object_agg(t.key, t.value), '{}'::jsonb) from " +
+	"(select distinct on (t.key) t.key, t.value from node as a cross join jsonb_each_text(a.tags) as t " +
+	"where " + ancestorsCond + " order by t.key, length(a.name) desc) as t)"
+
+// inheritedUpdate is the effectiveTagsUpdate, which updates the nodes effective ACLs the same way
+const inheritedUpdate = effectiveTagsUpdate + ", effective_acl = (select coalesce(jsonb_object_agg(t.key, t.value), '{}'::jsonb) from " +
+	"(select distinct on (t.key) t.key, t.value from node as a cross join jsonb_each_text(a.acl) as t " +
+	"where " + ancestorsCond + " order by t.key, length(a.name) desc) as t)"
+
+// descendantsCond returns the condition selecting the nodes d, which are the descendants of the node s. The
+// paths of the descendants start with the s name followed by '/', they are selected by the range of the paths
+// in the "C" collation ('0' follows '/'), so the paths index is used.
+func descendantsCond(d, s string) string {
+	return fmt.Sprintf("%[1]s.path collate \"C\" >= %[2]s.name || '/' and %[1]s.path collate \"C\" < %[2]s.name || '0'", d, s)
+}
+
+// updateInherited updates the effective tags and ACLs of the nodes with the IDs provided and of all their
+// descendants, it returns the nodes updated with the effective tags and ACLs only by their IDs
+func (m *modelTx) updateInherited(ids ...int64) (map[int64]persistence.Node, error) {
+	rows, err := m.executor().QueryxContext(m.ctx, inheritedUpdate+" where n.id in (select unnest($1::bigint[]) union "+
+		"select d.id from node as s inner join node as d on "+descendantsCond("d", "s")+" where s.id = any($1)) "+
+		"returning n.id, n.effective_tags, n.effective_acl", pq.Array(ids))
+	if err != nil {
+		return nil, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	nodes, err := persistence.ScanRows[persistence.Node](rows)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]persistence.Node, len(nodes))
+	for _, n := range nodes {
+		res[n.ID] = n
+	}
+	return res, nil
+}
+
+// logNodeChanges writes the changes of the nodes selected by the where condition to the changelog,
+// the condition parameters must be numbered from $1
+func (m *modelTx) logNodeChanges(op persistence.ChangeOp, where string, args ...any) error {
+	n := len(args)
+	args = append(args, time.Now())
+	_, err := m.executor().ExecContext(m.ctx, fmt.Sprintf("insert into changelog (object, op, node_id, path, name, tags, effective_tags, attrs, flags, created_at) "+
+		"select '%s', '%s', n.id, n.path, n.name, n.tags, n.effective_tags, n.attrs, n.flags, $%d::timestamptz from node as n where %s order by n.id",
+		persistence.ChangeObjectNode, op, n+1, where), args...)
+	return persistence.MapError(err)
+}
+
+// logRecordChanges writes the changes of the index records selected by the where condition to the
+// changelog, the opExpr is the SQL expression of the change operation. The condition parameters
+// must be numbered from $1.
+func (m *modelTx) logRecordChanges(opExpr, where string, args ...any) error {
+	n := len(args)
+	args = append(args, time.Now())
+	_, err := m.executor().ExecContext(m.ctx, fmt.Sprintf("insert into changelog (object, op, node_id, path, name, tags, effective_tags, attrs, flags, record_id, format, meta, created_at) "+
+		"select '%s', %s, n.id, n.path, n.name, n.tags, n.effective_tags, n.attrs, n.flags, ir.id, ir.format, ir.meta, $%d::timestamptz "+
+		"from index_record as ir inner join node as n on n.id = ir.node_id where %s order by n.id, ir.id",
+		persistence.ChangeObjectRecord, opExpr, n+1, where), args...)
+	return persistence.MapError(err)
+}
+
+func (m *modelTx) ListChanges(query persistence.ChangesQuery) (persistence.QueryResult[persistence.Change, int64], error) {
+	if query.Limit <= 0 {
+		return persistence.QueryResult[persistence.Change, int64]{}, fmt.Errorf("the limit must be positive: %w", errors.ErrInvalid)
+	}
+	var sb strings.Builder
+	if err := m.dbe.tr.Translate(&sb, query.FilterConditions); err != nil {
+		return persistence.QueryResult[persistence.Change, int64]{}, err
+	}
+	// the changes are selected up to the last ID only, so the cursor is advanced to
+	// the last ID if the changes matching the filter are not found
+	lastID, err := m.LastChangeID()
+	if err != nil {
+		return persistence.QueryResult[persistence.Change, int64]{}, err
+	}
+	where := "n.seq > $1 and n.seq <= $2"
+	if sb.Len() > 0 {
+		where += " and (" + sb.String() + ")"
+	}
+	// the changelog is joined as ir to provide the record format and meta to the filter conditions
+	rows, err := m.executor().QueryxContext(m.ctx, "select "+changeColumns+" from changelog as n inner join changelog as ir on ir.id = n.id "+
+		"where "+where+" order by n.seq limit $3", query.FromID, lastID, query.Limit)
+	if err != nil {
+		return persistence.QueryResult[persistence.Change, int64]{}, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	res, err := persistence.ScanRows[persistence.Change](rows)
+	if err != nil {
+		return persistence.QueryResult[persistence.Change, int64]{}, persistence.MapError(err)
+	}
+	nextID := max(query.FromID, lastID)
+	if len(res) == query.Limit {
+		nextID = res[len(res)-1].ID
+	}
+	return persistence.QueryResult[persistence.Change, int64]{Items: res, NextID: nextID}, nil
+}
+
+func (m *modelTx) LastChangeID() (int64, error) {
+	if err := m.sequenceChanges(); err != nil {
+		return 0, err
+	}
+	id, err := persistence.Count(m.ctx, m.executor(), "select coalesce(max(seq), 0) from changelog")
+	return id, persistence.MapError(err)
+}
+
+// sequenceChanges numbers the changes of the finished transactions in its own short transaction, so
+// the writers are never blocked by the changelog readers or by each other
+func (m *modelTx) sequenceChanges() error {
+	tx, err := m.db.BeginTxx(m.ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return persistence.MapError(err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	if _, err = tx.ExecContext(m.ctx, "select pg_advisory_xact_lock($1)", changelogLockID); err != nil {
+		return persistence.MapError(err)
+	}
+	if _, err = tx.ExecContext(m.ctx, sequenceChangesStmt); err != nil {
+		return persistence.MapError(err)
+	}
+	return persistence.MapError(tx.Commit())
+}
+
+func (m *modelTx) PurgeChanges(before time.Time) (int64, error) {
+	res, err := m.executor().ExecContext(m.ctx, "delete from changelog where created_at < $1", before)
+	if err != nil {
+		return 0, persistence.MapError(err)
+	}
+	return res.RowsAffected()
+}
+
+func (m *modelTx) CreateWebhook(webhook persistence.Webhook) (persistence.Webhook, error) {
+	if len(webhook.ID) == 0 {
+		return persistence.Webhook{}, fmt.Errorf("webhook ID must be non-empty: %w", errors.ErrInvalid)
+	}
+	if len(webhook.URL) == 0 {
+		return persistence.Webhook{}, fmt.Errorf("webhook URL must be non-empty: %w", errors.ErrInvalid)
+	}
+	webhook.Path = persistence.ConcatPath(webhook.Path, "")
+	if webhook.Events == nil {
+		webhook.Events = persistence.Strings{}
+	}
+	webhook.CreatedAt = time.Now()
+	webhook.UpdatedAt = webhook.CreatedAt
+	_, err := m.executor().ExecContext(m.ctx, "insert into webhook (id, url, secret, path, events, cursor, created_at, updated_at) "+
+		"values ($1, $2, $3, $4, $5, $6, $7, $8)", webhook.ID, webhook.URL, webhook.Secret, webhook.Path,
+		webhook.Events, webhook.Cursor, webhook.CreatedAt, webhook.UpdatedAt)
+	if err != nil {
+		return persistence.Webhook{}, persistence.MapError(err)
+	}
+	return webhook, nil
+}
+
+func (m *modelTx) GetWebhook(ID string) (persistence.Webhook, error) {
+	var w persistence.Webhook
+	return w, persistence.MapError(m.executor().GetContext(m.ctx, &w, "select * from webhook where id=$1", ID))
+}
+
+func (m *modelTx) DeleteWebhook(ID string) error {
+	res, err := m.executor().ExecContext(m.ctx, "delete from webhook where id=$1", ID)
+	if err != nil {
+		return persistence.MapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return errors.ErrNotExist
+	}
+	return nil
+}
+
+func (m *modelTx) ListWebhooks() ([]persistence.Webhook, error) {
+	rows, err := m.executor().QueryxContext(m.ctx, "select * from webhook order by id")
+	if err != nil {
+		return nil, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return persistence.ScanRows[persistence.Webhook](rows)
+}
+
+func (m *modelTx) LockWebhook(ID, owner string, expired, lockedUntil time.Time) (persistence.Webhook, error) {
+	var w persistence.Webhook
+	return w, persistence.MapError(m.executor().GetContext(m.ctx, &w, "update webhook set locked_by = $1, locked_until = $2 "+
+		"where id = $3 and (locked_by = $1 or locked_until <= $4) returning *", owner, lockedUntil, ID, expired))
+}
+
+func (m *modelTx) UpdateWebhookCursor(ID, owner string, cursor int64) error {
+	res, err := m.executor().ExecContext(m.ctx, "update webhook set cursor = $1, attempts = 0, next_attempt_at = $2, updated_at = $3 "+
+		"where id = $4 and locked_by = $5", cursor, time.Time{}, time.Now(), ID, owner)
+	if err != nil {
+		return persistence.MapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return errors.ErrNotExist
+	}
+	return nil
+}
+
+func (m *modelTx) UpdateWebhookAttempts(ID, owner string, attempts int, nextAttemptAt time.Time) error {
+	res, err := m.executor().ExecContext(m.ctx, "update webhook set attempts = $1, next_attempt_at = $2, updated_at = $3 "+
+		"where id = $4 and locked_by = $5", attempts, nextAttemptAt, time.Now(), ID, owner)
+	if err != nil {
+		return persistence.MapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return errors.ErrNotExist
+	}
+	return nil
+}
+
+func (m *modelTx) CreateDeadLetter(dl persistence.DeadLetter) (persistence.DeadLetter, error) {
+	if len(dl.WebhookID) == 0 {
+		return persistence.DeadLetter{}, fmt.Errorf("dead letter webhook ID must be non-empty: %w", errors.ErrInvalid)
+	}
+	dl.CreatedAt = time.Now()
+	rows, err := m.executor().QueryxContext(m.ctx, "insert into webhook_dead_letter (webhook_id, change_id, event, payload, error, attempts, created_at) "+
+		"values ($1, $2, $3, $4, $5, $6, $7) returning id", dl.WebhookID, dl.ChangeID, dl.Event, string(dl.Payload), dl.Error, dl.Attempts, dl.CreatedAt)
+	if err != nil {
+		return persistence.DeadLetter{}, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return persistence.DeadLetter{}, err
+	}
+	dl.ID = ids[0]
+	return dl, nil
+}
+
+func (m *modelTx) ListDeadLetters(query persistence.DeadLettersQuery) ([]persistence.DeadLetter, error) {
+	rows, err := m.executor().QueryxContext(m.ctx, "select * from webhook_dead_letter where webhook_id = $1 order by id desc limit $2 offset $3",
+		query.WebhookID, query.Limit, query.Offset)
+	if err != nil {
+		return nil, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return persistence.ScanRows[persistence.DeadLetter](rows)
+}
+
+func (m *modelTx) CreateIdempotencyKey(ik persistence.IdempotencyKey) error {
+	if len(ik.Key) == 0 {
+		return fmt.Errorf("idempotency key must be non-empty: %w", errors.ErrInvalid)
+	}
+	ik.CreatedAt = time.Now()
+	_, err := m.executor().ExecContext(m.ctx, "insert into idempotency_key (key, fingerprint, done, response, locked_until, created_at) "+
+		"values ($1, $2, $3, $4, $5, $6)", ik.Key, ik.Fingerprint, ik.Done, ik.Response, ik.LockedUntil, ik.CreatedAt)
+	return persistence.MapError(err)
+}
+
+func (m *modelTx) GetIdempotencyKey(key string) (persistence.IdempotencyKey, error) {
+	var ik persistence.IdempotencyKey
+	return ik, persistence.MapError(m.executor().GetContext(m.ctx, &ik, "select * from idempotency_key where key=$1", key))
+}
+
+func (m *modelTx) LockIdempotencyKey(key string, expired, lockedUntil time.Time) error {
+	res, err := m.executor().ExecContext(m.ctx, "update idempotency_key set locked_until = $1 where key = $2 and not done and locked_until <= $3",
+		lockedUntil, key, expired)
+	if err != nil {
+		return persistence.MapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return errors.ErrNotExist
+	}
+	return nil
+}
+
+func (m *modelTx) CompleteIdempotencyKey(key string, response []byte) error {
+	if response == nil {
+		response = []byte{}
+	}
+	res, err := m.executor().ExecContext(m.ctx, "update idempotency_key set done = true, response = $1 where key = $2", response, key)
+	if err != nil {
+		return persistence.MapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return errors.ErrNotExist
+	}
+	return nil
+}
+
+func (m *modelTx) DeleteIdempotencyKey(key string) error {
+	res, err := m.executor().ExecContext(m.ctx, "delete from idempotency_key where key=$1", key)
+	if err != nil {
+		return persistence.MapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return errors.ErrNotExist
+	}
+	return nil
+}
+
+func (m *modelTx) PurgeIdempotencyKeys(before time.Time) (int64, error) {
+	res, err := m.executor().ExecContext(m.ctx, "delete from idempotency_key where created_at < $1", before)
+	if err != nil {
+		return 0, persistence.MapError(err)
+	}
+	return res.RowsAffected()
+}
+
+func (m *modelTx) CreateAttrSchema(as persistence.AttrSchema) (persistence.AttrSchema, error) {
+	as.Prefix = persistence.ToNodePath(as.Prefix)
+	if err := as.Check(); err != nil {
+		return persistence.AttrSchema{}, err
+	}
+	var eas persistence.AttrSchema
+	err := m.executor().GetContext(m.ctx, &eas, "select * from attr_schema where name = $1 and type != $2 limit 1", as.Name, as.Type)
+	if err == nil {
+		return persistence.AttrSchema{}, fmt.Errorf("the attribute %q is declared with the %s type for %q: %w", as.Name, eas.Type, eas.Prefix, errors.ErrConflict)
+	}
+	if err = persistence.MapError(err); !errors.Is(err, errors.ErrNotExist) {
+		return persistence.AttrSchema{}, err
+	}
+	as.CreatedAt = time.Now()
+	if _, err = m.executor().ExecContext(m.ctx, "insert into attr_schema (prefix, name, type, created_at) values ($1, $2, $3, $4)",
+		as.Prefix, as.Name, as.Type, as.CreatedAt); err != nil {
+		return persistence.AttrSchema{}, persistence.MapError(err)
+	}
+	// the strings are matched by the jsonb containment, the other types are compared by their typed values
+	idx := fmt.Sprintf("create index if not exists %q on \"node\" (%s)", attrIndexName(as.Name), ql.PqAttrValue("attrs", as.Name, as.Type.Kind()))
+	if as.Type == persistence.AttrString || as.Type == persistence.AttrStrings {
+		idx = fmt.Sprintf("create index if not exists %q on \"node\" using gin ((attrs -> '%s') jsonb_path_ops)", attrIndexName(as.Name), as.Name)
+	}
+	if _, err = m.executor().ExecContext(m.ctx, idx); err != nil {
+		return persistence.AttrSchema{}, persistence.MapError(err)
+	}
+	return as, nil
+}
+
+func (m *modelTx) DeleteAttrSchema(prefix, name string) error {
+	res, err := m.executor().ExecContext(m.ctx, "delete from attr_schema where prefix = $1 and name = $2", persistence.ToNodePath(prefix), name)
+	if err != nil {
+		return persistence.MapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return errors.ErrNotExist
+	}
+	cnt, err = persistence.Count(m.ctx, m.executor(), "select count(*) from attr_schema where name = $1", name)
+	if err != nil || cnt > 0 {
+		return err
+	}
+	_, err = m.executor().ExecContext(m.ctx, fmt.Sprintf("drop index if exists %q", attrIndexName(name)))
+	return persistence.MapError(err)
+}
+
+func (m *modelTx) ListAttrSchemas() ([]persistence.AttrSchema, error) {
+	rows, err := m.executor().QueryxContext(m.ctx, "select * from attr_schema order by prefix, name")
+	if err != nil {
+		return nil, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return persistence.ScanRows[persistence.AttrSchema](rows)
+}
+
+func (m *modelTx) CreateJob(job persistence.Job) (persistence.Job, error) {
+	if len(job.Status) == 0 {
+		return persistence.Job{}, fmt.Errorf("job status must be non-empty: %w", errors.ErrInvalid)
+	}
+	job.CreatedAt = time.Now()
+	job.UpdatedAt = job.CreatedAt
+	rows, err := m.executor().QueryxContext(m.ctx, "insert into job (node_id, path, parser, status, records, error, locked_by, locked_until, created_at, updated_at) "+
+		"values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) returning id", job.NodeID, job.Path, job.Parser, job.Status, job.Records, job.Error,
+		job.LockedBy, job.LockedUntil, job.CreatedAt, job.UpdatedAt)
+	if err != nil {
+		return persistence.Job{}, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return persistence.Job{}, err
+	}
+	job.ID = ids[0]
+	return job, nil
+}
+
+func (m *modelTx) GetJob(ID int64) (persistence.Job, error) {
+	var j persistence.Job
+	return j, persistence.MapError(m.executor().GetContext(m.ctx, &j, "select * from job where id=$1", ID))
+}
+
+func (m *modelTx) UpdateJob(job persistence.Job) error {
+	res, err := m.executor().ExecContext(m.ctx, "update job set status = $1, records = $2, error = $3, updated_at = $4 where id = $5",
+		job.Status, job.Records, job.Error, time.Now(), job.ID)
+	if err != nil {
+		return persistence.MapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return errors.ErrNotExist
+	}
+	return nil
+}
+
+func (m *modelTx) ListJobs(query persistence.JobsQuery) ([]persistence.Job, error) {
+	where, args := "($3::text = '' or status = $3)", []any{query.Limit, query.Offset, query.Status}
+	if !query.LockedBefore.IsZero() {
+		where += " and locked_until < $4"
+		args = append(args, query.LockedBefore)
+	}
+	rows, err := m.executor().QueryxContext(m.ctx, "select * from job where "+where+" order by id desc limit $1 offset $2", args...)
+	if err != nil {
+		return nil, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return persistence.ScanRows[persistence.Job](rows)
+}
+
+func (m *modelTx) LockJob(ID int64, owner string, expired, lockedUntil time.Time) (persistence.Job, error) {
+	var j persistence.Job
+	return j, persistence.MapError(m.executor().GetContext(m.ctx, &j, "update job set locked_by = $1, locked_until = $2 "+
+		"where id = $3 and status in ($4, $5) and (locked_by = $1 or locked_until <= $6) returning *",
+		owner, lockedUntil, ID, persistence.JobPending, persistence.JobRunning, expired))
+}
+
+func (m *modelTx) StartJob(ID int64, owner string) (persistence.Job, error) {
+	var j persistence.Job
+	return j, persistence.MapError(m.executor().GetContext(m.ctx, &j, "update job set status = $1, records = 0, updated_at = $2 "+
+		"where id = $3 and status = $4 and locked_by = $5 returning *", persistence.JobRunning, time.Now(), ID, persistence.JobPending, owner))
+}
+
+func (m *modelTx) Search(query persistence.SearchQuery) (persistence.SearchQueryResult, error) {
+	if len(query.TextQuery) == 0 && len(query.Embedding) == 0 {
+		return persistence.SearchQueryResult{}, fmt.Errorf("text query or embedding must be non-empty: %w", errors.ErrInvalid)
+	}
+	lexical, semantic := m.dbe.searchFn, m.dbe.vectorFn
+	if query.Engine != "" {
+		var ok bool
+		if lexical, ok = m.dbe.engines[SearchModuleName(query.Engine)]; !ok {
+			if query.Engine != SearchModulePgvector || semantic == nil {
+				return persistence.SearchQueryResult{}, fmt.Errorf("the search engine %q is not turned on: %w", query.Engine, errors.ErrInvalid)
+			}
+		}
+	}
+	if lexical == nil && semantic == nil {
+		return persistence.SearchQueryResult{}, errors.ErrUnimplemented
+	}
+	return persistence.Search(query, m.searchFunc(lexical), m.searchFunc(semantic))
+}
+
+func (m *modelTx) searchFunc(fn SearchFn) persistence.SearchFunc {
+	if fn == nil {
+		return nil
+	}
+	return func(q persistence.SearchQuery) (persistence.SearchQueryResult, error) {
+		return fn(m.ctx, m.executor(), q)
+	}
+}
+
+// attrIndexName returns the name of the index of the node attribute values
+func attrIndexName(name string) string {
+	return "idx_node_attr_" + name
+}
+
+// accessCond returns the condition selecting the nodes "n" accessible with the access a, which
+// is appended to the other conditions, it is empty if a is nil
+func accessCond(a *persistence.Access) string {
+	if a == nil {
+		return ""
+	}
+	return " and " + persistence.PqAccessCondition(*a)
+}
+
+func scanNodes(rows *sqlx.Rows) ([]persistence.Node, error) {
+	nodes, err := persistence.ScanRows[persistence.Node](rows)
+	if err != nil {
+		return nodes, err
 	}
 	for i, n := range nodes {
 		cleanNameAfterRead(&n)
@@ -568,6 +1695,31 @@ func scanNodes(rows *sqlx.Rows) ([]persistence.Node, error) {
 	return nodes, nil
 }
 
+func scanIDs(rows *sqlx.Rows) ([]int64, error) {
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, persistence.MapError(err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// recordNodeIDs returns the distinct IDs of the records nodes
+func recordNodeIDs(records []persistence.IndexRecord) []int64 {
+	var ids []int64
+	seen := make(map[int64]bool)
+	for _, r := range records {
+		if !seen[r.NodeID] {
+			seen[r.NodeID] = true
+			ids = append(ids, r.NodeID)
+		}
+	}
+	return ids
+}
+
 func cleanNameAfterRead(n *persistence.Node) {
 	if n == nil {
 		return
@@ -19,10 +19,13 @@ import (
 	"encoding/json"
 	"github.com/acquirecloud/golibs/errors"
 	_ "github.com/lib/pq"
+	"github.com/simila-io/simila/pkg/embedding"
 	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/pgvector"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
 )
 
 type (
@@ -41,6 +44,26 @@ type (
 	pgFtsTestSuite struct {
 		pgTestSuite
 	}
+
+	pgBleveTestSuite struct {
+		pgTestSuite
+	}
+
+	pgPgvectorTestSuite struct {
+		pgTestSuite
+	}
+
+	pgHybridTestSuite struct {
+		pgTestSuite
+	}
+
+	pgMultiTestSuite struct {
+		pgTestSuite
+	}
+
+	pgSwitchTestSuite struct {
+		pgTestSuite
+	}
 )
 
 func TestRunCommonTestSuite(t *testing.T) {
@@ -59,6 +82,50 @@ func TestRunFtsTestSuite(t *testing.T) {
 	suite.Run(t, &pgFtsTestSuite{newPqTestSuite(SearchModuleFts)})
 }
 
+func TestRunBleveTestSuite(t *testing.T) {
+	suite.Run(t, &pgBleveTestSuite{newPqTestSuite(SearchModuleBleve)})
+}
+
+func TestRunPgvectorTestSuite(t *testing.T) {
+	suite.Run(t, &pgPgvectorTestSuite{newPqTestSuite(SearchModulePgvector)})
+}
+
+func TestRunHybridTestSuite(t *testing.T) {
+	suite.Run(t, &pgHybridTestSuite{newPqTestSuite(SearchModuleFts, WithSemanticSearch())})
+}
+
+func TestRunMultiTestSuite(t *testing.T) {
+	suite.Run(t, &pgMultiTestSuite{newPqTestSuite(SearchModuleFts, WithSearchModules(SearchModuleTrigram, SearchModuleBleve))})
+}
+
+func TestRunSwitchTestSuite(t *testing.T) {
+	suite.Run(t, &pgSwitchTestSuite{newPqTestSuite(SearchModuleTrigram, WithEmbedder(embedding.NewHashing(3)), func(o *options) {
+		o.switchPoll = 100 * time.Millisecond
+	})})
+}
+
+func TestOptions_searchModules(t *testing.T) {
+	o := options{pgvectorCfg: pgvector.DefaultConfig(), modules: []SearchModuleName{SearchModuleTrigram, SearchModuleFts, SearchModuleBleve}}
+	mods, err := o.searchModules(SearchModuleFts)
+	assert.Nil(t, err)
+	assert.Equal(t, []SearchModuleName{SearchModuleFts, SearchModuleTrigram, SearchModuleBleve}, mods)
+
+	o.semantic = true
+	mods, err = o.searchModules(SearchModuleNone)
+	assert.Nil(t, err)
+	assert.Equal(t, []SearchModuleName{SearchModuleNone, SearchModuleTrigram, SearchModuleFts, SearchModuleBleve, SearchModulePgvector}, mods)
+
+	o.pgvectorCfg.Dims = 0
+	_, err = o.searchModules(SearchModuleFts)
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+
+	for _, m := range []SearchModuleName{SearchModuleElastic, SearchModuleNone, "abc"} {
+		o = options{modules: []SearchModuleName{m}}
+		_, err = o.searchModules(SearchModuleBleve)
+		assert.True(t, errors.Is(err, errors.ErrInvalid))
+	}
+}
+
 // common
 
 func (ts *pgCommonTestSuite) TestFormat() {
@@ -94,3 +161,1303 @@ func (ts *pgCommonTestSuite) TestFormat() {
 	err = mtx.DeleteFormat(frmt.ID)
 	assert.ErrorIs(ts.T(), err, errors.ErrNotExist)
 }
+
+func (ts *pgCommonTestSuite) TestPreviewDeleteNodes() {
+	mtx := ts.db.NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"}, persistence.Node{Path: "/a", Name: "b"},
+		persistence.Node{Path: "/a/b", Name: "doc", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "ab", Flags: persistence.NodeFlagDocument})
+	assert.Nil(ts.T(), err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[2].ID, Format: "txt", Segment: "abc"},
+		persistence.IndexRecord{ID: "2", NodeID: nodes[2].ID, Format: "txt", Segment: "def"},
+		persistence.IndexRecord{ID: "1", NodeID: nodes[3].ID, Format: "txt", Segment: "ghi"})
+	assert.Nil(ts.T(), err)
+
+	p, err := mtx.PreviewDeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.True(ts.T(), p.ForceRequired)
+	assert.Equal(ts.T(), int64(3), p.Total)
+	assert.Equal(ts.T(), int64(2), p.Records)
+	assert.Equal(ts.T(), 3, len(p.Nodes))
+	assert.Equal(ts.T(), "/", p.Nodes[0].Path)
+	assert.Equal(ts.T(), "a", p.Nodes[0].Name)
+
+	p, err = mtx.PreviewDeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Offset: 1, Limit: 1})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(3), p.Total)
+	assert.Equal(ts.T(), 1, len(p.Nodes))
+	assert.Equal(ts.T(), "b", p.Nodes[0].Name)
+
+	p, err = mtx.PreviewDeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a' or node = '/a/b' or node = '/a/b/doc'", Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.False(ts.T(), p.ForceRequired)
+	assert.Equal(ts.T(), int64(3), p.Total)
+
+	p, err = mtx.PreviewDeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/x'", Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(0), p.Total)
+	assert.Equal(ts.T(), 0, len(p.Nodes))
+
+	// nothing is deleted, the trashed nodes are skipped, if the nodes are trashed
+	_, err = mtx.GetNode("/a/b/doc")
+	assert.Nil(ts.T(), err)
+	assert.Nil(ts.T(), mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a/b/doc'", Trash: true}))
+	p, err = mtx.PreviewDeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Trash: true, Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(2), p.Total)
+	assert.Equal(ts.T(), int64(0), p.Records)
+	p, err = mtx.PreviewDeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(3), p.Total)
+	assert.Equal(ts.T(), int64(2), p.Records)
+}
+
+func (ts *pgCommonTestSuite) TestMoveNode() {
+	mtx := ts.db.NewModelTx(context.Background())
+	_, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"}, persistence.Node{Path: "/a", Name: "b"},
+		persistence.Node{Path: "/a/b", Name: "doc", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "ab"}, persistence.Node{Path: "/", Name: "c"})
+	assert.Nil(ts.T(), err)
+
+	_, err = mtx.MoveNode("/a/b/doc", "/a/b")
+	assert.True(ts.T(), errors.Is(err, errors.ErrExist))
+	_, err = mtx.MoveNode("/a", "/a/b/x")
+	assert.True(ts.T(), errors.Is(err, errors.ErrInvalid))
+	_, err = mtx.MoveNode("/c", "/a/b/doc/c")
+	assert.True(ts.T(), errors.Is(err, errors.ErrInvalid))
+	_, err = mtx.MoveNode("/c", "/x/c")
+	assert.True(ts.T(), errors.Is(err, errors.ErrNotExist))
+	_, err = mtx.MoveNode("/x", "/y")
+	assert.True(ts.T(), errors.Is(err, errors.ErrNotExist))
+
+	n, err := mtx.MoveNode("/a", "/c/a2")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), "/c/", n.Path)
+	assert.Equal(ts.T(), "a2", n.Name)
+	n, err = mtx.GetNode("/c/a2/b/doc")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), "/c/a2/b/", n.Path)
+	_, err = mtx.GetNode("/a/b")
+	assert.True(ts.T(), errors.Is(err, errors.ErrNotExist))
+	_, err = mtx.GetNode("/ab")
+	assert.Nil(ts.T(), err)
+	nodes, err := mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: "prefix(path, '/c/a2/')", Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 2, len(nodes))
+
+	n, err = mtx.MoveNode("/c/a2/b/doc", "/doc2")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), "/", n.Path)
+	assert.Equal(ts.T(), "doc2", n.Name)
+}
+
+// bleve
+
+func (ts *pgCommonTestSuite) TestCopyNodes() {
+	mtx := ts.db.NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "t", Tags: persistence.Tags{"k": "v"}},
+		persistence.Node{Path: "/t", Name: "p1", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/t", Name: "sub"}, persistence.Node{Path: "/t/sub", Name: "p2", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "c"})
+	assert.Nil(ts.T(), err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[1].ID, Format: "txt", Segment: "abc"},
+		persistence.IndexRecord{ID: "2", NodeID: nodes[1].ID, Format: "txt", Segment: "def"},
+		persistence.IndexRecord{ID: "1", NodeID: nodes[3].ID, Format: "txt", Segment: "ghi"})
+	assert.Nil(ts.T(), err)
+
+	_, err = mtx.CopyNodes(persistence.CopyNodesQuery{From: "/t", To: "/t/x"})
+	assert.True(ts.T(), errors.Is(err, errors.ErrInvalid))
+	_, err = mtx.CopyNodes(persistence.CopyNodesQuery{From: "/t", To: "/c"})
+	assert.True(ts.T(), errors.Is(err, errors.ErrExist))
+	_, err = mtx.CopyNodes(persistence.CopyNodesQuery{From: "/t", To: "/x/t"})
+	assert.True(ts.T(), errors.Is(err, errors.ErrNotExist))
+	_, err = mtx.CopyNodes(persistence.CopyNodesQuery{From: "/t", To: "/t/p1/t"})
+	assert.True(ts.T(), errors.Is(err, errors.ErrInvalid))
+
+	res, err := mtx.CopyNodes(persistence.CopyNodesQuery{From: "/t", To: "/c/t2", Tags: persistence.Tags{"tenant": "1"}})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(4), res.Nodes)
+	assert.Equal(ts.T(), int64(3), res.Records)
+	assert.Equal(ts.T(), "/c/", res.Node.Path)
+	assert.Equal(ts.T(), "t2", res.Node.Name)
+
+	n, err := mtx.GetNode("/c/t2/sub/p2")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.Tags{"tenant": "1"}, n.Tags)
+	assert.Equal(ts.T(), int32(persistence.NodeFlagDocument), n.Flags)
+	qr, err := mtx.QueryIndexRecords(persistence.IndexRecordQuery{NodeID: n.ID, Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(qr.Items))
+	assert.Equal(ts.T(), "ghi", qr.Items[0].Segment)
+	n, err = mtx.GetNode("/t/sub/p2")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 0, len(n.Tags))
+
+	// the tags are copied, if not specified
+	res, err = mtx.CopyNodes(persistence.CopyNodesQuery{From: "/t", To: "/t3"})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.Tags{"k": "v"}, res.Node.Tags)
+}
+
+func (ts *pgCommonTestSuite) TestTrash() {
+	mtx := ts.db.NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"}, persistence.Node{Path: "/a", Name: "b"},
+		persistence.Node{Path: "/a/b", Name: "doc", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "ab", Flags: persistence.NodeFlagDocument})
+	assert.Nil(ts.T(), err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[2].ID, Format: "txt", Segment: "hello world"},
+		persistence.IndexRecord{ID: "1", NodeID: nodes[3].ID, Format: "txt", Segment: "hello there"})
+	assert.Nil(ts.T(), err)
+
+	err = mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Trash: true})
+	assert.True(ts.T(), errors.Is(err, errors.ErrConflict))
+	assert.Nil(ts.T(), mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Force: true, Trash: true}))
+	_, err = mtx.GetNode("/a/b/doc")
+	assert.True(ts.T(), errors.Is(err, errors.ErrNotExist))
+	nodes, err = mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: "prefix(path, '/')", Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(nodes))
+	assert.Equal(ts.T(), "ab", nodes[0].Name)
+	err = mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Trash: true})
+	assert.True(ts.T(), errors.Is(err, errors.ErrNotExist))
+
+	nodes, err = mtx.ListTrash(persistence.ListNodesQuery{Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 3, len(nodes))
+	assert.NotNil(ts.T(), nodes[0].DeletedAt)
+	nodes, err = mtx.ListTrash(persistence.ListNodesQuery{FilterConditions: "node = '/a/b/doc'", Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(nodes))
+
+	// the parents are restored with the node
+	_, err = mtx.RestoreNodes(persistence.RestoreNodesQuery{})
+	assert.True(ts.T(), errors.Is(err, errors.ErrInvalid))
+	cnt, err := mtx.RestoreNodes(persistence.RestoreNodesQuery{FilterConditions: "node = '/a/b/doc'"})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(3), cnt)
+	n, err := mtx.GetNode("/a/b/doc")
+	assert.Nil(ts.T(), err)
+	assert.Nil(ts.T(), n.DeletedAt)
+	_, err = mtx.RestoreNodes(persistence.RestoreNodesQuery{FilterConditions: "node = '/a/b/doc'"})
+	assert.True(ts.T(), errors.Is(err, errors.ErrNotExist))
+
+	// the trashed node keeps its name, until it is restored
+	assert.Nil(ts.T(), mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/ab'", Trash: true}))
+	_, err = mtx.CreateNodes(persistence.Node{Path: "/", Name: "ab"})
+	assert.True(ts.T(), errors.Is(err, errors.ErrExist))
+	cnt, err = mtx.RestoreNodes(persistence.RestoreNodesQuery{FilterConditions: "node = '/ab'"})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(1), cnt)
+	nodes, err = mtx.ListTrash(persistence.ListNodesQuery{Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 0, len(nodes))
+
+	assert.Nil(ts.T(), mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Force: true, Trash: true}))
+	cnt, err = mtx.PurgeTrash(time.Now().Add(-time.Hour))
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(0), cnt)
+	cnt, err = mtx.PurgeTrash(time.Now().Add(time.Second))
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(3), cnt)
+	nodes, err = mtx.ListTrash(persistence.ListNodesQuery{Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 0, len(nodes))
+}
+
+func (ts *pgCommonTestSuite) TestDeleteNodesPrefixSibling() {
+	mtx := ts.db.NewModelTx(context.Background())
+	_, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"}, persistence.Node{Path: "/a", Name: "doc", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "ab"}, persistence.Node{Path: "/ab", Name: "doc", Flags: persistence.NodeFlagDocument})
+	assert.Nil(ts.T(), err)
+
+	// the children of the folder /ab are not the children of the folder /a
+	p, err := mtx.PreviewDeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a' or node = '/a/doc'", Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.False(ts.T(), p.ForceRequired)
+	assert.Equal(ts.T(), int64(2), p.Total)
+	assert.Nil(ts.T(), mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Force: true, Trash: true}))
+	_, err = mtx.GetNode("/ab/doc")
+	assert.Nil(ts.T(), err)
+	cnt, err := mtx.RestoreNodes(persistence.RestoreNodesQuery{FilterConditions: "node = '/a'"})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(2), cnt)
+	assert.Nil(ts.T(), mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Force: true}))
+	_, err = mtx.GetNode("/ab/doc")
+	assert.Nil(ts.T(), err)
+	_, err = mtx.GetNode("/a/doc")
+	assert.True(ts.T(), errors.Is(err, errors.ErrNotExist))
+}
+
+func (ts *pgCommonTestSuite) TestRecordHistory() {
+	mtx := ts.db.NewModelTx(context.Background())
+	_, err := mtx.CreateFormat(persistence.Format{ID: "hist", KeepHistory: true})
+	assert.Nil(ts.T(), err)
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a", Flags: persistence.NodeFlagDocument})
+	assert.Nil(ts.T(), err)
+	id := nodes[0].ID
+
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: id, Format: "hist", Segment: "v1"},
+		persistence.IndexRecord{ID: "2", NodeID: id, Format: "txt", Segment: "b1"})
+	assert.Nil(ts.T(), err)
+	time.Sleep(10 * time.Millisecond)
+	t1 := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: id, Format: "hist", Segment: "v2"},
+		persistence.IndexRecord{ID: "2", NodeID: id, Format: "txt", Segment: "b2"},
+		persistence.IndexRecord{ID: "3", NodeID: id, Format: "hist", Segment: "c1"})
+	assert.Nil(ts.T(), err)
+	time.Sleep(10 * time.Millisecond)
+	t2 := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	_, err = mtx.DeleteIndexRecords(persistence.IndexRecord{ID: "1", NodeID: id})
+	assert.Nil(ts.T(), err)
+
+	revs, err := mtx.ListIndexRecordRevisions(persistence.IndexRecordRevisionsQuery{NodeID: id, Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 2, len(revs))
+	assert.Equal(ts.T(), "v2", revs[0].Segment)
+	assert.True(ts.T(), revs[0].Deleted)
+	assert.Equal(ts.T(), "v1", revs[1].Segment)
+	assert.False(ts.T(), revs[1].Deleted)
+	assert.True(ts.T(), revs[0].Revision > revs[1].Revision)
+	revs, err = mtx.ListIndexRecordRevisions(persistence.IndexRecordRevisionsQuery{NodeID: id, RecordID: "3", Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 0, len(revs))
+
+	// the txt format doesn't keep the history, so the record 2 is not available as of t1
+	qr, err := mtx.QueryIndexRecords(persistence.IndexRecordQuery{NodeID: id, AsOf: t1, Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(1), qr.Total)
+	assert.Equal(ts.T(), "v1", qr.Items[0].Segment)
+	qr, err = mtx.QueryIndexRecords(persistence.IndexRecordQuery{NodeID: id, AsOf: t2, Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 3, len(qr.Items))
+	assert.Equal(ts.T(), "v2", qr.Items[0].Segment)
+
+	d, err := persistence.DiffIndexRecords(mtx, id, t1, t2)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 2, len(d.Added))
+	assert.Equal(ts.T(), 0, len(d.Removed))
+	assert.Equal(ts.T(), 1, len(d.Changed))
+	assert.Equal(ts.T(), "v1", d.Changed[0].From.Segment)
+	assert.Equal(ts.T(), "v2", d.Changed[0].To.Segment)
+	d, err = persistence.DiffIndexRecords(mtx, id, t2, time.Time{})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 0, len(d.Added))
+	assert.Equal(ts.T(), "1", d.Removed[0].ID)
+	assert.Equal(ts.T(), 0, len(d.Changed))
+	_, err = persistence.DiffIndexRecords(mtx, id, time.Time{}, t2)
+	assert.ErrorIs(ts.T(), err, errors.ErrInvalid)
+
+	assert.ErrorIs(ts.T(), mtx.DeleteFormat("hist"), errors.ErrConflict)
+}
+
+func (ts *pgCommonTestSuite) TestChanges() {
+	mtx := ts.db.NewModelTx(context.Background())
+	start, err := mtx.LastChangeID()
+	assert.Nil(ts.T(), err)
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"})
+	assert.Nil(ts.T(), err)
+	a := nodes[0]
+	nodes, err = mtx.CreateNodes(persistence.Node{Path: "/a", Name: "b", Flags: persistence.NodeFlagDocument})
+	assert.Nil(ts.T(), err)
+	b := nodes[0]
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: b.ID, Format: "txt", Segment: "v1"})
+	assert.Nil(ts.T(), err)
+	time.Sleep(10 * time.Millisecond)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: b.ID, Format: "txt", Segment: "v2"})
+	assert.Nil(ts.T(), err)
+	assert.Nil(ts.T(), mtx.UpdateNode(persistence.Node{ID: a.ID, Tags: persistence.Tags{"k": "v"}}))
+	_, err = mtx.DeleteIndexRecords(persistence.IndexRecord{ID: "1", NodeID: b.ID})
+	assert.Nil(ts.T(), err)
+	assert.Nil(ts.T(), mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Force: true}))
+
+	res, err := mtx.ListChanges(persistence.ChangesQuery{FromID: start, Limit: 100})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 8, len(res.Items))
+	type change struct {
+		object persistence.ChangeObject
+		op     persistence.ChangeOp
+		name   string
+		rec    string
+	}
+	var changes []change
+	for _, c := range res.Items {
+		changes = append(changes, change{c.Object, c.Op, c.Name, c.RecordID})
+	}
+	assert.Equal(ts.T(), []change{
+		{persistence.ChangeObjectNode, persistence.ChangeOpCreate, "/a", ""},
+		{persistence.ChangeObjectNode, persistence.ChangeOpCreate, "/a/b", ""},
+		{persistence.ChangeObjectRecord, persistence.ChangeOpCreate, "/a/b", "1"},
+		{persistence.ChangeObjectRecord, persistence.ChangeOpUpdate, "/a/b", "1"},
+		{persistence.ChangeObjectNode, persistence.ChangeOpUpdate, "/a", ""},
+		{persistence.ChangeObjectRecord, persistence.ChangeOpDelete, "/a/b", "1"},
+		{persistence.ChangeObjectNode, persistence.ChangeOpDelete, "/a", ""},
+		{persistence.ChangeObjectNode, persistence.ChangeOpDelete, "/a/b", ""},
+	}, changes)
+	assert.Equal(ts.T(), "v", res.Items[4].Tags["k"])
+	last := res.NextID
+	assert.Equal(ts.T(), res.Items[7].ID, last)
+
+	res, err = mtx.ListChanges(persistence.ChangesQuery{FromID: start, FilterConditions: "format = 'txt'", Limit: 2})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 2, len(res.Items))
+	assert.Equal(ts.T(), persistence.ChangeOpUpdate, res.Items[1].Op)
+	res, err = mtx.ListChanges(persistence.ChangesQuery{FromID: res.NextID, FilterConditions: "format = 'txt'", Limit: 2})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(res.Items))
+	assert.Equal(ts.T(), persistence.ChangeOpDelete, res.Items[0].Op)
+	assert.Equal(ts.T(), last, res.NextID)
+
+	res, err = mtx.ListChanges(persistence.ChangesQuery{FromID: start, FilterConditions: "node = '/a/b' and format = 'txt'", Limit: 100})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 3, len(res.Items))
+	res, err = mtx.ListChanges(persistence.ChangesQuery{FromID: start, FilterConditions: "tag(\"k\") = 'v'", Limit: 100})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 2, len(res.Items))
+	res, err = mtx.ListChanges(persistence.ChangesQuery{FromID: start, FilterConditions: "node = '/c'", Limit: 100})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 0, len(res.Items))
+	assert.Equal(ts.T(), last, res.NextID)
+	_, err = mtx.ListChanges(persistence.ChangesQuery{FromID: start})
+	assert.ErrorIs(ts.T(), err, errors.ErrInvalid)
+
+	cnt, err := mtx.PurgeChanges(time.Now().Add(-time.Hour))
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(0), cnt)
+	cnt, err = mtx.PurgeChanges(time.Now().Add(time.Second))
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(8), cnt)
+}
+
+func (ts *pgCommonTestSuite) TestChangesConcurrentWriters() {
+	ctx := context.Background()
+	mtx := ts.db.NewModelTx(ctx)
+	start, err := mtx.LastChangeID()
+	assert.Nil(ts.T(), err)
+
+	// the first writer keeps its transaction open, while the second one commits
+	w1 := ts.db.NewModelTx(ctx)
+	w1.MustBegin()
+	defer func() {
+		_ = w1.Rollback()
+	}()
+	_, err = w1.CreateNodes(persistence.Node{Path: "/", Name: "a"})
+	assert.Nil(ts.T(), err)
+	done := make(chan error, 1)
+	go func() {
+		w2 := ts.db.NewModelTx(ctx)
+		w2.MustBegin()
+		_, err := w2.CreateNodes(persistence.Node{Path: "/", Name: "b"})
+		if err == nil {
+			err = w2.Commit()
+		}
+		done <- err
+	}()
+	select {
+	case err = <-done:
+		assert.Nil(ts.T(), err)
+	case <-time.After(5 * time.Second):
+		ts.T().Fatal("the second writer is blocked by the first one")
+	}
+	_, err = w1.CreateNodes(persistence.Node{Path: "/", Name: "c"})
+	assert.Nil(ts.T(), err)
+
+	// the change of the second writer is numbered, while the first writer is still running
+	res, err := mtx.ListChanges(persistence.ChangesQuery{FromID: start, Limit: 100})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(res.Items))
+	assert.Equal(ts.T(), "/b", res.Items[0].Name)
+	assert.Equal(ts.T(), start+1, res.Items[0].ID)
+	assert.Equal(ts.T(), start+1, res.NextID)
+
+	// the changes of the first writer follow it, so the cursor doesn't skip them
+	assert.Nil(ts.T(), w1.Commit())
+	res, err = mtx.ListChanges(persistence.ChangesQuery{FromID: res.NextID, Limit: 100})
+	assert.Nil(ts.T(), err)
+	var names []string
+	for i, c := range res.Items {
+		assert.Equal(ts.T(), start+int64(i)+2, c.ID)
+		names = append(names, c.Name)
+	}
+	assert.Equal(ts.T(), []string{"/a", "/c"}, names)
+	assert.Equal(ts.T(), start+3, res.NextID)
+}
+
+func (ts *pgCommonTestSuite) TestVersions() {
+	mtx := ts.db.NewModelTx(context.Background())
+	_, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a", Flags: persistence.NodeFlagFolder},
+		persistence.Node{Path: "/", Name: "b", Flags: persistence.NodeFlagFolder},
+		persistence.Node{Path: "/a/", Name: "d", Flags: persistence.NodeFlagDocument})
+	assert.Nil(ts.T(), err)
+	n, err := mtx.LockNode("/a/d")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(1), n.Version)
+	_, err = mtx.LockNode("/a/e")
+	assert.ErrorIs(ts.T(), err, errors.ErrNotExist)
+
+	n.Tags = persistence.Tags{"k": "v"}
+	assert.Nil(ts.T(), mtx.UpdateNode(n))
+	n, err = mtx.GetNode("/a/d")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(2), n.Version)
+
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: n.ID, Format: "txt", Segment: "s1"},
+		persistence.IndexRecord{ID: "2", NodeID: n.ID, Format: "txt", Segment: "s2"})
+	assert.Nil(ts.T(), err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: n.ID, Format: "txt", Segment: "s11"})
+	assert.Nil(ts.T(), err)
+	qr, err := mtx.QueryIndexRecords(persistence.IndexRecordQuery{NodeID: n.ID, Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 2, len(qr.Items))
+	for _, r := range qr.Items {
+		if r.ID == "1" {
+			assert.Equal(ts.T(), int64(2), r.Version)
+		} else {
+			assert.Equal(ts.T(), int64(1), r.Version)
+		}
+	}
+	n, err = mtx.GetNode("/a/d")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(4), n.Version)
+
+	_, err = mtx.DeleteIndexRecords(persistence.IndexRecord{ID: "2", NodeID: n.ID})
+	assert.Nil(ts.T(), err)
+	n, err = mtx.MoveNode("/a/d", "/b/d")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(6), n.Version)
+	n, err = mtx.GetNode("/b/d")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(6), n.Version)
+}
+
+func (ts *pgCommonTestSuite) TestWebhooks() {
+	mtx := ts.db.NewModelTx(context.Background())
+	_, err := mtx.CreateWebhook(persistence.Webhook{URL: "http://localhost/hook"})
+	assert.ErrorIs(ts.T(), err, errors.ErrInvalid)
+	wh, err := mtx.CreateWebhook(persistence.Webhook{ID: "w1", URL: "http://localhost/hook", Secret: "s", Path: "a/b/", Events: persistence.Strings{"node.create"}, Cursor: 3})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), "/a/b", wh.Path)
+	_, err = mtx.CreateWebhook(persistence.Webhook{ID: "w1", URL: "http://localhost/hook"})
+	assert.ErrorIs(ts.T(), err, errors.ErrExist)
+	_, err = mtx.CreateWebhook(persistence.Webhook{ID: "w2", URL: "http://localhost/hook2"})
+	assert.Nil(ts.T(), err)
+
+	wh1, err := mtx.GetWebhook("w1")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.Strings{"node.create"}, wh1.Events)
+	assert.Equal(ts.T(), "s", wh1.Secret)
+	assert.Equal(ts.T(), int64(3), wh1.Cursor)
+	now := time.Now()
+	assert.ErrorIs(ts.T(), mtx.UpdateWebhookCursor("w1", "o1", 10), errors.ErrNotExist)
+	wh1, err = mtx.LockWebhook("w1", "o1", now, now.Add(time.Minute))
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), "o1", wh1.LockedBy)
+	_, err = mtx.LockWebhook("w1", "o2", now, now.Add(time.Minute))
+	assert.ErrorIs(ts.T(), err, errors.ErrNotExist)
+	_, err = mtx.LockWebhook("w3", "o1", now, now.Add(time.Minute))
+	assert.ErrorIs(ts.T(), err, errors.ErrNotExist)
+	assert.Nil(ts.T(), mtx.UpdateWebhookAttempts("w1", "o1", 2, now.Add(time.Second)))
+	assert.ErrorIs(ts.T(), mtx.UpdateWebhookAttempts("w1", "o2", 3, now), errors.ErrNotExist)
+	wh1, err = mtx.GetWebhook("w1")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 2, wh1.Attempts)
+	assert.True(ts.T(), wh1.NextAttemptAt.After(now))
+	assert.Nil(ts.T(), mtx.UpdateWebhookCursor("w1", "o1", 10))
+	wh1, err = mtx.GetWebhook("w1")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(10), wh1.Cursor)
+	assert.Equal(ts.T(), 0, wh1.Attempts)
+	assert.ErrorIs(ts.T(), mtx.UpdateWebhookCursor("w3", "o1", 10), errors.ErrNotExist)
+	// the expired lock is taken by another owner
+	wh1, err = mtx.LockWebhook("w1", "o2", now.Add(time.Minute), now.Add(2*time.Minute))
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), "o2", wh1.LockedBy)
+	assert.ErrorIs(ts.T(), mtx.UpdateWebhookCursor("w1", "o1", 11), errors.ErrNotExist)
+	whs, err := mtx.ListWebhooks()
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 2, len(whs))
+	assert.Equal(ts.T(), "w1", whs[0].ID)
+	assert.Equal(ts.T(), persistence.Strings{}, whs[1].Events)
+
+	_, err = mtx.CreateDeadLetter(persistence.DeadLetter{WebhookID: "w3", ChangeID: 1, Event: "node.create", Payload: []byte("{}")})
+	assert.ErrorIs(ts.T(), err, errors.ErrConflict)
+	for i := 1; i <= 3; i++ {
+		_, err = mtx.CreateDeadLetter(persistence.DeadLetter{WebhookID: "w1", ChangeID: int64(i), Event: "node.create",
+			Payload: []byte(`{"id":1}`), Error: "failed", Attempts: 2})
+		assert.Nil(ts.T(), err)
+	}
+	dls, err := mtx.ListDeadLetters(persistence.DeadLettersQuery{WebhookID: "w1", Limit: 2})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 2, len(dls))
+	assert.Equal(ts.T(), int64(3), dls[0].ChangeID)
+	assert.Equal(ts.T(), `{"id":1}`, string(dls[0].Payload))
+	assert.Equal(ts.T(), 2, dls[0].Attempts)
+	dls, err = mtx.ListDeadLetters(persistence.DeadLettersQuery{WebhookID: "w1", Offset: 2, Limit: 2})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(dls))
+	assert.Equal(ts.T(), int64(1), dls[0].ChangeID)
+
+	assert.Nil(ts.T(), mtx.DeleteWebhook("w1"))
+	_, err = mtx.GetWebhook("w1")
+	assert.ErrorIs(ts.T(), err, errors.ErrNotExist)
+	assert.ErrorIs(ts.T(), mtx.DeleteWebhook("w1"), errors.ErrNotExist)
+	dls, err = mtx.ListDeadLetters(persistence.DeadLettersQuery{WebhookID: "w1", Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 0, len(dls))
+}
+
+func (ts *pgBleveTestSuite) TestSearch() {
+	mtx := ts.db.NewModelTx(context.Background())
+
+	_, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "doc1", Tags: persistence.Tags{"lang": "en"}, Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "doc2", Tags: persistence.Tags{"lang": "de"}, Flags: persistence.NodeFlagDocument})
+	assert.Nil(ts.T(), err)
+	nodes, err := mtx.ListNodes(persistence.ListNodesQuery{Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 2, len(nodes))
+	ids := map[string]int64{}
+	for _, n := range nodes {
+		ids[n.Name] = n.ID
+	}
+
+	_, err = mtx.UpsertIndexRecords(
+		persistence.IndexRecord{ID: "1", NodeID: ids["doc1"], Segment: "red apples and green pears", Format: "txt", RankMult: 1.0},
+		persistence.IndexRecord{ID: "2", NodeID: ids["doc1"], Segment: "yellow bananas", Format: "txt", RankMult: 1.0},
+		persistence.IndexRecord{ID: "1", NodeID: ids["doc2"], Segment: "an apple a day", Format: "txt", RankMult: 1.0})
+	assert.Nil(ts.T(), err)
+
+	res, err := mtx.Search(persistence.SearchQuery{TextQuery: "apple", Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(2), res.Total)
+	assert.Equal(ts.T(), 2, len(res.Items))
+
+	res, err = mtx.Search(persistence.SearchQuery{TextQuery: "apple", FilterConditions: "tag(\"lang\") = \"en\"", Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(res.Items))
+	assert.Equal(ts.T(), "1", res.Items[0].ID)
+	assert.Equal(ts.T(), "/doc1", res.Items[0].Path)
+	assert.Equal(ts.T(), []string{"apples"}, res.Items[0].MatchedKeywordsList)
+
+	// the node tags are changed, so the records must be re-indexed
+	err = mtx.UpdateNode(persistence.Node{ID: ids["doc2"], Tags: persistence.Tags{"lang": "en"}})
+	assert.Nil(ts.T(), err)
+	res, err = mtx.Search(persistence.SearchQuery{TextQuery: "apple", FilterConditions: "tag(\"lang\") = \"en\"", Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 2, len(res.Items))
+
+	err = mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = \"/doc1\""})
+	assert.Nil(ts.T(), err)
+	res, err = mtx.Search(persistence.SearchQuery{TextQuery: "apple", Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(res.Items))
+	assert.Equal(ts.T(), "/doc2", res.Items[0].Path)
+}
+
+func (ts *pgBleveTestSuite) TestSearchAccess() {
+	mtx := ts.db.NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a", ACL: persistence.ACL{"alice": persistence.PermissionRead}},
+		persistence.Node{Path: "/a/", Name: "doc", Flags: persistence.NodeFlagDocument})
+	assert.Nil(ts.T(), err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[1].ID, Segment: "red apples", Format: "txt", RankMult: 1.0})
+	assert.Nil(ts.T(), err)
+
+	total := func(principal string) int64 {
+		res, err := mtx.Search(persistence.SearchQuery{TextQuery: "apple", Limit: 10,
+			Access: &persistence.Access{Principals: []string{principal}, Permission: persistence.PermissionRead}})
+		assert.Nil(ts.T(), err)
+		assert.Equal(ts.T(), int(res.Total), len(res.Items))
+		return res.Total
+	}
+	assert.Equal(ts.T(), int64(1), total("alice"))
+	assert.Equal(ts.T(), int64(0), total("bob"))
+
+	// the descendants records must be re-indexed when the folder ACL is changed
+	_, err = mtx.SetNodeACL("/a", "bob", persistence.PermissionWrite)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(1), total("bob"))
+	_, err = mtx.SetNodeACL("/a", "alice", "")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(0), total("alice"))
+
+	// the copy inherits the destination ACL, but not the ACL of the original
+	_, err = mtx.SetNodeACL("/a/doc", "eve", persistence.PermissionAdmin)
+	assert.Nil(ts.T(), err)
+	cr, err := mtx.CopyNodes(persistence.CopyNodesQuery{From: "/a/doc", To: "/a/doc2"})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 0, len(cr.Node.ACL))
+	assert.Equal(ts.T(), persistence.ACL{"bob": persistence.PermissionWrite}, cr.Node.EffectiveACL)
+	assert.Equal(ts.T(), int64(1), total("eve"))
+	assert.Equal(ts.T(), int64(2), total("bob"))
+}
+
+// pgvector
+
+func (ts *pgCommonTestSuite) TestIdempotencyKeys() {
+	mtx := ts.db.NewModelTx(context.Background())
+	assert.ErrorIs(ts.T(), mtx.CreateIdempotencyKey(persistence.IdempotencyKey{Fingerprint: "f"}), errors.ErrInvalid)
+	assert.Nil(ts.T(), mtx.CreateIdempotencyKey(persistence.IdempotencyKey{Key: "k1", Fingerprint: "f1"}))
+	assert.ErrorIs(ts.T(), mtx.CreateIdempotencyKey(persistence.IdempotencyKey{Key: "k1", Fingerprint: "f2"}), errors.ErrExist)
+	assert.Nil(ts.T(), mtx.CreateIdempotencyKey(persistence.IdempotencyKey{Key: "k2", Fingerprint: "f2"}))
+
+	ik, err := mtx.GetIdempotencyKey("k1")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), "f1", ik.Fingerprint)
+	assert.False(ts.T(), ik.Done)
+	assert.Nil(ts.T(), ik.Response)
+	_, err = mtx.GetIdempotencyKey("k3")
+	assert.ErrorIs(ts.T(), err, errors.ErrNotExist)
+
+	// the lease is taken only if the current one is expired
+	now := time.Now().Truncate(time.Millisecond)
+	assert.Nil(ts.T(), mtx.LockIdempotencyKey("k1", now, now.Add(time.Minute)))
+	assert.ErrorIs(ts.T(), mtx.LockIdempotencyKey("k1", now, now.Add(2*time.Minute)), errors.ErrNotExist)
+	assert.Nil(ts.T(), mtx.LockIdempotencyKey("k1", now.Add(time.Minute), now.Add(2*time.Minute)))
+	ik, err = mtx.GetIdempotencyKey("k1")
+	assert.Nil(ts.T(), err)
+	assert.True(ts.T(), now.Add(2*time.Minute).Equal(ik.LockedUntil))
+	assert.ErrorIs(ts.T(), mtx.LockIdempotencyKey("k3", now, now), errors.ErrNotExist)
+
+	assert.Nil(ts.T(), mtx.CompleteIdempotencyKey("k1", []byte("resp")))
+	assert.ErrorIs(ts.T(), mtx.LockIdempotencyKey("k1", now.Add(time.Hour), now), errors.ErrNotExist)
+	assert.ErrorIs(ts.T(), mtx.CompleteIdempotencyKey("k3", []byte("resp")), errors.ErrNotExist)
+	ik, err = mtx.GetIdempotencyKey("k1")
+	assert.Nil(ts.T(), err)
+	assert.True(ts.T(), ik.Done)
+	assert.Equal(ts.T(), []byte("resp"), ik.Response)
+
+	assert.Nil(ts.T(), mtx.DeleteIdempotencyKey("k2"))
+	assert.ErrorIs(ts.T(), mtx.DeleteIdempotencyKey("k2"), errors.ErrNotExist)
+	cnt, err := mtx.PurgeIdempotencyKeys(time.Now().Add(-time.Minute))
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(0), cnt)
+	cnt, err = mtx.PurgeIdempotencyKeys(time.Now().Add(time.Minute))
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(1), cnt)
+	_, err = mtx.GetIdempotencyKey("k1")
+	assert.ErrorIs(ts.T(), err, errors.ErrNotExist)
+}
+
+func (ts *pgCommonTestSuite) TestJobs() {
+	mtx := ts.db.NewModelTx(context.Background())
+	_, err := mtx.CreateJob(persistence.Job{NodeID: 1, Path: "/a"})
+	assert.ErrorIs(ts.T(), err, errors.ErrInvalid)
+	j1, err := mtx.CreateJob(persistence.Job{NodeID: 1, Path: "/a", Parser: "txt", Status: persistence.JobPending})
+	assert.Nil(ts.T(), err)
+	j2, err := mtx.CreateJob(persistence.Job{NodeID: 2, Path: "/b", Parser: "txt", Status: persistence.JobPending})
+	assert.Nil(ts.T(), err)
+	assert.True(ts.T(), j2.ID > j1.ID)
+
+	j1.Status = persistence.JobRunning
+	j1.Records = 10
+	assert.Nil(ts.T(), mtx.UpdateJob(j1))
+	assert.ErrorIs(ts.T(), mtx.UpdateJob(persistence.Job{ID: j2.ID + 1, Status: persistence.JobDone}), errors.ErrNotExist)
+	j, err := mtx.GetJob(j1.ID)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.JobRunning, j.Status)
+	assert.Equal(ts.T(), int64(10), j.Records)
+	assert.Equal(ts.T(), "/a", j.Path)
+	assert.Equal(ts.T(), "txt", j.Parser)
+	_, err = mtx.GetJob(j2.ID + 1)
+	assert.ErrorIs(ts.T(), err, errors.ErrNotExist)
+
+	jobs, err := mtx.ListJobs(persistence.JobsQuery{Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 2, len(jobs))
+	assert.Equal(ts.T(), j2.ID, jobs[0].ID)
+	jobs, err = mtx.ListJobs(persistence.JobsQuery{Status: persistence.JobRunning, Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(jobs))
+	assert.Equal(ts.T(), j1.ID, jobs[0].ID)
+	jobs, err = mtx.ListJobs(persistence.JobsQuery{Offset: 1, Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(jobs))
+	assert.Equal(ts.T(), j1.ID, jobs[0].ID)
+
+	// the job is locked by its owner, or by another one, when the lock is expired
+	now := time.Now()
+	j, err = mtx.LockJob(j1.ID, "i1", now, now.Add(time.Minute))
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), "i1", j.LockedBy)
+	_, err = mtx.LockJob(j1.ID, "i2", now, now.Add(time.Minute))
+	assert.True(ts.T(), errors.Is(err, errors.ErrNotExist))
+	_, err = mtx.LockJob(j1.ID, "i1", now, now.Add(2*time.Minute))
+	assert.Nil(ts.T(), err)
+	jobs, err = mtx.ListJobs(persistence.JobsQuery{LockedBefore: now.Add(time.Minute), Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(jobs))
+	assert.Equal(ts.T(), j2.ID, jobs[0].ID)
+	j, err = mtx.LockJob(j1.ID, "i2", now.Add(3*time.Minute), now.Add(4*time.Minute))
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), "i2", j.LockedBy)
+	j, err = mtx.GetJob(j1.ID)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), "i2", j.LockedBy)
+	j.Status = persistence.JobDone
+	assert.Nil(ts.T(), mtx.UpdateJob(j))
+	_, err = mtx.LockJob(j1.ID, "i2", now.Add(5*time.Minute), now.Add(6*time.Minute))
+	assert.True(ts.T(), errors.Is(err, errors.ErrNotExist))
+
+	// the pending job is started by the owner of its lock only
+	_, err = mtx.StartJob(j2.ID, "i1")
+	assert.True(ts.T(), errors.Is(err, errors.ErrNotExist))
+	_, err = mtx.LockJob(j2.ID, "i1", now, now.Add(time.Minute))
+	assert.Nil(ts.T(), err)
+	j, err = mtx.StartJob(j2.ID, "i1")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.JobRunning, j.Status)
+	assert.Equal(ts.T(), int64(0), j.Records)
+	_, err = mtx.StartJob(j2.ID, "i1")
+	assert.True(ts.T(), errors.Is(err, errors.ErrNotExist))
+}
+
+func (ts *pgCommonTestSuite) TestPatchNodes() {
+	mtx := ts.db.NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a", Tags: persistence.Tags{"k": "v", "x": "1"}, Flags: persistence.NodeFlagFolder},
+		persistence.Node{Path: "/a/", Name: "d", Tags: persistence.Tags{"k": "v"}, Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "b", Tags: persistence.Tags{"k": "w"}, Flags: persistence.NodeFlagFolder})
+	assert.Nil(ts.T(), err)
+
+	cnt, err := mtx.PatchNodes(persistence.PatchNodesQuery{ID: nodes[0].ID, Patch: persistence.TagsPatch{Remove: []string{"x"}, Set: persistence.Tags{"y": "2"}}})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(1), cnt)
+	n, err := mtx.GetNode("/a")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.Tags{"k": "v", "y": "2"}, n.Tags)
+	assert.Equal(ts.T(), int64(2), n.Version)
+
+	cnt, err = mtx.PatchNodes(persistence.PatchNodesQuery{FilterConditions: "tag('k') = 'v'", Patch: persistence.TagsPatch{Set: persistence.Tags{"k": "v2", "z": "3"}}})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(2), cnt)
+	n, err = mtx.GetNode("/a/d")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.Tags{"k": "v2", "z": "3"}, n.Tags)
+	assert.Equal(ts.T(), int64(2), n.Version)
+
+	cnt, err = mtx.PatchNodes(persistence.PatchNodesQuery{FilterConditions: "node = '/b'", Patch: persistence.TagsPatch{Clear: true, Remove: []string{"y"}}})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(1), cnt)
+	n, err = mtx.GetNode("/b")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.Tags{}, n.Tags)
+
+	// the attributes are replaced by the same update as the tags
+	cnt, err = mtx.PatchNodes(persistence.PatchNodesQuery{ID: nodes[1].ID, Patch: persistence.TagsPatch{Remove: []string{"z"}},
+		Attrs: persistence.Attrs{"pages": float64(3)}})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(1), cnt)
+	n, err = mtx.GetNode("/a/d")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.Tags{"k": "v2"}, n.Tags)
+	assert.Equal(ts.T(), persistence.Attrs{"pages": float64(3)}, n.Attrs)
+	assert.Equal(ts.T(), int64(3), n.Version)
+
+	_, err = mtx.PatchNodes(persistence.PatchNodesQuery{FilterConditions: "tag('k') = 'v'", Patch: persistence.TagsPatch{Clear: true}})
+	assert.True(ts.T(), errors.Is(err, errors.ErrNotExist))
+	_, err = mtx.PatchNodes(persistence.PatchNodesQuery{ID: nodes[0].ID})
+	assert.True(ts.T(), errors.Is(err, errors.ErrInvalid))
+}
+
+func (ts *pgCommonTestSuite) TestAttrSchemas() {
+	mtx := ts.db.NewModelTx(context.Background())
+	as, err := mtx.CreateAttrSchema(persistence.AttrSchema{Prefix: "/docs", Name: "price", Type: persistence.AttrNumber})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), "/docs/", as.Prefix)
+	_, err = mtx.CreateAttrSchema(persistence.AttrSchema{Prefix: "/", Name: "price", Type: persistence.AttrNumber})
+	assert.Nil(ts.T(), err)
+	_, err = mtx.CreateAttrSchema(persistence.AttrSchema{Prefix: "/docs/", Name: "price", Type: persistence.AttrNumber})
+	assert.True(ts.T(), errors.Is(err, errors.ErrExist))
+	_, err = mtx.CreateAttrSchema(persistence.AttrSchema{Prefix: "/other/", Name: "price", Type: persistence.AttrString})
+	assert.True(ts.T(), errors.Is(err, errors.ErrConflict))
+
+	schemas, err := mtx.ListAttrSchemas()
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 2, len(schemas))
+	cnt, err := persistence.Count(context.Background(), ts.db.db, "select count(*) from pg_indexes where indexname = 'idx_node_attr_price'")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(1), cnt)
+
+	assert.Nil(ts.T(), mtx.DeleteAttrSchema("/docs", "price"))
+	assert.True(ts.T(), errors.Is(mtx.DeleteAttrSchema("/docs/", "price"), errors.ErrNotExist))
+	assert.Nil(ts.T(), mtx.DeleteAttrSchema("/", "price"))
+	cnt, err = persistence.Count(context.Background(), ts.db.db, "select count(*) from pg_indexes where indexname = 'idx_node_attr_price'")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(0), cnt)
+}
+
+func (ts *pgCommonTestSuite) TestNodeAttrs() {
+	mtx := ts.db.NewModelTx(context.Background())
+	_, err := mtx.CreateAttrSchema(persistence.AttrSchema{Prefix: "/", Name: "authors", Type: persistence.AttrStrings})
+	assert.Nil(ts.T(), err)
+	_, err = mtx.CreateNodes(persistence.Node{Path: "/", Name: "a", Flags: persistence.NodeFlagDocument, Attrs: persistence.Attrs{"price": 10.0,
+		"published": "2024-02-01T00:00:00.000Z", "draft": false, "authors": []string{"x", "y"}, "lang": "en"}},
+		persistence.Node{Path: "/", Name: "b", Flags: persistence.NodeFlagDocument, Attrs: persistence.Attrs{"price": 25.5,
+			"published": "2023-12-31T00:00:00.000Z", "draft": true, "authors": []string{"y"}}},
+		persistence.Node{Path: "/", Name: "c", Flags: persistence.NodeFlagDocument, Attrs: persistence.Attrs{"price": "cheap"}},
+		persistence.Node{Path: "/", Name: "d", Flags: persistence.NodeFlagDocument})
+	assert.Nil(ts.T(), err)
+
+	for fc, names := range map[string][]string{
+		"attr('price') > 9.5":                              {"a", "b"},
+		"attr('price') in [10, 20]":                        {"a"},
+		"attr('published') >= date('2024-01-01')":          {"a"},
+		"attr('published') < date('2024-01-01T00:00:00Z')": {"b"},
+		"attr('draft') != true":                            {"a"},
+		"attr('authors') = 'y'":                            {"a", "b"},
+		"attr('authors') != 'x'":                           {"b"},
+		"attr('lang') like 'e%'":                           {"a"},
+		"attr('price') = 'cheap'":                          {"c"},
+		"attr('price') in []":                              nil,
+	} {
+		nodes, err := mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: fc, Limit: 10})
+		assert.Nil(ts.T(), err, fc)
+		var res []string
+		for _, n := range nodes {
+			res = append(res, n.Name)
+		}
+		assert.ElementsMatch(ts.T(), names, res, fc)
+	}
+
+	n, err := mtx.GetNode("/b")
+	assert.Nil(ts.T(), err)
+	assert.Nil(ts.T(), mtx.UpdateNode(persistence.Node{ID: n.ID, Attrs: persistence.Attrs{"price": 1.0}}))
+	n, err = mtx.GetNode("/b")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.Attrs{"price": 1.0}, n.Attrs)
+}
+
+func (ts *pgCommonTestSuite) TestRecordMeta() {
+	mtx := ts.db.NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"})
+	assert.Nil(ts.T(), err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[0].ID, Format: "txt", Segment: "hello world", Meta: persistence.Tags{"section": "abstract"}},
+		persistence.IndexRecord{ID: "2", NodeID: nodes[0].ID, Format: "txt", Segment: "hello there", Meta: persistence.Tags{"section": "body", "lang": "en"}},
+		persistence.IndexRecord{ID: "3", NodeID: nodes[0].ID, Format: "txt", Segment: "hello again"})
+	assert.Nil(ts.T(), err)
+
+	qr, err := mtx.QueryIndexRecords(persistence.IndexRecordQuery{NodeID: nodes[0].ID, Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.Tags{"section": "abstract"}, qr.Items[0].Meta)
+	assert.Equal(ts.T(), persistence.Tags{}, qr.Items[2].Meta)
+
+	for fc, ids := range map[string][]string{
+		"rmeta('section') = 'abstract'":                         {"1"},
+		"rmeta('lang') = 'en' or rmeta('section') = 'abstract'": {"1", "2"},
+		"rmeta('section') like 'b%'":                            {"2"},
+		"rmeta('unknown') = 'x'":                                nil,
+	} {
+		changes, err := mtx.ListChanges(persistence.ChangesQuery{FilterConditions: fc, Limit: 10})
+		assert.Nil(ts.T(), err, fc)
+		var resIDs []string
+		for _, c := range changes.Items {
+			resIDs = append(resIDs, c.RecordID)
+		}
+		assert.ElementsMatch(ts.T(), ids, resIDs, fc)
+	}
+
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[0].ID, Format: "txt", Segment: "hello world", Meta: persistence.Tags{"section": "intro"}})
+	assert.Nil(ts.T(), err)
+	qr, err = mtx.QueryIndexRecords(persistence.IndexRecordQuery{NodeID: nodes[0].ID, Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.Tags{"section": "intro"}, qr.Items[0].Meta)
+}
+
+func (ts *pgCommonTestSuite) TestInheritedTags() {
+	mtx := ts.db.NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a", Tags: persistence.Tags{"team": "x", "env": "prod"}},
+		persistence.Node{Path: "/a/", Name: "b", Tags: persistence.Tags{"team": "y"}},
+		persistence.Node{Path: "/a/b/", Name: "doc", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "c", Tags: persistence.Tags{"team": "c"}})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.Tags{"team": "y", "env": "prod"}, nodes[2].EffectiveTags)
+	assert.Equal(ts.T(), persistence.Tags{"team": "c"}, nodes[3].EffectiveTags)
+
+	names := func(fc string) []string {
+		ns, err := mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: fc, Limit: 10})
+		assert.Nil(ts.T(), err, fc)
+		var res []string
+		for _, n := range ns {
+			res = append(res, persistence.ConcatPath(n.Path, n.Name))
+		}
+		return res
+	}
+	assert.Equal(ts.T(), []string{"/a/b", "/a/b/doc"}, names("inheritedTag('team') = 'y'"))
+	assert.Equal(ts.T(), []string{"/a", "/a/b", "/a/b/doc"}, names("inheritedTag('env') = 'prod'"))
+	assert.Nil(ts.T(), names("tag('env') = 'prod' and node = '/a/b/doc'"))
+
+	// the folder tags are applied to the subtree right away
+	assert.Nil(ts.T(), mtx.UpdateNode(persistence.Node{ID: nodes[0].ID, Tags: persistence.Tags{"team": "z"}}))
+	assert.Nil(ts.T(), names("inheritedTag('env') = 'prod'"))
+	_, err = mtx.PatchNodes(persistence.PatchNodesQuery{ID: nodes[1].ID, Patch: persistence.TagsPatch{Remove: []string{"team"}}})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), []string{"/a", "/a/b", "/a/b/doc"}, names("inheritedTag('team') = 'z'"))
+
+	n, err := mtx.MoveNode("/a/b", "/c/b")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.Tags{"team": "c"}, n.EffectiveTags)
+	doc, err := mtx.GetNode("/c/b/doc")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.Tags{"team": "c"}, doc.EffectiveTags)
+
+	cr, err := mtx.CopyNodes(persistence.CopyNodesQuery{From: "/c/b", To: "/a/b"})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.Tags{"team": "z"}, cr.Node.EffectiveTags)
+	assert.Equal(ts.T(), []string{"/a", "/a/b", "/a/b/doc"}, names("inheritedTag('team') = 'z'"))
+
+	changes, err := mtx.ListChanges(persistence.ChangesQuery{FilterConditions: "inheritedTag('team') = 'c' and node = '/c/b/doc'", Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(changes.Items))
+	assert.Equal(ts.T(), persistence.Tags{"team": "c"}, changes.Items[0].EffectiveTags)
+
+	// the node with the name sharing the prefix of the folder name doesn't inherit the folder tags
+	_, err = mtx.CreateNodes(persistence.Node{Path: "/", Name: "ab", Flags: persistence.NodeFlagDocument})
+	assert.Nil(ts.T(), err)
+	assert.Nil(ts.T(), mtx.UpdateNode(persistence.Node{ID: nodes[0].ID, Tags: persistence.Tags{"team": "w"}}))
+	ab, err := mtx.GetNode("/ab")
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 0, len(ab.EffectiveTags))
+	assert.Equal(ts.T(), []string{"/a", "/a/b", "/a/b/doc"}, names("inheritedTag('team') = 'w'"))
+}
+
+func (ts *pgCommonTestSuite) TestNodeACL() {
+	mtx := ts.db.NewModelTx(context.Background())
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a", ACL: persistence.ACL{"alice": persistence.PermissionRead}},
+		persistence.Node{Path: "/a/", Name: "doc", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "b"})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.ACL{"alice": persistence.PermissionRead}, nodes[1].EffectiveACL)
+
+	n, err := mtx.SetNodeACL("/a/doc", "bob", persistence.PermissionWrite)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.ACL{"bob": persistence.PermissionWrite}, n.ACL)
+	assert.Equal(ts.T(), persistence.ACL{"alice": persistence.PermissionRead, "bob": persistence.PermissionWrite}, n.EffectiveACL)
+	_, err = mtx.SetNodeACL("/a", "alice", persistence.PermissionAdmin)
+	assert.Nil(ts.T(), err)
+	_, err = mtx.SetNodeACL("/a", "a'b", persistence.PermissionRead)
+	assert.ErrorIs(ts.T(), err, errors.ErrInvalid)
+	_, err = mtx.SetNodeACL("/a", "alice", "owner")
+	assert.ErrorIs(ts.T(), err, errors.ErrInvalid)
+	_, err = mtx.SetNodeACL("/c", "alice", persistence.PermissionRead)
+	assert.ErrorIs(ts.T(), err, errors.ErrNotExist)
+
+	names := func(a persistence.Access) []string {
+		ns, err := mtx.ListNodes(persistence.ListNodesQuery{FilterConditions: "path like '/%'", Access: &a, Limit: 10})
+		assert.Nil(ts.T(), err)
+		var res []string
+		for _, n := range ns {
+			res = append(res, persistence.ConcatPath(n.Path, n.Name))
+		}
+		return res
+	}
+	assert.Equal(ts.T(), []string{"/a", "/a/doc"}, names(persistence.Access{Principals: []string{"alice"}, Permission: persistence.PermissionAdmin}))
+	assert.Equal(ts.T(), []string{"/a/doc"}, names(persistence.Access{Principals: []string{"x", "bob"}, Permission: persistence.PermissionWrite}))
+	assert.Nil(ts.T(), names(persistence.Access{Principals: []string{"bob"}, Permission: persistence.PermissionAdmin}))
+	assert.Nil(ts.T(), names(persistence.Access{Principals: []string{"x'"}, Permission: persistence.PermissionRead}))
+
+	_, err = mtx.PatchNodes(persistence.PatchNodesQuery{FilterConditions: "path like '/%'", Patch: persistence.TagsPatch{Set: persistence.Tags{"k": "v"}},
+		Access: &persistence.Access{Principals: []string{"eve"}, Permission: persistence.PermissionWrite}})
+	assert.ErrorIs(ts.T(), err, errors.ErrNotExist)
+
+	// the folder children must be accessible to delete it
+	bob := &persistence.Access{Principals: []string{"bob"}, Permission: persistence.PermissionWrite}
+	_, err = mtx.SetNodeACL("/a", "bob", persistence.PermissionWrite)
+	assert.Nil(ts.T(), err)
+	_, err = mtx.SetNodeACL("/a/doc", "bob", persistence.PermissionRead)
+	assert.Nil(ts.T(), err)
+	err = mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Force: true, Access: bob})
+	assert.ErrorIs(ts.T(), err, errors.ErrNotAuthorized)
+	_, err = mtx.SetNodeACL("/a/doc", "bob", "")
+	assert.Nil(ts.T(), err)
+	err = mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/b'", Access: bob})
+	assert.ErrorIs(ts.T(), err, errors.ErrNotExist)
+	assert.Nil(ts.T(), mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Force: true, Access: bob}))
+}
+
+func (ts *pgPgvectorTestSuite) TestSearch() {
+	mtx := ts.db.NewModelTx(context.Background())
+
+	_, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "doc1", Tags: persistence.Tags{"lang": "en"}, Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "doc2", Tags: persistence.Tags{"lang": "de"}, Flags: persistence.NodeFlagDocument})
+	assert.Nil(ts.T(), err)
+	nodes, err := mtx.ListNodes(persistence.ListNodesQuery{Limit: 10})
+	assert.Nil(ts.T(), err)
+	ids := map[string]int64{}
+	for _, n := range nodes {
+		ids[n.Name] = n.ID
+	}
+
+	_, err = mtx.UpsertIndexRecords(
+		persistence.IndexRecord{ID: "1", NodeID: ids["doc1"], Segment: "apples", Format: "txt", Embedding: persistence.Embedding{1, 0, 0}},
+		persistence.IndexRecord{ID: "2", NodeID: ids["doc1"], Segment: "pears", Format: "txt", Embedding: persistence.Embedding{0.7, 0.7, 0}},
+		persistence.IndexRecord{ID: "1", NodeID: ids["doc2"], Segment: "bananas", Format: "txt", Embedding: persistence.Embedding{0, 0, 1}},
+		persistence.IndexRecord{ID: "2", NodeID: ids["doc2"], Segment: "no embedding", Format: "txt"})
+	assert.Nil(ts.T(), err)
+
+	res, err := mtx.Search(persistence.SearchQuery{Embedding: persistence.Embedding{1, 0.1, 0}, GroupByPathOff: true, Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(3), res.Total)
+	assert.Equal(ts.T(), 3, len(res.Items))
+	assert.Equal(ts.T(), "apples", res.Items[0].Segment)
+	assert.Equal(ts.T(), "pears", res.Items[1].Segment)
+	assert.Equal(ts.T(), "bananas", res.Items[2].Segment)
+	assert.True(ts.T(), res.Items[0].Score > res.Items[1].Score)
+	assert.Equal(ts.T(), persistence.Embedding{1, 0, 0}, res.Items[0].Embedding)
+
+	res, err = mtx.Search(persistence.SearchQuery{TextQuery: "[1, 0.1, 0]", Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(2), res.Total)
+	assert.Equal(ts.T(), "apples", res.Items[0].Segment)
+	assert.Equal(ts.T(), "bananas", res.Items[1].Segment)
+
+	res, err = mtx.Search(persistence.SearchQuery{TextQuery: "[0, 0, 1]", FilterConditions: "tag(\"lang\") = \"en\"", Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(res.Items))
+	assert.Equal(ts.T(), "/doc1", res.Items[0].Path)
+
+	_, err = mtx.Search(persistence.SearchQuery{TextQuery: "apples", Limit: 10})
+	assert.ErrorIs(ts.T(), err, errors.ErrInvalid)
+}
+
+// hybrid
+
+func (ts *pgHybridTestSuite) TestSearch() {
+	mtx := ts.db.NewModelTx(context.Background())
+
+	_, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "doc1", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "doc2", Flags: persistence.NodeFlagDocument})
+	assert.Nil(ts.T(), err)
+	nodes, err := mtx.ListNodes(persistence.ListNodesQuery{Limit: 10})
+	assert.Nil(ts.T(), err)
+	ids := map[string]int64{}
+	for _, n := range nodes {
+		ids[n.Name] = n.ID
+	}
+
+	_, err = mtx.UpsertIndexRecords(
+		persistence.IndexRecord{ID: "1", NodeID: ids["doc1"], Segment: "red apples", Format: "txt", Embedding: persistence.Embedding{1, 0, 0}},
+		persistence.IndexRecord{ID: "2", NodeID: ids["doc1"], Segment: "green pears", Format: "txt", Embedding: persistence.Embedding{0, 1, 0}},
+		persistence.IndexRecord{ID: "1", NodeID: ids["doc2"], Segment: "apple pie", Format: "txt", Embedding: persistence.Embedding{0, 0, 1}})
+	assert.Nil(ts.T(), err)
+
+	q := persistence.SearchQuery{TextQuery: "pears", Embedding: persistence.Embedding{0, 0.1, 1}, GroupByPathOff: true, Limit: 10}
+
+	// the default mode is lexical
+	res, err := mtx.Search(q)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(res.Items))
+	assert.NotNil(ts.T(), res.Items[0].LexicalScore)
+	assert.Nil(ts.T(), res.Items[0].SemanticScore)
+
+	q.Mode = persistence.SearchModeSemantic
+	res, err = mtx.Search(q)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 3, len(res.Items))
+	assert.Equal(ts.T(), "apple pie", res.Items[0].Segment)
+	assert.NotNil(ts.T(), res.Items[0].SemanticScore)
+
+	q.Mode = persistence.SearchModeHybrid
+	res, err = mtx.Search(q)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(3), res.Total)
+	assert.Equal(ts.T(), 3, len(res.Items))
+	assert.Equal(ts.T(), "green pears", res.Items[0].Segment)
+	assert.NotNil(ts.T(), res.Items[0].LexicalScore)
+	assert.NotNil(ts.T(), res.Items[0].SemanticScore)
+	assert.Nil(ts.T(), res.Items[1].LexicalScore)
+}
+
+// multi
+
+func (ts *pgMultiTestSuite) TestSearch() {
+	mtx := ts.db.NewModelTx(context.Background())
+
+	_, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "doc1", Flags: persistence.NodeFlagDocument})
+	assert.Nil(ts.T(), err)
+	nodes, err := mtx.ListNodes(persistence.ListNodesQuery{Limit: 10})
+	assert.Nil(ts.T(), err)
+
+	_, err = mtx.UpsertIndexRecords(
+		persistence.IndexRecord{ID: "1", NodeID: nodes[0].ID, Segment: "Johnathan Smith", Format: "txt"},
+		persistence.IndexRecord{ID: "2", NodeID: nodes[0].ID, Segment: "the apples are red", Format: "txt"})
+	assert.Nil(ts.T(), err)
+
+	// the default engine
+	q := persistence.SearchQuery{TextQuery: "apple", GroupByPathOff: true, Limit: 10}
+	res, err := mtx.Search(q)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(res.Items))
+	assert.Equal(ts.T(), "2", res.Items[0].ID)
+
+	// fuzzy match is found by trigram only
+	q.TextQuery = "Jonathan Smit"
+	res, err = mtx.Search(q)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 0, len(res.Items))
+	q.Engine = SearchModuleTrigram
+	res, err = mtx.Search(q)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(res.Items))
+	assert.Equal(ts.T(), "1", res.Items[0].ID)
+
+	q.Engine = SearchModuleBleve
+	q.TextQuery = "apple"
+	res, err = mtx.Search(q)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(res.Items))
+	assert.Equal(ts.T(), "2", res.Items[0].ID)
+
+	for _, e := range []string{SearchModuleGroonga, SearchModulePgvector, "abc"} {
+		q.Engine = e
+		_, err = mtx.Search(q)
+		assert.True(ts.T(), errors.Is(err, errors.ErrInvalid))
+	}
+}
+
+func (ts *pgMultiTestSuite) TestTrash() {
+	mtx := ts.db.NewModelTx(context.Background())
+	_, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "doc1", Flags: persistence.NodeFlagDocument})
+	assert.Nil(ts.T(), err)
+	nodes, err := mtx.ListNodes(persistence.ListNodesQuery{Limit: 10})
+	assert.Nil(ts.T(), err)
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "1", NodeID: nodes[0].ID, Segment: "the apples are red", Format: "txt"})
+	assert.Nil(ts.T(), err)
+
+	engines := []string{"", SearchModuleTrigram, SearchModuleBleve}
+	assert.Nil(ts.T(), mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/doc1'", Trash: true}))
+	for _, e := range engines {
+		res, err := mtx.Search(persistence.SearchQuery{TextQuery: "apples", Engine: e, Limit: 10})
+		assert.Nil(ts.T(), err)
+		assert.Equal(ts.T(), 0, len(res.Items), e)
+	}
+
+	cnt, err := mtx.RestoreNodes(persistence.RestoreNodesQuery{FilterConditions: "node = '/doc1'"})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), int64(1), cnt)
+	for _, e := range engines {
+		res, err := mtx.Search(persistence.SearchQuery{TextQuery: "apples", Engine: e, Limit: 10})
+		assert.Nil(ts.T(), err)
+		assert.Equal(ts.T(), 1, len(res.Items), e)
+	}
+}
+
+// switch
+
+func (ts *pgSwitchTestSuite) TestSwitchEngine() {
+	ctx := context.Background()
+	mtx := ts.db.NewModelTx(ctx)
+	_, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "doc1", Flags: persistence.NodeFlagDocument})
+	assert.Nil(ts.T(), err)
+	nodes, err := mtx.ListNodes(persistence.ListNodesQuery{Limit: 10})
+	assert.Nil(ts.T(), err)
+	_, err = mtx.UpsertIndexRecords(
+		persistence.IndexRecord{ID: "1", NodeID: nodes[0].ID, Segment: "Johnathan Smith", Format: "txt"},
+		persistence.IndexRecord{ID: "2", NodeID: nodes[0].ID, Segment: "the apples are red", Format: "txt"})
+	assert.Nil(ts.T(), err)
+
+	_, err = ts.db.GetEngineSwitch(ctx)
+	assert.True(ts.T(), errors.Is(err, errors.ErrNotExist))
+	_, err = ts.db.SwitchEngine(ctx, SearchModuleBleve)
+	assert.True(ts.T(), errors.Is(err, errors.ErrInvalid))
+	_, err = ts.db.SwitchEngine(ctx, SearchModuleTrigram)
+	assert.True(ts.T(), errors.Is(err, errors.ErrInvalid))
+
+	es, err := ts.db.SwitchEngine(ctx, SearchModuleFts)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.EngineSwitchRunning, es.State)
+	assert.Eventually(ts.T(), func() bool {
+		es, err = ts.db.GetEngineSwitch(ctx)
+		return err == nil && es.State != persistence.EngineSwitchRunning
+	}, time.Minute, 100*time.Millisecond)
+	assert.Equal(ts.T(), persistence.EngineSwitchDone, es.State)
+	_, err = ts.db.CancelEngineSwitch(ctx)
+	assert.True(ts.T(), errors.Is(err, errors.ErrNotExist))
+
+	// the existing and the new records are found by the new default engine
+	_, err = mtx.UpsertIndexRecords(persistence.IndexRecord{ID: "3", NodeID: nodes[0].ID, Segment: "green apple", Format: "txt"})
+	assert.Nil(ts.T(), err)
+	q := persistence.SearchQuery{TextQuery: "apple", GroupByPathOff: true, Limit: 10}
+	res, err := mtx.Search(q)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 2, len(res.Items))
+
+	// the old engine is still turned on
+	q.Engine = SearchModuleTrigram
+	q.TextQuery = "Jonathan Smit"
+	res, err = mtx.Search(q)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 1, len(res.Items))
+
+	// the switch is in effect after the restart
+	ts.db.Shutdown()
+	ts.db, err = GetDb(ctx, ts.dbCont.DbConfig().DataSourceFull(), ts.sModule)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), SearchModuleName(SearchModuleFts), ts.db.dbe.Load().search)
+}
+
+func (ts *pgSwitchTestSuite) TestSwitchToPgvector() {
+	ctx := context.Background()
+	mtx := ts.db.NewModelTx(ctx)
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "doc1", Flags: persistence.NodeFlagDocument})
+	assert.Nil(ts.T(), err)
+	_, err = mtx.UpsertIndexRecords(
+		persistence.IndexRecord{ID: "1", NodeID: nodes[0].ID, Segment: "Johnathan Smith", Format: "txt"},
+		persistence.IndexRecord{ID: "2", NodeID: nodes[0].ID, Segment: "the apples are red", Format: "txt"})
+	assert.Nil(ts.T(), err)
+
+	// another instance sharing the database
+	other, err := GetDb(ctx, ts.dbCont.DbConfig().DataSourceFull(), ts.sModule, append([]Option{
+		WithPgvectorConfig(pgvector.Config{Dims: 3, IndexType: pgvector.IndexHnsw})}, ts.opts...)...)
+	assert.Nil(ts.T(), err)
+	defer other.Shutdown()
+
+	_, err = ts.db.SwitchEngine(ctx, SearchModulePgvector)
+	assert.Nil(ts.T(), err)
+	var es persistence.EngineSwitch
+	assert.Eventually(ts.T(), func() bool {
+		es, err = ts.db.GetEngineSwitch(ctx)
+		return err == nil && es.State != persistence.EngineSwitchRunning
+	}, time.Minute, 100*time.Millisecond)
+	assert.Equal(ts.T(), persistence.EngineSwitchDone, es.State, es.Error)
+
+	// the existing records are embedded before the flip
+	var cnt int64
+	assert.Nil(ts.T(), ts.db.db.GetContext(ctx, &cnt, "select count(*) from index_record where embedding is null"))
+	assert.Equal(ts.T(), int64(0), cnt)
+	embs, err := embedding.NewHashing(3).Embed(ctx, []string{"the apples are red"})
+	assert.Nil(ts.T(), err)
+	res, err := ts.db.NewModelTx(ctx).Search(persistence.SearchQuery{Embedding: embs[0], GroupByPathOff: true, Limit: 10})
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), 2, len(res.Items))
+
+	// the other instance follows the switch without the restart
+	assert.Eventually(ts.T(), func() bool {
+		return other.dbe.Load().search == SearchModulePgvector
+	}, 10*time.Second, 100*time.Millisecond)
+	assert.True(ts.T(), other.dbe.Load().embeddings)
+}
+
+func (ts *pgSwitchTestSuite) TestSwitchAbandoned() {
+	ctx := context.Background()
+	var ID int64
+	assert.Nil(ts.T(), ts.db.db.GetContext(ctx, &ID, "insert into engine_switch (from_engine, to_engine, state, phase, owner) "+
+		"values ($1, $2, $3, $4, 'other') returning id", SearchModuleTrigram, SearchModuleFts, persistence.EngineSwitchRunning, switchPhasePrepare))
+
+	// the switch of the running instance survives the start of another one
+	other, err := GetDb(ctx, ts.dbCont.DbConfig().DataSourceFull(), ts.sModule, ts.opts...)
+	assert.Nil(ts.T(), err)
+	defer other.Shutdown()
+	es, err := ts.db.GetEngineSwitch(ctx)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.EngineSwitchRunning, es.State)
+	_, err = other.SwitchEngine(ctx, SearchModuleFts)
+	assert.True(ts.T(), errors.Is(err, errors.ErrConflict))
+
+	// the switch, which is not refreshed by its instance, is failed
+	_, err = ts.db.db.ExecContext(ctx, "update engine_switch set updated_at = $1 where id = $2", time.Now().Add(-2*defaultSwitchTimeout), ID)
+	assert.Nil(ts.T(), err)
+	assert.Nil(ts.T(), failAbandonedSwitches(ctx, ts.db.db, defaultSwitchTimeout))
+	es, err = ts.db.GetEngineSwitch(ctx)
+	assert.Nil(ts.T(), err)
+	assert.Equal(ts.T(), persistence.EngineSwitchFailed, es.State)
+	assert.Equal(ts.T(), "abandoned by the instance other", es.Error)
+}
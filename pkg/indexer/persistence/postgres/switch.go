@@ -0,0 +1,549 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/fts"
+	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/groonga"
+	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/pgvector"
+	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/trigram"
+	"maps"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// switcher keeps the state of the online search engine switch
+	switcher struct {
+		ctx    context.Context // the background context, it is canceled on shutdown
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+
+		// configured is the search module the Db was built with, the switches made from it are followed
+		configured SearchModuleName
+		// owner is the unique name of the instance, which is stored in the switches it runs
+		owner string
+
+		lock sync.Mutex
+		cur  *persistence.EngineSwitch // the running or the last switch made by the Db, it can be nil
+		stop context.CancelFunc        // cancels the running switch, it is nil if no switch is running
+	}
+)
+
+const (
+	switchPhasePrepare  = "prepare"
+	switchPhaseBackfill = "backfill"
+	switchPhaseIndex    = "index"
+
+	backfillBatchSize     = 1000
+	indexProgressInterval = time.Second
+	// defaultSwitchPoll is the default interval of checking the switches made by the other instances
+	defaultSwitchPoll = 10 * time.Second
+	// defaultSwitchTimeout is the default time after which the running switch, which is not updated
+	// by its owner, is failed. The owner updates the switch 4 times within the timeout.
+	defaultSwitchTimeout = time.Minute
+)
+
+var _ persistence.EngineSwitcher = (*Db)(nil)
+
+// SwitchEngine is a part of the persistence.EngineSwitcher interface. The target module migrations
+// are applied without the indexes first, then the existing records are filled, if needed, and the
+// indexes are created concurrently. The default module is serving the searches until the flip.
+// Only the modules, which keep the index in Postgres, can be switched to online. The switch to
+// pgvector requires the embedding provider (see WithEmbedder) to fill the existing records embeddings.
+func (d *Db) SwitchEngine(ctx context.Context, engine string) (persistence.EngineSwitch, error) {
+	to := SearchModuleName(engine)
+	switch to {
+	case SearchModuleGroonga, SearchModuleTrigram, SearchModuleFts:
+	case SearchModulePgvector:
+		if err := d.o.pgvectorCfg.Check(); err != nil {
+			return persistence.EngineSwitch{}, err
+		}
+		if d.o.embedder == nil {
+			return persistence.EngineSwitch{}, fmt.Errorf("the embedding provider must be configured to switch to %q: %w", engine, errors.ErrInvalid)
+		}
+		if d.o.embedder.Dims() != d.o.pgvectorCfg.Dims {
+			return persistence.EngineSwitch{}, fmt.Errorf("the embedding provider dimensions %d don't match the pgvector ones %d: %w",
+				d.o.embedder.Dims(), d.o.pgvectorCfg.Dims, errors.ErrInvalid)
+		}
+	default:
+		return persistence.EngineSwitch{}, fmt.Errorf("the online switch to the search engine %q is not supported: %w", engine, errors.ErrInvalid)
+	}
+
+	d.sw.lock.Lock()
+	defer d.sw.lock.Unlock()
+	if d.sw.stop != nil {
+		return persistence.EngineSwitch{}, fmt.Errorf("the switch to %q is running: %w", d.sw.cur.To, errors.ErrConflict)
+	}
+	from := d.dbe.Load().search
+	if from == to {
+		return persistence.EngineSwitch{}, fmt.Errorf("the search engine %q is already the default one: %w", engine, errors.ErrInvalid)
+	}
+	if err := failAbandonedSwitches(ctx, d.db, d.o.switchTimeout); err != nil {
+		return persistence.EngineSwitch{}, persistence.MapError(err)
+	}
+	// the switch is not started, if another instance is running one
+	es := persistence.EngineSwitch{From: string(from), To: engine, State: persistence.EngineSwitchRunning, Phase: switchPhasePrepare, Owner: d.sw.owner}
+	err := d.db.GetContext(ctx, &es, "insert into engine_switch (from_engine, to_engine, state, phase, owner) select $1, $2, $3, $4, $5 "+
+		"where not exists (select 1 from engine_switch where state = $3) returning *", es.From, es.To, es.State, es.Phase, es.Owner)
+	if errors.Is(err, sql.ErrNoRows) {
+		return persistence.EngineSwitch{}, fmt.Errorf("the search engine switch is run by another instance: %w", errors.ErrConflict)
+	}
+	if err != nil {
+		return persistence.EngineSwitch{}, persistence.MapError(err)
+	}
+	d.logger.Infof("the search engine switch %s -> %s is started", from, to)
+
+	var sctx context.Context
+	sctx, d.sw.stop = context.WithCancel(d.sw.ctx)
+	d.sw.cur = &es
+	d.sw.wg.Add(1)
+	go d.runSwitch(sctx, to)
+	return es, nil
+}
+
+// GetEngineSwitch is a part of the persistence.EngineSwitcher interface
+func (d *Db) GetEngineSwitch(ctx context.Context) (persistence.EngineSwitch, error) {
+	d.sw.lock.Lock()
+	defer d.sw.lock.Unlock()
+	if d.sw.cur != nil {
+		return *d.sw.cur, nil
+	}
+	var es persistence.EngineSwitch
+	if err := d.db.GetContext(ctx, &es, "select * from engine_switch order by id desc limit 1"); err != nil {
+		return persistence.EngineSwitch{}, persistence.MapError(err)
+	}
+	return es, nil
+}
+
+// CancelEngineSwitch is a part of the persistence.EngineSwitcher interface. The switch
+// is canceled asynchronously, so the returned switch may be still running.
+func (d *Db) CancelEngineSwitch(_ context.Context) (persistence.EngineSwitch, error) {
+	d.sw.lock.Lock()
+	defer d.sw.lock.Unlock()
+	if d.sw.stop == nil {
+		return persistence.EngineSwitch{}, fmt.Errorf("no search engine switch is running: %w", errors.ErrNotExist)
+	}
+	d.sw.stop()
+	return *d.sw.cur, nil
+}
+
+// runSwitch builds the module indexes and flips the default module, if succeeded
+func (d *Db) runSwitch(ctx context.Context, to SearchModuleName) {
+	defer d.sw.wg.Done()
+	hctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d.heartbeat(hctx, d.sw.cur.ID)
+	}()
+	err := d.buildModule(ctx, to)
+	cancel()
+	<-done
+
+	d.sw.lock.Lock()
+	defer d.sw.lock.Unlock()
+	d.sw.stop()
+	d.sw.stop = nil
+	es := d.sw.cur
+	switch {
+	case err == nil:
+		es.State = persistence.EngineSwitchDone
+		es.Progress = 1
+	case ctx.Err() != nil:
+		es.State = persistence.EngineSwitchCanceled
+	default:
+		es.State = persistence.EngineSwitchFailed
+		es.Error = err.Error()
+	}
+	// the flip is persisted first, so the new module is used after the restart
+	if serr := d.saveSwitch(context.Background(), es); serr != nil && err == nil {
+		es.State = persistence.EngineSwitchFailed
+		es.Error = serr.Error()
+		err = serr
+	}
+	if es.State != persistence.EngineSwitchDone {
+		d.logger.Warnf("the search engine switch %s -> %s is %s: %v", es.From, es.To, es.State, err)
+		return
+	}
+	if err = d.flip(to); err != nil {
+		d.logger.Errorf("the search engine %s is built, but could not be turned on until the restart: %v", to, err)
+		return
+	}
+	d.logger.Infof("the search engine is switched %s -> %s", es.From, es.To)
+}
+
+// buildModule applies the online migrations of the module and creates its indexes
+func (d *Db) buildModule(ctx context.Context, m SearchModuleName) error {
+	var migrs []*migrate.Migration
+	var idxs map[string]string
+	switch m {
+	case SearchModuleGroonga:
+		migrs, idxs = groonga.OnlineMigrations(), groonga.OnlineIndexes()
+	case SearchModuleTrigram:
+		migrs, idxs = trigram.OnlineMigrations(), trigram.OnlineIndexes()
+	case SearchModuleFts:
+		migrs, idxs = fts.OnlineMigrations(), fts.OnlineIndexes()
+	case SearchModulePgvector:
+		migrs, idxs = pgvector.OnlineMigrations(d.o.pgvectorCfg), pgvector.OnlineIndexes(d.o.pgvectorCfg)
+	}
+	// the migrations of the modules turned on are in the database, so the unknown ones are ignored
+	ms := migrate.MigrationSet{IgnoreUnknown: true}
+	mms := migrate.MemoryMigrationSource{Migrations: append(migrations(), migrs...)}
+	if _, err := ms.ExecContext(ctx, d.db.DB, "postgres", mms, migrate.Up); err != nil {
+		return fmt.Errorf("migration failed: %w", err)
+	}
+	if m == SearchModulePgvector {
+		// the embeddings are stored from now on, so the new records are indexed
+		d.updateDbe(func(dbe *dbExt) {
+			dbe.embeddings = true
+		})
+		if err := d.setPhase(ctx, switchPhaseBackfill); err != nil {
+			return err
+		}
+		// the other instances store the embeddings after they see the backfill phase
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(d.o.switchPoll):
+		}
+		if err := d.backfillEmbeddings(ctx); err != nil {
+			return fmt.Errorf("backfill failed: %w", err)
+		}
+	}
+	if m == SearchModuleFts {
+		if err := d.setPhase(ctx, switchPhaseBackfill); err != nil {
+			return err
+		}
+		if err := d.backfill(ctx); err != nil {
+			return fmt.Errorf("backfill failed: %w", err)
+		}
+	}
+	if err := d.setPhase(ctx, switchPhaseIndex); err != nil {
+		return err
+	}
+	for name, stmt := range idxs {
+		if err := d.createIndex(ctx, name, stmt); err != nil {
+			return fmt.Errorf("could not create the index %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// backfill fills the fts module column of the existing records by batches
+func (d *Db) backfill(ctx context.Context) error {
+	var total, done int64
+	if err := d.db.GetContext(ctx, &total, "select greatest(reltuples, 0)::bigint from pg_class where oid = 'index_record'::regclass"); err != nil {
+		return persistence.MapError(err)
+	}
+	nodeID, id := int64(0), ""
+	for {
+		err := d.db.QueryRowxContext(ctx, fts.BackfillBatch, nodeID, id, backfillBatchSize).Scan(&nodeID, &id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return persistence.MapError(err)
+		}
+		done += backfillBatchSize
+		if total > 0 {
+			d.setProgress(float64(min(done, total)) / float64(total))
+		}
+	}
+}
+
+// backfillEmbeddings fills the embeddings of the existing records by batches
+func (d *Db) backfillEmbeddings(ctx context.Context) error {
+	var total, done int64
+	if err := d.db.GetContext(ctx, &total, "select count(*) from index_record where embedding is null"); err != nil {
+		return persistence.MapError(err)
+	}
+	nodeID, id := int64(0), ""
+	for {
+		var recs []persistence.IndexRecord
+		if err := d.db.SelectContext(ctx, &recs, pgvector.BackfillSelect, nodeID, id, backfillBatchSize); err != nil {
+			return persistence.MapError(err)
+		}
+		if len(recs) == 0 {
+			return nil
+		}
+		texts := make([]string, len(recs))
+		for i, r := range recs {
+			texts[i] = r.Segment
+		}
+		embs, err := d.o.embedder.Embed(ctx, texts)
+		if err != nil {
+			return fmt.Errorf("could not embed %d records: %w", len(texts), err)
+		}
+		nodeIDs, ids, vals := make([]int64, len(recs)), make([]string, len(recs)), make([]string, len(recs))
+		for i, r := range recs {
+			nodeIDs[i], ids[i], vals[i] = r.NodeID, r.ID, persistence.Embedding(embs[i]).String()
+		}
+		if _, err = d.db.ExecContext(ctx, pgvector.BackfillUpdate, pq.Array(nodeIDs), pq.Array(ids), pq.Array(vals)); err != nil {
+			return persistence.MapError(err)
+		}
+		nodeID, id = recs[len(recs)-1].NodeID, recs[len(recs)-1].ID
+		done += int64(len(recs))
+		if total > 0 {
+			d.setProgress(float64(min(done, total)) / float64(total))
+		}
+	}
+}
+
+// createIndex creates the index concurrently, the invalid index left by the failed build is dropped first
+func (d *Db) createIndex(ctx context.Context, name, stmt string) error {
+	var valid bool
+	err := d.db.GetContext(ctx, &valid, "select i.indisvalid from pg_index as i inner join pg_class as c on c.oid = i.indexrelid where c.relname = $1", name)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return persistence.MapError(err)
+	}
+	if err == nil && !valid {
+		if _, err = d.db.ExecContext(ctx, fmt.Sprintf("drop index concurrently if exists %q", name)); err != nil {
+			return persistence.MapError(err)
+		}
+	}
+
+	pctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go d.watchIndexProgress(pctx)
+	if _, err = d.db.ExecContext(ctx, stmt); err != nil {
+		return persistence.MapError(err)
+	}
+	return nil
+}
+
+// watchIndexProgress updates the switch progress by the pg_stat_progress_create_index view
+// until the ctx is closed. The index is built by a table scan and then validated by another table
+// scan, so the first scan is reported as [0, 0.5] progress and the validation one as [0.5, 1].
+func (d *Db) watchIndexProgress(ctx context.Context) {
+	ticker := time.NewTicker(indexProgressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		var p struct {
+			Phase       string `db:"phase"`
+			BlocksDone  int64  `db:"blocks_done"`
+			BlocksTotal int64  `db:"blocks_total"`
+		}
+		err := d.db.GetContext(ctx, &p, "select phase, blocks_done, blocks_total from pg_stat_progress_create_index "+
+			"where relid = 'index_record'::regclass limit 1")
+		if err != nil || p.BlocksTotal == 0 {
+			continue
+		}
+		progress := float64(p.BlocksDone) / float64(p.BlocksTotal) / 2
+		if strings.HasPrefix(p.Phase, "index validation") {
+			progress += 0.5
+		}
+		d.setProgress(progress)
+	}
+}
+
+// heartbeat updates the running switch periodically until the ctx is closed, so the other instances
+// don't consider it abandoned. The switch is canceled, if it is failed by another instance meanwhile.
+func (d *Db) heartbeat(ctx context.Context, ID int64) {
+	ticker := time.NewTicker(d.o.switchTimeout / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		res, err := d.db.ExecContext(ctx, "update engine_switch set updated_at = $1 where id = $2 and state = $3",
+			time.Now(), ID, persistence.EngineSwitchRunning)
+		if err != nil {
+			if ctx.Err() == nil {
+				d.logger.Warnf("could not update the search engine switch %d: %v", ID, err)
+			}
+			continue
+		}
+		if cnt, _ := res.RowsAffected(); cnt == 0 {
+			d.logger.Warnf("the search engine switch %d is not running anymore, canceling it", ID)
+			d.sw.lock.Lock()
+			if d.sw.stop != nil {
+				d.sw.stop()
+			}
+			d.sw.lock.Unlock()
+			return
+		}
+	}
+}
+
+// setPhase persists the new phase of the running switch
+func (d *Db) setPhase(ctx context.Context, phase string) error {
+	d.sw.lock.Lock()
+	defer d.sw.lock.Unlock()
+	d.sw.cur.Phase = phase
+	d.sw.cur.Progress = 0
+	return d.saveSwitch(ctx, d.sw.cur)
+}
+
+// setProgress updates the progress of the running switch, the progress is not persisted
+func (d *Db) setProgress(progress float64) {
+	d.sw.lock.Lock()
+	defer d.sw.lock.Unlock()
+	d.sw.cur.Progress = progress
+	d.sw.cur.UpdatedAt = time.Now()
+}
+
+// saveSwitch persists the switch, it returns ErrConflict if the switch is not running in the database
+// anymore, e.g. it is failed by another instance as abandoned
+func (d *Db) saveSwitch(ctx context.Context, es *persistence.EngineSwitch) error {
+	es.UpdatedAt = time.Now()
+	res, err := d.db.ExecContext(ctx, "update engine_switch set state = $1, phase = $2, progress = $3, error = $4, updated_at = $5 "+
+		"where id = $6 and state = $7", es.State, es.Phase, es.Progress, es.Error, es.UpdatedAt, es.ID, persistence.EngineSwitchRunning)
+	if err != nil {
+		return persistence.MapError(err)
+	}
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return fmt.Errorf("the search engine switch %d is not running anymore: %w", es.ID, errors.ErrConflict)
+	}
+	return nil
+}
+
+// flip makes the module m the default one
+func (d *Db) flip(m SearchModuleName) error {
+	var err error
+	d.updateDbe(func(dbe *dbExt) {
+		var tr = dbe.tr
+		if tr, err = openModule(d.sw.ctx, d.db, d.o, m, dbe); err != nil {
+			return
+		}
+		dbe.search = m
+		dbe.searchFn = dbe.engines[m]
+		dbe.tr = tr
+	})
+	return err
+}
+
+// updateDbe replaces the dbe by its copy changed by the fn
+func (d *Db) updateDbe(fn func(dbe *dbExt)) {
+	dbe := *d.dbe.Load()
+	dbe.engines = maps.Clone(dbe.engines)
+	fn(&dbe)
+	d.dbe.Store(&dbe)
+}
+
+// watchSwitches follows the switches made by the other instances sharing the database until
+// the Db is shut down. The module switched to becomes the default one, and the embeddings are
+// stored while the switch to pgvector fills them.
+func (d *Db) watchSwitches() {
+	defer d.sw.wg.Done()
+	ticker := time.NewTicker(d.o.switchPoll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.sw.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := d.followSwitches(d.sw.ctx); err != nil && d.sw.ctx.Err() == nil {
+			d.logger.Warnf("could not check the search engine switches: %v", err)
+		}
+	}
+}
+
+// followSwitches applies the switches made by the other instances, the switch run by the Db is not affected
+func (d *Db) followSwitches(ctx context.Context) error {
+	if err := failAbandonedSwitches(ctx, d.db, d.o.switchTimeout); err != nil {
+		return persistence.MapError(err)
+	}
+	d.sw.lock.Lock()
+	defer d.sw.lock.Unlock()
+	if d.sw.stop != nil {
+		return nil
+	}
+	dbe := d.dbe.Load()
+	if !dbe.embeddings {
+		var exists bool
+		err := d.db.GetContext(ctx, &exists, "select exists (select 1 from engine_switch where state = $1 and to_engine = $2 and phase <> $3)",
+			persistence.EngineSwitchRunning, SearchModulePgvector, switchPhasePrepare)
+		if err != nil {
+			return persistence.MapError(err)
+		}
+		if exists {
+			d.updateDbe(func(dbe *dbExt) {
+				dbe.embeddings = true
+			})
+		}
+	}
+	to, err := lastSwitchedModule(ctx, d.db, d.sw.configured)
+	if err != nil || to == dbe.search {
+		return err
+	}
+	if err = d.flip(to); err != nil {
+		return err
+	}
+	d.logger.Infof("the search engine is switched %s -> %s by another instance", dbe.search, to)
+	return nil
+}
+
+// switchedModule returns the search module to be used by default. If the default module
+// was switched online since the search module was configured, the module it was switched
+// to is used. The switches abandoned by the stopped instances are marked as failed, the switches
+// run by the live instances are not affected.
+func switchedModule(ctx context.Context, db *sqlx.DB, search SearchModuleName, timeout time.Duration) (SearchModuleName, error) {
+	var exists bool
+	if err := db.GetContext(ctx, &exists, "select to_regclass('engine_switch') is not null"); err != nil || !exists {
+		return search, err
+	}
+	if err := failAbandonedSwitches(ctx, db, timeout); err != nil {
+		return search, err
+	}
+	return lastSwitchedModule(ctx, db, search)
+}
+
+// failAbandonedSwitches marks the running switches, which are not updated by their owners for the timeout, as failed
+func failAbandonedSwitches(ctx context.Context, db *sqlx.DB, timeout time.Duration) error {
+	now := time.Now()
+	_, err := db.ExecContext(ctx, "update engine_switch set state = $1, error = 'abandoned by the instance ' || owner, updated_at = $2 "+
+		"where state = $3 and updated_at < $4", persistence.EngineSwitchFailed, now, persistence.EngineSwitchRunning, now.Add(-timeout))
+	return err
+}
+
+// switchOwner returns the unique name of the instance for the switches it runs
+func switchOwner() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d-%d", host, os.Getpid(), time.Now().UnixNano())
+}
+
+// lastSwitchedModule returns the module the configured search module was switched to by the
+// finished switches, or the search module itself, if it was not switched
+func lastSwitchedModule(ctx context.Context, db *sqlx.DB, search SearchModuleName) (SearchModuleName, error) {
+	var switches []persistence.EngineSwitch
+	if err := db.SelectContext(ctx, &switches, "select * from engine_switch where state = $1 order by id", persistence.EngineSwitchDone); err != nil {
+		return search, err
+	}
+	for _, es := range switches {
+		if SearchModuleName(es.From) == search {
+			search = SearchModuleName(es.To)
+		}
+	}
+	return search, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/jmoiron/sqlx"
 	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/pgvector"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"time"
@@ -14,13 +15,14 @@ type (
 	pgTestSuite struct {
 		suite.Suite
 		sModule SearchModuleName
+		opts    []Option
 		dbCont  persistence.DbContainer
 		db      *Db
 	}
 )
 
-func newPqTestSuite(pgExt SearchModuleName) pgTestSuite {
-	return pgTestSuite{sModule: pgExt}
+func newPqTestSuite(pgExt SearchModuleName, opts ...Option) pgTestSuite {
+	return pgTestSuite{sModule: pgExt, opts: opts}
 }
 
 func (ts *pgTestSuite) SetupSuite() {
@@ -28,7 +30,7 @@ func (ts *pgTestSuite) SetupSuite() {
 	var dbCont persistence.DbContainer
 
 	switch ts.sModule {
-	case SearchModuleNone, SearchModuleGroonga, SearchModuleTrigram, SearchModuleFts:
+	case SearchModuleNone, SearchModuleGroonga, SearchModuleTrigram, SearchModuleFts, SearchModuleBleve, SearchModulePgvector:
 		ctx, cancelFn := context.WithTimeout(context.Background(), time.Minute)
 		defer cancelFn()
 		dbCont, err = persistence.NewPgDbContainer(ctx, "simila/similadb:latest", persistence.WithDbName("simila_test"))
@@ -68,7 +70,9 @@ func (ts *pgTestSuite) BeforeTest(suiteName, testName string) {
 	assert.Nil(ts.T(), ts.dropCreatePgDb(ctx))
 
 	var err error
-	ts.db, err = GetDb(ctx, dbCfg.DataSourceFull(), ts.sModule)
+	opts := append([]Option{WithBleveIndexDir(ts.T().TempDir()),
+		WithPgvectorConfig(pgvector.Config{Dims: 3, IndexType: pgvector.IndexHnsw})}, ts.opts...)
+	ts.db, err = GetDb(ctx, dbCfg.DataSourceFull(), ts.sModule, opts...)
 	assert.Nil(ts.T(), err)
 	assert.Nil(ts.T(), ts.db.Init(ctx))
 }
@@ -17,6 +17,9 @@ create extension if not exists pg_trgm;
 `
 	createSegmentIndexUp = `
 create index if not exists "idx_index_record_segment_trgm" on "index_record" using gin ("segment" gin_trgm_ops);
+`
+	createSegmentIndexConcurrentlyUp = `
+create index concurrently if not exists "idx_index_record_segment_trgm" on "index_record" using gin ("segment" gin_trgm_ops);
 `
 	createSegmentIndexDown = `
 drop index if exists "idx_index_record_segment_trgm";
@@ -58,6 +61,22 @@ func Migrations(rollback bool) []*migrate.Migration {
 	}
 }
 
+// OnlineMigrations returns the same migrations as Migrations, but the segment index
+// is not created by them, it must be created by OnlineIndexes after the migrations
+// are applied. It allows to turn the module on while the "index_record" table is in use.
+func OnlineMigrations() []*migrate.Migration {
+	return []*migrate.Migration{
+		createExtension("2000", false),
+		createSegmentIndex("2001", true),
+	}
+}
+
+// OnlineIndexes returns the module indexes (the index name to the create statement),
+// which are created concurrently, after the OnlineMigrations are applied.
+func OnlineIndexes() map[string]string {
+	return map[string]string{"idx_index_record_segment_trgm": createSegmentIndexConcurrentlyUp}
+}
+
 // SessionParams returns a map of k:v pairs, which represent DB settings
 // to be applied as soon as the DB session is started. This may be needed
 // to tweak parameters of the DB extension, since some controlled DB envs
@@ -73,13 +92,19 @@ func SessionParams() map[string]any {
 // segment of text is matched against the whole query text using `trigram word similarity`,
 // see https://www.postgresql.org/docs/current/pgtrgm.html.
 func Search(ctx context.Context, qx sqlx.QueryerContext, q persistence.SearchQuery) (persistence.SearchQueryResult, error) {
-	var sb strings.Builder
-	sb.Grow(2 * len(q.FilterConditions))
-	if err := FcTranslator.Translate(&sb, q.FilterConditions); err != nil {
+	var fc strings.Builder
+	fc.Grow(2 * len(q.FilterConditions))
+	if err := FcTranslator.Translate(&fc, q.FilterConditions); err != nil {
 		return persistence.SearchQueryResult{}, persistence.MapError(err)
 	}
-	if sb.Len() > 0 {
-		sb.WriteString(" and ")
+	// the trashed nodes records are never returned
+	var sb strings.Builder
+	sb.WriteString("n.deleted_at is null and ")
+	if q.Access != nil {
+		sb.WriteString(persistence.PqAccessCondition(*q.Access) + " and ")
+	}
+	if fc.Len() > 0 {
+		sb.WriteString("(" + fc.String() + ") and ")
 	}
 
 	var params []any
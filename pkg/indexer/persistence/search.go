@@ -0,0 +1,207 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistence
+
+import (
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"sort"
+)
+
+type (
+	// SearchFunc runs the search for the query provided
+	SearchFunc func(q SearchQuery) (SearchQueryResult, error)
+)
+
+const (
+	SearchModeLexical  SearchMode = "lexical"
+	SearchModeSemantic SearchMode = "semantic"
+	SearchModeHybrid   SearchMode = "hybrid"
+
+	FusionRRF      FusionMethod = "rrf"
+	FusionWeighted FusionMethod = "weighted"
+
+	// DefaultSemanticWeight is the semantic score weight used by FusionWeighted, if it is not specified
+	DefaultSemanticWeight = 0.5
+
+	// rrfK is the reciprocal rank fusion constant, which lowers the impact of the top ranks
+	rrfK = 60
+)
+
+// Search runs the query in the q.Mode using the lexical and the semantic search functions,
+// either of them may be nil, if the mode is not supported by the search engine. If the
+// q.Mode is empty, the lexical search is used, or the semantic one if lexical is nil.
+//
+// In the SearchModeHybrid both searches are run for the first q.Offset + q.Limit records,
+// the results are merged by the q.Fusion method. The result Total is the maximum of the
+// both search totals, so it can be lower than the number of the records found by both of them together.
+func Search(q SearchQuery, lexical, semantic SearchFunc) (SearchQueryResult, error) {
+	mode := q.Mode
+	if mode == "" {
+		mode = SearchModeLexical
+		if lexical == nil {
+			mode = SearchModeSemantic
+		}
+	}
+	switch mode {
+	case SearchModeLexical:
+		if lexical == nil {
+			return SearchQueryResult{}, fmt.Errorf("the lexical search is not supported: %w", errors.ErrInvalid)
+		}
+		res, err := lexical(q)
+		for i := range res.Items {
+			score := res.Items[i].Score
+			res.Items[i].LexicalScore = &score
+		}
+		return res, err
+	case SearchModeSemantic:
+		if semantic == nil {
+			return SearchQueryResult{}, fmt.Errorf("the semantic search is not supported: %w", errors.ErrInvalid)
+		}
+		res, err := semantic(q)
+		for i := range res.Items {
+			score := res.Items[i].Score
+			res.Items[i].SemanticScore = &score
+		}
+		return res, err
+	case SearchModeHybrid:
+		if lexical == nil || semantic == nil {
+			return SearchQueryResult{}, fmt.Errorf("the hybrid search requires both the lexical and the semantic searches: %w", errors.ErrInvalid)
+		}
+		return hybridSearch(q, lexical, semantic)
+	}
+	return SearchQueryResult{}, fmt.Errorf("unknown search mode %q: %w", q.Mode, errors.ErrInvalid)
+}
+
+func hybridSearch(q SearchQuery, lexical, semantic SearchFunc) (SearchQueryResult, error) {
+	if q.Fusion != "" && q.Fusion != FusionRRF && q.Fusion != FusionWeighted {
+		return SearchQueryResult{}, fmt.Errorf("unknown fusion method %q: %w", q.Fusion, errors.ErrInvalid)
+	}
+	w := q.SemanticWeight
+	if w == 0 {
+		w = DefaultSemanticWeight
+	}
+	if w < 0 || w > 1 {
+		return SearchQueryResult{}, fmt.Errorf("the semantic weight must be in [0, 1], but %f: %w", w, errors.ErrInvalid)
+	}
+
+	wq := q
+	wq.Offset = 0
+	wq.Limit = q.Offset + q.Limit
+	lr, err := lexical(wq)
+	if err != nil {
+		return SearchQueryResult{}, fmt.Errorf("the lexical search failed: %w", err)
+	}
+	sr, err := semantic(wq)
+	if err != nil {
+		return SearchQueryResult{}, fmt.Errorf("the semantic search failed: %w", err)
+	}
+
+	items := fuse(lr.Items, sr.Items, q.Fusion, w, q.GroupByPathOff)
+	res := SearchQueryResult{Total: max(lr.Total, sr.Total)}
+	if q.Offset < len(items) {
+		res.Items = items[q.Offset:min(len(items), q.Offset+q.Limit)]
+	}
+	return res, nil
+}
+
+// fuse merges the lexical and the semantic results into one list ordered by
+// the fused score. The same record (or the same node, if the results are grouped
+// by path) found by both searches is returned once with the both component scores.
+func fuse(lexical, semantic []SearchQueryResultItem, fusion FusionMethod, w float64, groupByPathOff bool) []SearchQueryResultItem {
+	key := func(it SearchQueryResultItem) string {
+		if groupByPathOff {
+			return fmt.Sprintf("%d/%s", it.NodeID, it.ID)
+		}
+		return fmt.Sprintf("%d", it.NodeID)
+	}
+
+	var res []SearchQueryResultItem
+	scores := map[string]float64{}
+	idx := map[string]int{}
+	add := func(items []SearchQueryResultItem, weight float64, lex bool) {
+		norm := rrfScores(items)
+		if fusion == FusionWeighted {
+			norm = normScores(items)
+		}
+		for i, it := range items {
+			k := key(it)
+			j, ok := idx[k]
+			if !ok {
+				// the lexical item is added first, so it is kept with its matched keywords
+				j = len(res)
+				idx[k] = j
+				res = append(res, it)
+			}
+			score := it.Score
+			if lex {
+				res[j].LexicalScore = &score
+			} else {
+				res[j].SemanticScore = &score
+			}
+			scores[k] += weight * norm[i]
+		}
+	}
+	if fusion == FusionWeighted {
+		add(lexical, 1-w, true)
+		add(semantic, w, false)
+	} else {
+		add(lexical, 1, true)
+		add(semantic, 1, false)
+	}
+
+	for i := range res {
+		res[i].Score = float32(scores[key(res[i])])
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Score != res[j].Score {
+			return res[i].Score > res[j].Score
+		}
+		if res[i].Path != res[j].Path {
+			return res[i].Path < res[j].Path
+		}
+		return res[i].ID < res[j].ID
+	})
+	return res
+}
+
+// rrfScores returns the reciprocal rank scores for the items ordered by rank
+func rrfScores(items []SearchQueryResultItem) []float64 {
+	res := make([]float64, len(items))
+	for i := range items {
+		res[i] = 1.0 / float64(rrfK+i+1)
+	}
+	return res
+}
+
+// normScores returns the items scores min-max normalized to [0, 1]
+func normScores(items []SearchQueryResultItem) []float64 {
+	res := make([]float64, len(items))
+	if len(items) == 0 {
+		return res
+	}
+	lo, hi := items[0].Score, items[0].Score
+	for _, it := range items {
+		lo = min(lo, it.Score)
+		hi = max(hi, it.Score)
+	}
+	for i, it := range items {
+		res[i] = 1
+		if hi > lo {
+			res[i] = float64(it.Score-lo) / float64(hi-lo)
+		}
+	}
+	return res
+}
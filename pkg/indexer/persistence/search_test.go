@@ -0,0 +1,122 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persistence
+
+import (
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func testSearchFunc(total int64, items ...SearchQueryResultItem) SearchFunc {
+	return func(q SearchQuery) (SearchQueryResult, error) {
+		res := SearchQueryResult{Total: total}
+		if q.Offset < len(items) {
+			res.Items = append(res.Items, items[q.Offset:min(len(items), q.Offset+q.Limit)]...)
+		}
+		return res, nil
+	}
+}
+
+func testItem(nID int64, id string, score float32) SearchQueryResultItem {
+	return SearchQueryResultItem{IndexRecord: IndexRecord{ID: id, NodeID: nID}, Score: score}
+}
+
+func TestSearch_Modes(t *testing.T) {
+	lex := testSearchFunc(1, testItem(1, "1", 3))
+	sem := testSearchFunc(1, testItem(2, "1", 0.5))
+
+	res, err := Search(SearchQuery{Limit: 10}, lex, sem)
+	assert.Nil(t, err)
+	assert.Equal(t, "1", res.Items[0].ID)
+	assert.Equal(t, float32(3), *res.Items[0].LexicalScore)
+	assert.Nil(t, res.Items[0].SemanticScore)
+
+	res, err = Search(SearchQuery{Limit: 10}, nil, sem)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), res.Items[0].NodeID)
+	assert.Equal(t, float32(0.5), *res.Items[0].SemanticScore)
+	assert.Nil(t, res.Items[0].LexicalScore)
+
+	_, err = Search(SearchQuery{Mode: SearchModeSemantic}, lex, nil)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	_, err = Search(SearchQuery{Mode: SearchModeLexical}, nil, sem)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	_, err = Search(SearchQuery{Mode: SearchModeHybrid}, lex, nil)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	_, err = Search(SearchQuery{Mode: "abc"}, lex, sem)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	_, err = Search(SearchQuery{Mode: SearchModeHybrid, Fusion: "abc"}, lex, sem)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	_, err = Search(SearchQuery{Mode: SearchModeHybrid, SemanticWeight: 1.5}, lex, sem)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+}
+
+func TestSearch_HybridRRF(t *testing.T) {
+	lex := testSearchFunc(3, testItem(1, "1", 10), testItem(1, "2", 5), testItem(2, "1", 1))
+	sem := testSearchFunc(5, testItem(3, "1", 0.9), testItem(1, "2", 0.8), testItem(1, "1", 0.1))
+
+	res, err := Search(SearchQuery{Mode: SearchModeHybrid, GroupByPathOff: true, Limit: 10}, lex, sem)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), res.Total)
+	assert.Equal(t, 4, len(res.Items))
+	// 1/61 + 1/63 > 1/62 + 1/62
+	assert.Equal(t, int64(1), res.Items[0].NodeID)
+	assert.Equal(t, "1", res.Items[0].ID)
+	assert.Equal(t, float32(10), *res.Items[0].LexicalScore)
+	assert.Equal(t, float32(0.1), *res.Items[0].SemanticScore)
+	assert.InDelta(t, 1.0/61+1.0/63, res.Items[0].Score, 1e-6)
+	assert.Equal(t, "2", res.Items[1].ID)
+	assert.Equal(t, int64(3), res.Items[2].NodeID)
+	assert.Nil(t, res.Items[2].LexicalScore)
+	assert.Equal(t, int64(2), res.Items[3].NodeID)
+	assert.Nil(t, res.Items[3].SemanticScore)
+
+	// grouped by node
+	res, err = Search(SearchQuery{Mode: SearchModeHybrid, Limit: 10}, lex, sem)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(res.Items))
+	assert.Equal(t, int64(1), res.Items[0].NodeID)
+
+	// pagination
+	res, err = Search(SearchQuery{Mode: SearchModeHybrid, GroupByPathOff: true, Offset: 1, Limit: 2}, lex, sem)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(res.Items))
+	assert.Equal(t, "2", res.Items[0].ID)
+	assert.Equal(t, int64(1), res.Items[0].NodeID)
+
+	res, err = Search(SearchQuery{Mode: SearchModeHybrid, Offset: 10, Limit: 2}, lex, sem)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(res.Items))
+}
+
+func TestSearch_HybridWeighted(t *testing.T) {
+	lex := testSearchFunc(2, testItem(1, "1", 10), testItem(2, "1", 2))
+	sem := testSearchFunc(2, testItem(2, "1", 0.9), testItem(1, "1", 0.3))
+
+	q := SearchQuery{Mode: SearchModeHybrid, Fusion: FusionWeighted, SemanticWeight: 0.8, Limit: 10}
+	res, err := Search(q, lex, sem)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(res.Items))
+	assert.Equal(t, int64(2), res.Items[0].NodeID)
+	assert.InDelta(t, 0.8, res.Items[0].Score, 1e-6)
+	assert.InDelta(t, 0.2, res.Items[1].Score, 1e-6)
+
+	q.SemanticWeight = 0.1
+	res, err = Search(q, lex, sem)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), res.Items[0].NodeID)
+	assert.InDelta(t, 0.9, res.Items[0].Score, 1e-6)
+}
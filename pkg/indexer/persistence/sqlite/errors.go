@@ -0,0 +1,41 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// recordColumns is the list of the index_record columns mapped to the persistence.IndexRecord
+const recordColumns = "id, node_id, segment, vector, format, rank_multiplier, created_at, updated_at, version, meta"
+
+// mapError maps the SQLite constraint errors to the persistence errors the same
+// way as persistence.MapError does for Postgres
+func mapError(err error) error {
+	if sqlErr, ok := err.(*sqlite.Error); ok {
+		switch sqlErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY, sqlite3.SQLITE_CONSTRAINT_TRIGGER:
+			// "on delete restrict" violations are reported as the trigger constraint
+			return fmt.Errorf("%v: %w", sqlErr.Error(), errors.ErrConflict)
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+			return fmt.Errorf("%v: %w", sqlErr.Error(), errors.ErrExist)
+		}
+	}
+	return persistence.MapError(err)
+}
@@ -0,0 +1,253 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fts
+
+import (
+	"context"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/jmoiron/sqlx"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/ql"
+	"strings"
+)
+
+const (
+	// the FTS5 external content table is kept in sync with the index_record
+	// table by the triggers
+	createSegmentFtsUp = `
+create virtual table if not exists "index_record_fts" using fts5(
+    "segment",
+    content='index_record',
+    content_rowid='rid',
+    tokenize='porter unicode61'
+);
+
+create trigger if not exists "trg_index_record_fts_insert" after insert on "index_record" begin
+    insert into "index_record_fts" (rowid, "segment") values (new."rid", new."segment");
+end;
+
+create trigger if not exists "trg_index_record_fts_delete" after delete on "index_record" begin
+    insert into "index_record_fts" ("index_record_fts", rowid, "segment") values ('delete', old."rid", old."segment");
+end;
+
+create trigger if not exists "trg_index_record_fts_update" after update of "segment" on "index_record" begin
+    insert into "index_record_fts" ("index_record_fts", rowid, "segment") values ('delete', old."rid", old."segment");
+    insert into "index_record_fts" (rowid, "segment") values (new."rid", new."segment");
+end;
+
+insert into "index_record_fts" ("index_record_fts") values ('rebuild');
+`
+	createSegmentFtsDown = `
+drop trigger if exists "trg_index_record_fts_update";
+drop trigger if exists "trg_index_record_fts_delete";
+drop trigger if exists "trg_index_record_fts_insert";
+drop table if exists "index_record_fts";
+`
+
+	// recordColumns is the list of the index_record columns mapped to the persistence.IndexRecord
+	recordColumns = "ir.id, ir.node_id, ir.segment, ir.vector, ir.format, ir.rank_multiplier, ir.meta, ir.created_at, ir.updated_at"
+)
+
+var FcTranslator = ql.NewTranslator(ql.SqliteFilterConditionsDialect)
+
+func createSegmentFts(id string, rollback bool) *migrate.Migration {
+	m := &migrate.Migration{
+		Id:   id,
+		Down: []string{createSegmentFtsDown},
+	}
+	if !rollback {
+		m.Up = []string{createSegmentFtsUp}
+	}
+	return m
+}
+
+// Migrations returns migrations to be applied on top of
+// the "common" migrations for the "fts" search module to work,
+// the "fts" module migration IDs range is [3000-3999]
+func Migrations(rollback bool) []*migrate.Migration {
+	return []*migrate.Migration{
+		createSegmentFts("3000", rollback),
+	}
+}
+
+// Search is an implementation of the search function for the SQLite FTS5 extension. The text
+// query syntax is similar to the Postgres websearch_to_tsquery(): the terms are AND-ed, the quoted
+// text is searched as a phrase, the "or" keyword joins the terms by OR and the terms prefixed by "-"
+// must not be in the record segment.
+func Search(ctx context.Context, qx sqlx.QueryerContext, q persistence.SearchQuery) (persistence.SearchQueryResult, error) {
+	ftsQuery, err := toFtsQuery(q.TextQuery)
+	if err != nil {
+		return persistence.SearchQueryResult{}, err
+	}
+
+	var fc strings.Builder
+	fc.Grow(2 * len(q.FilterConditions))
+	if err := FcTranslator.Translate(&fc, q.FilterConditions); err != nil {
+		return persistence.SearchQueryResult{}, persistence.MapError(err)
+	}
+	// the trashed nodes records are never returned
+	var sb strings.Builder
+	sb.WriteString("n.deleted_at is null and ")
+	if q.Access != nil {
+		sb.WriteString(persistence.SqliteAccessCondition(*q.Access) + " and ")
+	}
+	if fc.Len() > 0 {
+		sb.WriteString("(" + fc.String() + ") and ")
+	}
+	sb.WriteString(" index_record_fts match ? ")
+	params := []any{ftsQuery}
+
+	where := sb.String()
+	from := `index_record_fts
+		inner join index_record as ir on ir.rid = index_record_fts.rowid
+		inner join node as n on n.id = ir.node_id`
+	kwFmt := "'<<', '>>', '...', 10"
+
+	var count string
+	var query string
+
+	if q.GroupByPathOff {
+		count = fmt.Sprintf("select count(*) from %s where %s", from, where)
+
+		query = fmt.Sprintf(`select %s,
+			n.name as path,
+			(-bm25(index_record_fts)*ir.rank_multiplier) as score,
+			snippet(index_record_fts, 0, %s) as matched_keywords
+			from %s
+			where %s
+			order by score desc, ir.id
+			limit ? offset ?`, recordColumns, kwFmt, from, where)
+	} else {
+		count = fmt.Sprintf("select count(distinct ir.node_id) from %s where %s", from, where)
+
+		query = fmt.Sprintf(`with r as materialized (
+				select %s,
+				n.name as path,
+				(-bm25(index_record_fts)*ir.rank_multiplier) as score,
+				snippet(index_record_fts, 0, %s) as matched_keywords
+				from %s
+				where %s
+			)
+			select id, node_id, segment, vector, format, rank_multiplier, meta, created_at, updated_at, path, score, matched_keywords
+			from (
+				select r.*, row_number() over (partition by r.node_id order by r.score desc, r.id) as rn from r
+			)
+			where rn = 1
+			order by score desc, path, id
+			limit ? offset ?`, recordColumns, kwFmt, from, where)
+	}
+
+	// count
+	total, err := persistence.Count(ctx, qx, count, params...)
+	if err != nil {
+		return persistence.SearchQueryResult{}, persistence.MapError(err)
+	}
+
+	// query
+	if q.Limit <= 0 {
+		return persistence.SearchQueryResult{Total: total}, nil
+	}
+	params = append(params, q.Limit, q.Offset)
+	rows, err := qx.QueryxContext(ctx, query, params...)
+	if err != nil {
+		return persistence.SearchQueryResult{}, persistence.MapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	// results
+	res, err := persistence.ScanRowsQueryResultAndMap(rows,
+		persistence.MapKeywordsToListFn("<<", ">>"))
+	if err != nil {
+		return persistence.SearchQueryResult{}, persistence.MapError(err)
+	}
+	return persistence.SearchQueryResult{Items: res, Total: total}, nil
+}
+
+// toFtsQuery turns the text query into the FTS5 query string. Every term is
+// quoted, so the FTS5 syntax characters in the text query are not interpreted.
+func toFtsQuery(text string) (string, error) {
+	var terms, excluded []string
+	or := false
+	for _, t := range splitTextQuery(text) {
+		if strings.EqualFold(t, "or") {
+			or = len(terms) > 0
+			continue
+		}
+		if strings.HasPrefix(t, "-") {
+			if t = strings.Trim(t[1:], "\""); t != "" {
+				excluded = append(excluded, quote(t))
+			}
+			continue
+		}
+		if t = strings.Trim(t, "\""); t == "" {
+			continue
+		}
+		if or {
+			terms[len(terms)-1] += " OR " + quote(t)
+			or = false
+			continue
+		}
+		terms = append(terms, quote(t))
+	}
+	if len(terms) == 0 {
+		return "", fmt.Errorf("the text query %q must contain at least one term to search: %w", text, errors.ErrInvalid)
+	}
+	var sb strings.Builder
+	for i, t := range terms {
+		if i > 0 {
+			sb.WriteString(" AND ")
+		}
+		sb.WriteString("(")
+		sb.WriteString(t)
+		sb.WriteString(")")
+	}
+	for _, t := range excluded {
+		sb.WriteString(" NOT ")
+		sb.WriteString(t)
+	}
+	return sb.String(), nil
+}
+
+// splitTextQuery splits the text by spaces, keeping the quoted phrases together
+func splitTextQuery(text string) []string {
+	var res []string
+	var sb strings.Builder
+	quoted := false
+	for _, r := range text {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			sb.WriteRune(r)
+		case !quoted && (r == ' ' || r == '\t' || r == '\n' || r == '\r'):
+			if sb.Len() > 0 {
+				res = append(res, sb.String())
+				sb.Reset()
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	if sb.Len() > 0 {
+		res = append(res, sb.String())
+	}
+	return res
+}
+
+func quote(s string) string {
+	return "\"" + strings.ReplaceAll(s, "\"", "\"\"") + "\""
+}
@@ -0,0 +1,476 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	migrate "github.com/rubenv/sql-migrate"
+	"github.com/simila-io/simila/pkg/indexer/persistence/sqlite/fts"
+)
+
+const (
+	// the index_record table has the "rid" integer primary key, which is the stable
+	// rowid of the record, so it can be referenced by the search module tables (e.g. FTS5)
+	initSchemaUp = `
+create table if not exists "format"
+(
+    "id"          varchar(255) not null,
+    "basis"       blob,
+    "created_at"  timestamp    not null default current_timestamp,
+    "updated_at"  timestamp    not null default current_timestamp,
+    primary key ("id")
+);
+
+create table if not exists "node"
+(
+    "id"         integer       not null primary key autoincrement,
+    "path"       varchar(1024) not null,
+    "name"       varchar(1024) not null,
+    "tags"       text          not null default '{}',
+    "flags"      integer       not null default 0,
+    "created_at" timestamp     not null default current_timestamp,
+    "updated_at" timestamp     not null default current_timestamp,
+    unique("name")
+);
+
+create index if not exists "idx_node_flags" on "node" ("flags");
+create index if not exists "idx_node_created_at" on "node" ("created_at");
+create index if not exists "idx_node_path" on "node" ("path");
+
+create table if not exists "index_record"
+(
+    "rid"             integer       not null primary key autoincrement,
+    "id"              varchar(255)  not null,
+    "node_id"         integer       not null references "node" ("id") on delete cascade,
+    "segment"         text          not null,
+    "vector"          blob,
+    "format"          varchar(255)  not null references "format" ("id") on delete restrict,
+    "rank_multiplier" real          not null default 1.0,
+    "created_at"      timestamp     not null default current_timestamp,
+    "updated_at"      timestamp     not null default current_timestamp,
+    unique ("node_id", "id")
+);
+
+create index if not exists "idx_index_record_node_id" on "index_record" ("node_id");
+create index if not exists "idx_index_record_format" on "index_record" ("format");
+create index if not exists "idx_index_record_created_at" on "index_record" ("created_at");
+`
+	initSchemaDown = `
+drop table if exists "index_record";
+drop table if exists "node";
+drop table if exists "format";
+`
+
+	addTxtFormatUp = `
+insert into format (id) values('txt') on conflict do nothing;
+`
+	addTxtFormatDown = `
+delete from format where id='txt';
+`
+
+	addNodeDeletedAtUp = `
+alter table "node" add column "deleted_at" timestamp;
+create index if not exists "idx_node_deleted_at" on "node" ("deleted_at");
+`
+	addNodeDeletedAtDown = `
+drop index if exists "idx_node_deleted_at";
+alter table "node" drop column "deleted_at";
+`
+
+	addRecordHistoryUp = `
+alter table "format" add column "keep_history" boolean not null default 0;
+
+create table if not exists "index_record_history"
+(
+    "revision"        integer      not null primary key autoincrement,
+    "id"              varchar(255) not null,
+    "node_id"         integer      not null references "node" ("id") on delete cascade,
+    "segment"         text         not null,
+    "vector"          blob,
+    "format"          varchar(255) not null references "format" ("id") on delete restrict,
+    "rank_multiplier" real         not null default 1.0,
+    "created_at"      timestamp    not null,
+    "updated_at"      timestamp    not null,
+    "replaced_at"     timestamp    not null,
+    "deleted"         boolean      not null default 0
+);
+
+create index if not exists "idx_index_record_history_node_id_id" on "index_record_history" ("node_id", "id");
+create index if not exists "idx_index_record_history_replaced_at" on "index_record_history" ("replaced_at");
+`
+	addRecordHistoryDown = `
+drop table if exists "index_record_history";
+alter table "format" drop column "keep_history";
+`
+
+	createChangelogUp = `
+create table if not exists "changelog"
+(
+    "id"         integer       not null primary key autoincrement,
+    "object"     varchar(32)   not null,
+    "op"         varchar(32)   not null,
+    "node_id"    integer       not null,
+    "path"       varchar(1024) not null,
+    "name"       varchar(1024) not null,
+    "tags"       text          not null default '{}',
+    "flags"      integer       not null default 0,
+    "record_id"  varchar(255)  not null default '',
+    "format"     varchar(255)  not null default '',
+    "created_at" timestamp     not null default current_timestamp
+);
+
+create index if not exists "idx_changelog_created_at" on "changelog" ("created_at");
+`
+	createChangelogDown = `
+drop table if exists "changelog";
+`
+
+	createWebhooksUp = `
+create table if not exists "webhook"
+(
+    "id"         varchar(255)  not null primary key,
+    "url"        varchar(2048) not null,
+    "secret"     varchar(255)  not null default '',
+    "path"       varchar(1024) not null default '/',
+    "events"     text          not null default '[]',
+    "cursor"     integer       not null default 0,
+    "created_at" timestamp     not null default current_timestamp,
+    "updated_at" timestamp     not null default current_timestamp
+);
+
+create table if not exists "webhook_dead_letter"
+(
+    "id"         integer      not null primary key autoincrement,
+    "webhook_id" varchar(255) not null references "webhook" ("id") on delete cascade,
+    "change_id"  integer      not null,
+    "event"      varchar(64)  not null,
+    "payload"    text         not null,
+    "error"      text         not null default '',
+    "attempts"   integer      not null default 0,
+    "created_at" timestamp    not null default current_timestamp
+);
+
+create index if not exists "idx_webhook_dead_letter_webhook_id" on "webhook_dead_letter" ("webhook_id");
+`
+	createWebhooksDown = `
+drop table if exists "webhook_dead_letter";
+drop table if exists "webhook";
+`
+
+	addVersionsUp = `
+alter table "node" add column "version" integer not null default 1;
+alter table "index_record" add column "version" integer not null default 1;
+alter table "index_record_history" add column "version" integer not null default 1;
+`
+	addVersionsDown = `
+alter table "index_record_history" drop column "version";
+alter table "index_record" drop column "version";
+alter table "node" drop column "version";
+`
+
+	createIdempotencyKeysUp = `
+create table if not exists "idempotency_key"
+(
+    "key"         varchar(255) not null primary key,
+    "fingerprint" varchar(255) not null,
+    "done"        boolean      not null default false,
+    "response"    blob,
+    "created_at"  timestamp    not null default current_timestamp
+);
+
+create index if not exists "idx_idempotency_key_created_at" on "idempotency_key" ("created_at");
+`
+	createIdempotencyKeysDown = `
+drop table if exists "idempotency_key";
+`
+
+	createJobsUp = `
+create table if not exists "job"
+(
+    "id"         integer       not null primary key autoincrement,
+    "node_id"    integer       not null,
+    "path"       varchar(1024) not null,
+    "parser"     varchar(255)  not null,
+    "status"     varchar(32)   not null,
+    "records"    integer       not null default 0,
+    "error"      text          not null default '',
+    "created_at" timestamp     not null default current_timestamp,
+    "updated_at" timestamp     not null default current_timestamp
+);
+
+create index if not exists "idx_job_status" on "job" ("status");
+`
+	createJobsDown = `
+drop table if exists "job";
+`
+
+	// the attributes indexes are created by CreateAttrSchema, they must be dropped
+	// (the attributes declarations deleted) before the attrs columns can be dropped
+	addNodeAttrsUp = `
+alter table "node" add column "attrs" text not null default '{}';
+alter table "changelog" add column "attrs" text not null default '{}';
+
+create table if not exists "attr_schema"
+(
+    "prefix"     varchar(1024) not null,
+    "name"       varchar(255)  not null,
+    "type"       varchar(32)   not null,
+    "created_at" timestamp     not null default current_timestamp,
+    primary key ("prefix", "name")
+);
+`
+	addNodeAttrsDown = `
+drop table if exists "attr_schema";
+alter table "changelog" drop column "attrs";
+alter table "node" drop column "attrs";
+`
+
+	addRecordMetaUp = `
+alter table "index_record" add column "meta" text not null default '{}';
+alter table "index_record_history" add column "meta" text not null default '{}';
+alter table "changelog" add column "meta" text not null default '{}';
+`
+	addRecordMetaDown = `
+alter table "changelog" drop column "meta";
+alter table "index_record_history" drop column "meta";
+alter table "index_record" drop column "meta";
+`
+
+	addEffectiveTagsUp = `
+alter table "node" add column "effective_tags" text not null default '{}';
+alter table "changelog" add column "effective_tags" text not null default '{}';
+` + effectiveTagsUpdate + ` where true;
+`
+	addEffectiveTagsDown = `
+alter table "changelog" drop column "effective_tags";
+alter table "node" drop column "effective_tags";
+`
+
+	addNodeACLUp = `
+alter table "node" add column "acl" text not null default '{}';
+alter table "node" add column "effective_acl" text not null default '{}';
+`
+	addNodeACLDown = `
+alter table "node" drop column "effective_acl";
+alter table "node" drop column "acl";
+`
+
+	addIdempotencyKeyLeaseUp = `
+alter table "idempotency_key" add column "locked_until" timestamp not null default '1970-01-01 00:00:00+00:00';
+`
+	addIdempotencyKeyLeaseDown = `
+alter table "idempotency_key" drop column "locked_until";
+`
+
+	addJobLockUp = `
+alter table "job" add column "locked_by" varchar(255) not null default '';
+alter table "job" add column "locked_until" timestamp not null default '1970-01-01 00:00:00+00:00';
+`
+	addJobLockDown = `
+alter table "job" drop column "locked_until";
+alter table "job" drop column "locked_by";
+`
+
+	addWebhookLockUp = `
+alter table "webhook" add column "attempts" integer not null default 0;
+alter table "webhook" add column "next_attempt_at" timestamp not null default '1970-01-01 00:00:00+00:00';
+alter table "webhook" add column "locked_by" varchar(255) not null default '';
+alter table "webhook" add column "locked_until" timestamp not null default '1970-01-01 00:00:00+00:00';
+`
+	addWebhookLockDown = `
+alter table "webhook" drop column "locked_until";
+alter table "webhook" drop column "locked_by";
+alter table "webhook" drop column "next_attempt_at";
+alter table "webhook" drop column "attempts";
+`
+)
+
+func initSchema(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{initSchemaUp},
+		Down: []string{initSchemaDown},
+	}
+}
+
+func addTxtFormat(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addTxtFormatUp},
+		Down: []string{addTxtFormatDown},
+	}
+}
+
+func addNodeDeletedAt(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addNodeDeletedAtUp},
+		Down: []string{addNodeDeletedAtDown},
+	}
+}
+
+func addRecordHistory(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addRecordHistoryUp},
+		Down: []string{addRecordHistoryDown},
+	}
+}
+
+func createChangelog(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{createChangelogUp},
+		Down: []string{createChangelogDown},
+	}
+}
+
+func createWebhooks(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{createWebhooksUp},
+		Down: []string{createWebhooksDown},
+	}
+}
+
+func addVersions(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addVersionsUp},
+		Down: []string{addVersionsDown},
+	}
+}
+
+func createIdempotencyKeys(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{createIdempotencyKeysUp},
+		Down: []string{createIdempotencyKeysDown},
+	}
+}
+
+func createJobs(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{createJobsUp},
+		Down: []string{createJobsDown},
+	}
+}
+
+func addNodeAttrs(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addNodeAttrsUp},
+		Down: []string{addNodeAttrsDown},
+	}
+}
+
+func addRecordMeta(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addRecordMetaUp},
+		Down: []string{addRecordMetaDown},
+	}
+}
+
+func addEffectiveTags(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addEffectiveTagsUp},
+		Down: []string{addEffectiveTagsDown},
+	}
+}
+
+func addNodeACL(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addNodeACLUp},
+		Down: []string{addNodeACLDown},
+	}
+}
+
+func addIdempotencyKeyLease(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addIdempotencyKeyLeaseUp},
+		Down: []string{addIdempotencyKeyLeaseDown},
+	}
+}
+
+func addJobLock(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addJobLockUp},
+		Down: []string{addJobLockDown},
+	}
+}
+
+func addWebhookLock(id string) *migrate.Migration {
+	return &migrate.Migration{
+		Id:   id,
+		Up:   []string{addWebhookLockUp},
+		Down: []string{addWebhookLockDown},
+	}
+}
+
+// migrations returns the "common" migrations, the range of
+// "common" migrations IDs [0-999] the same as for postgres
+func migrations() []*migrate.Migration {
+	return []*migrate.Migration{
+		initSchema("0"),
+		addTxtFormat("1"),
+		addNodeDeletedAt("2"),
+		addRecordHistory("3"),
+		createChangelog("4"),
+		createWebhooks("5"),
+		addVersions("6"),
+		createIdempotencyKeys("7"),
+		createJobs("8"),
+		addNodeAttrs("9"),
+		addRecordMeta("10"),
+		addEffectiveTags("11"),
+		addNodeACL("12"),
+		addIdempotencyKeyLease("13"),
+		addJobLock("14"),
+		addWebhookLock("15"),
+	}
+}
+
+func migrateFtsUp(ctx context.Context, db *sql.DB) error {
+	var migrs []*migrate.Migration
+	migrs = append(migrs, migrations()...)
+	migrs = append(migrs, fts.Migrations(false)...)
+	mms := migrate.MemoryMigrationSource{
+		Migrations: migrs,
+	}
+	if _, err := migrate.ExecContext(ctx, db, "sqlite3", mms, migrate.Up); err != nil {
+		return err
+	}
+	return nil
+}
+
+func migrateFtsDown(ctx context.Context, db *sql.DB) error {
+	var migrs []*migrate.Migration
+	migrs = append(migrs, migrations()...)
+	migrs = append(migrs, fts.Migrations(true)...)
+	mms := migrate.MemoryMigrationSource{
+		Migrations: migrs,
+	}
+	if _, err := migrate.ExecContext(ctx, db, "sqlite3", mms, migrate.Down); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,1591 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/acquirecloud/golibs/logging"
+	"github.com/jmoiron/sqlx"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/ql"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type (
+	// Db implements persistence.Db
+	Db struct {
+		logger logging.Logger
+		dbe    dbExt
+		db     *sqlx.DB
+	}
+
+	dbExt struct {
+		searchFn SearchFn
+		tr       ql.Translator
+	}
+
+	// SearchFn is used to provide different search implementations
+	SearchFn func(ctx context.Context, qx sqlx.QueryerContext, q persistence.SearchQuery) (persistence.SearchQueryResult, error)
+
+	// exec is a helper interface to provide joined functionality of sqlx.DB and sqlx.Tx
+	// it is used by the tx.executor()
+	exec interface {
+		sqlx.QueryerContext
+		sqlx.Ext
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+		QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	}
+
+	// tx implements the Tx interface
+	tx struct {
+		ctx context.Context // context for all the operations within the tx
+		db  *sqlx.DB        // never nil
+		tx  *sqlx.Tx        // keeps active transaction, if it exists. It can be nil, if not started.
+	}
+
+	// modelTx is a helper to persist persistence objects ModelTx
+	modelTx struct {
+		dbe dbExt
+		*tx // the active transaction, never nil for the object
+	}
+)
+
+func newDb(sdb *sqlx.DB, dbe dbExt) *Db {
+	return &Db{db: sdb, dbe: dbe, logger: logging.NewLogger("db.sqlite")}
+}
+
+// Init implements linker.Initializer interface
+func (d *Db) Init(ctx context.Context) error {
+	d.logger.Infof("Initializing...")
+	return nil
+}
+
+// Shutdown implements linker.Shutdowner interface
+func (d *Db) Shutdown() {
+	d.logger.Infof("Shutdown")
+	if d.db == nil {
+		d.logger.Errorf("not initialized, but shutting down")
+		return
+	}
+	err := d.db.Close()
+	if err != nil {
+		d.logger.Warnf("could not close the DB connection: %v", err)
+	}
+}
+
+// NewModelTx returns the new ModelTx object
+func (d *Db) NewModelTx(ctx context.Context) persistence.ModelTx {
+	return &modelTx{tx: d.NewTx(ctx).(*tx), dbe: d.dbe}
+}
+
+// NewTx returns the new Tx object
+func (d *Db) NewTx(ctx context.Context) persistence.Tx {
+	return &tx{ctx: ctx, db: d.db}
+}
+
+// ============================== tx ====================================
+
+func (t *tx) executor() exec {
+	if t.tx == nil {
+		return t.db
+	}
+	return t.tx
+}
+
+// MustBegin is a part of the Tx interface
+func (t *tx) MustBegin() {
+	_ = t.Commit()
+	t.tx = t.db.MustBeginTx(t.ctx, nil)
+}
+
+// MustBeginSerializable is a part of the Tx interface. SQLite transactions are
+// always serializable, so it is the same as MustBegin
+func (t *tx) MustBeginSerializable() {
+	t.MustBegin()
+}
+
+// Rollback rolls the transaction bock (if started)
+func (t *tx) Rollback() error {
+	var err error
+	if t.tx != nil {
+		err = t.tx.Rollback()
+		t.tx = nil
+	}
+	return err
+}
+
+func (t *tx) Commit() error {
+	var err error
+	if t.tx != nil {
+		err = t.tx.Commit()
+		t.tx = nil
+	}
+	return err
+}
+
+// ExecQuery executes a query with params within the transaction
+func (t *tx) execQuery(sqlQuery string, params ...interface{}) error {
+	_, err := t.executor().ExecContext(t.ctx, sqlQuery, params...)
+	return err
+}
+
+// ExecScript runs the sqlScript (file name)
+func (t *tx) ExecScript(sqlScript string) error {
+	file, err := os.ReadFile(sqlScript)
+	if err != nil {
+		return fmt.Errorf("could not read %s in ExecScript: %w", sqlScript, err)
+	}
+
+	requests := strings.Split(string(file), ";")
+	for _, request := range requests {
+		if strings.Trim(request, " ") == "" {
+			continue
+		}
+		if err = t.execQuery(request); err != nil {
+			return fmt.Errorf("could not execute %s in ExecScript: %w", request, err)
+		}
+	}
+	return nil
+}
+
+// ============================== modelTx ====================================
+
+func (m *modelTx) CreateFormat(format persistence.Format) (persistence.Format, error) {
+	if len(format.ID) == 0 {
+		return persistence.Format{}, fmt.Errorf("format ID must be non-empty: %w", errors.ErrInvalid)
+	}
+	if len(format.Basis) == 0 {
+		format.Basis = []byte("{}")
+	}
+	format.CreatedAt = time.Now().UTC()
+	format.UpdatedAt = format.CreatedAt
+	_, err := m.executor().ExecContext(m.ctx, "insert into format (id, basis, keep_history, created_at, updated_at) values (?, ?, ?, ?, ?)",
+		format.ID, format.Basis, format.KeepHistory, format.CreatedAt, format.UpdatedAt)
+	if err != nil {
+		return persistence.Format{}, mapError(err)
+	}
+	return format, nil
+}
+
+func (m *modelTx) GetFormat(ID string) (persistence.Format, error) {
+	var f persistence.Format
+	return f, mapError(m.executor().GetContext(m.ctx, &f, "select * from format where id=?", ID))
+}
+
+func (m *modelTx) DeleteFormat(ID string) error {
+	res, err := m.executor().ExecContext(m.ctx, "delete from format where id=?", ID)
+	if err != nil {
+		return mapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return errors.ErrNotExist
+	}
+	return nil
+}
+
+func (m *modelTx) ListFormats() ([]persistence.Format, error) {
+	rows, err := m.executor().QueryxContext(m.ctx, "select * from format order by id")
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return persistence.ScanRows[persistence.Format](rows)
+}
+
+func (m *modelTx) CreateNodes(nodes ...persistence.Node) ([]persistence.Node, error) {
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+
+	var sb strings.Builder
+	var params []any
+	var names strings.Builder
+	var nameParams []any
+
+	sb.WriteString("insert into node (path, name, tags, attrs, acl, flags, created_at, updated_at) values ")
+	now := time.Now().UTC()
+
+	for i, n := range nodes {
+		if len(n.Path) == 0 {
+			return nil, fmt.Errorf("node path for item=%d must be specified: %w", i, errors.ErrInvalid)
+		}
+		n.Path = persistence.ToNodePath(n.Path)
+		var err error
+		n.Name, err = persistence.CleanName(n.Name)
+		if err != nil {
+			return nil, err
+		}
+		if n.Tags == nil {
+			n.Tags = make(persistence.Tags)
+		}
+		if i > 0 {
+			sb.WriteString(",")
+			names.WriteString(",")
+		}
+
+		sb.WriteString("(?, ?, ?, ?, ?, ?, ?, ?)")
+		names.WriteString("?")
+		nameParams = append(nameParams, persistence.ConcatPath(n.Path, n.Name))
+
+		params = append(params, n.Path)
+		params = append(params, persistence.ConcatPath(n.Path, n.Name))
+		params = append(params, n.Tags.JSON())
+		params = append(params, n.Attrs.JSON())
+		params = append(params, n.ACL.JSON())
+		params = append(params, n.Flags)
+		params = append(params, now)
+		params = append(params, now)
+	}
+	sb.WriteString(" returning *")
+
+	// the trashed nodes keep their names until they are restored or purged
+	var trashed string
+	if err := mapError(m.executor().GetContext(m.ctx, &trashed, "select name from node where name in ("+names.String()+") and deleted_at is not null limit 1", nameParams...)); err == nil {
+		return nil, fmt.Errorf("node with name=%s is in the trash, it must be restored or purged first: %w", trashed, errors.ErrExist)
+	} else if !errors.Is(err, errors.ErrNotExist) {
+		return nil, err
+	}
+	rows, err := m.executor().QueryxContext(m.ctx, sb.String(), params...)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	res, err := scanNodes(rows)
+	if err != nil {
+		return nil, err
+	}
+	inh, err := m.updateInherited(nodeIDs(res))
+	if err != nil {
+		return nil, err
+	}
+	for i := range res {
+		res[i].EffectiveTags, res[i].EffectiveACL = inh[res[i].ID].EffectiveTags, inh[res[i].ID].EffectiveACL
+	}
+	return res, m.logNodeChangesByIDs(persistence.ChangeOpCreate, nodeIDs(res))
+}
+
+func (m *modelTx) ListNodes(query persistence.ListNodesQuery) ([]persistence.Node, error) {
+	var sb strings.Builder
+	if err := m.dbe.tr.Translate(&sb, query.FilterConditions); err != nil {
+		return nil, err
+	}
+	rows, err := m.executor().QueryxContext(m.ctx, fmt.Sprintf("select * from node as n where n.deleted_at is null and (%s)%s order by n.name limit ? offset ?",
+		sb.String(), accessCond(query.Access)), query.Limit, query.Offset)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return scanNodes(rows)
+}
+
+// ListAllNodesByPath returns all nodes for the path. For example for the path="/a/b/doc.txt"
+// the result nodes will be {<"/", "a">, {<"/a/", "b">, <"/a/b/", "doc.txt">}
+func (m *modelTx) ListAllNodesByPath(path string) ([]persistence.Node, error) {
+	var sb strings.Builder
+	var args []any
+	pathSoFar := "/"
+	names := persistence.SplitPath(path)
+	for _, n := range names {
+		if sb.Len() > 0 {
+			sb.WriteString(" or ")
+		}
+		sb.WriteString("name = ?")
+		pathSoFar = persistence.ConcatPath(pathSoFar, n)
+		args = append(args, pathSoFar)
+	}
+	if sb.Len() == 0 {
+		return nil, nil
+	}
+
+	rows, err := m.executor().QueryxContext(m.ctx, fmt.Sprintf("select * from node where deleted_at is null and (%s) order by name", sb.String()), args...)
+	if err != nil {
+		return nil, mapError(err)
+
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return scanNodes(rows)
+}
+
+func (m *modelTx) GetNode(fqnp string) (persistence.Node, error) {
+	var node persistence.Node
+	if err := m.executor().GetContext(m.ctx, &node, "select * from node where name = ? and deleted_at is null", fqnp); err != nil {
+		return persistence.Node{}, mapError(err)
+	}
+	cleanNameAfterRead(&node)
+	return node, nil
+}
+
+func (m *modelTx) LockNode(fqnp string) (persistence.Node, error) {
+	// the database connection is used by one transaction at a time, so the node is locked by the transaction
+	return m.GetNode(fqnp)
+}
+
+func (m *modelTx) UpdateNode(node persistence.Node) error {
+	if node.ID == 0 {
+		return fmt.Errorf("node ID must be specified: %w", errors.ErrInvalid)
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("update node set")
+
+	var args []any
+	if len(node.Tags) > 0 {
+		sb.WriteString(" tags = ?")
+		args = append(args, node.Tags.JSON())
+	}
+	if node.Attrs != nil {
+		if len(args) > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(" attrs = ?")
+		args = append(args, node.Attrs.JSON())
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	sb.WriteString(", updated_at = ?, version = version + 1 where id = ? and deleted_at is null")
+	args = append(args, time.Now().UTC(), node.ID)
+
+	res, err := m.executor().ExecContext(m.ctx, sb.String(), args...)
+	if err != nil {
+		return mapError(err)
+	}
+	rows, _ := res.RowsAffected()
+	if rows == 0 {
+		return errors.ErrNotExist
+	}
+	if len(node.Tags) > 0 {
+		if _, err = m.updateInherited([]int64{node.ID}); err != nil {
+			return err
+		}
+	}
+	return m.logNodeChanges(persistence.ChangeOpUpdate, "", "n.id = ?", node.ID)
+}
+
+func (m *modelTx) PatchNodes(query persistence.PatchNodesQuery) (int64, error) {
+	if err := query.Check(); err != nil {
+		return 0, err
+	}
+	var rows *sqlx.Rows
+	var err error
+	if query.ID != 0 {
+		rows, err = m.executor().QueryxContext(m.ctx, "select n.id from node as n where n.id = ? and n.deleted_at is null"+accessCond(query.Access), query.ID)
+	} else {
+		var sb strings.Builder
+		if err = m.dbe.tr.Translate(&sb, query.FilterConditions); err != nil {
+			return 0, err
+		}
+		rows, err = m.executor().QueryxContext(m.ctx, "select distinct n.id from node as n left join index_record as ir on ir.node_id = n.id "+
+			"where n.deleted_at is null and ("+sb.String()+")"+accessCond(query.Access)+" order by n.id")
+	}
+	if err != nil {
+		return 0, mapError(err)
+	}
+	ids, err := scanIDs(rows)
+	_ = rows.Close()
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, errors.ErrNotExist
+	}
+
+	// the removed keys are set to null in the JSON merge patch, so they are removed by the json_patch
+	patch := make(map[string]any, len(query.Patch.Remove)+len(query.Patch.Set))
+	for _, k := range query.Patch.Remove {
+		patch[k] = nil
+	}
+	for k, v := range query.Patch.Set {
+		patch[k] = v
+	}
+	pb, err := json.Marshal(patch)
+	if err != nil {
+		return 0, err
+	}
+	tags := "tags"
+	if query.Patch.Clear {
+		tags = "'{}'"
+	}
+	var attrs any
+	if query.Attrs != nil {
+		attrs = query.Attrs.JSON()
+	}
+	now := time.Now().UTC()
+	for _, chunk := range chunkIDs(ids) {
+		where, args := idsCond("id", chunk)
+		if _, err = m.executor().ExecContext(m.ctx, "update node set tags = json_patch("+tags+", ?), attrs = coalesce(?, attrs), updated_at = ?, "+
+			"version = version + 1 where "+where, append([]any{string(pb), attrs, now}, args...)...); err != nil {
+			return 0, mapError(err)
+		}
+	}
+	if _, err = m.updateInherited(ids); err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), m.logNodeChangesByIDs(persistence.ChangeOpUpdate, ids)
+}
+
+func (m *modelTx) SetNodeACL(fqnp, principal string, perm persistence.Permission) (persistence.Node, error) {
+	if err := persistence.CheckACLEntry(principal, perm); err != nil {
+		return persistence.Node{}, err
+	}
+	node, err := m.GetNode(fqnp)
+	if err != nil {
+		return persistence.Node{}, err
+	}
+	// the principal is removed by the null value in the JSON merge patch
+	patch := map[string]any{principal: nil}
+	if perm != "" {
+		patch[principal] = perm
+	}
+	pb, err := json.Marshal(patch)
+	if err != nil {
+		return persistence.Node{}, err
+	}
+	if _, err = m.executor().ExecContext(m.ctx, "update node set acl = json_patch(acl, ?) where id = ?", string(pb), node.ID); err != nil {
+		return persistence.Node{}, mapError(err)
+	}
+	if _, err = m.updateInherited([]int64{node.ID}); err != nil {
+		return persistence.Node{}, err
+	}
+	return m.GetNode(fqnp)
+}
+
+func (m *modelTx) MoveNode(from, to string) (persistence.Node, error) {
+	from, to = persistence.ConcatPath(from, ""), persistence.ConcatPath(to, "")
+	node, err := m.GetNode(from)
+	if err != nil {
+		return persistence.Node{}, err
+	}
+	if from == to {
+		return node, nil
+	}
+	path, err := m.targetPath(from, to)
+	if err != nil {
+		return persistence.Node{}, err
+	}
+
+	// the node and its descendants fqnps are prefixed by the from fqnp, which is replaced by the to one
+	now := time.Now().UTC()
+	prefixLen := utf8.RuneCountInString(from)
+	_, err = m.executor().ExecContext(m.ctx, "update node set "+
+		"path = case when id = ? then ? else ? || substr(path, ?) end, "+
+		"name = ? || substr(name, ?), updated_at = ?, version = version + 1 "+
+		"where id = ? or substr(path, 1, ?) = ?",
+		node.ID, path, to, prefixLen+1, to, prefixLen+1, now, node.ID, prefixLen+1, from+"/")
+	if err != nil {
+		return persistence.Node{}, mapError(err)
+	}
+	inh, err := m.updateInherited([]int64{node.ID})
+	if err != nil {
+		return persistence.Node{}, err
+	}
+	err = m.logNodeChanges(persistence.ChangeOpUpdate, "", "n.id = ? or substr(n.path, 1, ?) = ?",
+		node.ID, utf8.RuneCountInString(to)+1, to+"/")
+	if err != nil {
+		return persistence.Node{}, err
+	}
+	node.Path, node.Name = persistence.ToNodePathName(to)
+	node.EffectiveTags, node.EffectiveACL = inh[node.ID].EffectiveTags, inh[node.ID].EffectiveACL
+	node.UpdatedAt = now
+	node.Version++
+	return node, nil
+}
+
+func (m *modelTx) CopyNodes(query persistence.CopyNodesQuery) (persistence.CopyNodesResult, error) {
+	from, to := persistence.ConcatPath(query.From, ""), persistence.ConcatPath(query.To, "")
+	node, err := m.GetNode(from)
+	if err != nil {
+		return persistence.CopyNodesResult{}, err
+	}
+	path, err := m.targetPath(from, to)
+	if err != nil {
+		return persistence.CopyNodesResult{}, err
+	}
+	var tags any
+	if query.Tags != nil {
+		tags = query.Tags.JSON()
+	}
+
+	// the copies fqnps are the originals ones with the from prefix replaced by the to one, the copies
+	// ACLs are empty, so they inherit the destination ACL instead of the grants of the originals
+	now := time.Now().UTC()
+	prefixLen := utf8.RuneCountInString(from)
+	rows, err := m.executor().QueryxContext(m.ctx, "insert into node (path, name, tags, attrs, flags, created_at, updated_at) "+
+		"select case when id = ? then ? else ? || substr(path, ?) end, ? || substr(name, ?), "+
+		"coalesce(?, tags), attrs, flags, ?, ? from node where (id = ? or substr(path, 1, ?) = ?) and deleted_at is null returning *",
+		node.ID, path, to, prefixLen+1, to, prefixLen+1, tags, now, now, node.ID, prefixLen+1, from+"/")
+	if err != nil {
+		return persistence.CopyNodesResult{}, mapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	nodes, err := scanNodes(rows)
+	if err != nil {
+		return persistence.CopyNodesResult{}, mapError(err)
+	}
+
+	res, err := m.executor().ExecContext(m.ctx, fmt.Sprintf("insert into index_record (%s) "+
+		"select ir.id, dn.id, ir.segment, ir.vector, ir.format, ir.rank_multiplier, ?, ?, 1, ir.meta "+
+		"from index_record as ir inner join node as sn on sn.id = ir.node_id "+
+		"inner join node as dn on dn.name = ? || substr(sn.name, ?) "+
+		"where (sn.id = ? or substr(sn.path, 1, ?) = ?) and sn.deleted_at is null", recordColumns),
+		now, now, to, prefixLen+1, node.ID, prefixLen+1, from+"/")
+	if err != nil {
+		return persistence.CopyNodesResult{}, mapError(err)
+	}
+	recs, _ := res.RowsAffected()
+
+	ids := nodeIDs(nodes)
+	inh, err := m.updateInherited(ids)
+	if err != nil {
+		return persistence.CopyNodesResult{}, err
+	}
+	for i := range nodes {
+		nodes[i].EffectiveTags, nodes[i].EffectiveACL = inh[nodes[i].ID].EffectiveTags, inh[nodes[i].ID].EffectiveACL
+	}
+	if err = m.logNodeChangesByIDs(persistence.ChangeOpCreate, ids); err != nil {
+		return persistence.CopyNodesResult{}, err
+	}
+	for _, chunk := range chunkIDs(ids) {
+		where, args := idsCond("ir.node_id", chunk)
+		if err = m.logRecordChanges(changeOp(persistence.ChangeOpCreate), where, args...); err != nil {
+			return persistence.CopyNodesResult{}, err
+		}
+	}
+
+	var root persistence.Node
+	for _, n := range nodes {
+		if persistence.ConcatPath(n.Path, n.Name) == to {
+			root = n
+		}
+	}
+	return persistence.CopyNodesResult{Node: root, Nodes: int64(len(nodes)), Records: recs}, nil
+}
+
+// targetPath checks whether the node with the from fqnp can be moved or copied to the fqnp to, and
+// returns the path part of the to fqnp. The parent folder of the to fqnp must exist.
+func (m *modelTx) targetPath(from, to string) (string, error) {
+	if to == "/" {
+		return "", fmt.Errorf("the node cannot be placed to the root: %w", errors.ErrInvalid)
+	}
+	if persistence.InSubtree(from, to) {
+		return "", fmt.Errorf("the node %q cannot be placed into its own subtree %q: %w", from, to, errors.ErrInvalid)
+	}
+	path, _ := persistence.ToNodePathName(to)
+	if path == "/" {
+		return path, nil
+	}
+	parent, err := m.GetNode(persistence.ConcatPath(path, ""))
+	if err != nil {
+		return "", fmt.Errorf("could not get the parent folder %q: %w", path, err)
+	}
+	if parent.Flags&persistence.NodeFlagDocument != 0 {
+		return "", fmt.Errorf("the node cannot be placed into the document %q: %w", path, errors.ErrInvalid)
+	}
+	return path, nil
+}
+
+func (m *modelTx) DeleteNodes(query persistence.DeleteNodesQuery) error {
+	n1, children, err := m.deleteNodesSql(query)
+	if err != nil {
+		return err
+	}
+	if err = m.checkDeleteAccess(query, n1, children); err != nil {
+		return err
+	}
+	if !query.Force {
+		found, err := m.hasUnmatchedChildren(n1, children)
+		if err != nil {
+			return err
+		}
+		if found {
+			return fmt.Errorf("matched nodes have children that do not match the condition (force=%t): %w",
+				query.Force, errors.ErrConflict)
+		}
+	}
+	// the records of the deleted nodes are deleted with them, so the node changes only are logged
+	if err = m.logNodeChanges(persistence.ChangeOpDelete, n1, "n.id in ("+deleteTargets(children, query.Trash)+")", persistence.NodeFlagFolder); err != nil {
+		return err
+	}
+	var res sql.Result
+	if query.Trash {
+		res, err = m.executor().ExecContext(m.ctx, n1+"update node set deleted_at = ? where deleted_at is null and (id in (select id from n1) or id in ("+children+"))",
+			time.Now().UTC(), persistence.NodeFlagFolder)
+	} else {
+		res, err = m.executor().ExecContext(m.ctx, n1+"delete from node where id in (select id from n1) or id in ("+children+")",
+			persistence.NodeFlagFolder)
+	}
+	if err != nil {
+		return mapError(err)
+	}
+	if cnt, _ := res.RowsAffected(); cnt == 0 {
+		return errors.ErrNotExist
+	}
+	return nil
+}
+
+func (m *modelTx) PreviewDeleteNodes(query persistence.DeleteNodesQuery) (persistence.DeleteNodesPreview, error) {
+	n1, children, err := m.deleteNodesSql(query)
+	if err != nil {
+		return persistence.DeleteNodesPreview{}, err
+	}
+	if err = m.checkDeleteAccess(query, n1, children); err != nil {
+		return persistence.DeleteNodesPreview{}, err
+	}
+	var res persistence.DeleteNodesPreview
+	if res.ForceRequired, err = m.hasUnmatchedChildren(n1, children); err != nil {
+		return persistence.DeleteNodesPreview{}, err
+	}
+
+	targets := deleteTargets(children, query.Trash)
+	if res.Total, err = persistence.Count(m.ctx, m.executor(), n1+"select count(*) from node where id in ("+targets+")", persistence.NodeFlagFolder); err != nil {
+		return persistence.DeleteNodesPreview{}, err
+	}
+	if res.Records, err = persistence.Count(m.ctx, m.executor(), n1+"select count(*) from index_record where node_id in ("+targets+")", persistence.NodeFlagFolder); err != nil {
+		return persistence.DeleteNodesPreview{}, err
+	}
+	rows, err := m.executor().QueryxContext(m.ctx, n1+"select * from node where id in ("+targets+") order by name limit ? offset ?",
+		persistence.NodeFlagFolder, query.Limit, query.Offset)
+	if err != nil {
+		return persistence.DeleteNodesPreview{}, mapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	if res.Nodes, err = scanNodes(rows); err != nil {
+		return persistence.DeleteNodesPreview{}, mapError(err)
+	}
+	return res, nil
+}
+
+// deleteNodesSql returns the n1 common table expression, which contains the nodes matching the
+// DeleteNodesQuery filter, and the query selecting the children of the matched folders. The children
+// are the nodes which path starts with the folder name followed by '/'. The trashed nodes are not
+// matched, they are deleted together with their live ancestors only.
+func (m *modelTx) deleteNodesSql(query persistence.DeleteNodesQuery) (string, string, error) {
+	var sb strings.Builder
+	if err := m.dbe.tr.Translate(&sb, query.FilterConditions); err != nil {
+		return "", "", err
+	}
+	n1 := "with n1 as (select distinct n.id, n.name, n.flags from node as n left join index_record as ir on ir.node_id = n.id where n.deleted_at is null and (" +
+		sb.String() + ")" + accessCond(query.Access) + ") "
+	children := "select n2.id from node as n2, n1 where n1.flags = ? and substr(n2.path, 1, length(n1.name) + 1) = n1.name || '/'"
+	return n1, children, nil
+}
+
+// deleteTargets returns the query, which selects the IDs of the nodes to be deleted by the DeleteNodes
+// for the n1 common table expression and the children query returned by the deleteNodesSql
+func deleteTargets(children string, trash bool) string {
+	targets := "select id from node where (id in (select id from n1) or id in (" + children + "))"
+	if trash {
+		targets += " and deleted_at is null"
+	}
+	return targets
+}
+
+// checkDeleteAccess returns ErrNotAuthorized if some of the nodes to be deleted by the DeleteNodes
+// are not accessible for the query, the children of the matched folders are checked as well
+func (m *modelTx) checkDeleteAccess(query persistence.DeleteNodesQuery, n1, children string) error {
+	if query.Access == nil {
+		return nil
+	}
+	cnt, err := persistence.Count(m.ctx, m.executor(), n1+"select count(*) from node as n where n.id in ("+deleteTargets(children, query.Trash)+") and not "+
+		persistence.SqliteAccessCondition(*query.Access), persistence.NodeFlagFolder)
+	if err != nil {
+		return err
+	}
+	if cnt > 0 {
+		return fmt.Errorf("%d nodes to be deleted are not accessible: %w", cnt, errors.ErrNotAuthorized)
+	}
+	return nil
+}
+
+// hasUnmatchedChildren returns whether the matched folders have live children, which are not matched
+func (m *modelTx) hasUnmatchedChildren(n1, children string) (bool, error) {
+	rows, err := m.executor().QueryxContext(m.ctx, n1+children+" and n2.deleted_at is null and n2.id not in (select id from n1) limit 1", persistence.NodeFlagFolder)
+	if err != nil {
+		return false, mapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return rows.Next(), nil
+}
+
+func (m *modelTx) ListTrash(query persistence.ListNodesQuery) ([]persistence.Node, error) {
+	var sb strings.Builder
+	if err := m.dbe.tr.Translate(&sb, query.FilterConditions); err != nil {
+		return nil, err
+	}
+	where := "n.deleted_at is not null"
+	if sb.Len() > 0 {
+		where += " and (" + sb.String() + ")"
+	}
+	rows, err := m.executor().QueryxContext(m.ctx, fmt.Sprintf("select * from node as n where %s order by n.name limit ? offset ?", where), query.Limit, query.Offset)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return scanNodes(rows)
+}
+
+func (m *modelTx) RestoreNodes(query persistence.RestoreNodesQuery) (int64, error) {
+	var sb strings.Builder
+	if err := m.dbe.tr.Translate(&sb, query.FilterConditions); err != nil {
+		return 0, err
+	}
+	if sb.Len() == 0 {
+		return 0, fmt.Errorf("the filter conditions must be specified: %w", errors.ErrInvalid)
+	}
+	// the trashed descendants of the matched folders and the trashed ancestors of the matched nodes are restored as well
+	n1 := "with n1 as (select distinct n.id, n.path, n.name, n.flags from node as n left join index_record as ir on ir.node_id = n.id where n.deleted_at is not null and (" + sb.String() + ")) "
+	rows, err := m.executor().QueryxContext(m.ctx, n1+"update node set deleted_at = null, updated_at = ? where deleted_at is not null and ("+
+		"id in (select id from n1) or "+
+		"id in (select n2.id from node as n2, n1 where n1.flags = ? and substr(n2.path, 1, length(n1.name) + 1) = n1.name || '/') or "+
+		"id in (select n2.id from node as n2, n1 where substr(n1.path, 1, length(n2.name) + 1) = n2.name || '/')) returning id",
+		time.Now().UTC(), persistence.NodeFlagFolder)
+	if err != nil {
+		return 0, mapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, errors.ErrNotExist
+	}
+	return int64(len(ids)), m.logNodeChangesByIDs(persistence.ChangeOpCreate, ids)
+}
+
+func (m *modelTx) PurgeTrash(before time.Time) (int64, error) {
+	res, err := m.executor().ExecContext(m.ctx, "delete from node where deleted_at < ?", before.UTC())
+	if err != nil {
+		return 0, mapError(err)
+	}
+	return res.RowsAffected()
+}
+
+func (m *modelTx) UpsertIndexRecords(records ...persistence.IndexRecord) (int64, error) {
+	if len(records) == 0 {
+		return 0, nil
+	}
+
+	var sb strings.Builder
+	var params []any
+
+	sb.WriteString("insert into index_record (id, node_id, segment, vector, format, rank_multiplier, meta, created_at, updated_at) values ")
+	now := time.Now().UTC()
+	for i, r := range records {
+		if len(r.ID) == 0 {
+			return 0, fmt.Errorf("record ID for item=%d  must be specified: %w", i, errors.ErrInvalid)
+		}
+		if r.NodeID == 0 {
+			return 0, fmt.Errorf("record node ID for item=%d must be specified: %w", i, errors.ErrInvalid)
+		}
+		if len(r.Format) == 0 {
+			return 0, fmt.Errorf("record format for item=%d must be specified: %w", i, errors.ErrInvalid)
+		}
+		if r.RankMult <= 0 {
+			r.RankMult = 1.0
+		}
+		if len(r.Vector) == 0 {
+			r.Vector = []byte("{}")
+		}
+		if i > 0 {
+			sb.WriteString(",")
+		}
+
+		sb.WriteString("(?, ?, ?, ?, ?, ?, ?, ?, ?)")
+
+		params = append(params, r.ID)
+		params = append(params, r.NodeID)
+		params = append(params, r.Segment)
+		params = append(params, r.Vector)
+		params = append(params, r.Format)
+		params = append(params, r.RankMult)
+		params = append(params, r.Meta.JSON())
+		params = append(params, now)
+		params = append(params, now)
+	}
+	sb.WriteString(" on conflict (node_id, id) " +
+		"do update set segment = excluded.segment, vector = excluded.vector, format = excluded.format, " +
+		"rank_multiplier = excluded.rank_multiplier, meta = excluded.meta, updated_at = excluded.updated_at, version = index_record.version + 1")
+	if err := m.keepRecordHistory(records, now, false); err != nil {
+		return 0, err
+	}
+	res, err := m.executor().ExecContext(m.ctx, sb.String(), params...)
+	if err != nil {
+		return 0, mapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	where, args := recordsCond(records)
+	if err = m.logRecordChanges(recordUpsertOp, where, args...); err != nil {
+		return 0, err
+	}
+	return cnt, m.touchNodes(records)
+}
+
+func (m *modelTx) DeleteIndexRecords(records ...persistence.IndexRecord) (int64, error) {
+	if len(records) == 0 {
+		return 0, nil
+	}
+
+	var sb strings.Builder
+	var args []any
+
+	for _, r := range records {
+		if sb.Len() > 0 {
+			sb.WriteString(" or ")
+		}
+		sb.WriteString("(node_id = ? and id = ?)")
+		args = append(args, r.NodeID, r.ID)
+	}
+	if sb.Len() == 0 {
+		return 0, nil
+	}
+
+	if err := m.keepRecordHistory(records, time.Now().UTC(), true); err != nil {
+		return 0, err
+	}
+	logWhere, logArgs := recordsCond(records)
+	if err := m.logRecordChanges(changeOp(persistence.ChangeOpDelete), logWhere, logArgs...); err != nil {
+		return 0, err
+	}
+	res, err := m.executor().ExecContext(m.ctx, fmt.Sprintf("delete from index_record where %s", sb.String()), args...)
+	if err != nil {
+		return 0, mapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return 0, errors.ErrNotExist
+	}
+	return cnt, m.touchNodes(records)
+}
+
+func (m *modelTx) QueryIndexRecords(query persistence.IndexRecordQuery) (persistence.QueryResult[persistence.IndexRecord, string], error) {
+	if query.NodeID == 0 {
+		return persistence.QueryResult[persistence.IndexRecord, string]{}, fmt.Errorf("node ID must be specified: %w", errors.ErrInvalid)
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(" node_id = ? ")
+
+	// the records as of the time are the current records updated before the time,
+	// and the history revisions, which were valid at the time
+	src := "index_record"
+	args := make([]any, 0)
+	if !query.AsOf.IsZero() {
+		asOf := query.AsOf.UTC()
+		src = fmt.Sprintf("(select %s from index_record where node_id = ? and updated_at <= ? "+
+			"union all "+
+			"select %s from index_record_history where node_id = ? and updated_at <= ? and replaced_at > ?) as ir", recordColumns, recordColumns)
+		args = append(args, query.NodeID, asOf, query.NodeID, asOf, asOf)
+	}
+	args = append(args, query.NodeID)
+
+	if len(query.FromID) > 0 {
+		if len(args) > 0 {
+			sb.WriteString(" and ")
+		}
+		sb.WriteString(" id >= ? ")
+		args = append(args, query.FromID)
+	}
+	if len(query.Format) > 0 {
+		if len(args) > 0 {
+			sb.WriteString(" and ")
+		}
+		sb.WriteString(" format = ? ")
+		args = append(args, query.Format)
+	}
+	if !query.CreatedBefore.IsZero() {
+		if len(args) > 0 {
+			sb.WriteString(" and ")
+		}
+		sb.WriteString(" created_at < ? ")
+		args = append(args, query.CreatedBefore.UTC())
+	}
+	if !query.CreatedAfter.IsZero() {
+		if len(args) > 0 {
+			sb.WriteString(" and ")
+		}
+		sb.WriteString(" created_at > ? ")
+		args = append(args, query.CreatedAfter.UTC())
+	}
+
+	var where string
+	if sb.Len() > 0 {
+		where = " where " + sb.String()
+	}
+
+	// count
+	total, err := persistence.Count(m.ctx, m.executor(), fmt.Sprintf("select count(*) from %s %s ", src, where), args...)
+	if err != nil {
+		return persistence.QueryResult[persistence.IndexRecord, string]{}, mapError(err)
+	}
+
+	// query
+	if query.Limit <= 0 {
+		return persistence.QueryResult[persistence.IndexRecord, string]{Total: total}, nil
+	}
+	args = append(args, query.Limit+1)
+	rows, err := m.executor().QueryxContext(m.ctx, fmt.Sprintf("select %s from %s %s order by id limit ?", recordColumns, src, where), args...)
+	if err != nil {
+		return persistence.QueryResult[persistence.IndexRecord, string]{Total: total}, mapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	// results
+	res, err := persistence.ScanRowsQueryResult[persistence.IndexRecord](rows)
+	if err != nil {
+		return persistence.QueryResult[persistence.IndexRecord, string]{}, mapError(err)
+	}
+	var nextID string
+	if len(res) > query.Limit {
+		nextID = res[len(res)-1].ID
+		res = res[:query.Limit]
+	}
+	return persistence.QueryResult[persistence.IndexRecord, string]{Items: res, NextID: nextID, Total: total}, nil
+}
+
+func (m *modelTx) ListIndexRecordRevisions(query persistence.IndexRecordRevisionsQuery) ([]persistence.IndexRecordRevision, error) {
+	if query.NodeID == 0 {
+		return nil, fmt.Errorf("node ID must be specified: %w", errors.ErrInvalid)
+	}
+	where, args := "node_id = ?", []any{query.NodeID}
+	if len(query.RecordID) > 0 {
+		where += " and id = ?"
+		args = append(args, query.RecordID)
+	}
+	args = append(args, query.Limit, query.Offset)
+	rows, err := m.executor().QueryxContext(m.ctx, fmt.Sprintf("select * from index_record_history where %s "+
+		"order by revision desc limit ? offset ?", where), args...)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return persistence.ScanRows[persistence.IndexRecordRevision](rows)
+}
+
+// keepRecordHistory copies the current versions of the records of the formats keeping the history
+// to the index_record_history, it must be called before the records are replaced or deleted
+func (m *modelTx) keepRecordHistory(records []persistence.IndexRecord, replacedAt time.Time, deleted bool) error {
+	where, args := recordsCond(records)
+	_, err := m.executor().ExecContext(m.ctx, "insert into index_record_history "+
+		"(id, node_id, segment, vector, format, rank_multiplier, meta, created_at, updated_at, version, replaced_at, deleted) "+
+		"select ir.id, ir.node_id, ir.segment, ir.vector, ir.format, ir.rank_multiplier, ir.meta, ir.created_at, ir.updated_at, ir.version, ?, ? "+
+		"from index_record as ir inner join format as f on f.id = ir.format "+
+		"where f.keep_history and ("+where+")", append([]any{replacedAt, deleted}, args...)...)
+	return mapError(err)
+}
+
+// touchNodes increments the versions of the records nodes
+func (m *modelTx) touchNodes(records []persistence.IndexRecord) error {
+	for _, chunk := range chunkIDs(recordNodeIDs(records)) {
+		where, args := idsCond("id", chunk)
+		if _, err := m.executor().ExecContext(m.ctx, "update node set version = version + 1 where "+where, args...); err != nil {
+			return mapError(err)
+		}
+	}
+	return nil
+}
+
+// recordsCond returns the condition selecting the records by their IDs
+func recordsCond(records []persistence.IndexRecord) (string, []any) {
+	var sb strings.Builder
+	var args []any
+	for _, r := range records {
+		if sb.Len() > 0 {
+			sb.WriteString(" or ")
+		}
+		sb.WriteString("(ir.node_id = ? and ir.id = ?)")
+		args = append(args, r.NodeID, r.ID)
+	}
+	return sb.String(), args
+}
+
+// maxIDsInCond is the maximum number of IDs in the condition built by the idsCond
+const maxIDsInCond = 500
+
+// recordUpsertOp is the change operation of the upserted records, the record is created if its
+// creation and update times are the same
+const recordUpsertOp = "case when ir.created_at = ir.updated_at then 'create' else 'update' end"
+
+// ancestorsCond selects the updated node and its ancestors as the nodes a. The ancestors names are the
+// prefixes of the node path, which are split by '/' one by one, so the ancestors are found by the names index.
+const ancestorsCond = "a.name in (with recursive p(prefix, rest) as (select '', substr(node.path, 2) union all " +
+	"select prefix || '/' || substr(rest, 1, instr(rest, '/') - 1), substr(rest, instr(rest, '/') + 1) from p where instr(rest, '/') > 0) " +
+	"select prefix from p where prefix != '' union all select node.name)"
+
+// effectiveTagsUpdate is the statement updating the nodes effective tags, the condition selecting the nodes
+// must be appended to it. For every tag key the value of the deepest node (the node itself or its ancestor) is taken.
+const effectiveTagsUpdate = "update node set effective_tags = (select coalesce(json_group_object(key, value), '{}') from " +
+	"(select t.key, t.value, row_number() over (partition by t.key order by length(a.name) desc) as rn " +
+	"from node as a, json_each(a.tags) as t where " + ancestorsCond + ") where rn = 1)"
+
+// inheritedUpdate is the effectiveTagsUpdate, which updates the nodes effective ACLs the same way
+const inheritedUpdate = effectiveTagsUpdate + ", effective_acl = (select coalesce(json_group_object(key, value), '{}') from " +
+	"(select t.key, t.value, row_number() over (partition by t.key order by length(a.name) desc) as rn " +
+	"from node as a, json_each(a.acl) as t where " + ancestorsCond + ") where rn = 1)"
+
+// descendantsCond returns the condition selecting the nodes d, which are the descendants of the node s. The
+// paths of the descendants start with the s name followed by '/', they are selected by the range of the
+// paths ('0' follows '/'), so the paths index is used.
+func descendantsCond(d, s string) string {
+	return fmt.Sprintf("%[1]s.path >= %[2]s.name || '/' and %[1]s.path < %[2]s.name || '0'", d, s)
+}
+
+// changeOp returns the change operation SQL constant
+func changeOp(op persistence.ChangeOp) string {
+	return "'" + string(op) + "'"
+}
+
+// idsCond returns the condition selecting the column values from the IDs provided
+func idsCond(column string, ids []int64) (string, []any) {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return column + " in (" + strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",") + ")", args
+}
+
+// chunkIDs splits the IDs into the chunks of maxIDsInCond size at most
+func chunkIDs(ids []int64) [][]int64 {
+	var res [][]int64
+	for len(ids) > 0 {
+		n := min(len(ids), maxIDsInCond)
+		res = append(res, ids[:n])
+		ids = ids[n:]
+	}
+	return res
+}
+
+// updateInherited updates the effective tags and ACLs of the nodes with the IDs provided and of all their
+// descendants, it returns the nodes updated with the effective tags and ACLs only by their IDs
+func (m *modelTx) updateInherited(ids []int64) (map[int64]persistence.Node, error) {
+	res := make(map[int64]persistence.Node, len(ids))
+	for _, chunk := range chunkIDs(ids) {
+		where, args := idsCond("s.id", chunk)
+		rows, err := m.executor().QueryxContext(m.ctx, inheritedUpdate+" where id in (select s.id from node as s where "+where+" union "+
+			"select d.id from node as s inner join node as d on "+descendantsCond("d", "s")+" where "+where+") "+
+			"returning id, effective_tags, effective_acl", append(args, args...)...)
+		if err != nil {
+			return nil, mapError(err)
+		}
+		nodes, err := persistence.ScanRows[persistence.Node](rows)
+		_ = rows.Close()
+		if err != nil {
+			return nil, mapError(err)
+		}
+		for _, n := range nodes {
+			res[n.ID] = n
+		}
+	}
+	return res, nil
+}
+
+// logNodeChangesByIDs writes the changes of the nodes with the IDs provided to the changelog
+func (m *modelTx) logNodeChangesByIDs(op persistence.ChangeOp, ids []int64) error {
+	for _, chunk := range chunkIDs(ids) {
+		where, args := idsCond("n.id", chunk)
+		if err := m.logNodeChanges(op, "", where, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// logNodeChanges writes the changes of the nodes selected by the where condition to the changelog,
+// the with contains the common table expressions used by the condition, it may be empty
+func (m *modelTx) logNodeChanges(op persistence.ChangeOp, with, where string, args ...any) error {
+	_, err := m.executor().ExecContext(m.ctx, fmt.Sprintf("%sinsert into changelog (object, op, node_id, path, name, tags, effective_tags, attrs, flags, created_at) "+
+		"select '%s', '%s', n.id, n.path, n.name, n.tags, n.effective_tags, n.attrs, n.flags, ? from node as n where %s order by n.id",
+		with, persistence.ChangeObjectNode, op, where), append([]any{time.Now().UTC()}, args...)...)
+	return mapError(err)
+}
+
+// logRecordChanges writes the changes of the index records selected by the where condition to the
+// changelog, the opExpr is the SQL expression of the change operation
+func (m *modelTx) logRecordChanges(opExpr, where string, args ...any) error {
+	_, err := m.executor().ExecContext(m.ctx, fmt.Sprintf("insert into changelog (object, op, node_id, path, name, tags, effective_tags, attrs, flags, record_id, format, meta, created_at) "+
+		"select '%s', %s, n.id, n.path, n.name, n.tags, n.effective_tags, n.attrs, n.flags, ir.id, ir.format, ir.meta, ? "+
+		"from index_record as ir inner join node as n on n.id = ir.node_id where %s order by n.id, ir.id",
+		persistence.ChangeObjectRecord, opExpr, where), append([]any{time.Now().UTC()}, args...)...)
+	return mapError(err)
+}
+
+func (m *modelTx) ListChanges(query persistence.ChangesQuery) (persistence.QueryResult[persistence.Change, int64], error) {
+	if query.Limit <= 0 {
+		return persistence.QueryResult[persistence.Change, int64]{}, fmt.Errorf("the limit must be positive: %w", errors.ErrInvalid)
+	}
+	var sb strings.Builder
+	if err := m.dbe.tr.Translate(&sb, query.FilterConditions); err != nil {
+		return persistence.QueryResult[persistence.Change, int64]{}, err
+	}
+	// the changes are selected up to the last ID only, so the cursor is advanced to
+	// the last ID if the changes matching the filter are not found
+	lastID, err := m.LastChangeID()
+	if err != nil {
+		return persistence.QueryResult[persistence.Change, int64]{}, err
+	}
+	where := "n.id > ? and n.id <= ?"
+	if sb.Len() > 0 {
+		where += " and (" + sb.String() + ")"
+	}
+	// the changelog is joined as ir to provide the record format and meta to the filter conditions
+	rows, err := m.executor().QueryxContext(m.ctx, "select n.* from changelog as n inner join changelog as ir on ir.id = n.id "+
+		"where "+where+" order by n.id limit ?", query.FromID, lastID, query.Limit)
+	if err != nil {
+		return persistence.QueryResult[persistence.Change, int64]{}, mapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	res, err := persistence.ScanRows[persistence.Change](rows)
+	if err != nil {
+		return persistence.QueryResult[persistence.Change, int64]{}, mapError(err)
+	}
+	nextID := max(query.FromID, lastID)
+	if len(res) == query.Limit {
+		nextID = res[len(res)-1].ID
+	}
+	return persistence.QueryResult[persistence.Change, int64]{Items: res, NextID: nextID}, nil
+}
+
+func (m *modelTx) LastChangeID() (int64, error) {
+	id, err := persistence.Count(m.ctx, m.executor(), "select coalesce(max(id), 0) from changelog")
+	return id, mapError(err)
+}
+
+func (m *modelTx) PurgeChanges(before time.Time) (int64, error) {
+	res, err := m.executor().ExecContext(m.ctx, "delete from changelog where created_at < ?", before.UTC())
+	if err != nil {
+		return 0, mapError(err)
+	}
+	return res.RowsAffected()
+}
+
+func (m *modelTx) CreateWebhook(webhook persistence.Webhook) (persistence.Webhook, error) {
+	if len(webhook.ID) == 0 {
+		return persistence.Webhook{}, fmt.Errorf("webhook ID must be non-empty: %w", errors.ErrInvalid)
+	}
+	if len(webhook.URL) == 0 {
+		return persistence.Webhook{}, fmt.Errorf("webhook URL must be non-empty: %w", errors.ErrInvalid)
+	}
+	webhook.Path = persistence.ConcatPath(webhook.Path, "")
+	if webhook.Events == nil {
+		webhook.Events = persistence.Strings{}
+	}
+	webhook.CreatedAt = time.Now().UTC()
+	webhook.UpdatedAt = webhook.CreatedAt
+	_, err := m.executor().ExecContext(m.ctx, "insert into webhook (id, url, secret, path, events, cursor, created_at, updated_at) "+
+		"values (?, ?, ?, ?, ?, ?, ?, ?)", webhook.ID, webhook.URL, webhook.Secret, webhook.Path,
+		webhook.Events, webhook.Cursor, webhook.CreatedAt, webhook.UpdatedAt)
+	if err != nil {
+		return persistence.Webhook{}, mapError(err)
+	}
+	return webhook, nil
+}
+
+func (m *modelTx) GetWebhook(ID string) (persistence.Webhook, error) {
+	var w persistence.Webhook
+	return w, mapError(m.executor().GetContext(m.ctx, &w, "select * from webhook where id=?", ID))
+}
+
+func (m *modelTx) DeleteWebhook(ID string) error {
+	res, err := m.executor().ExecContext(m.ctx, "delete from webhook where id=?", ID)
+	if err != nil {
+		return mapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return errors.ErrNotExist
+	}
+	return nil
+}
+
+func (m *modelTx) ListWebhooks() ([]persistence.Webhook, error) {
+	rows, err := m.executor().QueryxContext(m.ctx, "select * from webhook order by id")
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return persistence.ScanRows[persistence.Webhook](rows)
+}
+
+func (m *modelTx) LockWebhook(ID, owner string, expired, lockedUntil time.Time) (persistence.Webhook, error) {
+	var w persistence.Webhook
+	return w, mapError(m.executor().GetContext(m.ctx, &w, "update webhook set locked_by = ?, locked_until = ? "+
+		"where id = ? and (locked_by = ? or locked_until <= ?) returning *", owner, lockedUntil.UTC(), ID, owner, expired.UTC()))
+}
+
+func (m *modelTx) UpdateWebhookCursor(ID, owner string, cursor int64) error {
+	res, err := m.executor().ExecContext(m.ctx, "update webhook set cursor = ?, attempts = 0, next_attempt_at = ?, updated_at = ? "+
+		"where id = ? and locked_by = ?", cursor, time.Time{}.UTC(), time.Now().UTC(), ID, owner)
+	if err != nil {
+		return mapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return errors.ErrNotExist
+	}
+	return nil
+}
+
+func (m *modelTx) UpdateWebhookAttempts(ID, owner string, attempts int, nextAttemptAt time.Time) error {
+	res, err := m.executor().ExecContext(m.ctx, "update webhook set attempts = ?, next_attempt_at = ?, updated_at = ? "+
+		"where id = ? and locked_by = ?", attempts, nextAttemptAt.UTC(), time.Now().UTC(), ID, owner)
+	if err != nil {
+		return mapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return errors.ErrNotExist
+	}
+	return nil
+}
+
+func (m *modelTx) CreateDeadLetter(dl persistence.DeadLetter) (persistence.DeadLetter, error) {
+	if len(dl.WebhookID) == 0 {
+		return persistence.DeadLetter{}, fmt.Errorf("dead letter webhook ID must be non-empty: %w", errors.ErrInvalid)
+	}
+	dl.CreatedAt = time.Now().UTC()
+	rows, err := m.executor().QueryxContext(m.ctx, "insert into webhook_dead_letter (webhook_id, change_id, event, payload, error, attempts, created_at) "+
+		"values (?, ?, ?, ?, ?, ?, ?) returning id", dl.WebhookID, dl.ChangeID, dl.Event, string(dl.Payload), dl.Error, dl.Attempts, dl.CreatedAt)
+	if err != nil {
+		return persistence.DeadLetter{}, mapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return persistence.DeadLetter{}, err
+	}
+	dl.ID = ids[0]
+	return dl, nil
+}
+
+func (m *modelTx) ListDeadLetters(query persistence.DeadLettersQuery) ([]persistence.DeadLetter, error) {
+	rows, err := m.executor().QueryxContext(m.ctx, "select * from webhook_dead_letter where webhook_id = ? order by id desc limit ? offset ?",
+		query.WebhookID, query.Limit, query.Offset)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return persistence.ScanRows[persistence.DeadLetter](rows)
+}
+
+func (m *modelTx) CreateIdempotencyKey(ik persistence.IdempotencyKey) error {
+	if len(ik.Key) == 0 {
+		return fmt.Errorf("idempotency key must be non-empty: %w", errors.ErrInvalid)
+	}
+	ik.CreatedAt = time.Now().UTC()
+	_, err := m.executor().ExecContext(m.ctx, "insert into idempotency_key (key, fingerprint, done, response, locked_until, created_at) "+
+		"values (?, ?, ?, ?, ?, ?)", ik.Key, ik.Fingerprint, ik.Done, ik.Response, ik.LockedUntil.UTC(), ik.CreatedAt)
+	return mapError(err)
+}
+
+func (m *modelTx) GetIdempotencyKey(key string) (persistence.IdempotencyKey, error) {
+	var ik persistence.IdempotencyKey
+	return ik, mapError(m.executor().GetContext(m.ctx, &ik, "select * from idempotency_key where key=?", key))
+}
+
+func (m *modelTx) LockIdempotencyKey(key string, expired, lockedUntil time.Time) error {
+	res, err := m.executor().ExecContext(m.ctx, "update idempotency_key set locked_until = ? where key = ? and not done and locked_until <= ?",
+		lockedUntil.UTC(), key, expired.UTC())
+	if err != nil {
+		return mapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return errors.ErrNotExist
+	}
+	return nil
+}
+
+func (m *modelTx) CompleteIdempotencyKey(key string, response []byte) error {
+	if response == nil {
+		response = []byte{}
+	}
+	res, err := m.executor().ExecContext(m.ctx, "update idempotency_key set done = true, response = ? where key = ?", response, key)
+	if err != nil {
+		return mapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return errors.ErrNotExist
+	}
+	return nil
+}
+
+func (m *modelTx) DeleteIdempotencyKey(key string) error {
+	res, err := m.executor().ExecContext(m.ctx, "delete from idempotency_key where key=?", key)
+	if err != nil {
+		return mapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return errors.ErrNotExist
+	}
+	return nil
+}
+
+func (m *modelTx) PurgeIdempotencyKeys(before time.Time) (int64, error) {
+	res, err := m.executor().ExecContext(m.ctx, "delete from idempotency_key where created_at < ?", before.UTC())
+	if err != nil {
+		return 0, mapError(err)
+	}
+	return res.RowsAffected()
+}
+
+func (m *modelTx) CreateAttrSchema(as persistence.AttrSchema) (persistence.AttrSchema, error) {
+	as.Prefix = persistence.ToNodePath(as.Prefix)
+	if err := as.Check(); err != nil {
+		return persistence.AttrSchema{}, err
+	}
+	var eas persistence.AttrSchema
+	err := m.executor().GetContext(m.ctx, &eas, "select * from attr_schema where name = ? and type != ? limit 1", as.Name, as.Type)
+	if err == nil {
+		return persistence.AttrSchema{}, fmt.Errorf("the attribute %q is declared with the %s type for %q: %w", as.Name, eas.Type, eas.Prefix, errors.ErrConflict)
+	}
+	if err = mapError(err); !errors.Is(err, errors.ErrNotExist) {
+		return persistence.AttrSchema{}, err
+	}
+	as.CreatedAt = time.Now().UTC()
+	if _, err = m.executor().ExecContext(m.ctx, "insert into attr_schema (prefix, name, type, created_at) values (?, ?, ?, ?)",
+		as.Prefix, as.Name, as.Type, as.CreatedAt); err != nil {
+		return persistence.AttrSchema{}, mapError(err)
+	}
+	// the strings lists values are matched by json_each(), which cannot use an index
+	if as.Type != persistence.AttrStrings {
+		if _, err = m.executor().ExecContext(m.ctx, fmt.Sprintf("create index if not exists %q on node (%s)",
+			attrIndexName(as.Name), ql.SqliteAttrValue("attrs", as.Name, as.Type.Kind()))); err != nil {
+			return persistence.AttrSchema{}, mapError(err)
+		}
+	}
+	return as, nil
+}
+
+func (m *modelTx) DeleteAttrSchema(prefix, name string) error {
+	res, err := m.executor().ExecContext(m.ctx, "delete from attr_schema where prefix = ? and name = ?", persistence.ToNodePath(prefix), name)
+	if err != nil {
+		return mapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return errors.ErrNotExist
+	}
+	cnt, err = persistence.Count(m.ctx, m.executor(), "select count(*) from attr_schema where name = ?", name)
+	if err != nil || cnt > 0 {
+		return err
+	}
+	_, err = m.executor().ExecContext(m.ctx, fmt.Sprintf("drop index if exists %q", attrIndexName(name)))
+	return mapError(err)
+}
+
+func (m *modelTx) ListAttrSchemas() ([]persistence.AttrSchema, error) {
+	rows, err := m.executor().QueryxContext(m.ctx, "select * from attr_schema order by prefix, name")
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return persistence.ScanRows[persistence.AttrSchema](rows)
+}
+
+func (m *modelTx) CreateJob(job persistence.Job) (persistence.Job, error) {
+	if len(job.Status) == 0 {
+		return persistence.Job{}, fmt.Errorf("job status must be non-empty: %w", errors.ErrInvalid)
+	}
+	job.CreatedAt = time.Now().UTC()
+	job.UpdatedAt = job.CreatedAt
+	rows, err := m.executor().QueryxContext(m.ctx, "insert into job (node_id, path, parser, status, records, error, locked_by, locked_until, created_at, updated_at) "+
+		"values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) returning id", job.NodeID, job.Path, job.Parser, job.Status, job.Records, job.Error,
+		job.LockedBy, job.LockedUntil.UTC(), job.CreatedAt, job.UpdatedAt)
+	if err != nil {
+		return persistence.Job{}, mapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	ids, err := scanIDs(rows)
+	if err != nil {
+		return persistence.Job{}, err
+	}
+	job.ID = ids[0]
+	return job, nil
+}
+
+func (m *modelTx) GetJob(ID int64) (persistence.Job, error) {
+	var j persistence.Job
+	return j, mapError(m.executor().GetContext(m.ctx, &j, "select * from job where id=?", ID))
+}
+
+func (m *modelTx) UpdateJob(job persistence.Job) error {
+	res, err := m.executor().ExecContext(m.ctx, "update job set status = ?, records = ?, error = ?, updated_at = ? where id = ?",
+		job.Status, job.Records, job.Error, time.Now().UTC(), job.ID)
+	if err != nil {
+		return mapError(err)
+	}
+	cnt, _ := res.RowsAffected()
+	if cnt == 0 {
+		return errors.ErrNotExist
+	}
+	return nil
+}
+
+func (m *modelTx) ListJobs(query persistence.JobsQuery) ([]persistence.Job, error) {
+	where, args := "(? = '' or status = ?)", []any{query.Status, query.Status}
+	if !query.LockedBefore.IsZero() {
+		where += " and locked_until < ?"
+		args = append(args, query.LockedBefore.UTC())
+	}
+	rows, err := m.executor().QueryxContext(m.ctx, "select * from job where "+where+" order by id desc limit ? offset ?",
+		append(args, query.Limit, query.Offset)...)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return persistence.ScanRows[persistence.Job](rows)
+}
+
+func (m *modelTx) LockJob(ID int64, owner string, expired, lockedUntil time.Time) (persistence.Job, error) {
+	var j persistence.Job
+	return j, mapError(m.executor().GetContext(m.ctx, &j, "update job set locked_by = ?, locked_until = ? "+
+		"where id = ? and status in (?, ?) and (locked_by = ? or locked_until <= ?) returning *",
+		owner, lockedUntil.UTC(), ID, persistence.JobPending, persistence.JobRunning, owner, expired.UTC()))
+}
+
+func (m *modelTx) StartJob(ID int64, owner string) (persistence.Job, error) {
+	var j persistence.Job
+	return j, mapError(m.executor().GetContext(m.ctx, &j, "update job set status = ?, records = 0, updated_at = ? "+
+		"where id = ? and status = ? and locked_by = ? returning *", persistence.JobRunning, time.Now().UTC(), ID, persistence.JobPending, owner))
+}
+
+func (m *modelTx) Search(query persistence.SearchQuery) (persistence.SearchQueryResult, error) {
+	if len(query.TextQuery) == 0 {
+		return persistence.SearchQueryResult{}, fmt.Errorf("text query must be non-empty: %w", errors.ErrInvalid)
+	}
+	if query.Engine != "" && query.Engine != Driver {
+		return persistence.SearchQueryResult{}, fmt.Errorf("the search engine %q is not supported: %w", query.Engine, errors.ErrInvalid)
+	}
+	if m.dbe.searchFn == nil {
+		return persistence.SearchQueryResult{}, errors.ErrUnimplemented
+	}
+	// the semantic search is not supported, so the lexical search is used only
+	return persistence.Search(query, func(q persistence.SearchQuery) (persistence.SearchQueryResult, error) {
+		return m.dbe.searchFn(m.ctx, m.executor(), q)
+	}, nil)
+}
+
+// accessCond returns the condition selecting the nodes "n" accessible with the access a, which
+// is appended to the other conditions, it is empty if a is nil
+func accessCond(a *persistence.Access) string {
+	if a == nil {
+		return ""
+	}
+	return " and " + persistence.SqliteAccessCondition(*a)
+}
+
+// attrIndexName returns the name of the index of the node attribute values
+func attrIndexName(name string) string {
+	return "idx_node_attr_" + name
+}
+
+func scanNodes(rows *sqlx.Rows) ([]persistence.Node, error) {
+	nodes, err := persistence.ScanRows[persistence.Node](rows)
+	if err != nil {
+		return nodes, err
+	}
+	for i, n := range nodes {
+		cleanNameAfterRead(&n)
+		nodes[i] = n
+	}
+	return nodes, nil
+}
+
+func scanIDs(rows *sqlx.Rows) ([]int64, error) {
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, mapError(err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func nodeIDs(nodes []persistence.Node) []int64 {
+	ids := make([]int64, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID
+	}
+	return ids
+}
+
+// recordNodeIDs returns the distinct IDs of the records nodes
+func recordNodeIDs(records []persistence.IndexRecord) []int64 {
+	var ids []int64
+	seen := make(map[int64]bool)
+	for _, r := range records {
+		if !seen[r.NodeID] {
+			seen[r.NodeID] = true
+			ids = append(ids, r.NodeID)
+		}
+	}
+	return ids
+}
+
+func cleanNameAfterRead(n *persistence.Node) {
+	if n == nil {
+		return
+	}
+	n.Name = n.Name[len(n.Path):]
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/simila-io/simila/pkg/indexer/persistence/sqlite/fts"
+	"strings"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	// Driver is the name of the SQLite driver, which may be specified in the DB configuration
+	Driver = "sqlite"
+
+	// connParams are applied to every connection: the foreign keys must be turned
+	// on for the cascade deletes, and LIKE must be case-sensitive the same way as in Postgres
+	connParams = "_pragma=foreign_keys(1)&_pragma=case_sensitive_like(1)&_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)"
+)
+
+// MustGetDb does the same as GetDb but panics in case of an error
+func MustGetDb(ctx context.Context, fileName string) *Db {
+	db, err := GetDb(ctx, fileName)
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
+
+// GetDb returns the Db object for the SQLite database file provided. The file is created, if
+// it doesn't exist. The ":memory:" file name may be used for the in-memory database.
+func GetDb(ctx context.Context, fileName string) (*Db, error) {
+	db, err := sqlx.ConnectContext(ctx, Driver, DataSourceName(fileName))
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to the database: %w", err)
+	}
+	// SQLite allows only one writer at a time, so the only connection is used
+	// to serialize the transactions instead of getting "database is locked" errors.
+	db.SetMaxOpenConns(1)
+	if err = migrateFtsUp(ctx, db.DB); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("migration failed: %w", err)
+	}
+	return newDb(db, dbExt{tr: fts.FcTranslator, searchFn: fts.Search}), nil
+}
+
+// DataSourceName returns the data source name for the database file provided
+func DataSourceName(fileName string) string {
+	sep := "?"
+	if strings.Contains(fileName, "?") {
+		sep = "&"
+	}
+	return fmt.Sprintf("file:%s%s%s", strings.TrimPrefix(fileName, "file:"), sep, connParams)
+}
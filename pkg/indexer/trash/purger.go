@@ -0,0 +1,133 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trash
+
+import (
+	"context"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/acquirecloud/golibs/logging"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"sync"
+	"time"
+)
+
+type (
+	// Config defines the trash purge settings
+	Config struct {
+		// Retention is how long the trashed nodes are kept, the nodes trashed earlier are purged.
+		// The purge is turned off if it is 0.
+		Retention time.Duration
+		// Interval is how often the purge runs
+		Interval time.Duration
+	}
+
+	// Purger deletes the nodes, which are kept in the trash longer than the retention period,
+	// in the background
+	Purger struct {
+		Db persistence.Db `inject:""`
+
+		cfg    Config
+		logger logging.Logger
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+	}
+)
+
+const (
+	DefaultRetention = 30 * 24 * time.Hour
+	DefaultInterval  = time.Hour
+)
+
+// DefaultConfig returns the default trash purge settings
+func DefaultConfig() Config {
+	return Config{Retention: DefaultRetention, Interval: DefaultInterval}
+}
+
+// Check returns an error if the config is not valid
+func (c Config) Check() error {
+	if c.Retention < 0 {
+		return fmt.Errorf("the trash retention %s must not be negative: %w", c.Retention, errors.ErrInvalid)
+	}
+	if c.Retention > 0 && c.Interval <= 0 {
+		return fmt.Errorf("the trash purge interval %s must be positive: %w", c.Interval, errors.ErrInvalid)
+	}
+	return nil
+}
+
+// NewPurger creates the new Purger
+func NewPurger(cfg Config) *Purger {
+	return &Purger{cfg: cfg, logger: logging.NewLogger("trash.Purger")}
+}
+
+// Init implements linker.Initializer interface
+func (p *Purger) Init(ctx context.Context) error {
+	if err := p.cfg.Check(); err != nil {
+		return err
+	}
+	if p.cfg.Retention == 0 {
+		p.logger.Infof("Initializing... the trash purge is off")
+		return nil
+	}
+	p.logger.Infof("Initializing... retention=%s, interval=%s", p.cfg.Retention, p.cfg.Interval)
+	var pctx context.Context
+	pctx, p.cancel = context.WithCancel(context.Background())
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			if _, err := p.Purge(pctx); err != nil && pctx.Err() == nil {
+				p.logger.Warnf("could not purge the trash: %v", err)
+			}
+			select {
+			case <-pctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown implements linker.Shutdowner interface
+func (p *Purger) Shutdown() {
+	p.logger.Infof("Shutdown")
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+}
+
+// Purge deletes the nodes trashed before the retention period, it returns the number of the nodes deleted
+func (p *Purger) Purge(ctx context.Context) (int64, error) {
+	mtx := p.Db.NewModelTx(ctx)
+	mtx.MustBegin()
+	defer func() {
+		_ = mtx.Rollback()
+	}()
+	cnt, err := mtx.PurgeTrash(time.Now().Add(-p.cfg.Retention))
+	if err != nil {
+		return 0, err
+	}
+	if err = mtx.Commit(); err != nil {
+		return 0, err
+	}
+	if cnt > 0 {
+		p.logger.Infof("%d trashed nodes purged", cnt)
+	}
+	return cnt, nil
+}
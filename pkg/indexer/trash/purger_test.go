@@ -0,0 +1,59 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trash
+
+import (
+	"context"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/indexer/persistence/inmem"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestConfigCheck(t *testing.T) {
+	assert.Nil(t, DefaultConfig().Check())
+	assert.Nil(t, Config{}.Check())
+	assert.True(t, errors.Is(Config{Retention: -time.Hour}.Check(), errors.ErrInvalid))
+	assert.True(t, errors.Is(Config{Retention: time.Hour}.Check(), errors.ErrInvalid))
+}
+
+func TestPurge(t *testing.T) {
+	db := inmem.NewDb()
+	mtx := db.NewModelTx(context.Background())
+	_, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a", Flags: persistence.NodeFlagDocument},
+		persistence.Node{Path: "/", Name: "b", Flags: persistence.NodeFlagDocument})
+	assert.Nil(t, err)
+	assert.Nil(t, mtx.DeleteNodes(persistence.DeleteNodesQuery{FilterConditions: "node = '/a'", Trash: true}))
+
+	p := NewPurger(Config{Retention: time.Hour, Interval: time.Hour})
+	p.Db = db
+	cnt, err := p.Purge(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), cnt)
+
+	p.cfg.Retention = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	cnt, err = p.Purge(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), cnt)
+
+	nodes, err := mtx.ListTrash(persistence.ListNodesQuery{Limit: 10})
+	assert.Nil(t, err)
+	assert.Empty(t, nodes)
+	_, err = mtx.GetNode("/b")
+	assert.Nil(t, err)
+}
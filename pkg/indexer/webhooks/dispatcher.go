@@ -0,0 +1,403 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/acquirecloud/golibs/logging"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// Config defines the webhook delivery settings
+	Config struct {
+		// Interval is how often the changelog is checked for the new events
+		Interval time.Duration
+		// MaxRetries is the number of the delivery retries after the first failed attempt,
+		// the event is moved to the dead letters after that.
+		MaxRetries int
+		// Backoff is the delay before the first retry, the delay is doubled for every next retry
+		Backoff time.Duration
+		// Timeout is the delivery request timeout
+		Timeout time.Duration
+		// Lease is how long a webhook is locked by the instance delivering its events, the lock
+		// is renewed while the events are delivered
+		Lease time.Duration
+	}
+
+	// Dispatcher reads the changelog in the background and POSTs the events to the subscribed
+	// webhooks. Every webhook has its own cursor in the changelog, so the events are delivered
+	// to the webhook in the order they happened. The webhook is locked by the dispatcher, which
+	// delivers its events, so several simila instances don't send the same events twice.
+	Dispatcher struct {
+		Db persistence.Db `inject:""`
+
+		cfg    Config
+		client *http.Client
+		logger logging.Logger
+		// owner is the unique name of the dispatcher, which locks the webhooks
+		owner  string
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+	}
+
+	// Payload is the JSON body of the event delivery
+	Payload struct {
+		// ID is the change cursor in the changelog, it is unique for every event
+		ID        int64     `json:"id"`
+		Webhook   string    `json:"webhook"`
+		Event     string    `json:"event"`
+		Node      Node      `json:"node"`
+		RecordID  string    `json:"recordId,omitempty"`
+		Format    string    `json:"format,omitempty"`
+		CreatedAt time.Time `json:"createdAt"`
+	}
+
+	// Node describes the changed node in the Payload
+	Node struct {
+		Path string            `json:"path"`
+		Name string            `json:"name"`
+		Type string            `json:"type"`
+		Tags map[string]string `json:"tags"`
+	}
+)
+
+const (
+	DefaultInterval   = 5 * time.Second
+	DefaultMaxRetries = 5
+	DefaultBackoff    = time.Second
+	DefaultTimeout    = 10 * time.Second
+	DefaultLease      = time.Minute
+
+	// HeaderEvent contains the event type of the delivery
+	HeaderEvent = "X-Simila-Event"
+	// HeaderDelivery contains the event ID of the delivery
+	HeaderDelivery = "X-Simila-Delivery"
+	// HeaderSignature contains the "sha256=" prefixed hex encoded HMAC-SHA256 of the request body,
+	// the webhook secret is the key. The header is not sent if the webhook has no secret.
+	HeaderSignature = "X-Simila-Signature"
+)
+
+// Events contains all the event types, a webhook may subscribe to
+var Events = []string{
+	EventType(persistence.ChangeObjectNode, persistence.ChangeOpCreate),
+	EventType(persistence.ChangeObjectNode, persistence.ChangeOpUpdate),
+	EventType(persistence.ChangeObjectNode, persistence.ChangeOpDelete),
+	EventType(persistence.ChangeObjectRecord, persistence.ChangeOpCreate),
+	EventType(persistence.ChangeObjectRecord, persistence.ChangeOpUpdate),
+	EventType(persistence.ChangeObjectRecord, persistence.ChangeOpDelete),
+}
+
+// EventType returns the event type for the changed object and the change operation, e.g. "node.create"
+func EventType(object persistence.ChangeObject, op persistence.ChangeOp) string {
+	return string(object) + "." + string(op)
+}
+
+// CheckEvents returns an error if the events list contains an unknown event type
+func CheckEvents(events []string) error {
+	for _, e := range events {
+		found := false
+		for _, ke := range Events {
+			found = found || e == ke
+		}
+		if !found {
+			return fmt.Errorf("unknown event type %q, expected one of %v: %w", e, Events, errors.ErrInvalid)
+		}
+	}
+	return nil
+}
+
+// Sign returns the signature of the body for the HeaderSignature header
+func Sign(secret string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write(body)
+	return "sha256=" + hex.EncodeToString(h.Sum(nil))
+}
+
+// DefaultConfig returns the default webhook delivery settings
+func DefaultConfig() Config {
+	return Config{Interval: DefaultInterval, MaxRetries: DefaultMaxRetries, Backoff: DefaultBackoff, Timeout: DefaultTimeout, Lease: DefaultLease}
+}
+
+// Check returns an error if the config is not valid
+func (c Config) Check() error {
+	if c.Interval <= 0 {
+		return fmt.Errorf("the webhook dispatch interval %s must be positive: %w", c.Interval, errors.ErrInvalid)
+	}
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("the webhook max retries %d must not be negative: %w", c.MaxRetries, errors.ErrInvalid)
+	}
+	if c.Backoff < 0 {
+		return fmt.Errorf("the webhook retry backoff %s must not be negative: %w", c.Backoff, errors.ErrInvalid)
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("the webhook delivery timeout %s must be positive: %w", c.Timeout, errors.ErrInvalid)
+	}
+	if c.Lease <= c.Timeout {
+		return fmt.Errorf("the webhook lease %s must be greater than the delivery timeout %s: %w", c.Lease, c.Timeout, errors.ErrInvalid)
+	}
+	return nil
+}
+
+// NewDispatcher creates the new Dispatcher
+func NewDispatcher(cfg Config) *Dispatcher {
+	return &Dispatcher{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}, logger: logging.NewLogger("webhooks.Dispatcher"), owner: newOwner()}
+}
+
+// Init implements linker.Initializer interface
+func (d *Dispatcher) Init(ctx context.Context) error {
+	if err := d.cfg.Check(); err != nil {
+		return err
+	}
+	d.logger.Infof("Initializing... interval=%s, maxRetries=%d, backoff=%s, timeout=%s, lease=%s, owner=%s",
+		d.cfg.Interval, d.cfg.MaxRetries, d.cfg.Backoff, d.cfg.Timeout, d.cfg.Lease, d.owner)
+	var dctx context.Context
+	dctx, d.cancel = context.WithCancel(context.Background())
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		ticker := time.NewTicker(d.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			if _, err := d.Dispatch(dctx); err != nil && dctx.Err() == nil {
+				d.logger.Warnf("could not dispatch the webhook events: %v", err)
+			}
+			select {
+			case <-dctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown implements linker.Shutdowner interface
+func (d *Dispatcher) Shutdown() {
+	d.logger.Infof("Shutdown")
+	if d.cancel != nil {
+		d.cancel()
+	}
+	d.wg.Wait()
+}
+
+// Dispatch delivers the changelog events to all the webhooks, it returns the number of the events
+// delivered. The webhooks are served independently, each one in its own goroutine, so a slow or
+// failing endpoint doesn't delay the deliveries to the others. A failed delivery is not retried in
+// place, the retry is scheduled with the exponential backoff and made by one of the next calls. The
+// events, which could not be delivered after all the retries, are moved to the dead letters.
+func (d *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	whs, err := d.Db.NewModelTx(ctx).ListWebhooks()
+	if err != nil {
+		return 0, err
+	}
+	var (
+		total int64
+		wg    sync.WaitGroup
+	)
+	now := time.Now()
+	for _, wh := range whs {
+		if wh.NextAttemptAt.After(now) {
+			continue
+		}
+		wg.Add(1)
+		go func(ID string) {
+			defer wg.Done()
+			cnt, err := d.dispatch(ctx, ID)
+			atomic.AddInt64(&total, int64(cnt))
+			if err != nil && ctx.Err() == nil {
+				d.logger.Warnf("could not dispatch the events to the webhook ID=%s: %v", ID, err)
+			}
+		}(wh.ID)
+	}
+	wg.Wait()
+	return int(total), ctx.Err()
+}
+
+// dispatch locks the webhook and delivers its events, it does nothing if the webhook is locked
+// by another instance or its delivery retry is not due yet
+func (d *Dispatcher) dispatch(ctx context.Context, ID string) (int, error) {
+	mtx := d.Db.NewModelTx(ctx)
+	now := time.Now()
+	wh, err := mtx.LockWebhook(ID, d.owner, now, now.Add(d.cfg.Lease))
+	if errors.Is(err, errors.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		// the lock is released with a new context, because ctx may be closed already
+		if _, err := d.Db.NewModelTx(context.Background()).LockWebhook(ID, d.owner, time.Now(), time.Time{}); err != nil && !errors.Is(err, errors.ErrNotExist) {
+			d.logger.Warnf("could not release the lock of the webhook ID=%s: %v", ID, err)
+		}
+	}()
+	if wh.NextAttemptAt.After(now) {
+		return 0, nil
+	}
+	fc, err := persistence.SubtreeConditions(wh.Path)
+	if err != nil {
+		return 0, err
+	}
+	renewAt := now.Add(d.cfg.Lease / 2)
+	const limit = 100
+	cnt := 0
+	for {
+		res, err := mtx.ListChanges(persistence.ChangesQuery{FromID: wh.Cursor, FilterConditions: fc, Limit: limit})
+		if err != nil {
+			return cnt, err
+		}
+		for _, c := range res.Items {
+			event := EventType(c.Object, c.Op)
+			if !subscribed(wh, event) {
+				continue
+			}
+			if now = time.Now(); now.After(renewAt) {
+				if _, err = mtx.LockWebhook(ID, d.owner, now, now.Add(d.cfg.Lease)); err != nil {
+					return cnt, fmt.Errorf("could not renew the lock of the webhook: %w", err)
+				}
+				renewAt = now.Add(d.cfg.Lease / 2)
+			}
+			delivered, next, err := d.deliver(ctx, mtx, wh, c, event)
+			if err != nil {
+				return cnt, err
+			}
+			if delivered {
+				cnt++
+			}
+			if !next {
+				return cnt, nil
+			}
+			wh.Cursor, wh.Attempts = c.ID, 0
+		}
+		if res.NextID > wh.Cursor {
+			wh.Cursor = res.NextID
+			if err = mtx.UpdateWebhookCursor(ID, d.owner, wh.Cursor); err != nil {
+				return cnt, err
+			}
+		}
+		if len(res.Items) < limit {
+			return cnt, nil
+		}
+	}
+}
+
+// deliver makes one attempt to POST the event to the webhook. It returns delivered=true if the event
+// is delivered, and next=true if the webhook cursor is moved past the event, so the next event may be
+// delivered. If the attempt fails, the retry is scheduled after the backoff, or the event is moved to
+// the dead letters, when all the retries are made.
+func (d *Dispatcher) deliver(ctx context.Context, mtx persistence.ModelTx, wh persistence.Webhook, c persistence.Change, event string) (delivered, next bool, err error) {
+	body, err := json.Marshal(toPayload(wh, c, event))
+	if err != nil {
+		return false, false, err
+	}
+	perr := d.post(ctx, wh, c, event, body)
+	if ctx.Err() != nil {
+		return false, false, ctx.Err()
+	}
+	if perr == nil {
+		return true, true, mtx.UpdateWebhookCursor(wh.ID, d.owner, c.ID)
+	}
+	attempts := wh.Attempts + 1
+	if attempts <= d.cfg.MaxRetries {
+		backoff := d.cfg.Backoff << (attempts - 1)
+		d.logger.Debugf("delivery of the event ID=%d to the webhook ID=%s failed, retrying in %s: %v", c.ID, wh.ID, backoff, perr)
+		return false, false, mtx.UpdateWebhookAttempts(wh.ID, d.owner, attempts, time.Now().Add(backoff))
+	}
+	d.logger.Warnf("could not deliver the event ID=%d to the webhook ID=%s after %d attempts: %v", c.ID, wh.ID, attempts, perr)
+	if _, err = mtx.CreateDeadLetter(persistence.DeadLetter{WebhookID: wh.ID, ChangeID: c.ID, Event: event,
+		Payload: body, Error: perr.Error(), Attempts: attempts}); err != nil {
+		return false, false, err
+	}
+	return false, true, mtx.UpdateWebhookCursor(wh.ID, d.owner, c.ID)
+}
+
+func (d *Dispatcher) post(ctx context.Context, wh persistence.Webhook, c persistence.Change, event string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, event)
+	req.Header.Set(HeaderDelivery, fmt.Sprintf("%d", c.ID))
+	if wh.Secret != "" {
+		req.Header.Set(HeaderSignature, Sign(wh.Secret, body))
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %q", resp.Status)
+	}
+	return nil
+}
+
+func subscribed(wh persistence.Webhook, event string) bool {
+	if len(wh.Events) == 0 {
+		return true
+	}
+	for _, e := range wh.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func toPayload(wh persistence.Webhook, c persistence.Change, event string) Payload {
+	tp := "folder"
+	if c.Flags&persistence.NodeFlagDocument != 0 {
+		tp = "document"
+	}
+	return Payload{
+		ID:        c.ID,
+		Webhook:   wh.ID,
+		Event:     event,
+		Node:      Node{Path: c.Path, Name: c.Name[len(c.Path):], Type: tp, Tags: c.Tags},
+		RecordID:  c.RecordID,
+		Format:    c.Format,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+// newOwner returns the unique name of the dispatcher, the host name makes it readable in the webhook locks
+func newOwner() string {
+	host, _ := os.Hostname()
+	if len(host) > 64 {
+		host = host[:64]
+	}
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return host + "-" + hex.EncodeToString(b)
+}
@@ -0,0 +1,285 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/indexer/persistence/inmem"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type delivery struct {
+	header  http.Header
+	payload Payload
+}
+
+type testServer struct {
+	*httptest.Server
+	lock       sync.Mutex
+	deliveries []delivery
+	fails      int
+}
+
+func newTestServer(fails int) *testServer {
+	ts := &testServer{fails: fails}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ts.lock.Lock()
+		defer ts.lock.Unlock()
+		if ts.fails != 0 {
+			ts.fails--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		d := delivery{header: r.Header}
+		_ = json.Unmarshal(body, &d.payload)
+		if r.Header.Get(HeaderSignature) != "" && r.Header.Get(HeaderSignature) != Sign("secret", body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		ts.deliveries = append(ts.deliveries, d)
+	}))
+	return ts
+}
+
+func newTestDispatcher(db persistence.Db) *Dispatcher {
+	d := NewDispatcher(Config{Interval: time.Hour, MaxRetries: 2, Backoff: time.Millisecond, Timeout: time.Second, Lease: time.Minute})
+	d.Db = db
+	return d
+}
+
+func TestConfigCheck(t *testing.T) {
+	assert.Nil(t, DefaultConfig().Check())
+	assert.True(t, errors.Is(Config{}.Check(), errors.ErrInvalid))
+	assert.True(t, errors.Is(Config{Interval: time.Second, MaxRetries: -1, Timeout: time.Second}.Check(), errors.ErrInvalid))
+	assert.True(t, errors.Is(Config{Interval: time.Second}.Check(), errors.ErrInvalid))
+	assert.True(t, errors.Is(Config{Interval: time.Second, Timeout: time.Second, Lease: time.Second}.Check(), errors.ErrInvalid))
+}
+
+func TestCheckEvents(t *testing.T) {
+	assert.Nil(t, CheckEvents(nil))
+	assert.Nil(t, CheckEvents([]string{"node.create", "record.delete"}))
+	assert.True(t, errors.Is(CheckEvents([]string{"node.created"}), errors.ErrInvalid))
+}
+
+func TestDispatch(t *testing.T) {
+	srv := newTestServer(0)
+	defer srv.Close()
+	db := inmem.NewDb()
+	mtx := db.NewModelTx(context.Background())
+	_, err := mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"})
+	assert.Nil(t, err)
+	_, err = mtx.CreateWebhook(persistence.Webhook{ID: "all", URL: srv.URL, Secret: "secret"})
+	assert.Nil(t, err)
+	_, err = mtx.CreateWebhook(persistence.Webhook{ID: "b", URL: srv.URL, Path: "/b", Events: persistence.Strings{"node.update"}})
+	assert.Nil(t, err)
+
+	nodes, err := mtx.CreateNodes(persistence.Node{Path: "/b", Name: "c", Flags: persistence.NodeFlagDocument})
+	assert.Nil(t, err)
+	assert.Nil(t, mtx.UpdateNode(persistence.Node{ID: nodes[0].ID, Tags: persistence.Tags{"k": "v"}}))
+
+	d := newTestDispatcher(db)
+	cnt, err := d.Dispatch(context.Background())
+	assert.Nil(t, err)
+	// all the events are delivered to "all", and the node update only is delivered to "b"
+	assert.Equal(t, 4, cnt)
+	assert.Equal(t, 4, len(srv.deliveries))
+	var events []string
+	for _, dl := range srv.deliveries {
+		if dl.payload.Webhook == "all" {
+			assert.Equal(t, Sign("secret", mustMarshal(dl.payload)), dl.header.Get(HeaderSignature))
+			events = append(events, dl.payload.Event)
+		} else {
+			assert.Equal(t, "", dl.header.Get(HeaderSignature))
+			assert.Equal(t, "node.update", dl.payload.Event)
+			assert.Equal(t, "/b/", dl.payload.Node.Path)
+			assert.Equal(t, "c", dl.payload.Node.Name)
+			assert.Equal(t, "document", dl.payload.Node.Type)
+			assert.Equal(t, map[string]string{"k": "v"}, dl.payload.Node.Tags)
+		}
+	}
+	assert.Equal(t, []string{"node.create", "node.create", "node.update"}, events)
+
+	// the cursors are moved, so nothing is delivered again
+	cnt, err = d.Dispatch(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 0, cnt)
+}
+
+func TestDispatchRetries(t *testing.T) {
+	srv := newTestServer(2)
+	defer srv.Close()
+	db := inmem.NewDb()
+	mtx := db.NewModelTx(context.Background())
+	_, err := mtx.CreateWebhook(persistence.Webhook{ID: "w", URL: srv.URL, Events: persistence.Strings{"node.create"}})
+	assert.Nil(t, err)
+	_, err = mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"})
+	assert.Nil(t, err)
+
+	// every call makes one attempt, the retry is made by the next call after the backoff
+	d := newTestDispatcher(db)
+	for i := 0; i < 2; i++ {
+		cnt, err := d.Dispatch(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, 0, cnt)
+		wh, err := mtx.GetWebhook("w")
+		assert.Nil(t, err)
+		assert.Equal(t, i+1, wh.Attempts)
+		time.Sleep(10 * time.Millisecond)
+	}
+	cnt, err := d.Dispatch(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, cnt)
+	assert.Equal(t, 1, len(srv.deliveries))
+	wh, err := mtx.GetWebhook("w")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, wh.Attempts)
+	assert.False(t, wh.LockedUntil.After(time.Now()))
+	dls, err := mtx.ListDeadLetters(persistence.DeadLettersQuery{WebhookID: "w", Limit: 10})
+	assert.Nil(t, err)
+	assert.Empty(t, dls)
+
+	// the first attempt and the 2 retries fail, so the event is moved to the dead letters
+	srv.fails = 3
+	_, err = mtx.CreateNodes(persistence.Node{Path: "/", Name: "b"})
+	assert.Nil(t, err)
+	for i := 0; i < 3; i++ {
+		cnt, err = d.Dispatch(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, 0, cnt)
+		time.Sleep(10 * time.Millisecond)
+	}
+	dls, err = mtx.ListDeadLetters(persistence.DeadLettersQuery{WebhookID: "w", Limit: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(dls))
+	assert.Equal(t, "node.create", dls[0].Event)
+	assert.Equal(t, 3, dls[0].Attempts)
+	var p Payload
+	assert.Nil(t, json.Unmarshal(dls[0].Payload, &p))
+	assert.Equal(t, "b", p.Node.Name)
+	assert.Equal(t, dls[0].ChangeID, p.ID)
+
+	// the dead letter is not redelivered, but the next events are
+	_, err = mtx.CreateNodes(persistence.Node{Path: "/", Name: "c"})
+	assert.Nil(t, err)
+	cnt, err = d.Dispatch(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, cnt)
+	assert.Equal(t, "c", srv.deliveries[1].payload.Node.Name)
+}
+
+func TestDispatchBackoff(t *testing.T) {
+	srv := newTestServer(1)
+	defer srv.Close()
+	db := inmem.NewDb()
+	mtx := db.NewModelTx(context.Background())
+	_, err := mtx.CreateWebhook(persistence.Webhook{ID: "w", URL: srv.URL})
+	assert.Nil(t, err)
+	_, err = mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"})
+	assert.Nil(t, err)
+
+	d := newTestDispatcher(db)
+	d.cfg.Backoff = time.Hour
+	cnt, err := d.Dispatch(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 0, cnt)
+	// the retry is not due yet, so the server is not called
+	srv.fails = 1
+	cnt, err = d.Dispatch(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 0, cnt)
+	assert.Equal(t, 1, srv.fails)
+	wh, err := mtx.GetWebhook("w")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, wh.Attempts)
+	assert.True(t, wh.NextAttemptAt.After(time.Now().Add(time.Minute)))
+}
+
+func TestDispatchIndependent(t *testing.T) {
+	srv := newTestServer(0)
+	defer srv.Close()
+	var calls atomic.Int32
+	dead := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer dead.Close()
+	db := inmem.NewDb()
+	mtx := db.NewModelTx(context.Background())
+	_, err := mtx.CreateWebhook(persistence.Webhook{ID: "a", URL: dead.URL})
+	assert.Nil(t, err)
+	_, err = mtx.CreateWebhook(persistence.Webhook{ID: "b", URL: srv.URL})
+	assert.Nil(t, err)
+	_, err = mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"}, persistence.Node{Path: "/", Name: "b"})
+	assert.Nil(t, err)
+
+	// the dead endpoint is called once per dispatch, and it doesn't hold the deliveries to the other webhook
+	d := newTestDispatcher(db)
+	d.cfg.Backoff = time.Hour
+	cnt, err := d.Dispatch(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, cnt)
+	assert.Equal(t, 2, len(srv.deliveries))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestDispatchLocked(t *testing.T) {
+	srv := newTestServer(0)
+	defer srv.Close()
+	db := inmem.NewDb()
+	mtx := db.NewModelTx(context.Background())
+	_, err := mtx.CreateWebhook(persistence.Webhook{ID: "w", URL: srv.URL})
+	assert.Nil(t, err)
+	_, err = mtx.CreateNodes(persistence.Node{Path: "/", Name: "a"})
+	assert.Nil(t, err)
+
+	// the webhook is served by another instance, so its events are not sent twice
+	now := time.Now()
+	_, err = mtx.LockWebhook("w", "other", now, now.Add(time.Minute))
+	assert.Nil(t, err)
+	d := newTestDispatcher(db)
+	cnt, err := d.Dispatch(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 0, cnt)
+	assert.Empty(t, srv.deliveries)
+
+	// the lock is expired, so the events are delivered by the dispatcher
+	_, err = mtx.LockWebhook("w", "other", now, now)
+	assert.Nil(t, err)
+	cnt, err = d.Dispatch(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, cnt)
+	wh, err := mtx.GetWebhook("w")
+	assert.Nil(t, err)
+	assert.False(t, wh.LockedUntil.After(time.Now()))
+}
+
+func mustMarshal(v any) []byte {
+	res, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
@@ -28,6 +28,7 @@ type (
 	// Parser allows to scan data in a specific format and update the index records
 	Parser interface {
 		// ScanRecords walks through the body, extracts the document records, and writes them via mtx associating with the nodeId
+		// The parser is expected to fill the records embeddings with embedding.EmbedRecords, if the embedding provider is configured.
 		ScanRecords(ctx context.Context, mtx persistence.ModelTx, nodeId int64, body io.Reader) (int64, error)
 	}
 
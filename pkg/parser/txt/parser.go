@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"github.com/acquirecloud/golibs/logging"
 	"github.com/logrange/linker"
+	"github.com/simila-io/simila/pkg/embedding"
 	"github.com/simila-io/simila/pkg/indexer/persistence"
 	"github.com/simila-io/simila/pkg/parser"
 	"io"
@@ -28,7 +29,8 @@ import (
 
 type (
 	txtParser struct {
-		PProvider parser.Provider `inject:""`
+		PProvider parser.Provider    `inject:""`
+		Embedder  embedding.Provider `inject:",optional"`
 
 		logger logging.Logger
 	}
@@ -65,6 +67,9 @@ func (tp *txtParser) ScanRecords(ctx context.Context, mtx persistence.ModelTx, n
 		}
 		recs = append(recs, persistence.IndexRecord{NodeID: nodeID, ID: fmt.Sprintf("%08x", line), Format: "txt", Segment: sgmnt, RankMult: 1.0})
 		if len(recs) >= 100 {
+			if err := embedding.EmbedRecords(ctx, tp.Embedder, recs); err != nil {
+				return 0, err
+			}
 			if n, err := mtx.UpsertIndexRecords(recs...); err != nil {
 				return n, err
 			}
@@ -73,6 +78,9 @@ func (tp *txtParser) ScanRecords(ctx context.Context, mtx persistence.ModelTx, n
 	}
 
 	if len(recs) > 0 {
+		if err := embedding.EmbedRecords(ctx, tp.Embedder, recs); err != nil {
+			return 0, err
+		}
 		if n, err := mtx.UpsertIndexRecords(recs...); err != nil {
 			return n, err
 		}
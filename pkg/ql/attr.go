@@ -0,0 +1,318 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ql
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/acquirecloud/golibs/errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// ValueKind is the type of the value the attribute is compared with
+	ValueKind string
+
+	// AttrCondition is the condition on the typed attribute, e.g. attr("published") >= date("2024-01-01").
+	// The attribute value is compared with the Values of the Kind type. The condition never matches if
+	// the attribute is not set, or its value has another type.
+	AttrCondition struct {
+		Name string
+		// Op is the operation in upper case
+		Op   string
+		Kind ValueKind
+		// Values contains the rvalues as strings: the numbers are formatted by FormatNumber, the dates
+		// are formatted by FormatDate and the booleans are "true" or "false". There is only one value
+		// for all the operations, but IN.
+		Values []string
+	}
+)
+
+const (
+	// KindString values are compared as strings, the strings lists match the =, != and IN
+	// operations if they contain the value
+	KindString ValueKind = "string"
+	KindNumber ValueKind = "number"
+	KindBool   ValueKind = "bool"
+	KindDate   ValueKind = "date"
+
+	// DateLayout is the layout of the timestamp attributes values. The layout has the fixed
+	// width, so the timestamps may be compared as strings.
+	DateLayout = "2006-01-02T15:04:05.000Z"
+
+	// MaxAttrNameLength is the maximum length of the attribute name
+	MaxAttrNameLength = 48
+)
+
+var (
+	attrNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	dateLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"}
+)
+
+// CheckAttrName returns ErrInvalid if the name cannot be used as the attribute name. The name
+// must start with a letter or the underscore, followed by letters, digits or underscores.
+func CheckAttrName(name string) error {
+	if len(name) > MaxAttrNameLength || !attrNameRegexp.MatchString(name) {
+		return fmt.Errorf("the attribute name %q must consist of up to %d letters, digits or underscores, and "+
+			"it must not start with a digit: %w", name, MaxAttrNameLength, errors.ErrInvalid)
+	}
+	return nil
+}
+
+// ParseDate parses the timestamp in RFC 3339 format, the time zone or the whole time part
+// may be omitted, for example, "2024-01-01" is the start of the day in UTC.
+func ParseDate(s string) (time.Time, error) {
+	for _, l := range dateLayouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("the date %q must be in RFC 3339 format, e.g. 2024-01-01 or 2024-01-01T10:00:00Z: %w", s, errors.ErrInvalid)
+}
+
+// FormatDate formats the timestamp in UTC according to the DateLayout
+func FormatDate(t time.Time) string {
+	return t.UTC().Format(DateLayout)
+}
+
+// FormatNumber formats the number constant without the trailing zeros
+func FormatNumber(n float32) string {
+	return strconv.FormatFloat(float64(n), 'f', -1, 32)
+}
+
+// ParseAttrCondition checks the condition c with the attr() lvalue and returns its typed form.
+// The type of the comparison is defined by the rvalue: a string, a number, date() or true/false.
+func ParseAttrCondition(c Condition) (AttrCondition, error) {
+	f := c.FirstParam.Function
+	if f == nil {
+		return AttrCondition{}, fmt.Errorf("attr must be a function: %w", errors.ErrInvalid)
+	}
+	if len(f.Params) != 1 || f.Params[0].id() != StringParamID {
+		return AttrCondition{}, fmt.Errorf("attr() function expects only one parameter - the name of the attribute (string): %w", errors.ErrInvalid)
+	}
+	ac := AttrCondition{Name: f.Params[0].Const.String, Op: strings.ToUpper(c.Op)}
+	if err := CheckAttrName(ac.Name); err != nil {
+		return AttrCondition{}, err
+	}
+	if c.SecondParam == nil {
+		return AttrCondition{}, fmt.Errorf("attr(%q) should be compared with something in a condition: %w", ac.Name, errors.ErrInvalid)
+	}
+	p := *c.SecondParam
+	switch p.id() {
+	case StringParamID:
+		ac.Kind, ac.Values = KindString, []string{p.Const.String}
+	case NumberParamID:
+		ac.Kind, ac.Values = KindNumber, []string{FormatNumber(p.Const.Number)}
+	case "date":
+		t, err := dateParam(p)
+		if err != nil {
+			return AttrCondition{}, err
+		}
+		ac.Kind, ac.Values = KindDate, []string{FormatDate(t)}
+	case "true", "false":
+		if p.Function != nil {
+			return AttrCondition{}, fmt.Errorf("%s must be an identifier: %w", p.Function.Name, errors.ErrInvalid)
+		}
+		if ac.Op != "=" && ac.Op != "!=" {
+			return AttrCondition{}, fmt.Errorf("attr(%q) may be compared with %s by = or != only: %w", ac.Name, p.Identifier, errors.ErrInvalid)
+		}
+		ac.Kind, ac.Values = KindBool, []string{p.Identifier}
+	case ArrayParamID:
+		ac.Kind = KindString
+		for i, cv := range p.Array {
+			k, v := KindString, cv.String
+			if !cv.IsString() {
+				k, v = KindNumber, FormatNumber(cv.Number)
+			}
+			if i > 0 && k != ac.Kind {
+				return AttrCondition{}, fmt.Errorf("the array %s must contain either strings or numbers: %w", p.Name(false), errors.ErrInvalid)
+			}
+			ac.Kind = k
+			ac.Values = append(ac.Values, v)
+		}
+	default:
+		return AttrCondition{}, fmt.Errorf("attr(%q) cannot be compared with %s: %w", ac.Name, p.Name(false), errors.ErrInvalid)
+	}
+	return ac, nil
+}
+
+// PqAttrValue returns the Postgres expression for the value of the attribute name stored in the jsonb column col.
+// The value is NULL, if the attribute is not set, or it is not of the kind. The strings and dates are compared
+// by their bytes, so the same expressions are used for the attributes indexes.
+func PqAttrValue(col, name string, kind ValueKind) string {
+	switch kind {
+	case KindNumber:
+		return fmt.Sprintf("(case when jsonb_typeof(%[1]s -> '%[2]s') = 'number' then (%[1]s ->> '%[2]s')::numeric end)", col, name)
+	case KindBool:
+		return fmt.Sprintf("(case when jsonb_typeof(%[1]s -> '%[2]s') = 'boolean' then (%[1]s ->> '%[2]s')::boolean end)", col, name)
+	}
+	return fmt.Sprintf("((case when jsonb_typeof(%[1]s -> '%[2]s') = 'string' then %[1]s ->> '%[2]s' end) collate \"C\")", col, name)
+}
+
+// SqliteAttrValue returns the SQLite expression for the value of the attribute name stored in the json column
+// col. The value is NULL, if the attribute is not set, or it is not of the kind. The booleans are 1 or 0.
+func SqliteAttrValue(col, name string, kind ValueKind) string {
+	types := "'text'"
+	switch kind {
+	case KindNumber:
+		types = "'integer', 'real'"
+	case KindBool:
+		types = "'true', 'false'"
+	}
+	return fmt.Sprintf("(case when json_type(%[1]s, '$.\"%[2]s\"') in (%[3]s) then json_extract(%[1]s, '$.\"%[2]s\"') end)", col, name, types)
+}
+
+func pqAttrCondition(tr Translator, sb *strings.Builder, c Condition) error {
+	ac, err := ParseAttrCondition(c)
+	if err != nil {
+		return err
+	}
+	if ac.Op == "IN" && len(ac.Values) == 0 {
+		sb.WriteString("false")
+		return nil
+	}
+	if ac.Kind == KindString && (ac.Op == "=" || ac.Op == "!=" || ac.Op == "IN") {
+		// the jsonb containment matches both the string and the strings list containing it
+		if ac.Op == "!=" {
+			sb.WriteString("NOT ")
+		}
+		sb.WriteString("(")
+		for i, v := range ac.Values {
+			if i > 0 {
+				sb.WriteString(" OR ")
+			}
+			b, _ := json.Marshal(v)
+			sb.WriteString(fmt.Sprintf("(n.attrs -> '%s') @> ", ac.Name))
+			pqString(sb, string(b))
+			sb.WriteString("::jsonb")
+		}
+		sb.WriteString(")")
+		return nil
+	}
+	sb.WriteString(PqAttrValue("n.attrs", ac.Name, ac.Kind))
+	writeAttrOp(sb, ac, func(v string) {
+		if ac.Kind == KindString || ac.Kind == KindDate {
+			pqString(sb, v)
+			return
+		}
+		sb.WriteString(v)
+	})
+	return nil
+}
+
+func sqliteAttrCondition(tr Translator, sb *strings.Builder, c Condition) error {
+	ac, err := ParseAttrCondition(c)
+	if err != nil {
+		return err
+	}
+	if ac.Op == "IN" && len(ac.Values) == 0 {
+		sb.WriteString("false")
+		return nil
+	}
+	if ac.Kind == KindString && (ac.Op == "=" || ac.Op == "!=" || ac.Op == "IN") {
+		// json_each returns the string itself or the strings list elements
+		if ac.Op == "!=" {
+			sb.WriteString("NOT ")
+		}
+		path := fmt.Sprintf("'$.\"%s\"'", ac.Name)
+		sb.WriteString(fmt.Sprintf("(case when json_type(n.attrs, %[1]s) is null then null else exists "+
+			"(select 1 from json_each(n.attrs, %[1]s) as a where a.type = 'text' and a.value in (", path))
+		for i, v := range ac.Values {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sqliteString(sb, v)
+		}
+		sb.WriteString(")) end)")
+		return nil
+	}
+	sb.WriteString(SqliteAttrValue("n.attrs", ac.Name, ac.Kind))
+	writeAttrOp(sb, ac, func(v string) {
+		switch ac.Kind {
+		case KindString, KindDate:
+			sqliteString(sb, v)
+		case KindBool:
+			if v == "true" {
+				sb.WriteString("1")
+			} else {
+				sb.WriteString("0")
+			}
+		default:
+			sb.WriteString(v)
+		}
+	})
+	return nil
+}
+
+// writeAttrOp writes the operation and the values of the attribute condition, the values are written by the value function
+func writeAttrOp(sb *strings.Builder, ac AttrCondition, value func(v string)) {
+	sb.WriteString(" ")
+	sb.WriteString(ac.Op)
+	sb.WriteString(" ")
+	if ac.Op != "IN" {
+		value(ac.Values[0])
+		return
+	}
+	sb.WriteString("(")
+	for i, v := range ac.Values {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		value(v)
+	}
+	sb.WriteString(")")
+}
+
+// dateConst returns the translation of the date() function to the string constant in DateLayout
+func dateConst(quote func(sb *strings.Builder, s string)) func(tr Translator, sb *strings.Builder, p Param) error {
+	return func(tr Translator, sb *strings.Builder, p Param) error {
+		t, err := dateParam(p)
+		if err != nil {
+			return err
+		}
+		quote(sb, FormatDate(t))
+		return nil
+	}
+}
+
+// boolConst returns the translation of the true and false identifiers to the string constants
+func boolConst(quote func(sb *strings.Builder, s string)) func(tr Translator, sb *strings.Builder, p Param) error {
+	return func(tr Translator, sb *strings.Builder, p Param) error {
+		if p.Function != nil {
+			return fmt.Errorf("%s must be an identifier: %w", p.Function.Name, errors.ErrInvalid)
+		}
+		quote(sb, p.Identifier)
+		return nil
+	}
+}
+
+func dateParam(p Param) (time.Time, error) {
+	if p.Function == nil {
+		return time.Time{}, fmt.Errorf("date must be a function: %w", errors.ErrInvalid)
+	}
+	if len(p.Function.Params) != 1 || p.Function.Params[0].id() != StringParamID {
+		return time.Time{}, fmt.Errorf("date() function expects only one parameter - the date (string): %w", errors.ErrInvalid)
+	}
+	return ParseDate(p.Function.Params[0].Const.String)
+}
+
+// pqString writes the string constant s in single quotes, the quotes inside are escaped the same way as for SQLite
+func pqString(sb *strings.Builder, s string) {
+	sqliteString(sb, s)
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 The Simila Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ql
+
+import (
+	"github.com/acquirecloud/golibs/errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	d, err := ParseDate("2024-01-02")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), d)
+	d, err = ParseDate("2024-01-02T10:11:12+02:00")
+	assert.Nil(t, err)
+	assert.Equal(t, "2024-01-02T08:11:12.000Z", FormatDate(d))
+	d, err = ParseDate("2024-01-02T10:11:12.5")
+	assert.Nil(t, err)
+	assert.Equal(t, "2024-01-02T10:11:12.500Z", FormatDate(d))
+	_, err = ParseDate("01/02/2024")
+	assert.True(t, errors.Is(err, errors.ErrInvalid))
+}
+
+func TestCheckAttrName(t *testing.T) {
+	assert.Nil(t, CheckAttrName("price"))
+	assert.Nil(t, CheckAttrName("_page_count2"))
+	assert.True(t, errors.Is(CheckAttrName(""), errors.ErrInvalid))
+	assert.True(t, errors.Is(CheckAttrName("2pages"), errors.ErrInvalid))
+	assert.True(t, errors.Is(CheckAttrName("a'b"), errors.ErrInvalid))
+	assert.True(t, errors.Is(CheckAttrName(strings.Repeat("a", MaxAttrNameLength+1)), errors.ErrInvalid))
+}
+
+func TestParseAttrCondition(t *testing.T) {
+	cond := func(expr string) Condition {
+		e, err := Parse(expr)
+		assert.Nil(t, err)
+		return *e.Or[0].And[0].Cond
+	}
+	ac, err := ParseAttrCondition(cond("attr('price') > 10.5"))
+	assert.Nil(t, err)
+	assert.Equal(t, AttrCondition{Name: "price", Op: ">", Kind: KindNumber, Values: []string{"10.5"}}, ac)
+	ac, err = ParseAttrCondition(cond("attr('published') >= date('2024-01-01')"))
+	assert.Nil(t, err)
+	assert.Equal(t, AttrCondition{Name: "published", Op: ">=", Kind: KindDate, Values: []string{"2024-01-01T00:00:00.000Z"}}, ac)
+	ac, err = ParseAttrCondition(cond("attr('draft') = false"))
+	assert.Nil(t, err)
+	assert.Equal(t, AttrCondition{Name: "draft", Op: "=", Kind: KindBool, Values: []string{"false"}}, ac)
+	ac, err = ParseAttrCondition(cond("attr('authors') in ['a', 'b']"))
+	assert.Nil(t, err)
+	assert.Equal(t, AttrCondition{Name: "authors", Op: "IN", Kind: KindString, Values: []string{"a", "b"}}, ac)
+
+	for _, expr := range []string{"attr('draft') > true", "attr('a') in [1, 'b']", "attr('a') = date('abc')",
+		"attr('a') = path", "attr(1) = 1", "attr('a b') = 1", "attr('a', 'b') = 1", "attr('a') = true()"} {
+		_, err = ParseAttrCondition(cond(expr))
+		assert.True(t, errors.Is(err, errors.ErrInvalid), expr)
+	}
+}
+
+func TestPqAttrConditions(t *testing.T) {
+	tr := NewTranslator(PqFilterConditionsDialect)
+	var sb strings.Builder
+	assert.Nil(t, tr.Translate(&sb, "attr('price') <= 9.99 and attr('published') >= date('2024-01-01') or attr('draft') != true"))
+	assert.Equal(t, "(case when jsonb_typeof(n.attrs -> 'price') = 'number' then (n.attrs ->> 'price')::numeric end) <= 9.99 AND "+
+		"((case when jsonb_typeof(n.attrs -> 'published') = 'string' then n.attrs ->> 'published' end) collate \"C\") >= '2024-01-01T00:00:00.000Z' OR "+
+		"(case when jsonb_typeof(n.attrs -> 'draft') = 'boolean' then (n.attrs ->> 'draft')::boolean end) != true", sb.String())
+
+	sb.Reset()
+	assert.Nil(t, tr.Translate(&sb, "attr('authors') in ['a', \"b'\"] and attr('lang') != 'en' and attr('title') like 'a%' and attr('x') in []"))
+	assert.Equal(t, "((n.attrs -> 'authors') @> '\"a\"'::jsonb OR (n.attrs -> 'authors') @> '\"b''\"'::jsonb) AND "+
+		"NOT ((n.attrs -> 'lang') @> '\"en\"'::jsonb) AND "+
+		"((case when jsonb_typeof(n.attrs -> 'title') = 'string' then n.attrs ->> 'title' end) collate \"C\") LIKE 'a%' AND false", sb.String())
+
+	sb.Reset()
+	assert.Nil(t, tr.Translate(&sb, "tag('published') > date('2024-01-01') and tag('draft') = true"))
+	assert.Equal(t, "n.tags ->> 'published' > '2024-01-01T00:00:00.000Z' AND n.tags ->> 'draft' = 'true'", sb.String())
+
+	for _, expr := range []string{"attr('a')", "true = attr('a')", "attr('a') like 1", "attr('a') in 'b'", "attr('a') = attr('b')"} {
+		assert.True(t, errors.Is(tr.Translate(&sb, expr), errors.ErrInvalid), expr)
+	}
+}
+
+func TestSqliteAttrConditions(t *testing.T) {
+	tr := NewTranslator(SqliteFilterConditionsDialect)
+	var sb strings.Builder
+	assert.Nil(t, tr.Translate(&sb, "attr('pages') in [1, 2] and attr('published') < date('2024-01-01') or attr('draft') = true"))
+	assert.Equal(t, "(case when json_type(n.attrs, '$.\"pages\"') in ('integer', 'real') then json_extract(n.attrs, '$.\"pages\"') end) IN (1, 2) AND "+
+		"(case when json_type(n.attrs, '$.\"published\"') in ('text') then json_extract(n.attrs, '$.\"published\"') end) < '2024-01-01T00:00:00.000Z' OR "+
+		"(case when json_type(n.attrs, '$.\"draft\"') in ('true', 'false') then json_extract(n.attrs, '$.\"draft\"') end) = 1", sb.String())
+
+	sb.Reset()
+	assert.Nil(t, tr.Translate(&sb, "attr('authors') != 'a'"))
+	assert.Equal(t, "NOT (case when json_type(n.attrs, '$.\"authors\"') is null then null else exists "+
+		"(select 1 from json_each(n.attrs, '$.\"authors\"') as a where a.type = 'text' and a.value in ('a')) end)", sb.String())
+}
@@ -56,10 +56,12 @@ type (
 		Array      []*Const  `|"[" (@@ {"," @@})?"]"`
 	}
 
-	// Const contains the constant either string or float32 value
+	// Const contains the constant either string or float32 value. The empty
+	// string literal is parsed separately, so it could be told from the number 0
 	Const struct {
 		Number float32 ` @Number`
 		String string  ` | @String`
+		Empty  bool    ` | @EmptyString`
 	}
 
 	// Function is a functional parameter
@@ -79,6 +81,10 @@ type (
 		// on the ql level the function hasPrefix(path, "abc") may be translated to the Postgres SQL:
 		// position('abc' in record.path) = 1
 		Translate func(tr Translator, sb *strings.Builder, p Param) error
+		// TranslateCondition is the function (can be nil), which is called for translation the whole condition
+		// c, where the parameter is the lvalue. It allows to translate the condition depending on the rvalue,
+		// for example, attr("price") > 10 is translated to the numeric comparison of the attribute value.
+		TranslateCondition func(tr Translator, sb *strings.Builder, c Condition) error
 	}
 
 	// Translator struct allows to turn AST objects (Expression, Condition etc.) to
@@ -93,6 +99,7 @@ var (
 		{`Keyword`, `(?i)\b(AND|OR|NOT|IN|LIKE)\b`},
 		{`Ident`, `[a-zA-Z_][a-zA-Z0-9_]*`},
 		{`Number`, `[-+]?\d*\.?\d+([eE][-+]?\d+)?`},
+		{`EmptyString`, `''|""`},
 		{`String`, `'[^']*'|"[^"]*"`},
 		{`Operators`, `!=|<=|>=|[,()=<>\]\[]`},
 		{"whitespace", `\s+`},
@@ -165,6 +172,34 @@ var (
 			},
 		},
 
+		// inheritedTag function is written the same way as the tag function, but it returns the tag value
+		// of the node or, if the node doesn't have the tag, of its nearest ancestor, which has the tag
+		"inheritedTag": {
+			Flags: PfLValue | PfComparable | PfRValue | PfInLike,
+			Translate: func(tr Translator, sb *strings.Builder, p Param) error {
+				if err := checkInheritedTag(p); err != nil {
+					return err
+				}
+				sb.WriteString("n.effective_tags ->> ")
+				_ = tr.Param2Sql(sb, p.Function.Params[0])
+				return nil
+			},
+		},
+
+		// rmeta function is written the way -> 'rmeta("section") = "abstract"', it refers to the
+		// index record meta, so it may be used only where the records are selected
+		"rmeta": {
+			Flags: PfLValue | PfComparable | PfRValue | PfInLike,
+			Translate: func(tr Translator, sb *strings.Builder, p Param) error {
+				if err := checkRMeta(p); err != nil {
+					return err
+				}
+				sb.WriteString("ir.meta ->> ")
+				_ = tr.Param2Sql(sb, p.Function.Params[0])
+				return nil
+			},
+		},
+
 		// prefix(s, p) returns whether the s has prefix p
 		"prefix": {
 			Flags: PfLValue | PfNop,
@@ -183,6 +218,131 @@ var (
 				return nil
 			},
 		},
+
+		// attr function is written the way -> 'attr("price") > 10' or 'attr("published") >= date("2024-01-01")',
+		// the attribute value is compared according to the type of the rvalue
+		"attr": {
+			Flags:              PfLValue | PfComparable | PfInLike,
+			TranslateCondition: pqAttrCondition,
+		},
+
+		// date("2024-01-01") is the timestamp constant, it is compared with the timestamp attributes
+		"date": {
+			Flags:     PfRValue | PfComparable,
+			Translate: dateConst(pqString),
+		},
+
+		// true and false are the boolean constants, they are compared with the bool attributes
+		"true":  {Flags: PfRValue | PfComparable, Translate: boolConst(pqString)},
+		"false": {Flags: PfRValue | PfComparable, Translate: boolConst(pqString)},
+	}
+
+	// SqliteFilterConditionsDialect is a set of specific dialects for
+	// translating filter conditions into SQLite where condition.
+	SqliteFilterConditionsDialect = map[string]Dialect{
+		StringParamID: {
+			Flags: PfRValue | PfComparable, // strings are rvalues only
+			Translate: func(tr Translator, sb *strings.Builder, p Param) error {
+				sqliteString(sb, p.Const.String)
+				return nil
+			},
+		},
+		NumberParamID: {Flags: PfRValue | PfComparable}, // numbers are rvalues only
+		ArrayParamID: {
+			Flags: PfRValue, // arrays are rvalues only
+			Translate: func(tr Translator, sb *strings.Builder, p Param) error {
+				// the SQLite IN operator expects the list in parentheses
+				sb.WriteString("(")
+				for i, c := range p.Array {
+					if i > 0 {
+						sb.WriteString(", ")
+					}
+					if c.IsString() {
+						sqliteString(sb, c.String)
+					} else {
+						sb.WriteString(c.Value())
+					}
+				}
+				sb.WriteString(")")
+				return nil
+			},
+		},
+		"path":   PqFilterConditionsDialect["path"],
+		"node":   PqFilterConditionsDialect["node"],
+		"format": PqFilterConditionsDialect["format"],
+
+		// tag function is written the way -> 'tag("abc") in ["1", "2", "3"]' or 'tag("t1") = "aaa"'
+		"tag": {
+			Flags: PfLValue | PfComparable | PfRValue | PfInLike,
+			Translate: func(tr Translator, sb *strings.Builder, p Param) error {
+				if p.Function == nil {
+					return fmt.Errorf("tag must be a function: %w", errors.ErrInvalid)
+				}
+				if len(p.Function.Params) != 1 {
+					return fmt.Errorf("tag() function expects only one parameter - the name of the tag: %w", errors.ErrInvalid)
+				}
+				if p.Function.Params[0].id() != StringParamID {
+					return fmt.Errorf("tag() function expects the tag name (string) as the parameter: %w", errors.ErrInvalid)
+				}
+				sb.WriteString("json_extract(n.tags, ")
+				sqliteString(sb, fmt.Sprintf("$.%q", p.Function.Params[0].Const.String))
+				sb.WriteString(")")
+				return nil
+			},
+		},
+
+		"inheritedTag": {
+			Flags: PfLValue | PfComparable | PfRValue | PfInLike,
+			Translate: func(tr Translator, sb *strings.Builder, p Param) error {
+				if err := checkInheritedTag(p); err != nil {
+					return err
+				}
+				sb.WriteString("json_extract(n.effective_tags, ")
+				sqliteString(sb, fmt.Sprintf("$.%q", p.Function.Params[0].Const.String))
+				sb.WriteString(")")
+				return nil
+			},
+		},
+
+		"rmeta": {
+			Flags: PfLValue | PfComparable | PfRValue | PfInLike,
+			Translate: func(tr Translator, sb *strings.Builder, p Param) error {
+				if err := checkRMeta(p); err != nil {
+					return err
+				}
+				sb.WriteString("json_extract(ir.meta, ")
+				sqliteString(sb, fmt.Sprintf("$.%q", p.Function.Params[0].Const.String))
+				sb.WriteString(")")
+				return nil
+			},
+		},
+
+		// prefix(s, p) returns whether the s has prefix p
+		"prefix": {
+			Flags: PfLValue | PfNop,
+			Translate: func(tr Translator, sb *strings.Builder, p Param) error {
+				if p.Function == nil {
+					return fmt.Errorf("prefix must be a function: %w", errors.ErrInvalid)
+				}
+				if len(p.Function.Params) != 2 {
+					return fmt.Errorf("prefix(s, p) function expects two parameters: %w", errors.ErrInvalid)
+				}
+				sb.WriteString(" instr(")
+				_ = tr.Param2Sql(sb, p.Function.Params[0])
+				sb.WriteString(", ")
+				_ = tr.Param2Sql(sb, p.Function.Params[1])
+				sb.WriteString(") = 1")
+				return nil
+			},
+		},
+
+		"attr": {
+			Flags:              PfLValue | PfComparable | PfInLike,
+			TranslateCondition: sqliteAttrCondition,
+		},
+		"date":  {Flags: PfRValue | PfComparable, Translate: dateConst(sqliteString)},
+		"true":  {Flags: PfRValue | PfComparable, Translate: boolConst(sqliteString)},
+		"false": {Flags: PfRValue | PfComparable, Translate: boolConst(sqliteString)},
 	}
 )
 
@@ -216,7 +376,7 @@ func NewTranslator(dialects map[string]Dialect) Translator {
 // - array: ArrayParamID
 func (p Param) id() string {
 	if p.Const != nil {
-		if p.Const.String != "" {
+		if p.Const.IsString() {
 			return StringParamID
 		}
 		return NumberParamID
@@ -258,23 +418,37 @@ func (p Param) Name(full bool) string {
 	return sb.String()
 }
 
+// IsString returns true if the constant is a string, the empty one as well
+func (c Const) IsString() bool {
+	return c.String != "" || c.Empty
+}
+
 // Value returns string value of the constant
 func (c Const) Value() string {
-	if c.String != "" {
+	if c.IsString() {
 		return fmt.Sprintf("%q", c.String)
 	}
 	return fmt.Sprintf("%f", c.Number)
 }
 
-// Translate translates the expression string to string according to the dialect of the translator
-func (tr Translator) Translate(sb *strings.Builder, expr string) error {
+// Parse turns the expression string to the AST object. It returns nil, if the expression is empty
+func Parse(expr string) (*Expression, error) {
 	expr = strings.TrimSpace(expr)
 	if len(expr) == 0 {
-		return nil
+		return nil, nil
 	}
 	e, err := parser.ParseString("", expr)
 	if err != nil {
-		return fmt.Errorf("failed to parse expression=%q: %w", expr, err)
+		return nil, fmt.Errorf("failed to parse expression=%q: %w", expr, err)
+	}
+	return e, nil
+}
+
+// Translate translates the expression string to string according to the dialect of the translator
+func (tr Translator) Translate(sb *strings.Builder, expr string) error {
+	e, err := Parse(expr)
+	if err != nil || e == nil {
+		return err
 	}
 	if err = tr.Expression2Sql(sb, e); err != nil {
 		return fmt.Errorf("failed to translate expression=%q: %w", expr, err)
@@ -380,6 +554,9 @@ func (tr Translator) Condition2Sql(sb *strings.Builder, c *Condition) error {
 	default:
 		return fmt.Errorf("unknown operation %s: %w", c.Op, errors.ErrInvalid)
 	}
+	if d.TranslateCondition != nil {
+		return d.TranslateCondition(tr, sb, *c)
+	}
 	if err := tr.Param2Sql(sb, &c.FirstParam); err != nil {
 		return err
 	}
@@ -421,3 +598,38 @@ func (tr Translator) Params2Sql(sb *strings.Builder, prms []*Param) error {
 	}
 	return nil
 }
+
+// sqliteString writes the string constant s in single quotes, escaping the quotes inside
+func sqliteString(sb *strings.Builder, s string) {
+	sb.WriteString("'")
+	sb.WriteString(strings.ReplaceAll(s, "'", "''"))
+	sb.WriteString("'")
+}
+
+// checkRMeta checks that the rmeta() function has only one parameter - the name of the record meta key
+func checkRMeta(p Param) error {
+	if p.Function == nil {
+		return fmt.Errorf("rmeta must be a function: %w", errors.ErrInvalid)
+	}
+	if len(p.Function.Params) != 1 {
+		return fmt.Errorf("rmeta() function expects only one parameter - the name of the record meta key: %w", errors.ErrInvalid)
+	}
+	if p.Function.Params[0].id() != StringParamID {
+		return fmt.Errorf("rmeta() function expects the record meta key (string) as the parameter: %w", errors.ErrInvalid)
+	}
+	return nil
+}
+
+// checkInheritedTag checks that the inheritedTag() function has only one parameter - the name of the tag
+func checkInheritedTag(p Param) error {
+	if p.Function == nil {
+		return fmt.Errorf("inheritedTag must be a function: %w", errors.ErrInvalid)
+	}
+	if len(p.Function.Params) != 1 {
+		return fmt.Errorf("inheritedTag() function expects only one parameter - the name of the tag: %w", errors.ErrInvalid)
+	}
+	if p.Function.Params[0].id() != StringParamID {
+		return fmt.Errorf("inheritedTag() function expects the tag name (string) as the parameter: %w", errors.ErrInvalid)
+	}
+	return nil
+}
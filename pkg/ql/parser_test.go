@@ -38,6 +38,16 @@ func TestParseParam(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "1234", res.Const.String)
 
+	res, err = p.ParseString("", "''")
+	assert.Nil(t, err)
+	assert.True(t, res.Const.IsString())
+	assert.Equal(t, StringParamID, res.id())
+
+	res, err = p.ParseString("", "0")
+	assert.Nil(t, err)
+	assert.False(t, res.Const.IsString())
+	assert.Equal(t, NumberParamID, res.id())
+
 	res, err = p.ParseString("", "lala")
 	assert.Nil(t, err)
 	assert.Equal(t, "lala", res.Identifier)
@@ -229,4 +239,61 @@ func TestPqFilterConditionsDialect(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Nil(t, tr.Expression2Sql(&sb, e))
 	assert.Equal(t, "n.tags ->> 'abc' = n.tags ->> 'def' AND ( position('/aaa/' in n.path) = 1 OR ir.format = 1234.300049) OR ir.format LIKE 'aaa%' OR n.name = '123'", sb.String())
+
+	sb.Reset()
+	e, err = parser.ParseString("", "rmeta('section') = 'abstract' or rmeta('lang') like 'en%'")
+	assert.Nil(t, err)
+	assert.Nil(t, tr.Expression2Sql(&sb, e))
+	assert.Equal(t, "ir.meta ->> 'section' = 'abstract' OR ir.meta ->> 'lang' LIKE 'en%'", sb.String())
+
+	e, err = parser.ParseString("", "rmeta('a', 'b') = 'c'")
+	assert.Nil(t, err)
+	assert.NotNil(t, tr.Expression2Sql(&sb, e))
+
+	sb.Reset()
+	e, err = parser.ParseString("", "inheritedTag('team') = 'x' and tag('team') != 'x'")
+	assert.Nil(t, err)
+	assert.Nil(t, tr.Expression2Sql(&sb, e))
+	assert.Equal(t, "n.effective_tags ->> 'team' = 'x' AND n.tags ->> 'team' != 'x'", sb.String())
+
+	e, err = parser.ParseString("", "inheritedTag(path) = 'x'")
+	assert.Nil(t, err)
+	assert.NotNil(t, tr.Expression2Sql(&sb, e))
+}
+
+func TestSqliteFilterConditionsDialect(t *testing.T) {
+	tr := NewTranslator(SqliteFilterConditionsDialect)
+
+	var sb strings.Builder
+	e, err := parser.ParseString("", "tag(1234) = \"234\"")
+	assert.Nil(t, err)
+	assert.NotNil(t, tr.Expression2Sql(&sb, e))
+
+	sb.Reset()
+	e, err = parser.ParseString("", "tag('abc') in ['a', \"b's\"] and (prefix(path, \"/aaa/\") or format = 1234.3) or format like \"aaa%\" or node = '123'")
+	assert.Nil(t, err)
+	assert.Nil(t, tr.Expression2Sql(&sb, e))
+	assert.Equal(t, "json_extract(n.tags, '$.\"abc\"') IN ('a', 'b''s') AND ( instr(n.path, '/aaa/') = 1 OR ir.format = 1234.300049) OR ir.format LIKE 'aaa%' OR n.name = '123'", sb.String())
+
+	sb.Reset()
+	e, err = parser.ParseString("", "rmeta('section') = 'abstract'")
+	assert.Nil(t, err)
+	assert.Nil(t, tr.Expression2Sql(&sb, e))
+	assert.Equal(t, "json_extract(ir.meta, '$.\"section\"') = 'abstract'", sb.String())
+
+	e, err = parser.ParseString("", "rmeta(1) = 'c'")
+	assert.Nil(t, err)
+	assert.NotNil(t, tr.Expression2Sql(&sb, e))
+
+	sb.Reset()
+	e, err = parser.ParseString("", "inheritedTag('team') in ['x', 'y']")
+	assert.Nil(t, err)
+	assert.Nil(t, tr.Expression2Sql(&sb, e))
+	assert.Equal(t, "json_extract(n.effective_tags, '$.\"team\"') IN ('x', 'y')", sb.String())
+
+	sb.Reset()
+	e, err = parser.ParseString("", "tag('k') in ['', \"\"]")
+	assert.Nil(t, err)
+	assert.Nil(t, tr.Expression2Sql(&sb, e))
+	assert.Equal(t, "json_extract(n.tags, '$.\"k\"') IN ('', '')", sb.String())
 }
@@ -20,7 +20,16 @@ import (
 	"github.com/acquirecloud/golibs/config"
 	"github.com/acquirecloud/golibs/logging"
 	"github.com/acquirecloud/golibs/transport"
+	"github.com/simila-io/simila/pkg/auth"
+	"github.com/simila-io/simila/pkg/embedding"
+	"github.com/simila-io/simila/pkg/indexer/changes"
+	"github.com/simila-io/simila/pkg/indexer/idempotency"
+	"github.com/simila-io/simila/pkg/indexer/jobs"
 	"github.com/simila-io/simila/pkg/indexer/persistence/postgres"
+	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/pgvector"
+	"github.com/simila-io/simila/pkg/indexer/trash"
+	"github.com/simila-io/simila/pkg/indexer/webhooks"
+	"time"
 )
 
 type (
@@ -32,8 +41,32 @@ type (
 		HttpPort int
 		// SearchEngine specifies which engine is used for search
 		SearchEngine string
+		// SearchEngines specifies the additional engines, which are turned on alongside the SearchEngine,
+		// so the engine can be chosen per search request. The postgres engines are supported only.
+		SearchEngines []string
 		// DB specifies settings for DB used as a full text search engine (e.g. postgres)
 		DB *DB
+		// Bleve specifies settings for the Bleve search engine, it is used with the "bleve" SearchEngine only
+		Bleve *Bleve
+		// Elastic specifies settings for the Elasticsearch (or OpenSearch) cluster, it is used with the "elastic" SearchEngine only
+		Elastic *Elastic
+		// Pgvector specifies settings for the pgvector semantic search, it is used with the "pgvector" SearchEngine,
+		// or with another postgres SearchEngine if the Pgvector.Semantic is set
+		Pgvector *Pgvector
+		// Embedding specifies settings for the records embeddings provider, the embeddings are not calculated if the Type is empty
+		Embedding *Embedding
+		// Trash specifies settings for the trashed nodes purge
+		Trash *Trash
+		// Changes specifies settings for the changelog purge
+		Changes *Changes
+		// Webhooks specifies settings for the webhook events delivery
+		Webhooks *Webhooks
+		// Idempotency specifies settings for the idempotency keys of the requests
+		Idempotency *Idempotency
+		// Jobs specifies settings for the asynchronous records creation jobs
+		Jobs *Jobs
+		// Auth specifies settings for the API callers authentication and the nodes access control
+		Auth *Auth
 	}
 
 	DB struct {
@@ -45,6 +78,115 @@ type (
 		DBName   string
 		SSLMode  string
 	}
+
+	Bleve struct {
+		// IndexDir is the directory where the Bleve index is stored
+		IndexDir string
+	}
+
+	Elastic struct {
+		// URL is the cluster address, e.g. "http://localhost:9200"
+		URL string
+		// Index is the name of the index where the records are stored
+		Index    string
+		Username string
+		Password string
+	}
+
+	Pgvector struct {
+		// Dims is the number of the embedding dimensions
+		Dims int
+		// IndexType is the vector index type: "hnsw" or "ivfflat"
+		IndexType string
+		// Semantic turns on the semantic search alongside the SearchEngine, so the semantic and the hybrid
+		// search modes are available
+		Semantic bool
+	}
+
+	Embedding struct {
+		// Type is the embedding provider type: "openai", "hashing" or empty to turn the embeddings off
+		Type string
+		// Dims is the number of the embedding dimensions, the Pgvector.Dims is used if it is 0
+		Dims int
+		// BatchSize is the maximum number of the records sent to the provider in one call
+		BatchSize int
+		// MaxRetries is the number of times a failed provider call is retried
+		MaxRetries int
+		// OpenAI specifies settings for the OpenAI-compatible embeddings API, it is used with the "openai" Type only
+		OpenAI *OpenAI
+	}
+
+	OpenAI struct {
+		// URL is the API base address, e.g. "https://api.openai.com/v1"
+		URL    string
+		APIKey string
+		Model  string
+	}
+
+	Trash struct {
+		// Retention is how long the trashed nodes are kept before they are purged, e.g. "720h".
+		// The purge is turned off if it is "0" or empty
+		Retention string
+		// PurgeInterval is how often the trashed nodes are purged, e.g. "1h"
+		PurgeInterval string
+	}
+
+	Changes struct {
+		// Retention is how long the changes are kept in the changelog before they are purged, e.g. "168h".
+		// The purge is turned off if it is "0" or empty
+		Retention string
+		// PurgeInterval is how often the changelog is purged, e.g. "1h"
+		PurgeInterval string
+	}
+
+	Webhooks struct {
+		// Interval is how often the changelog is checked for the new webhook events, e.g. "5s"
+		Interval string
+		// MaxRetries is the number of the delivery retries before the event is moved to the dead letters
+		MaxRetries int
+		// Backoff is the delay before the first delivery retry, it is doubled for every next retry, e.g. "1s"
+		Backoff string
+		// Timeout is the delivery request timeout, e.g. "10s"
+		Timeout string
+		// Lease is how long a webhook is locked by the instance delivering its events, e.g. "1m"
+		Lease string
+	}
+
+	Idempotency struct {
+		// TTL is how long the responses are kept for the idempotency keys, e.g. "24h"
+		TTL string
+		// PurgeInterval is how often the expired idempotency keys are purged, e.g. "1h"
+		PurgeInterval string
+		// Lease is how long an in-progress idempotency key is locked by its request, e.g. "1m"
+		Lease string
+	}
+
+	Jobs struct {
+		// Workers is the number of the jobs run concurrently
+		Workers int
+		// QueueSize is the max number of the jobs waiting for the workers
+		QueueSize int
+		// SpoolDir is the directory where the documents are kept until their jobs are finished
+		SpoolDir string
+		// Lease is how long the jobs are locked by the instance running them, e.g. "1m"
+		Lease string
+	}
+
+	Auth struct {
+		// Tokens are the API bearer tokens with the identities of their callers. The authentication
+		// and the nodes access control are turned off if there are no tokens
+		Tokens []AuthToken
+	}
+
+	AuthToken struct {
+		Token string
+		// Principal is the caller name, which the nodes ACLs grant the permissions to
+		Principal string
+		// Groups are the groups the caller is a member of
+		Groups []string
+		// Admin gives the caller the full access to all the nodes and to the cluster endpoints
+		Admin bool
+	}
 )
 
 func (d *DB) SourceName() string {
@@ -72,9 +214,187 @@ func getDefaultConfig() *Config {
 			DBName:   "simila",
 			SSLMode:  "disable",
 		},
+		Bleve: &Bleve{
+			IndexDir: "simila.bleve",
+		},
+		Elastic: &Elastic{
+			URL:   "http://localhost:9200",
+			Index: "simila",
+		},
+		Pgvector: &Pgvector{
+			Dims:      pgvector.DefaultDims,
+			IndexType: pgvector.IndexHnsw,
+		},
+		Embedding: &Embedding{
+			BatchSize:  embedding.DefaultBatchSize,
+			MaxRetries: embedding.DefaultMaxRetries,
+			OpenAI: &OpenAI{
+				URL:   embedding.DefaultOpenAIConfig().URL,
+				Model: embedding.DefaultOpenAIConfig().Model,
+			},
+		},
+		Trash: &Trash{
+			Retention:     trash.DefaultRetention.String(),
+			PurgeInterval: trash.DefaultInterval.String(),
+		},
+		Changes: &Changes{
+			Retention:     changes.DefaultRetention.String(),
+			PurgeInterval: changes.DefaultInterval.String(),
+		},
+		Webhooks: &Webhooks{
+			Interval:   webhooks.DefaultInterval.String(),
+			MaxRetries: webhooks.DefaultMaxRetries,
+			Backoff:    webhooks.DefaultBackoff.String(),
+			Timeout:    webhooks.DefaultTimeout.String(),
+			Lease:      webhooks.DefaultLease.String(),
+		},
+		Idempotency: &Idempotency{
+			TTL:           idempotency.DefaultTTL.String(),
+			PurgeInterval: idempotency.DefaultInterval.String(),
+			Lease:         idempotency.DefaultLease.String(),
+		},
+		Jobs: &Jobs{
+			Workers:   jobs.DefaultWorkers,
+			QueueSize: jobs.DefaultQueueSize,
+			SpoolDir:  jobs.DefaultConfig().SpoolDir,
+			Lease:     jobs.DefaultLease.String(),
+		},
+		Auth: &Auth{},
 	}
 }
 
+// embeddingConfig returns the embedding provider settings
+func (c *Config) embeddingConfig() embedding.Config {
+	res := embedding.DefaultConfig()
+	res.Type = c.Embedding.Type
+	res.Dims = c.Embedding.Dims
+	if res.Dims == 0 {
+		res.Dims = c.Pgvector.Dims
+	}
+	res.BatchSize = c.Embedding.BatchSize
+	res.MaxRetries = c.Embedding.MaxRetries
+	res.OpenAI.URL = c.Embedding.OpenAI.URL
+	res.OpenAI.APIKey = c.Embedding.OpenAI.APIKey
+	res.OpenAI.Model = c.Embedding.OpenAI.Model
+	return res
+}
+
+// trashConfig returns the trash purge settings
+func (c *Config) trashConfig() (trash.Config, error) {
+	var res trash.Config
+	var err error
+	if c.Trash.Retention != "" {
+		if res.Retention, err = time.ParseDuration(c.Trash.Retention); err != nil {
+			return res, fmt.Errorf("could not parse the trash retention %q: %w", c.Trash.Retention, err)
+		}
+	}
+	if c.Trash.PurgeInterval != "" {
+		if res.Interval, err = time.ParseDuration(c.Trash.PurgeInterval); err != nil {
+			return res, fmt.Errorf("could not parse the trash purge interval %q: %w", c.Trash.PurgeInterval, err)
+		}
+	}
+	return res, res.Check()
+}
+
+// changesConfig returns the changelog purge settings
+func (c *Config) changesConfig() (changes.Config, error) {
+	var res changes.Config
+	var err error
+	if c.Changes.Retention != "" {
+		if res.Retention, err = time.ParseDuration(c.Changes.Retention); err != nil {
+			return res, fmt.Errorf("could not parse the changelog retention %q: %w", c.Changes.Retention, err)
+		}
+	}
+	if c.Changes.PurgeInterval != "" {
+		if res.Interval, err = time.ParseDuration(c.Changes.PurgeInterval); err != nil {
+			return res, fmt.Errorf("could not parse the changelog purge interval %q: %w", c.Changes.PurgeInterval, err)
+		}
+	}
+	return res, res.Check()
+}
+
+func (c *Config) webhooksConfig() (webhooks.Config, error) {
+	res := webhooks.DefaultConfig()
+	res.MaxRetries = c.Webhooks.MaxRetries
+	var err error
+	if c.Webhooks.Interval != "" {
+		if res.Interval, err = time.ParseDuration(c.Webhooks.Interval); err != nil {
+			return res, fmt.Errorf("could not parse the webhooks interval %q: %w", c.Webhooks.Interval, err)
+		}
+	}
+	if c.Webhooks.Backoff != "" {
+		if res.Backoff, err = time.ParseDuration(c.Webhooks.Backoff); err != nil {
+			return res, fmt.Errorf("could not parse the webhooks backoff %q: %w", c.Webhooks.Backoff, err)
+		}
+	}
+	if c.Webhooks.Timeout != "" {
+		if res.Timeout, err = time.ParseDuration(c.Webhooks.Timeout); err != nil {
+			return res, fmt.Errorf("could not parse the webhooks timeout %q: %w", c.Webhooks.Timeout, err)
+		}
+	}
+	if c.Webhooks.Lease != "" {
+		if res.Lease, err = time.ParseDuration(c.Webhooks.Lease); err != nil {
+			return res, fmt.Errorf("could not parse the webhooks lease %q: %w", c.Webhooks.Lease, err)
+		}
+	}
+	return res, res.Check()
+}
+
+// idempotencyConfig returns the idempotency keys settings
+func (c *Config) idempotencyConfig() (idempotency.Config, error) {
+	res := idempotency.DefaultConfig()
+	var err error
+	if c.Idempotency.TTL != "" {
+		if res.TTL, err = time.ParseDuration(c.Idempotency.TTL); err != nil {
+			return res, fmt.Errorf("could not parse the idempotency keys TTL %q: %w", c.Idempotency.TTL, err)
+		}
+	}
+	if c.Idempotency.PurgeInterval != "" {
+		if res.Interval, err = time.ParseDuration(c.Idempotency.PurgeInterval); err != nil {
+			return res, fmt.Errorf("could not parse the idempotency keys purge interval %q: %w", c.Idempotency.PurgeInterval, err)
+		}
+	}
+	if c.Idempotency.Lease != "" {
+		if res.Lease, err = time.ParseDuration(c.Idempotency.Lease); err != nil {
+			return res, fmt.Errorf("could not parse the idempotency keys lease %q: %w", c.Idempotency.Lease, err)
+		}
+	}
+	return res, res.Check()
+}
+
+// jobsConfig returns the asynchronous jobs settings
+func (c *Config) jobsConfig() (jobs.Config, error) {
+	res := jobs.DefaultConfig()
+	if c.Jobs.Workers != 0 {
+		res.Workers = c.Jobs.Workers
+	}
+	if c.Jobs.QueueSize != 0 {
+		res.QueueSize = c.Jobs.QueueSize
+	}
+	if c.Jobs.SpoolDir != "" {
+		res.SpoolDir = c.Jobs.SpoolDir
+	}
+	if c.Jobs.Lease != "" {
+		var err error
+		if res.Lease, err = time.ParseDuration(c.Jobs.Lease); err != nil {
+			return res, fmt.Errorf("could not parse the jobs lease %q: %w", c.Jobs.Lease, err)
+		}
+	}
+	return res, res.Check()
+}
+
+// authConfig returns the authentication settings, ok is false if the authentication is turned off
+func (c *Config) authConfig() (res auth.Config, ok bool, err error) {
+	if len(c.Auth.Tokens) == 0 {
+		return res, false, nil
+	}
+	for _, t := range c.Auth.Tokens {
+		res.Tokens = append(res.Tokens, auth.Token{Token: t.Token,
+			Identity: auth.Identity{Principal: t.Principal, Groups: t.Groups, Admin: t.Admin}})
+	}
+	return res, true, res.Check()
+}
+
 func BuildConfig(cfgFile string) (*Config, error) {
 	log := logging.NewLogger("simila.ConfigBuilder")
 	log.Infof("trying to build config. cfgFile=%s", cfgFile)
@@ -91,8 +411,49 @@ func BuildConfig(cfgFile string) (*Config, error) {
 	return &cfg, nil
 }
 
-// String implements fmt.Stringify interface in a pretty console form
+// secretMask replaces the secrets values in the config String() form
+const secretMask = "*****"
+
+// String implements fmt.Stringify interface in a pretty console form, the passwords, the API keys
+// and the auth tokens are masked
 func (c *Config) String() string {
-	b, _ := json.MarshalIndent(*c, "", "  ")
+	b, _ := json.MarshalIndent(c.masked(), "", "  ")
 	return string(b)
 }
+
+// masked returns the copy of the config with the secrets masked
+func (c *Config) masked() Config {
+	res := *c
+	if c.DB != nil {
+		db := *c.DB
+		db.Password = mask(db.Password)
+		res.DB = &db
+	}
+	if c.Elastic != nil {
+		el := *c.Elastic
+		el.Password = mask(el.Password)
+		res.Elastic = &el
+	}
+	if c.Embedding != nil && c.Embedding.OpenAI != nil {
+		emb, oai := *c.Embedding, *c.Embedding.OpenAI
+		oai.APIKey = mask(oai.APIKey)
+		emb.OpenAI = &oai
+		res.Embedding = &emb
+	}
+	if c.Auth != nil {
+		a := Auth{Tokens: make([]AuthToken, len(c.Auth.Tokens))}
+		for i, t := range c.Auth.Tokens {
+			t.Token = mask(t.Token)
+			a.Tokens[i] = t
+		}
+		res.Auth = &a
+	}
+	return res
+}
+
+func mask(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return secretMask
+}
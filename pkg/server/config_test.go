@@ -15,11 +15,18 @@
 package server
 
 import (
+	"github.com/simila-io/simila/pkg/auth"
+	"github.com/simila-io/simila/pkg/indexer/changes"
+	"github.com/simila-io/simila/pkg/indexer/idempotency"
+	"github.com/simila-io/simila/pkg/indexer/jobs"
+	"github.com/simila-io/simila/pkg/indexer/trash"
+	"github.com/simila-io/simila/pkg/indexer/webhooks"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestBuildConfig_nofile(t *testing.T) {
@@ -43,6 +50,181 @@ grpctransport:
 	assert.Equal(t, "hoho", cfg.GrpcTransport.Network)
 }
 
+func TestBuildConfig_searchEngines(t *testing.T) {
+	t.Setenv("SIMILA_SEARCHENGINES", `["pgtrigram", "bleve"]`)
+	cfg, err := BuildConfig("")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"pgtrigram", "bleve"}, cfg.SearchEngines)
+}
+
+func TestConfig_embeddingConfig(t *testing.T) {
+	cfg := getDefaultConfig()
+	cfg.Embedding.Type = "hashing"
+	cfg.Pgvector.Dims = 3
+	assert.Equal(t, 3, cfg.embeddingConfig().Dims)
+	assert.Nil(t, cfg.embeddingConfig().Check())
+
+	cfg.Embedding.Dims = 5
+	assert.Equal(t, 5, cfg.embeddingConfig().Dims)
+}
+
+func TestConfig_trashConfig(t *testing.T) {
+	cfg := getDefaultConfig()
+	tc, err := cfg.trashConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, trash.DefaultConfig(), tc)
+
+	cfg.Trash.Retention = "0"
+	tc, err = cfg.trashConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), tc.Retention)
+
+	cfg.Trash.Retention = "1 day"
+	_, err = cfg.trashConfig()
+	assert.NotNil(t, err)
+}
+
+func TestConfig_changesConfig(t *testing.T) {
+	cfg := getDefaultConfig()
+	cc, err := cfg.changesConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, changes.DefaultConfig(), cc)
+
+	cfg.Changes.Retention = ""
+	cc, err = cfg.changesConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), cc.Retention)
+
+	cfg.Changes.PurgeInterval = "hourly"
+	_, err = cfg.changesConfig()
+	assert.NotNil(t, err)
+}
+
+func TestConfig_webhooksConfig(t *testing.T) {
+	cfg := getDefaultConfig()
+	wc, err := cfg.webhooksConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, webhooks.DefaultConfig(), wc)
+
+	cfg.Webhooks.Backoff = ""
+	cfg.Webhooks.MaxRetries = 0
+	wc, err = cfg.webhooksConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, webhooks.DefaultBackoff, wc.Backoff)
+	assert.Equal(t, 0, wc.MaxRetries)
+
+	cfg.Webhooks.Timeout = "0s"
+	_, err = cfg.webhooksConfig()
+	assert.NotNil(t, err)
+
+	cfg.Webhooks.Timeout = ""
+	cfg.Webhooks.Interval = "often"
+	_, err = cfg.webhooksConfig()
+	assert.NotNil(t, err)
+
+	cfg.Webhooks.Interval = ""
+	cfg.Webhooks.Lease = "30s"
+	wc, err = cfg.webhooksConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, 30*time.Second, wc.Lease)
+
+	cfg.Webhooks.Lease = "5s"
+	_, err = cfg.webhooksConfig()
+	assert.NotNil(t, err)
+}
+
+func TestConfig_idempotencyConfig(t *testing.T) {
+	cfg := getDefaultConfig()
+	ic, err := cfg.idempotencyConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, idempotency.DefaultConfig(), ic)
+
+	cfg.Idempotency.TTL = "1h"
+	ic, err = cfg.idempotencyConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Hour, ic.TTL)
+
+	cfg.Idempotency.TTL = "0"
+	_, err = cfg.idempotencyConfig()
+	assert.NotNil(t, err)
+
+	cfg.Idempotency.TTL = ""
+	cfg.Idempotency.PurgeInterval = "hourly"
+	_, err = cfg.idempotencyConfig()
+	assert.NotNil(t, err)
+
+	cfg.Idempotency.PurgeInterval = ""
+	cfg.Idempotency.Lease = "30s"
+	ic, err = cfg.idempotencyConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, 30*time.Second, ic.Lease)
+
+	cfg.Idempotency.Lease = "0"
+	_, err = cfg.idempotencyConfig()
+	assert.NotNil(t, err)
+}
+
+func TestConfig_jobsConfig(t *testing.T) {
+	cfg := getDefaultConfig()
+	jc, err := cfg.jobsConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, jobs.DefaultConfig(), jc)
+
+	cfg.Jobs.Workers = 2
+	cfg.Jobs.SpoolDir = "/tmp/jobs"
+	jc, err = cfg.jobsConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, jc.Workers)
+	assert.Equal(t, "/tmp/jobs", jc.SpoolDir)
+
+	cfg.Jobs.QueueSize = -1
+	_, err = cfg.jobsConfig()
+	assert.NotNil(t, err)
+
+	cfg.Jobs.QueueSize = 0
+	cfg.Jobs.Lease = "30s"
+	jc, err = cfg.jobsConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, 30*time.Second, jc.Lease)
+
+	cfg.Jobs.Lease = "soon"
+	_, err = cfg.jobsConfig()
+	assert.NotNil(t, err)
+}
+
+func TestConfig_authConfig(t *testing.T) {
+	cfg := getDefaultConfig()
+	_, ok, err := cfg.authConfig()
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	cfg.Auth.Tokens = []AuthToken{{Token: "t1", Principal: "alice", Groups: []string{"eng"}}, {Token: "t2", Principal: "root", Admin: true}}
+	ac, ok, err := cfg.authConfig()
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, auth.Config{Tokens: []auth.Token{{Token: "t1", Identity: auth.Identity{Principal: "alice", Groups: []string{"eng"}}},
+		{Token: "t2", Identity: auth.Identity{Principal: "root", Admin: true}}}}, ac)
+
+	cfg.Auth.Tokens = append(cfg.Auth.Tokens, AuthToken{Token: "t3"})
+	_, _, err = cfg.authConfig()
+	assert.NotNil(t, err)
+}
+
+func TestConfig_String(t *testing.T) {
+	cfg := getDefaultConfig()
+	cfg.DB.Password = "db-secret"
+	cfg.Elastic.Password = "es-secret"
+	cfg.Embedding.OpenAI = &OpenAI{URL: "http://localhost", APIKey: "api-secret"}
+	cfg.Auth.Tokens = []AuthToken{{Token: "token-secret", Principal: "alice"}}
+	s := cfg.String()
+	for _, secret := range []string{"db-secret", "es-secret", "api-secret", "token-secret"} {
+		assert.NotContains(t, s, secret)
+	}
+	assert.Contains(t, s, "alice")
+	assert.Equal(t, "db-secret", cfg.DB.Password)
+	assert.Equal(t, "token-secret", cfg.Auth.Tokens[0].Token)
+}
+
 func createFile(name, data string) {
 	f, _ := os.Create(name)
 	f.WriteString(data)
@@ -17,19 +17,32 @@ package server
 import (
 	"context"
 	"github.com/acquirecloud/golibs/logging"
+	"github.com/simila-io/simila/api/gen/admin/v1"
 	"github.com/simila-io/simila/api/gen/format/v1"
 	"github.com/simila-io/simila/api/gen/index/v1"
+	"github.com/simila-io/simila/api/gen/webhook/v1"
 	"github.com/simila-io/simila/pkg/api"
+	"github.com/simila-io/simila/pkg/auth"
+	"github.com/simila-io/simila/pkg/embedding"
 	"github.com/simila-io/simila/pkg/grpc"
 	"github.com/simila-io/simila/pkg/http"
+	"github.com/simila-io/simila/pkg/indexer/changes"
+	"github.com/simila-io/simila/pkg/indexer/idempotency"
+	"github.com/simila-io/simila/pkg/indexer/jobs"
+	"github.com/simila-io/simila/pkg/indexer/persistence"
+	"github.com/simila-io/simila/pkg/indexer/persistence/inmem"
 	"github.com/simila-io/simila/pkg/indexer/persistence/postgres"
+	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/elastic"
+	"github.com/simila-io/simila/pkg/indexer/persistence/postgres/pgvector"
+	"github.com/simila-io/simila/pkg/indexer/persistence/sqlite"
+	"github.com/simila-io/simila/pkg/indexer/trash"
+	"github.com/simila-io/simila/pkg/indexer/webhooks"
 	"github.com/simila-io/simila/pkg/parser"
 	"github.com/simila-io/simila/pkg/parser/txt"
 	"github.com/simila-io/simila/pkg/version"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 
-	"github.com/davecgh/go-spew/spew"
 	"github.com/logrange/linker"
 	ggrpc "google.golang.org/grpc"
 )
@@ -39,7 +52,7 @@ func Run(ctx context.Context, cfg *Config) error {
 	log := logging.NewLogger("server")
 	log.Infof("starting server: %s", version.BuildVersionString())
 
-	log.Infof(spew.Sprint(cfg))
+	log.Infof("config: %s", cfg)
 	defer log.Infof("server is stopped")
 
 	// gRPC server
@@ -48,14 +61,76 @@ func Run(ctx context.Context, cfg *Config) error {
 		grpc_health_v1.RegisterHealthServer(gs, health.NewServer())
 		index.RegisterServiceServer(gs, gsvc.IndexServiceServer())
 		format.RegisterServiceServer(gs, gsvc.FormatServiceServer())
+		admin.RegisterServiceServer(gs, gsvc.AdminServiceServer())
+		webhook.RegisterServiceServer(gs, gsvc.WebhookServiceServer())
 		return nil
 	}
 
 	// Http API (endpoints)
 	rst := api.NewRest(gsvc)
 
+	tcfg, err := cfg.trashConfig()
+	if err != nil {
+		return err
+	}
+	ccfg, err := cfg.changesConfig()
+	if err != nil {
+		return err
+	}
+	wcfg, err := cfg.webhooksConfig()
+	if err != nil {
+		return err
+	}
+	icfg, err := cfg.idempotencyConfig()
+	if err != nil {
+		return err
+	}
+	jcfg, err := cfg.jobsConfig()
+	if err != nil {
+		return err
+	}
+	acfg, authOn, err := cfg.authConfig()
+	if err != nil {
+		return err
+	}
+
+	var emb embedding.Provider
+	if cfg.Embedding.Type != "" {
+		emb = embedding.MustNew(cfg.embeddingConfig())
+	}
+
 	// DB
-	db := postgres.MustGetDb(ctx, cfg.DB.SourceName(), postgres.SearchModuleName(cfg.SearchEngine))
+	var db persistence.Db
+	switch {
+	case cfg.SearchEngine == inmem.SearchEngine:
+		db = inmem.NewDb()
+	case cfg.DB.Driver == sqlite.Driver:
+		db = sqlite.MustGetDb(ctx, cfg.DB.DBName)
+	default:
+		opts := []postgres.Option{
+			postgres.WithBleveIndexDir(cfg.Bleve.IndexDir),
+			postgres.WithElasticConfig(elastic.Config{
+				URL:      cfg.Elastic.URL,
+				Index:    cfg.Elastic.Index,
+				Username: cfg.Elastic.Username,
+				Password: cfg.Elastic.Password,
+			}),
+			postgres.WithPgvectorConfig(pgvector.Config{
+				Dims:      cfg.Pgvector.Dims,
+				IndexType: cfg.Pgvector.IndexType,
+			}),
+		}
+		if emb != nil {
+			opts = append(opts, postgres.WithEmbedder(emb))
+		}
+		if cfg.Pgvector.Semantic {
+			opts = append(opts, postgres.WithSemanticSearch())
+		}
+		for _, se := range cfg.SearchEngines {
+			opts = append(opts, postgres.WithSearchModules(postgres.SearchModuleName(se)))
+		}
+		db = postgres.MustGetDb(ctx, cfg.DB.SourceName(), postgres.SearchModuleName(cfg.SearchEngine), opts...)
+	}
 
 	inj := linker.New()
 	inj.Register(linker.Component{Name: "", Value: db})
@@ -64,6 +139,19 @@ func Run(ctx context.Context, cfg *Config) error {
 	inj.Register(linker.Component{Name: "", Value: http.NewRouter(http.Config{HttpPort: cfg.HttpPort, RestRegistrar: rst.RegisterEPs})})
 	inj.Register(linker.Component{Name: "", Value: parser.NewParserProvider()})
 	inj.Register(linker.Component{Name: "", Value: txt.New()})
+	inj.Register(linker.Component{Name: "", Value: trash.NewPurger(tcfg)})
+	inj.Register(linker.Component{Name: "", Value: changes.NewPurger(ccfg)})
+	inj.Register(linker.Component{Name: "", Value: webhooks.NewDispatcher(wcfg)})
+	inj.Register(linker.Component{Name: "", Value: idempotency.NewStore(icfg)})
+	inj.Register(linker.Component{Name: "", Value: jobs.NewRunner(jcfg)})
+	if emb != nil {
+		inj.Register(linker.Component{Name: "", Value: emb})
+	}
+	if authOn {
+		inj.Register(linker.Component{Name: "", Value: auth.NewAuthenticator(acfg)})
+	} else {
+		log.Warnf("no auth tokens are configured, the API is accessible without authentication")
+	}
 
 	inj.Init(ctx)
 	<-ctx.Done()